@@ -0,0 +1,150 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/bucket"
+	"github.com/GoogleContainerTools/config-sync/pkg/reconcilermanager"
+	"github.com/GoogleContainerTools/config-sync/pkg/util"
+	utillog "github.com/GoogleContainerTools/config-sync/pkg/util/log"
+	"k8s.io/klog/v2/textlogger"
+)
+
+var flEndpoint = flag.String("endpoint", util.EnvString(reconcilermanager.BucketSyncEndpoint, ""),
+	"the host, and optionally port, of the S3-compatible API")
+var flBucket = flag.String("bucket", util.EnvString(reconcilermanager.BucketSyncName, ""),
+	"the name of the bucket")
+var flPrefix = flag.String("prefix", util.EnvString(reconcilermanager.BucketSyncPrefix, ""),
+	"the prefix of the object keys to fetch (defaults to \"\", fetching every object)")
+var flRegion = flag.String("region", util.EnvString(reconcilermanager.BucketSyncRegion, bucket.DefaultRegion),
+	"the region of the bucket, used to sign requests")
+var flInsecure = flag.Bool("insecure", util.EnvBool(reconcilermanager.BucketSyncInsecure, false),
+	"connect to the endpoint over plain HTTP instead of HTTPS")
+var flAuth = flag.String("auth", util.EnvString(reconcilermanager.BucketSyncAuth, string(configsync.AuthNone)),
+	fmt.Sprintf("the authentication type for access to the bucket. Must be one of %s or %s. Defaults to %s",
+		configsync.AuthToken, configsync.AuthNone, configsync.AuthNone))
+var flRoot = flag.String("root", util.EnvString("BUCKET_SYNC_ROOT", util.EnvString("HOME", "")+"/bucket"),
+	"the root directory for bucket-sync operations, under which --dest will be created")
+var flDest = flag.String("dest", util.EnvString("BUCKET_SYNC_DEST", "rev"),
+	"the path (absolute or relative to --root) at which to create a symlink to the directory holding the retrieved files")
+var flErrorFile = flag.String("error-file", util.EnvString("BUCKET_SYNC_ERROR_FILE", ""),
+	"the name of a file into which errors will be written under --root (defaults to \"\", disabling error reporting)")
+var flWait = flag.Float64("wait", util.EnvFloat(reconcilermanager.BucketSyncWait, 1),
+	"the number of seconds between syncs")
+var flSyncTimeout = flag.Int("timeout", util.EnvInt("BUCKET_SYNC_TIMEOUT", 120),
+	"the max number of seconds allowed for a complete sync")
+var flOneTime = flag.Bool("one-time", util.EnvBool("BUCKET_SYNC_ONE_TIME", false),
+	"exit after the first sync")
+var flMaxSyncFailures = flag.Int("max-sync-failures", util.EnvInt("BUCKET_SYNC_MAX_SYNC_FAILURES", 0),
+	"the number of consecutive failures allowed before aborting (the first sync must succeed, -1 will retry forever after the initial sync)")
+var flAccessKeyID = flag.String("access-key-id", util.EnvString("BUCKET_SYNC_ACCESS_KEY_ID", ""),
+	"the access key ID to use for token authentication")
+var flSecretAccessKey = flag.String("secret-access-key", util.EnvString("BUCKET_SYNC_SECRET_ACCESS_KEY", ""),
+	"the secret access key to use for token authentication")
+
+func main() {
+	utillog.Setup()
+	log := utillog.NewLogger(textlogger.NewLogger(textlogger.NewConfig()), *flRoot, *flErrorFile)
+
+	log.Info("fetching bucket objects with arguments", "--endpoint", *flEndpoint,
+		"--bucket", *flBucket, "--prefix", *flPrefix, "--region", *flRegion,
+		"--insecure", *flInsecure, "--auth", *flAuth, "--root", *flRoot,
+		"--dest", *flDest, "--wait", *flWait, "--error-file", *flErrorFile,
+		"--timeout", *flSyncTimeout, "--one-time", *flOneTime,
+		"--max-sync-failures", *flMaxSyncFailures)
+
+	if *flEndpoint == "" {
+		utillog.HandleError(log, true, "ERROR: --endpoint must be specified")
+	}
+
+	if *flBucket == "" {
+		utillog.HandleError(log, true, "ERROR: --bucket must be specified")
+	}
+
+	if *flRoot == "" {
+		utillog.HandleError(log, true, "ERROR: --root must be specified")
+	}
+
+	if *flWait < 0 {
+		utillog.HandleError(log, true, "ERROR: --wait must be greater than or equal to 0")
+	}
+
+	if *flSyncTimeout < 0 {
+		utillog.HandleError(log, true, "ERROR: --timeout must be greater than 0")
+	}
+
+	client := &bucket.Client{
+		Endpoint: *flEndpoint,
+		Bucket:   *flBucket,
+		Region:   *flRegion,
+		Insecure: *flInsecure,
+	}
+	switch configsync.AuthType(*flAuth) {
+	case configsync.AuthNone:
+	case configsync.AuthToken:
+		if *flAccessKeyID == "" || *flSecretAccessKey == "" {
+			utillog.HandleError(log, true, "ERROR: --access-key-id and --secret-access-key must be set when --auth is %s", configsync.AuthToken)
+		}
+		client.AccessKeyID = *flAccessKeyID
+		client.SecretAccessKey = *flSecretAccessKey
+	default:
+		utillog.HandleError(log, true, "ERROR: --auth type must be one of %#v, but found %q",
+			[]configsync.AuthType{configsync.AuthNone, configsync.AuthToken}, *flAuth)
+	}
+	fetcher := &bucket.Fetcher{Client: client, Prefix: *flPrefix}
+
+	failCount := 0
+	pollPeriod := util.WaitTime(*flWait)
+	backoff := util.SyncContainerBackoff(pollPeriod)
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*time.Duration(*flSyncTimeout))
+		if err := fetcher.FetchObjects(ctx, *flRoot, *flDest); err != nil {
+			if *flMaxSyncFailures != -1 && failCount >= *flMaxSyncFailures {
+				// Exit after too many retries, maybe the error is not recoverable.
+				log.Error(err, "too many failures, aborting", "failCount", failCount)
+				os.Exit(1)
+			}
+
+			step := backoff.Step()
+
+			failCount++
+			log.Error(err, "unexpected error fetching bucket objects, will retry")
+			log.Info("waiting before retrying", "waitTime", step)
+			cancel()
+			time.Sleep(step)
+			continue
+		}
+
+		if *flOneTime {
+			log.DeleteErrorFile()
+			os.Exit(0)
+		}
+
+		backoff = util.SyncContainerBackoff(pollPeriod)
+		failCount = 0
+		log.DeleteErrorFile()
+		log.Info("next sync", "wait_time", pollPeriod)
+		cancel()
+		time.Sleep(pollPeriod)
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/GoogleContainerTools/config-sync/pkg/util"
 	utillog "github.com/GoogleContainerTools/config-sync/pkg/util/log"
 	"k8s.io/klog/v2/textlogger"
+	"sigs.k8s.io/kustomize/api/types"
 )
 
 var (
@@ -46,6 +47,12 @@ var (
 		"comma-separated list of filepaths to helm chart values, will be used to override the default values")
 	flIncludeCRDs = flag.String("include-crds", os.Getenv(reconcilermanager.HelmIncludeCRDs),
 		"include CRDs in the helm rendering output")
+	flAPIVersions = flag.String("api-versions", os.Getenv(reconcilermanager.HelmAPIVersions),
+		"comma-separated list of Kubernetes API versions used for Capabilities.APIVersions")
+	flKubeVersion = flag.String("kube-version", os.Getenv(reconcilermanager.HelmKubeVersion),
+		"the Kubernetes version used for Capabilities.KubeVersion")
+	flPostRenderPatches = flag.String("post-render-patches", os.Getenv(reconcilermanager.HelmPostRenderPatches),
+		"yaml list of kustomize patches applied to the helm rendering output")
 	flAuth = flag.String("auth", util.EnvString(reconcilermanager.HelmAuthType, string(configsync.AuthNone)),
 		fmt.Sprintf("the authentication type for access to the Helm repository. Must be one of %s, %s, %s, %s or %s. Defaults to %s",
 			configsync.AuthGCPServiceAccount, configsync.AuthK8sServiceAccount, configsync.AuthToken, configsync.AuthGCENode, configsync.AuthNone, configsync.AuthNone))
@@ -81,7 +88,8 @@ func main() {
 	log.Info("rendering Helm chart with arguments", "--repo", *flRepo,
 		"--chart", *flChart, "--version", *flVersion, "--root", *flRoot,
 		"--values", *flValuesYAML, "--values-file-paths", *flValuesFilePaths,
-		"--include-crds", *flIncludeCRDs, "--dest", *flDest, "--wait", *flWait,
+		"--include-crds", *flIncludeCRDs, "--api-versions", *flAPIVersions,
+		"--kube-version", *flKubeVersion, "--dest", *flDest, "--wait", *flWait,
 		"--error-file", *flErrorFile, "--timeout", *flSyncTimeout,
 		"--one-time", *flOneTime, "--max-sync-failures", *flMaxSyncFailures)
 
@@ -107,6 +115,20 @@ func main() {
 		}
 	}
 
+	var postRenderPatches []types.Patch
+	if *flPostRenderPatches != "" {
+		var err error
+		postRenderPatches, err = helm.ParsePostRenderPatches(*flPostRenderPatches)
+		if err != nil {
+			utillog.HandleError(log, true, "ERROR: --post-render-patches is invalid: %v", err)
+		}
+	}
+
+	var apiVersions []string
+	if len(*flAPIVersions) != 0 {
+		apiVersions = strings.Split(*flAPIVersions, ",")
+	}
+
 	initialSync := true
 	failCount := 0
 	pollPeriod := util.WaitTime(*flWait)
@@ -121,21 +143,24 @@ func main() {
 		}
 
 		hydrator := &helm.Hydrator{
-			Chart:           *flChart,
-			Repo:            *flRepo,
-			Version:         *flVersion,
-			ReleaseName:     *flReleaseName,
-			Namespace:       *flNamespace,
-			DeployNamespace: *flDeployNamespace,
-			ValuesYAML:      *flValuesYAML,
-			ValuesFilePaths: valuesFilePaths,
-			IncludeCRDs:     *flIncludeCRDs,
-			Auth:            configsync.AuthType(*flAuth),
-			HydrateRoot:     *flRoot,
-			Dest:            *flDest,
-			UserName:        *flUsername,
-			Password:        *flPassword,
-			CACertFilePath:  *flCACert,
+			Chart:             *flChart,
+			Repo:              *flRepo,
+			Version:           *flVersion,
+			ReleaseName:       *flReleaseName,
+			Namespace:         *flNamespace,
+			DeployNamespace:   *flDeployNamespace,
+			ValuesYAML:        *flValuesYAML,
+			ValuesFilePaths:   valuesFilePaths,
+			IncludeCRDs:       *flIncludeCRDs,
+			Auth:              configsync.AuthType(*flAuth),
+			HydrateRoot:       *flRoot,
+			Dest:              *flDest,
+			UserName:          *flUsername,
+			Password:          *flPassword,
+			CACertFilePath:    *flCACert,
+			APIVersions:       apiVersions,
+			KubeVersion:       *flKubeVersion,
+			PostRenderPatches: postRenderPatches,
 			CredentialProvider: &auth.CachingCredentialProvider{
 				Scopes: auth.OCISourceScopes(),
 			},
@@ -0,0 +1,162 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/httparchive"
+	"github.com/GoogleContainerTools/config-sync/pkg/reconcilermanager"
+	"github.com/GoogleContainerTools/config-sync/pkg/util"
+	utillog "github.com/GoogleContainerTools/config-sync/pkg/util/log"
+	"k8s.io/klog/v2/textlogger"
+)
+
+var flURL = flag.String("url", util.EnvString(reconcilermanager.HTTPSyncURL, ""),
+	"the HTTP(S) URL of the archive")
+var flAuth = flag.String("auth", util.EnvString(reconcilermanager.HTTPSyncAuth, string(configsync.AuthNone)),
+	fmt.Sprintf("the authentication type for access to the archive. Must be one of %s or %s. Defaults to %s",
+		configsync.AuthToken, configsync.AuthNone, configsync.AuthNone))
+var flChecksum = flag.String("checksum", util.EnvString(reconcilermanager.HTTPSyncChecksum, ""),
+	"the expected checksum of the archive, in the form sha256:HEX (defaults to \"\", disabling verification)")
+var flRoot = flag.String("root", util.EnvString("HTTP_SYNC_ROOT", util.EnvString("HOME", "")+"/http"),
+	"the root directory for http-sync operations, under which --dest will be created")
+var flDest = flag.String("dest", util.EnvString("HTTP_SYNC_DEST", "rev"),
+	"the path (absolute or relative to --root) at which to create a symlink to the directory holding the retrieved files")
+var flErrorFile = flag.String("error-file", util.EnvString("HTTP_SYNC_ERROR_FILE", ""),
+	"the name of a file into which errors will be written under --root (defaults to \"\", disabling error reporting)")
+var flWait = flag.Float64("wait", util.EnvFloat(reconcilermanager.HTTPSyncWait, 1),
+	"the number of seconds between syncs")
+var flSyncTimeout = flag.Int("timeout", util.EnvInt("HTTP_SYNC_TIMEOUT", 120),
+	"the max number of seconds allowed for a complete sync")
+var flOneTime = flag.Bool("one-time", util.EnvBool("HTTP_SYNC_ONE_TIME", false),
+	"exit after the first sync")
+var flMaxSyncFailures = flag.Int("max-sync-failures", util.EnvInt("HTTP_SYNC_MAX_SYNC_FAILURES", 0),
+	"the number of consecutive failures allowed before aborting (the first sync must succeed, -1 will retry forever after the initial sync)")
+var flUsername = flag.String("username", util.EnvString("HTTP_SYNC_USERNAME", ""),
+	"the username to use for basic authentication")
+var flPassword = flag.String("password", util.EnvString("HTTP_SYNC_PASSWORD", ""),
+	"the password to use for basic authentication")
+var flToken = flag.String("token", util.EnvString("HTTP_SYNC_TOKEN", ""),
+	"the bearer token to use for token authentication")
+
+func main() {
+	utillog.Setup()
+	log := utillog.NewLogger(textlogger.NewLogger(textlogger.NewConfig()), *flRoot, *flErrorFile)
+
+	log.Info("fetching HTTP archive with arguments", "--url", *flURL,
+		"--auth", *flAuth, "--checksum", *flChecksum, "--root", *flRoot,
+		"--dest", *flDest, "--wait", *flWait, "--error-file", *flErrorFile,
+		"--timeout", *flSyncTimeout, "--one-time", *flOneTime,
+		"--max-sync-failures", *flMaxSyncFailures)
+
+	if *flURL == "" {
+		utillog.HandleError(log, true, "ERROR: --url must be specified")
+	}
+
+	if *flRoot == "" {
+		utillog.HandleError(log, true, "ERROR: --root must be specified")
+	}
+
+	if *flWait < 0 {
+		utillog.HandleError(log, true, "ERROR: --wait must be greater than or equal to 0")
+	}
+
+	if *flSyncTimeout < 0 {
+		utillog.HandleError(log, true, "ERROR: --timeout must be greater than 0")
+	}
+
+	if *flChecksum != "" && !httparchive.HasChecksum(*flChecksum) {
+		utillog.HandleError(log, true, "ERROR: --checksum must have the %q prefix", httparchive.ChecksumPrefix)
+	}
+
+	fetcher := &httparchive.Fetcher{Checksum: *flChecksum}
+	switch configsync.AuthType(*flAuth) {
+	case configsync.AuthNone:
+	case configsync.AuthToken:
+		if *flToken == "" && *flUsername == "" {
+			utillog.HandleError(log, true, "ERROR: --token or --username must be set when --auth is %s", configsync.AuthToken)
+		}
+		if *flUsername != "" && *flPassword == "" {
+			utillog.HandleError(log, true, "ERROR: --password must be set when --username is specified")
+		}
+		fetcher.Username = *flUsername
+		fetcher.Password = *flPassword
+		fetcher.Token = *flToken
+	default:
+		utillog.HandleError(log, true, "ERROR: --auth type must be one of %#v, but found %q",
+			[]configsync.AuthType{configsync.AuthNone, configsync.AuthToken}, *flAuth)
+	}
+
+	initialSync := true
+	failCount := 0
+	pollPeriod := util.WaitTime(*flWait)
+	backoff := util.SyncContainerBackoff(pollPeriod)
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*time.Duration(*flSyncTimeout))
+		if err := fetcher.FetchArchive(ctx, *flURL, *flRoot, *flDest); err != nil {
+			if *flMaxSyncFailures != -1 && failCount >= *flMaxSyncFailures {
+				// Exit after too many retries, maybe the error is not recoverable.
+				log.Error(err, "too many failures, aborting", "failCount", failCount)
+				os.Exit(1)
+			}
+
+			step := backoff.Step()
+
+			failCount++
+			log.Error(err, "unexpected error fetching archive, will retry")
+			log.Info("waiting before retrying", "waitTime", step)
+			cancel()
+			time.Sleep(step)
+			continue
+		}
+
+		if initialSync {
+			if *flOneTime {
+				log.DeleteErrorFile()
+				os.Exit(0)
+			}
+			// If the archive checksum is pinned, then the archive should never
+			// change. We can exit early to avoid redundant sync attempts.
+			if *flChecksum != "" {
+				log.Info(httparchive.NoFurtherSyncsLog, "reason", "archive was provided with checksum")
+				log.DeleteErrorFile()
+				sleepForever()
+			}
+			initialSync = false
+		}
+
+		backoff = util.SyncContainerBackoff(pollPeriod)
+		failCount = 0
+		log.DeleteErrorFile()
+		log.Info("next sync", "wait_time", pollPeriod)
+		cancel()
+		time.Sleep(pollPeriod)
+	}
+}
+
+func sleepForever() {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt)
+	<-c
+	os.Exit(0)
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/GoogleContainerTools/config-sync/pkg/profiler"
 	"github.com/GoogleContainerTools/config-sync/pkg/reconcilermanager"
 	"github.com/GoogleContainerTools/config-sync/pkg/reconcilermanager/controllers"
+	"github.com/GoogleContainerTools/config-sync/pkg/util"
 	"github.com/GoogleContainerTools/config-sync/pkg/util/log"
 	"k8s.io/klog/v2"
 	"k8s.io/klog/v2/textlogger"
@@ -72,6 +73,18 @@ var (
 
 	reconcilerSignalsDir = flag.String("reconciler-signals", "/reconciler-signals",
 		"The absolute path in the container that contains reconciler signals written by the reconciler that unblock the rendering phase, for example, the latest image digest that is ready to render.")
+
+	hydrationSignalsDir = flag.String("hydration-signals", "/hydration-signals",
+		"The absolute path in the container where the hydration-controller serves the socket for the render requests and status of the reconciler.")
+
+	functionPipelineEnabled = flag.Bool("function-pipeline-enabled", util.EnvBool(reconcilermanager.FunctionPipelineEnabled, false),
+		"Run the KRM function pipeline declared in the Kptfile of the sync directory.")
+
+	functionDirs = flag.String("function-dirs", hydrate.DefaultFunctionDir,
+		"Comma-separated absolute paths in the container to the directories of the exec KRM functions allowed in the function pipeline.")
+
+	helmChartCacheDir = flag.String("helm-chart-cache", "helm-charts",
+		"the name of the directory under --repo-root caching the Helm charts pulled for the kustomizations. The charts are not cached if empty.")
 )
 
 func main() {
@@ -109,6 +122,11 @@ func main() {
 		klog.Fatalf("--reconiler-signals must be an absolute path: %v", err)
 	}
 
+	absHydrationSignalDir, err := cmpath.AbsoluteOS(*hydrationSignalsDir)
+	if err != nil {
+		klog.Fatalf("--hydration-signals must be an absolute path: %v", err)
+	}
+
 	// Normalize syncDirRelative.
 	// Some users specify the directory as if the root of the repository is "/".
 	// Strip this from the front of the passed directory so behavior is as
@@ -116,18 +134,48 @@ func main() {
 	dir := strings.TrimPrefix(*syncDir, "/")
 	relSyncDir := cmpath.RelativeOS(dir)
 
+	var absFunctionDirs []string
+	for _, dir := range strings.Split(*functionDirs, ",") {
+		if dir == "" {
+			continue
+		}
+		if _, err := cmpath.AbsoluteOS(dir); err != nil {
+			klog.Fatalf("--function-dirs must be absolute paths: %v", err)
+		}
+		absFunctionDirs = append(absFunctionDirs, dir)
+	}
+
+	var helmChartCache string
+	if *helmChartCacheDir != "" {
+		helmChartCache = absRepoRootDir.Join(cmpath.RelativeSlash(*helmChartCacheDir)).OSPath()
+	}
+	var helmCreds *hydrate.HelmCredentials
+	if username := os.Getenv(reconcilermanager.HydrationHelmUsername); username != "" {
+		helmCreds = &hydrate.HelmCredentials{
+			Username: username,
+			Password: os.Getenv(reconcilermanager.HydrationHelmPassword),
+			Hosts:    hydrate.ParseHelmHosts(os.Getenv(reconcilermanager.HydrationHelmHosts)),
+			Repo:     os.Getenv(reconcilermanager.HydrationHelmRepo),
+		}
+	}
+
 	hydrator := &hydrate.Hydrator{
-		DonePath:            absDonePath,
-		SourceType:          configsync.SourceType(*sourceType),
-		SourceRoot:          absSourceRootDir,
-		HydratedRoot:        absHydratedRootDir,
-		SourceLink:          *sourceLinkDir,
-		HydratedLink:        *hydratedLinkDir,
-		SyncDir:             relSyncDir,
-		ReconcilerSignalDir: absReconcilerSignalDir,
-		PollingPeriod:       *pollingPeriod,
-		RehydratePeriod:     *rehydratePeriod,
-		ReconcilerName:      *reconcilerName,
+		DonePath:                absDonePath,
+		SourceType:              configsync.SourceType(*sourceType),
+		SourceRoot:              absSourceRootDir,
+		HydratedRoot:            absHydratedRootDir,
+		SourceLink:              *sourceLinkDir,
+		HydratedLink:            *hydratedLinkDir,
+		SyncDir:                 relSyncDir,
+		ReconcilerSignalDir:     absReconcilerSignalDir,
+		PollingPeriod:           *pollingPeriod,
+		RehydratePeriod:         *rehydratePeriod,
+		ReconcilerName:          *reconcilerName,
+		FunctionPipelineEnabled: *functionPipelineEnabled,
+		FunctionDirs:            absFunctionDirs,
+		SignalSocket:            absHydrationSignalDir.Join(cmpath.RelativeSlash(hydrate.SignalSocketFile)).OSPath(),
+		HelmChartCache:          helmChartCache,
+		HelmCredentials:         helmCreds,
 	}
 
 	hydrator.Run(context.Background())
@@ -49,6 +49,12 @@ const (
 	// OutputJSON specifies exporting the output in JSON format.
 	OutputJSON = "json"
 
+	// substitutionFlag is the flag name for Substitution below.
+	substitutionFlag = "substitution"
+
+	// substitutionValuesFlag is the flag name for SubstitutionValuesFile below.
+	substitutionValuesFlag = "substitution-values"
+
 	// DefaultHydrationOutput specifies the default location to write the hydrated output.
 	DefaultHydrationOutput = "compiled"
 )
@@ -81,6 +87,15 @@ var (
 
 	// APIServerTimeout specifies the timeout for requests to the cluster API servers
 	APIServerTimeout = restconfig.DefaultTimeout
+
+	// Substitution directs whether to substitute the ${cluster.*} and
+	// ${values.*} variables, like a RootSync or RepoSync specifying
+	// spec.substitution.
+	Substitution bool
+
+	// SubstitutionValuesFile is the path of the file holding the values of the
+	// ${values.KEY} variables. It implies Substitution.
+	SubstitutionValuesFile string
 )
 
 // AddContexts adds the --contexts flag.
@@ -135,3 +150,12 @@ func AddOutputFormat(cmd *cobra.Command) {
 func AddAPIServerTimeout(cmd *cobra.Command) {
 	cmd.Flags().DurationVar(&APIServerTimeout, "api-server-timeout", restconfig.DefaultTimeout, fmt.Sprintf("Client-side timeout for talking to the API server; defaults to %s", restconfig.DefaultTimeout))
 }
+
+// AddSubstitutionValues adds the --substitution and --substitution-values
+// flags.
+func AddSubstitutionValues(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&Substitution, substitutionFlag, false,
+		`If true, substitute the ${cluster.*} and ${values.*} variables, like a RootSync or RepoSync specifying spec.substitution. Implied by --substitution-values.`)
+	cmd.Flags().StringVar(&SubstitutionValuesFile, substitutionValuesFlag, "",
+		`Path to a ConfigMap manifest, or a YAML map of strings, holding the values of the ${values.KEY} variables.`)
+}
@@ -17,6 +17,7 @@ package hydrate
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/GoogleContainerTools/config-sync/cmd/nomos/flags"
 	nomosparse "github.com/GoogleContainerTools/config-sync/cmd/nomos/parse"
@@ -28,15 +29,39 @@ import (
 	"github.com/GoogleContainerTools/config-sync/pkg/importer/filesystem"
 	"github.com/GoogleContainerTools/config-sync/pkg/importer/filesystem/cmpath"
 	"github.com/GoogleContainerTools/config-sync/pkg/importer/reader"
+	"github.com/GoogleContainerTools/config-sync/pkg/reconcilermanager"
 	"github.com/GoogleContainerTools/config-sync/pkg/status"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 var (
-	flat    bool
-	outPath string
+	flat          bool
+	outPath       string
+	rsyncFile     string
+	functionDirs  []string
+	clusterLabels string
+	compare       bool
 )
 
+// hydrateOptions are the options to hydrate the source configs of a RootSync
+// or RepoSync manifest.
+type hydrateOptions struct {
+	// path is the local directory of the source configs.
+	path string
+	// clusterName is the name of the cluster the configs are hydrated for.
+	clusterName string
+	// clusterLabels are the labels of the cluster the configs are hydrated
+	// for, used for cluster selection and substitution.
+	clusterLabels map[string]string
+	// functionDirs are the directories of the exec KRM functions.
+	functionDirs     []string
+	apiServerTimeout time.Duration
+	outputFormat     string
+	outPath          string
+	flat             bool
+}
+
 func init() {
 	flags.AddClusters(Cmd)
 	flags.AddPath(Cmd)
@@ -44,6 +69,26 @@ func init() {
 	flags.AddSourceFormat(Cmd)
 	flags.AddOutputFormat(Cmd)
 	flags.AddAPIServerTimeout(Cmd)
+	flags.AddSubstitutionValues(Cmd)
+	Cmd.Flags().StringVar(&rsyncFile, "rootsync", "",
+		`Path to a RootSync or RepoSync manifest. If set, hydrates the source
+configs of the RootSync or RepoSync the same way as its reconciler.
+
+The ConfigMaps referenced by the RootSync or RepoSync, like the
+spec.helm.valuesFileRefs, must be declared in the same file. For Git, OCI,
+HTTP and bucket sources, the source is read from --path, and spec.*.dir is
+relative to --path. For Helm sources, the chart is pulled with the local
+Helm configuration. --clusters accepts a single Cluster name, used as the
+name of the cluster.`)
+	Cmd.Flags().StringSliceVar(&functionDirs, "function-dirs", nil,
+		`Directories of the exec KRM functions allowed in the function pipeline of the Kptfile, when --rootsync is set.`)
+	Cmd.Flags().StringVar(&clusterLabels, "cluster-labels", "",
+		`Comma-separated labels of the cluster, like env=prod,region=eu, when --rootsync
+is set. The data of the ClusterInfo ConfigMap declared in the manifest take
+precedence.`)
+	Cmd.Flags().BoolVar(&compare, "compare", false,
+		`If enabled, print a matrix of the objects which differ between the clusters,
+and how they differ, instead of writing the hydrated configuration.`)
 	Cmd.Flags().BoolVar(&flat, "flat", false,
 		`If enabled, print all output to a single file`)
 	Cmd.Flags().StringVar(&outPath, "output", flags.DefaultHydrationOutput,
@@ -73,6 +118,16 @@ which you could kubectl apply -fR to the cluster, or have Config Sync sync to th
 		// Don't show usage on error, as argument validation passed.
 		cmd.SilenceUsage = true
 
+		if rsyncFile != "" {
+			if compare {
+				return fmt.Errorf("--compare must not be set with --rootsync")
+			}
+			return runHydrateSync(cmd)
+		}
+		if clusterLabels != "" {
+			return fmt.Errorf("--cluster-labels must only be set with --rootsync")
+		}
+
 		sourceFormat := configsync.SourceFormat(flags.SourceFormat)
 		if sourceFormat == "" {
 			sourceFormat = configsync.SourceFormatHierarchy
@@ -126,6 +181,7 @@ which you could kubectl apply -fR to the cluster, or have Config Sync sync to th
 		}
 
 		var allObjects []ast.FileObject
+		clusterObjects := map[string][]ast.FileObject{}
 		encounteredError := false
 		numClusters := 0
 		clusterFilterFunc := func(clusterName string, fileObjects []ast.FileObject, err status.MultiError) {
@@ -154,9 +210,23 @@ which you could kubectl apply -fR to the cluster, or have Config Sync sync to th
 			}
 
 			allObjects = append(allObjects, fileObjects...)
+			clusterObjects[clusterName] = fileObjects
 		}
 		hydrate.ForEachCluster(cmd.Context(), parseOpts, validateOpts, clusterFilterFunc)
 
+		if compare {
+			for _, objs := range clusterObjects {
+				hydrate.Clean(objs)
+			}
+			if err := hydrate.CompareClusters(clusterObjects).Print(cmd.OutOrStdout()); err != nil {
+				return err
+			}
+			if encounteredError {
+				os.Exit(1)
+			}
+			return nil
+		}
+
 		multiCluster := numClusters > 1
 		fileObjects := hydrate.GenerateFileObjects(multiCluster, allObjects...)
 		if flat {
@@ -175,3 +245,37 @@ which you could kubectl apply -fR to the cluster, or have Config Sync sync to th
 		return nil
 	},
 }
+
+// runHydrateSync hydrates the source configs of the RootSync or RepoSync
+// manifest of the --rootsync flag.
+func runHydrateSync(cmd *cobra.Command) error {
+	switch flags.OutputFormat {
+	case flags.OutputYAML, flags.OutputJSON: // do nothing
+	default:
+		return fmt.Errorf("format argument must be %q or %q", flags.OutputYAML, flags.OutputJSON)
+	}
+	if cmd.Flags().Changed(reconcilermanager.SourceFormat) {
+		return fmt.Errorf("--%s must not be set with --rootsync, the source format of the manifest is used", reconcilermanager.SourceFormat)
+	}
+	if len(flags.Clusters) > 1 {
+		return fmt.Errorf("--clusters must have at most one Cluster name with --rootsync, got %d", len(flags.Clusters))
+	}
+	var clusterName string
+	if len(flags.Clusters) == 1 {
+		clusterName = flags.Clusters[0]
+	}
+	parsedClusterLabels, err := labels.ConvertSelectorToLabelsMap(clusterLabels)
+	if err != nil {
+		return fmt.Errorf("invalid --cluster-labels: %w", err)
+	}
+	return hydrateSync(cmd.Context(), rsyncFile, hydrateOptions{
+		path:             flags.Path,
+		clusterName:      clusterName,
+		clusterLabels:    parsedClusterLabels,
+		functionDirs:     functionDirs,
+		apiServerTimeout: flags.APIServerTimeout,
+		outputFormat:     flags.OutputFormat,
+		outPath:          outPath,
+		flat:             flat,
+	})
+}
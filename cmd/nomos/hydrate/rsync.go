@@ -0,0 +1,456 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	nomosparse "github.com/GoogleContainerTools/config-sync/cmd/nomos/parse"
+	"github.com/GoogleContainerTools/config-sync/cmd/nomos/util"
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync/v1beta1"
+	"github.com/GoogleContainerTools/config-sync/pkg/declared"
+	"github.com/GoogleContainerTools/config-sync/pkg/helm"
+	"github.com/GoogleContainerTools/config-sync/pkg/hydrate"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/filesystem"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/filesystem/cmpath"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/reader"
+	"github.com/GoogleContainerTools/config-sync/pkg/kinds"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/parse"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	"github.com/GoogleContainerTools/config-sync/pkg/validate"
+	rsyncvalidate "github.com/GoogleContainerTools/config-sync/pkg/validate/rsync/validate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// syncManifest is a RootSync or RepoSync read from a manifest file, with the
+// ConfigMaps it references.
+type syncManifest struct {
+	// Kind is either RootSync or RepoSync.
+	Kind string
+	// Name is the name of the RootSync or RepoSync.
+	Name string
+	// Namespace is the namespace of the RootSync or RepoSync.
+	Namespace string
+	// SourceType is the source type of the RootSync or RepoSync.
+	SourceType configsync.SourceType
+	// SourceFormat is the format of the source configs.
+	SourceFormat configsync.SourceFormat
+	// NamespaceStrategy is the namespace strategy of a RootSync.
+	NamespaceStrategy configsync.NamespaceStrategy
+	// Dir is the directory of the source configs, relative to the source root.
+	Dir string
+	// Helm is the Helm chart of a Helm source.
+	Helm *v1beta1.HelmBase
+	// HelmReleaseNamespace is the namespace of the Helm release.
+	HelmReleaseNamespace string
+	// HelmDeployNamespace is the namespace the Helm chart is deployed to.
+	HelmDeployNamespace string
+	// FunctionPipelineEnabled is whether the KRM function pipeline of the
+	// Kptfile is run, as set by the function-pipeline annotation.
+	FunctionPipelineEnabled bool
+	// Substitution is the configuration of the substituted variables.
+	Substitution *v1beta1.Substitution
+	// ConfigMaps are the ConfigMaps in the manifest file, by name.
+	ConfigMaps map[string]*corev1.ConfigMap
+	// ClusterLabels are the labels of the cluster in the ClusterInfo ConfigMap
+	// declared in the manifest file, if any.
+	ClusterLabels map[string]string
+}
+
+// readSyncManifest reads the RootSync or RepoSync in the manifest file, the
+// ConfigMaps in its namespace and the ClusterInfo ConfigMap declared in the
+// same file.
+func readSyncManifest(path string) (*syncManifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	m := &syncManifest{ConfigMaps: map[string]*corev1.ConfigMap{}}
+	var syncs, configMaps []*unstructured.Unstructured
+	decoder := utilyaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		u := &unstructured.Unstructured{}
+		if err := decoder.Decode(&u.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+		}
+		if len(u.Object) == 0 {
+			continue
+		}
+		gk := u.GroupVersionKind().GroupKind()
+		switch gk {
+		case kinds.RootSyncV1Beta1().GroupKind(), kinds.RepoSyncV1Beta1().GroupKind():
+			syncs = append(syncs, u)
+		case kinds.ConfigMap().GroupKind():
+			configMaps = append(configMaps, u)
+		}
+	}
+	if len(syncs) != 1 {
+		return nil, fmt.Errorf("manifest %s must declare exactly one %s or %s, found %d",
+			path, configsync.RootSyncKind, configsync.RepoSyncKind, len(syncs))
+	}
+	if err := m.setSync(syncs[0]); err != nil {
+		return nil, fmt.Errorf("invalid %s in manifest %s: %w", syncs[0].GetKind(), path, err)
+	}
+	for _, u := range configMaps {
+		isClusterInfo := u.GetNamespace() == configsync.ControllerNamespace && u.GetName() == configsync.ClusterInfoConfigMapName
+		if u.GetNamespace() != m.Namespace && !isClusterInfo {
+			continue
+		}
+		cm := &corev1.ConfigMap{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, cm); err != nil {
+			return nil, fmt.Errorf("invalid ConfigMap %s in manifest %s: %w", u.GetName(), path, err)
+		}
+		if isClusterInfo {
+			// Like the reconciler-manager, the data of the ClusterInfo
+			// ConfigMap are the labels of the cluster.
+			if _, err := labels.ValidatedSelectorFromSet(cm.Data); err != nil {
+				return nil, fmt.Errorf("invalid cluster labels in ClusterInfo ConfigMap %s/%s in manifest %s: %w",
+					cm.Namespace, cm.Name, path, err)
+			}
+			m.ClusterLabels = cm.Data
+		}
+		if u.GetNamespace() == m.Namespace {
+			m.ConfigMaps[cm.Name] = cm
+		}
+	}
+	return m, nil
+}
+
+// setSync sets the fields of the manifest from the RootSync or RepoSync.
+// The v1alpha1 and v1beta1 versions have the same schema, so both are read as
+// v1beta1.
+func (m *syncManifest) setSync(u *unstructured.Unstructured) error {
+	m.Kind = u.GetKind()
+	m.Name = u.GetName()
+	m.Namespace = u.GetNamespace()
+	m.FunctionPipelineEnabled, _ = strconv.ParseBool(u.GetAnnotations()[metadata.FunctionPipelineAnnotationKey])
+	switch m.Kind {
+	case configsync.RootSyncKind:
+		rs := &v1beta1.RootSync{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, rs); err != nil {
+			return err
+		}
+		if m.Namespace == "" {
+			m.Namespace = configsync.ControllerNamespace
+		}
+		if rs.Spec.SourceType == "" {
+			// Defaulted by the CRD.
+			rs.Spec.SourceType = configsync.GitSource
+		}
+		if err := rsyncvalidate.RootSyncSpec(rs.Spec); err != nil {
+			return err
+		}
+		m.SourceType = rs.Spec.SourceType
+		m.SourceFormat = rs.Spec.SourceFormat
+		if m.SourceFormat == "" {
+			m.SourceFormat = configsync.SourceFormatHierarchy
+		}
+		m.NamespaceStrategy = rs.Spec.SafeOverride().NamespaceStrategy
+		if m.NamespaceStrategy == "" {
+			m.NamespaceStrategy = configsync.NamespaceStrategyImplicit
+		}
+		m.Substitution = rs.Spec.Substitution
+		if rs.Spec.Helm != nil {
+			m.Helm = &rs.Spec.Helm.HelmBase
+			m.HelmReleaseNamespace = rs.Spec.Helm.Namespace
+			m.HelmDeployNamespace = rs.Spec.Helm.DeployNamespace
+		}
+		m.Dir = sourceDir(rs.Spec.SourceType, rs.Spec.Git, rs.Spec.Oci, rs.Spec.HTTP, rs.Spec.Bucket)
+	case configsync.RepoSyncKind:
+		rs := &v1beta1.RepoSync{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, rs); err != nil {
+			return err
+		}
+		if m.Namespace == "" {
+			return fmt.Errorf("%s must have a namespace", configsync.RepoSyncKind)
+		}
+		if rs.Spec.SourceType == "" {
+			// Defaulted by the CRD.
+			rs.Spec.SourceType = configsync.GitSource
+		}
+		if err := rsyncvalidate.RepoSyncSpec(rs.Spec); err != nil {
+			return err
+		}
+		m.SourceType = rs.Spec.SourceType
+		// RepoSyncs only support the unstructured format.
+		m.SourceFormat = configsync.SourceFormatUnstructured
+		m.Substitution = rs.Spec.Substitution
+		if rs.Spec.Helm != nil {
+			m.Helm = &rs.Spec.Helm.HelmBase
+			m.HelmReleaseNamespace = rs.Namespace
+		}
+		m.Dir = sourceDir(rs.Spec.SourceType, rs.Spec.Git, rs.Spec.Oci, rs.Spec.HTTP, rs.Spec.Bucket)
+	}
+	return nil
+}
+
+// sourceDir returns the directory of the source configs of the source type.
+func sourceDir(sourceType configsync.SourceType, git *v1beta1.Git, oci *v1beta1.Oci, http *v1beta1.HTTP, bucket *v1beta1.Bucket) string {
+	switch sourceType {
+	case configsync.OciSource:
+		if oci != nil {
+			return oci.Dir
+		}
+	case configsync.HTTPSource:
+		if http != nil {
+			return http.Dir
+		}
+	case configsync.BucketSource:
+		if bucket != nil {
+			return bucket.Dir
+		}
+	case configsync.GitSource:
+		if git != nil {
+			return git.Dir
+		}
+	}
+	return ""
+}
+
+// scope returns the scope of the reconciler of the RootSync or RepoSync.
+func (m *syncManifest) scope() declared.Scope {
+	if m.Kind == configsync.RepoSyncKind {
+		return declared.Scope(m.Namespace)
+	}
+	return declared.RootScope
+}
+
+// configMapData returns the data key of the ConfigMap in the manifest.
+func (m *syncManifest) configMapData(name, dataKey, field string) (string, error) {
+	cm, found := m.ConfigMaps[name]
+	if !found {
+		return "", fmt.Errorf("ConfigMap %s/%s referenced by %s must be declared in the manifest", m.Namespace, name, field)
+	}
+	data, found := cm.Data[dataKey]
+	if !found {
+		return "", fmt.Errorf("ConfigMap %s/%s referenced by %s does not have data key %q", m.Namespace, name, field, dataKey)
+	}
+	return data, nil
+}
+
+// substitutionValues returns the substitution values of the ConfigMap
+// referenced by spec.substitution.valuesFrom.
+func (m *syncManifest) substitutionValues() (map[string]string, error) {
+	if m.Substitution == nil || m.Substitution.ValuesFrom == nil {
+		return nil, nil
+	}
+	name := m.Substitution.ValuesFrom.Name
+	cm, found := m.ConfigMaps[name]
+	if !found {
+		return nil, fmt.Errorf("ConfigMap %s/%s referenced by spec.substitution.valuesFrom must be declared in the manifest", m.Namespace, name)
+	}
+	return cm.Data, nil
+}
+
+// renderHelmChart renders the Helm chart the same way as the helm-sync
+// container, under the root directory, and returns the directory of the
+// rendered chart.
+func (m *syncManifest) renderHelmChart(ctx context.Context, root string) (string, error) {
+	if m.Helm == nil {
+		return "", fmt.Errorf("%s must specify spec.helm when spec.sourceType is %q", m.Kind, configsync.HelmSource)
+	}
+	var valuesFilePaths []string
+	for i, vf := range m.Helm.ValuesFileRefs {
+		data, err := m.configMapData(vf.Name, rsyncvalidate.HelmValuesFileDataKeyOrDefault(vf.DataKey), "spec.helm.valuesFileRefs")
+		if err != nil {
+			return "", err
+		}
+		path := filepath.Join(root, fmt.Sprintf("helm_values_file_path_%d", i))
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			return "", err
+		}
+		valuesFilePaths = append(valuesFilePaths, path)
+	}
+	var patchesFromData string
+	if m.Helm.PostRender != nil && m.Helm.PostRender.PatchesFrom != nil {
+		patchesFrom := m.Helm.PostRender.PatchesFrom
+		data, err := m.configMapData(patchesFrom.Name, rsyncvalidate.HelmPostRenderDataKeyOrDefault(patchesFrom.DataKey), "spec.helm.postRender.patchesFrom")
+		if err != nil {
+			return "", err
+		}
+		patchesFromData = data
+	}
+	postRenderPatches, err := helm.PostRenderPatches(m.Helm.PostRender, patchesFromData)
+	if err != nil {
+		return "", err
+	}
+	var valuesYAML string
+	if m.Helm.Values != nil {
+		valuesYAML = string(m.Helm.Values.Raw)
+	}
+
+	hydrator := &helm.Hydrator{
+		Chart:           m.Helm.Chart,
+		Repo:            m.Helm.Repo,
+		Version:         m.Helm.Version,
+		ReleaseName:     m.Helm.ReleaseName,
+		Namespace:       m.HelmReleaseNamespace,
+		DeployNamespace: m.HelmDeployNamespace,
+		ValuesYAML:      valuesYAML,
+		ValuesFilePaths: valuesFilePaths,
+		IncludeCRDs:     fmt.Sprint(m.Helm.IncludeCRDs),
+		HydrateRoot:     filepath.Join(root, "helm"),
+		// The rendered chart is linked out of the hydrate root, which holds
+		// the rendered chart itself when the version is not set.
+		Dest: filepath.Join("..", "chart"),
+		// The charts are pulled with the local Helm configuration, like the
+		// credentials of `helm registry login`.
+		Auth:              configsync.AuthNone,
+		APIVersions:       m.Helm.APIVersions,
+		KubeVersion:       m.Helm.KubeVersion,
+		PostRenderPatches: postRenderPatches,
+	}
+	if err := os.MkdirAll(hydrator.HydrateRoot, os.ModePerm); err != nil {
+		return "", err
+	}
+	if err := hydrator.HelmTemplate(ctx); err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(filepath.Join(root, "chart"))
+}
+
+// hydrateSync hydrates the source configs of the RootSync or RepoSync in the
+// manifest file, the same way as its reconciler, and writes the output.
+func hydrateSync(ctx context.Context, manifestPath string, opts hydrateOptions) error {
+	m, err := readSyncManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	var syncDir string
+	switch m.SourceType {
+	case configsync.HelmSource:
+		tmpDir, err := os.MkdirTemp(os.TempDir(), "helm-")
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = os.RemoveAll(tmpDir)
+		}()
+		if syncDir, err = m.renderHelmChart(ctx, tmpDir); err != nil {
+			return fmt.Errorf("unable to render the Helm chart %s: %w", m.Helm.Chart, err)
+		}
+	default:
+		// The source is read from the local directory, like a clone of the Git
+		// repository, or the extracted OCI image, HTTP archive or bucket.
+		syncDir, err = filepath.Abs(filepath.Join(opts.path, m.Dir))
+		if err != nil {
+			return err
+		}
+	}
+	rootDir, err := cmpath.AbsoluteOS(syncDir)
+	if err != nil {
+		return err
+	}
+	if rootDir, err = rootDir.EvalSymlinks(); err != nil {
+		return err
+	}
+
+	renderedDir, rendered, err := hydrate.RenderSource(rootDir.OSPath(), m.FunctionPipelineEnabled, opts.functionDirs)
+	if err != nil {
+		return err
+	}
+	if rendered {
+		if m.SourceFormat == configsync.SourceFormatHierarchy {
+			return fmt.Errorf("%s must be %s when rendering is needed", "spec.sourceFormat", configsync.SourceFormatUnstructured)
+		}
+		rootDir = renderedDir
+		defer func() {
+			_ = os.RemoveAll(renderedDir.OSPath())
+		}()
+	}
+
+	files, err := nomosparse.FindFiles(rootDir)
+	if err != nil {
+		return err
+	}
+	if m.SourceFormat == configsync.SourceFormatHierarchy {
+		files = filesystem.FilterHierarchyFiles(rootDir, files)
+	}
+	filePaths := reader.FilePaths{
+		RootDir:   rootDir,
+		PolicyDir: cmpath.RelativeOS(rootDir.OSPath()),
+		Files:     files,
+	}
+
+	validateOpts, err := hydrate.ValidateOptions(rootDir, opts.apiServerTimeout)
+	if err != nil {
+		return err
+	}
+	validateOpts.FieldManager = util.FieldManager
+	validateOpts.ClusterName = opts.clusterName
+	validateOpts.ClusterLabels = labels.Merge(opts.clusterLabels, m.ClusterLabels)
+	validateOpts.SyncName = m.Name
+	if m.Substitution != nil {
+		validateOpts.SubstitutionEnabled = true
+	}
+	if values, err := m.substitutionValues(); err != nil {
+		return err
+	} else if values != nil {
+		validateOpts.SubstitutionValues = values
+	}
+	validateOpts = parse.OptionsForScope(validateOpts, m.scope())
+
+	objs, errs := filesystem.NewParser(&reader.File{}).Parse(filePaths)
+	if !status.HasBlockingErrors(errs) {
+		var err status.MultiError
+		switch {
+		case m.SourceFormat == configsync.SourceFormatHierarchy:
+			objs, err = validate.Hierarchical(objs, validateOpts)
+		default:
+			if m.scope() == declared.RootScope && m.NamespaceStrategy == configsync.NamespaceStrategyImplicit {
+				// Unlike the reconciler, the implicit Namespaces are added whether
+				// or not they already exist in the cluster.
+				validateOpts.Visitors = append(validateOpts.Visitors, func(objs []ast.FileObject) ([]ast.FileObject, status.MultiError) {
+					return parse.AddImplicitNamespaces(objs, nil)
+				})
+			}
+			objs, err = validate.Unstructured(ctx, nil, objs, validateOpts)
+		}
+		errs = status.Append(errs, err)
+	}
+	if status.HasBlockingErrors(errs) {
+		return errs
+	}
+	if errs != nil {
+		util.PrintErrOrDie(fmt.Errorf("errors for %s %s/%s: %w", m.Kind, m.Namespace, m.Name, errs))
+	}
+
+	fileObjects := hydrate.GenerateFileObjects(false, objs...)
+	if opts.flat {
+		return hydrate.PrintFlatOutput(opts.outPath, opts.outputFormat, fileObjects)
+	}
+	return hydrate.PrintDirectoryOutput(opts.outPath, opts.outputFormat, fileObjects)
+}
@@ -0,0 +1,439 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleContainerTools/config-sync/cmd/nomos/flags"
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestReadSyncManifest(t *testing.T) {
+	testCases := []struct {
+		name     string
+		manifest string
+		want     *syncManifest
+		wantErr  string
+	}{
+		{
+			name: "RootSync with defaults",
+			manifest: `apiVersion: configsync.gke.io/v1beta1
+kind: RootSync
+metadata:
+  name: root-sync
+  namespace: config-management-system
+spec:
+  git:
+    repo: https://github.com/example/repo
+    dir: configs
+    auth: none
+`,
+			want: &syncManifest{
+				Kind:              configsync.RootSyncKind,
+				Name:              "root-sync",
+				Namespace:         configsync.ControllerNamespace,
+				SourceType:        configsync.GitSource,
+				SourceFormat:      configsync.SourceFormatHierarchy,
+				NamespaceStrategy: configsync.NamespaceStrategyImplicit,
+				Dir:               "configs",
+			},
+		},
+		{
+			name: "v1alpha1 RepoSync with ConfigMaps",
+			manifest: `apiVersion: configsync.gke.io/v1alpha1
+kind: RepoSync
+metadata:
+  name: repo-sync
+  namespace: bookstore
+spec:
+  sourceType: oci
+  oci:
+    image: us-docker.pkg.dev/example/configs
+    dir: bookstore
+    auth: none
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: values
+  namespace: bookstore
+data:
+  replicas: "3"
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: other-namespace
+  namespace: default
+`,
+			want: &syncManifest{
+				Kind:         configsync.RepoSyncKind,
+				Name:         "repo-sync",
+				Namespace:    "bookstore",
+				SourceType:   configsync.OciSource,
+				SourceFormat: configsync.SourceFormatUnstructured,
+				Dir:          "bookstore",
+			},
+		},
+		{
+			name: "RepoSync with the ClusterInfo ConfigMap",
+			manifest: `apiVersion: configsync.gke.io/v1beta1
+kind: RepoSync
+metadata:
+  name: repo-sync
+  namespace: bookstore
+spec:
+  git:
+    repo: https://github.com/example/repo
+    auth: none
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cluster-info
+  namespace: config-management-system
+data:
+  env: prod
+`,
+			want: &syncManifest{
+				Kind:          configsync.RepoSyncKind,
+				Name:          "repo-sync",
+				Namespace:     "bookstore",
+				SourceType:    configsync.GitSource,
+				SourceFormat:  configsync.SourceFormatUnstructured,
+				ClusterLabels: map[string]string{"env": "prod"},
+			},
+		},
+		{
+			name: "invalid ClusterInfo ConfigMap",
+			manifest: `apiVersion: configsync.gke.io/v1beta1
+kind: RootSync
+metadata:
+  name: root-sync
+  namespace: config-management-system
+spec:
+  git:
+    repo: https://github.com/example/repo
+    auth: none
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cluster-info
+  namespace: config-management-system
+data:
+  env: not a label value
+`,
+			wantErr: "invalid cluster labels in ClusterInfo ConfigMap config-management-system/cluster-info",
+		},
+		{
+			name: "no RootSync",
+			manifest: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: values
+`,
+			wantErr: "must declare exactly one RootSync or RepoSync, found 0",
+		},
+		{
+			name: "invalid RootSync",
+			manifest: `apiVersion: configsync.gke.io/v1beta1
+kind: RootSync
+metadata:
+  name: root-sync
+  namespace: config-management-system
+spec:
+  sourceType: helm
+`,
+			wantErr: "invalid RootSync",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rsync.yaml")
+			writeFile(t, path, tc.manifest)
+
+			got, err := readSyncManifest(path)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want.Kind, got.Kind)
+			assert.Equal(t, tc.want.Name, got.Name)
+			assert.Equal(t, tc.want.Namespace, got.Namespace)
+			assert.Equal(t, tc.want.SourceType, got.SourceType)
+			assert.Equal(t, tc.want.SourceFormat, got.SourceFormat)
+			assert.Equal(t, tc.want.NamespaceStrategy, got.NamespaceStrategy)
+			assert.Equal(t, tc.want.Dir, got.Dir)
+			assert.Equal(t, tc.want.ClusterLabels, got.ClusterLabels)
+		})
+	}
+}
+
+func TestHydrateSync(t *testing.T) {
+	flags.SkipAPIServer = true
+	defer func() {
+		flags.SkipAPIServer = false
+	}()
+
+	testCases := []struct {
+		name      string
+		manifest  string
+		wantFiles map[string]string
+		wantErr   string
+	}{
+		{
+			name: "RootSync with implicit namespaces and substitution",
+			manifest: `apiVersion: configsync.gke.io/v1beta1
+kind: RootSync
+metadata:
+  name: root-sync
+  namespace: config-management-system
+spec:
+  sourceFormat: unstructured
+  git:
+    repo: https://github.com/example/repo
+    dir: configs
+    auth: none
+  substitution:
+    valuesFrom:
+      name: values
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: values
+  namespace: config-management-system
+data:
+  tier: gold
+`,
+			wantFiles: map[string]string{
+				"bookstore/configmap_settings.yaml": "tier: gold",
+				"namespace_bookstore.yaml":          "client.lifecycle.config.k8s.io/deletion: detach",
+			},
+		},
+		{
+			name: "RootSync with explicit namespaces",
+			manifest: `apiVersion: configsync.gke.io/v1beta1
+kind: RootSync
+metadata:
+  name: root-sync
+  namespace: config-management-system
+spec:
+  sourceFormat: unstructured
+  git:
+    repo: https://github.com/example/repo
+    dir: configs
+    auth: none
+  override:
+    namespaceStrategy: explicit
+  substitution:
+    valuesFrom:
+      name: values
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: values
+  namespace: config-management-system
+data:
+  tier: silver
+`,
+			wantFiles: map[string]string{
+				"bookstore/configmap_settings.yaml": "tier: silver",
+			},
+		},
+		{
+			name: "missing substitution ConfigMap",
+			manifest: `apiVersion: configsync.gke.io/v1beta1
+kind: RootSync
+metadata:
+  name: root-sync
+  namespace: config-management-system
+spec:
+  sourceFormat: unstructured
+  git:
+    repo: https://github.com/example/repo
+    dir: configs
+    auth: none
+  substitution:
+    valuesFrom:
+      name: values
+`,
+			wantErr: "ConfigMap config-management-system/values referenced by spec.substitution.valuesFrom must be declared in the manifest",
+		},
+		{
+			name: "RepoSync with an object in another namespace",
+			manifest: `apiVersion: configsync.gke.io/v1beta1
+kind: RepoSync
+metadata:
+  name: repo-sync
+  namespace: shipping
+spec:
+  git:
+    repo: https://github.com/example/repo
+    dir: configs
+    auth: none
+`,
+			wantErr: "bookstore",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFile(t, filepath.Join(dir, "repo", "configs", "settings.yaml"), `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: bookstore
+data:
+  tier: ${values.tier}
+`)
+			manifest := filepath.Join(dir, "rsync.yaml")
+			writeFile(t, manifest, tc.manifest)
+			out := filepath.Join(dir, "compiled")
+
+			err := hydrateSync(context.Background(), manifest, hydrateOptions{
+				path:         filepath.Join(dir, "repo"),
+				outputFormat: flags.OutputYAML,
+				outPath:      out,
+			})
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			for file, want := range tc.wantFiles {
+				data, err := os.ReadFile(filepath.Join(out, file))
+				require.NoError(t, err)
+				assert.Contains(t, string(data), want)
+			}
+			if _, found := tc.wantFiles["namespace_bookstore.yaml"]; !found {
+				_, err := os.Stat(filepath.Join(out, "namespace_bookstore.yaml"))
+				assert.True(t, os.IsNotExist(err))
+			}
+		})
+	}
+}
+
+func TestHydrateSyncClusterLabels(t *testing.T) {
+	flags.SkipAPIServer = true
+	defer func() {
+		flags.SkipAPIServer = false
+	}()
+
+	testCases := []struct {
+		name          string
+		clusterInfo   string
+		clusterLabels map[string]string
+		wantSelected  bool
+		wantRegion    string
+	}{
+		{
+			name:          "labels of the flag",
+			clusterLabels: map[string]string{"env": "prod", "region": "eu"},
+			wantSelected:  true,
+			wantRegion:    "eu",
+		},
+		{
+			name: "ClusterInfo ConfigMap takes precedence",
+			clusterInfo: `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cluster-info
+  namespace: config-management-system
+data:
+  env: dev
+  region: us
+`,
+			clusterLabels: map[string]string{"env": "prod", "region": "eu"},
+			wantSelected:  false,
+			wantRegion:    "us",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFile(t, filepath.Join(dir, "repo", "selector.yaml"), `apiVersion: configmanagement.gke.io/v1
+kind: ClusterSelector
+metadata:
+  name: prod
+spec:
+  selector:
+    matchLabels:
+      env: prod
+`)
+			writeFile(t, filepath.Join(dir, "repo", "prod.yaml"), `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: prod
+  namespace: bookstore
+  annotations:
+    configmanagement.gke.io/cluster-selector: prod
+`)
+			writeFile(t, filepath.Join(dir, "repo", "region.yaml"), `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: region
+  namespace: bookstore
+data:
+  region: ${cluster.labels.region}
+`)
+			manifest := filepath.Join(dir, "rsync.yaml")
+			writeFile(t, manifest, `apiVersion: configsync.gke.io/v1beta1
+kind: RootSync
+metadata:
+  name: root-sync
+  namespace: config-management-system
+spec:
+  sourceFormat: unstructured
+  git:
+    repo: https://github.com/example/repo
+    auth: none
+  substitution: {}
+`+tc.clusterInfo)
+			out := filepath.Join(dir, "compiled")
+
+			err := hydrateSync(context.Background(), manifest, hydrateOptions{
+				path:          filepath.Join(dir, "repo"),
+				clusterLabels: tc.clusterLabels,
+				outputFormat:  flags.OutputYAML,
+				outPath:       out,
+			})
+			require.NoError(t, err)
+			_, err = os.Stat(filepath.Join(out, "bookstore", "configmap_prod.yaml"))
+			assert.Equal(t, tc.wantSelected, err == nil)
+			data, err := os.ReadFile(filepath.Join(out, "bookstore", "configmap_region.yaml"))
+			require.NoError(t, err)
+			assert.Contains(t, string(data), "region: "+tc.wantRegion)
+		})
+	}
+}
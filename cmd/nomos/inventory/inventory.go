@@ -0,0 +1,244 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package inventory contains the nomos inventory CLI commands.
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleContainerTools/config-sync/cmd/nomos/flags"
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync/v1beta1"
+	kptv1alpha1 "github.com/GoogleContainerTools/config-sync/pkg/api/kpt.dev/v1alpha1"
+	"github.com/GoogleContainerTools/config-sync/pkg/applier"
+	csinventory "github.com/GoogleContainerTools/config-sync/pkg/applier/inventory"
+	"github.com/GoogleContainerTools/config-sync/pkg/client/restconfig"
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/declared"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/klog/v2"
+	"k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	syncName      string
+	syncNamespace string
+	adoptFrom     string
+	dryRun        bool
+)
+
+func init() {
+	repairCmd.Flags().StringVar(&syncName, "sync-name", configsync.RootSyncName,
+		"Name of the RootSync or RepoSync whose inventory is repaired.")
+	repairCmd.Flags().StringVar(&syncNamespace, "sync-namespace", configsync.ControllerNamespace,
+		fmt.Sprintf("Namespace of the RootSync or RepoSync whose inventory is repaired. RootSyncs are in the %s namespace.",
+			configsync.ControllerNamespace))
+	repairCmd.Flags().StringVar(&adoptFrom, "adopt-from", "",
+		"Adopt the objects managed by another RootSync, specified as NAME, or RepoSync, specified as NAMESPACE/NAME.")
+	repairCmd.Flags().BoolVar(&dryRun, "dry-run", false,
+		"If true, only print the changes, without updating the objects or the inventory.")
+	repairCmd.Flags().DurationVar(&flags.ClientTimeout, "timeout", restconfig.DefaultTimeout,
+		"Timeout for connecting to the cluster")
+	Cmd.AddCommand(repairCmd)
+}
+
+// Cmd is the Cobra object representing the nomos inventory command.
+var Cmd = &cobra.Command{
+	Use:   "inventory",
+	Short: "Manage the inventory of the objects managed by a RootSync or RepoSync.",
+}
+
+var repairCmd = &cobra.Command{
+	Use:   "repair",
+	Short: "Rebuild the ResourceGroup inventory of a RootSync or RepoSync from the cluster.",
+	Long: "Rebuild the ResourceGroup inventory of a RootSync or RepoSync from the objects on the cluster " +
+		"annotated as managed by it, when the inventory was lost or corrupted. Reports the managed objects " +
+		"which are not declared by the last synced commit anymore, which are pruned by the next sync.",
+	Args: cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		// Don't show usage on error, as argument validation passed.
+		cmd.SilenceUsage = true
+
+		scope := declared.RootScope
+		if syncNamespace != configsync.ControllerNamespace {
+			scope = declared.Scope(syncNamespace)
+		}
+		var adoptFromManager string
+		if adoptFrom != "" {
+			adoptScope, adoptName, err := declared.ParseSyncRef(adoptFrom)
+			if err != nil {
+				return err
+			}
+			adoptFromManager = declared.ResourceManager(adoptScope, adoptName)
+		}
+		return repair(cmd.Context(), scope, adoptFromManager)
+	},
+}
+
+func repair(ctx context.Context, scope declared.Scope, adoptFromManager string) error {
+	cfg, err := restconfig.NewRestConfig(flags.ClientTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to create rest config: %w", err)
+	}
+	c, err := client.New(cfg, client.Options{})
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+	dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create discovery client: %w", err)
+	}
+	configFlags, err := restconfig.NewConfigFlags(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create config flags: %w", err)
+	}
+
+	commit, err := lastSyncedCommit(ctx, c, scope)
+	if err != nil {
+		return err
+	}
+	statusMode, err := inventoryStatusMode(ctx, c, scope)
+	if err != nil {
+		return err
+	}
+	ic := csinventory.NewInventoryConverter(scope, syncName, statusMode)
+	invClient, err := ic.UnstructuredClientFromFactory(util.NewFactory(util.NewMatchVersionFlags(configFlags)))
+	if err != nil {
+		return fmt.Errorf("failed to create inventory client: %w", err)
+	}
+
+	resourceLists, err := discovery.ServerPreferredResources(dc)
+	if err != nil {
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return fmt.Errorf("failed to discover the API resources: %w", err)
+		}
+		klog.Warningf("Skipping the API groups that failed discovery: %v", err)
+	}
+
+	result, err := applier.RepairInventory(ctx, c, invClient, scope, syncName, applier.InventoryRepairOptions{
+		GVKs:      listableGVKs(resourceLists),
+		AdoptFrom: adoptFromManager,
+		Commit:    commit,
+		DryRun:    dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	verb := "Repaired"
+	if dryRun {
+		verb = "Would repair"
+	}
+	fmt.Printf("%s the inventory of %s %s/%s\n", verb, scope.SyncKind(), syncNamespace, syncName)
+	printObjects("Added to the inventory", result.Added)
+	printObjects("Removed from the inventory", result.Removed)
+	printObjects("Adopted", result.Adopted)
+	if commit != "" {
+		printObjects(fmt.Sprintf("Not declared by commit %s, pruned by the next sync", commit), result.Undeclared)
+	}
+	if !result.Changed() {
+		fmt.Println("The inventory is up to date")
+	}
+	return nil
+}
+
+// lastSyncedCommit returns the last commit synced by the RootSync or RepoSync,
+// or an empty string if unknown.
+func lastSyncedCommit(ctx context.Context, c client.Client, scope declared.Scope) (string, error) {
+	key := client.ObjectKey{Namespace: syncNamespace, Name: syncName}
+	var rsStatus v1beta1.Status
+	if scope == declared.RootScope {
+		rs := &v1beta1.RootSync{}
+		if err := c.Get(ctx, key, rs); err != nil {
+			return "", fmt.Errorf("failed to get RootSync %s: %w", key, err)
+		}
+		rsStatus = rs.Status.Status
+	} else {
+		rs := &v1beta1.RepoSync{}
+		if err := c.Get(ctx, key, rs); err != nil {
+			return "", fmt.Errorf("failed to get RepoSync %s: %w", key, err)
+		}
+		rsStatus = rs.Status.Status
+	}
+	return rsStatus.LastSyncedCommit, nil
+}
+
+// inventoryStatusMode returns the status mode of the existing ResourceGroup,
+// or the default status mode if it doesn't exist.
+func inventoryStatusMode(ctx context.Context, c client.Client, scope declared.Scope) (metadata.StatusMode, error) {
+	rg := &kptv1alpha1.ResourceGroup{}
+	key := client.ObjectKey{Namespace: scope.SyncNamespace(), Name: syncName}
+	if err := c.Get(ctx, key, rg); err != nil {
+		if apierrors.IsNotFound(err) {
+			return metadata.StatusEnabled, nil
+		}
+		return "", fmt.Errorf("failed to get ResourceGroup %s: %w", key, err)
+	}
+	if core.GetAnnotation(rg, metadata.StatusModeAnnotationKey) == metadata.StatusDisabled.String() {
+		return metadata.StatusDisabled, nil
+	}
+	return metadata.StatusEnabled, nil
+}
+
+// listableGVKs returns the kinds of the resources which can be listed,
+// excluding subresources.
+func listableGVKs(resourceLists []*metav1.APIResourceList) []schema.GroupVersionKind {
+	var gvks []schema.GroupVersionKind
+	for _, list := range resourceLists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			klog.Warningf("Skipping invalid group version %q: %v", list.GroupVersion, err)
+			continue
+		}
+		for _, resource := range list.APIResources {
+			if strings.Contains(resource.Name, "/") {
+				continue
+			}
+			if !containsVerb(resource.Verbs, "list") || !containsVerb(resource.Verbs, "patch") {
+				continue
+			}
+			gvks = append(gvks, gv.WithKind(resource.Kind))
+		}
+	}
+	return gvks
+}
+
+func containsVerb(verbs metav1.Verbs, verb string) bool {
+	for _, v := range verbs {
+		if v == verb {
+			return true
+		}
+	}
+	return false
+}
+
+func printObjects(header string, refs object.ObjMetadataSet) {
+	if len(refs) == 0 {
+		return
+	}
+	fmt.Printf("%s (%d):\n", header, len(refs))
+	for _, ref := range refs {
+		fmt.Printf("  %s\n", ref)
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inventory
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/kinds"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestListableGVKs(t *testing.T) {
+	allVerbs := metav1.Verbs{"get", "list", "watch", "create", "update", "patch", "delete"}
+	resourceLists := []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "configmaps", Kind: "ConfigMap", Verbs: allVerbs},
+				{Name: "pods/status", Kind: "Pod", Verbs: allVerbs},
+				{Name: "bindings", Kind: "Binding", Verbs: metav1.Verbs{"create"}},
+			},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "deployments", Kind: "Deployment", Verbs: allVerbs},
+			},
+		},
+	}
+	assert.Equal(t, []schema.GroupVersionKind{kinds.ConfigMap(), kinds.Deployment()}, listableGVKs(resourceLists))
+}
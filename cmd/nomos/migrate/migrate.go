@@ -28,6 +28,7 @@ import (
 	"github.com/GoogleContainerTools/config-sync/cmd/nomos/status"
 	"github.com/GoogleContainerTools/config-sync/cmd/nomos/util"
 	"github.com/GoogleContainerTools/config-sync/pkg/api/configmanagement"
+	v1 "github.com/GoogleContainerTools/config-sync/pkg/api/configmanagement/v1"
 	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
 	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync/v1beta1"
 	"github.com/GoogleContainerTools/config-sync/pkg/client/restconfig"
@@ -67,6 +68,9 @@ const (
 var dryRun bool
 var waitTimeout time.Duration
 var removeConfigManagement bool
+var toUnstructured bool
+var unstructuredOutput string
+var namespaceSelectorMode string
 
 func init() {
 	Cmd.Flags().StringSliceVar(&flags.Contexts, "contexts", nil,
@@ -77,18 +81,31 @@ func init() {
 	Cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", defaultWaitTimeout, "Timeout for waiting for condition to be true")
 	Cmd.Flags().BoolVar(&removeConfigManagement, "remove-configmanagement", false,
 		`If enabled, removes the ConfigManagement operator and CRD. This establishes a standalone OSS Config Sync install.`)
+	Cmd.Flags().BoolVar(&toUnstructured, "to-unstructured", false,
+		`If enabled, converts the hierarchical repository of --path to an unstructured repository written to --output, instead of migrating the clusters.`)
+	flags.AddPath(Cmd)
+	flags.AddSkipAPIServerCheck(Cmd)
+	flags.AddAPIServerTimeout(Cmd)
+	Cmd.Flags().StringVar(&unstructuredOutput, "output", defaultUnstructuredOutput,
+		`Directory to write the unstructured repository to, with --to-unstructured. It must not exist or be empty.`)
+	Cmd.Flags().StringVar(&namespaceSelectorMode, "namespace-selector-mode", v1.NSSelectorStaticMode,
+		fmt.Sprintf(`Mode of the NamespaceSelectors of the unstructured repository, with --to-unstructured. Must be %q or %q.`, v1.NSSelectorStaticMode, v1.NSSelectorDynamicMode))
 }
 
 // Cmd performs the migration from mono-repo to multi-repo for all the provided contexts.
 var Cmd = &cobra.Command{
 	Use:   "migrate",
 	Short: "Migrates to the new Config Sync architecture by enabling the multi-repo mode.",
-	Long:  "Migrates to the new Config Sync architecture by enabling the multi-repo mode. It provides you with additional features and gives you the flexibility to sync to a single repository, or multiple repositories.",
+	Long:  "Migrates to the new Config Sync architecture by enabling the multi-repo mode. It provides you with additional features and gives you the flexibility to sync to a single repository, or multiple repositories. With --to-unstructured, converts a hierarchical repository to an unstructured repository instead.",
 	Args:  cobra.ExactArgs(0),
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		// Don't show usage on error, as argument validation passed.
 		cmd.SilenceUsage = true
 
+		if toUnstructured {
+			return migrateToUnstructured(cmd.Context(), flags.Path, unstructuredOutput, namespaceSelectorMode)
+		}
+
 		var contexts []string
 		if len(flags.Contexts) == 0 {
 			currentContext, err := restconfig.CurrentContextName()
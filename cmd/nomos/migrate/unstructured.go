@@ -0,0 +1,333 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/GoogleContainerTools/config-sync/cmd/nomos/flags"
+	nomosparse "github.com/GoogleContainerTools/config-sync/cmd/nomos/parse"
+	"github.com/GoogleContainerTools/config-sync/cmd/nomos/util"
+	v1 "github.com/GoogleContainerTools/config-sync/pkg/api/configmanagement/v1"
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configmanagement/v1/repo"
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/hydrate"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast/node"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/filesystem"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/filesystem/cmpath"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/reader"
+	"github.com/GoogleContainerTools/config-sync/pkg/kinds"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	"github.com/GoogleContainerTools/config-sync/pkg/validate/fileobjects"
+	treehydrate "github.com/GoogleContainerTools/config-sync/pkg/validate/tree/hydrate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	defaultUnstructuredOutput = "unstructured"
+	// inheritedDir is the directory of the objects inherited by a Namespace in
+	// the unstructured repository, under the directory of the Namespace.
+	inheritedDir = "inherited"
+)
+
+// migrateToUnstructured converts the hierarchical repository in the path
+// directory to an unstructured repository written to the output directory.
+func migrateToUnstructured(ctx context.Context, path, output, selectorMode string) error {
+	if selectorMode != v1.NSSelectorStaticMode && selectorMode != v1.NSSelectorDynamicMode {
+		return fmt.Errorf("--namespace-selector-mode must be %q or %q, got %q", v1.NSSelectorStaticMode, v1.NSSelectorDynamicMode, selectorMode)
+	}
+	if entries, err := os.ReadDir(output); err == nil && len(entries) > 0 {
+		return fmt.Errorf("the output directory %s must be empty", output)
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	rootDir, err := cmpath.AbsoluteOS(abs)
+	if err != nil {
+		return err
+	}
+	rootDir, err = rootDir.EvalSymlinks()
+	if err != nil {
+		return err
+	}
+	files, err := nomosparse.FindFiles(rootDir)
+	if err != nil {
+		return err
+	}
+	validateOpts, err := hydrate.ValidateOptions(rootDir, flags.APIServerTimeout)
+	if err != nil {
+		return err
+	}
+	validateOpts.FieldManager = util.FieldManager
+	parseOpts := hydrate.ParseOptions{
+		Parser:       filesystem.NewParser(&reader.File{}),
+		SourceFormat: configsync.SourceFormatHierarchy,
+		FilePaths: reader.FilePaths{
+			RootDir:   rootDir,
+			PolicyDir: cmpath.RelativeOS(rootDir.OSPath()),
+			Files:     filesystem.FilterHierarchyFiles(rootDir, files),
+		},
+	}
+
+	// The conversion assumes the repository is valid for every cluster.
+	invalid := false
+	hydrate.ForEachCluster(ctx, parseOpts, validateOpts, func(clusterName string, _ []ast.FileObject, err status.MultiError) {
+		if err != nil {
+			if clusterName == "" {
+				clusterName = nomosparse.UnregisteredCluster
+			}
+			util.PrintErrOrDie(fmt.Errorf("errors for Cluster %q: %w", clusterName, err))
+			invalid = true
+		}
+	})
+	if invalid {
+		return fmt.Errorf("the hierarchical repository %s must be valid to be migrated", abs)
+	}
+
+	objs, errs := parseOpts.Parser.Parse(parseOpts.FilePaths)
+	if errs != nil {
+		return errs
+	}
+	converted, err := convertToUnstructured(objs, selectorMode)
+	if err != nil {
+		return err
+	}
+	if err := hydrate.PrintDirectoryOutput(output, flags.OutputYAML, converted); err != nil {
+		return err
+	}
+	printSuccess("The unstructured repository has been written to %s", output)
+	printHint("Sync it with a RootSync with spec.sourceFormat: %s", configsync.SourceFormatUnstructured)
+	return nil
+}
+
+// convertToUnstructured converts the objects of a valid hierarchical
+// repository to the objects of an equivalent unstructured repository:
+//   - The objects inherited from abstract namespaces are copied into each
+//     Namespace, under its inherited/ directory.
+//   - The objects of abstract namespaces with a legacy NamespaceSelector are
+//     kept in place with the NamespaceSelector of the given mode, if it selects
+//     the same Namespaces as in the hierarchy. Otherwise, they are copied into
+//     the selected Namespaces.
+//   - The Namespaces declared in abstract namespaces are removed, and their
+//     labels and annotations are set on the descendant Namespaces.
+//   - The Repo and HierarchyConfigs are removed.
+//
+// The other objects are kept at the same path, so that their source path
+// doesn't change.
+func convertToUnstructured(objs []ast.FileObject, selectorMode string) ([]ast.FileObject, error) {
+	var result []ast.FileObject
+	scoped := &fileobjects.Scoped{}
+	for _, obj := range objs {
+		// Config Sync sets the source path when the objects are parsed.
+		core.RemoveAnnotations(obj, metadata.SourcePathAnnotationKey)
+		switch obj.Split()[0] {
+		case repo.SystemDir:
+			scoped.Cluster = append(scoped.Cluster, obj)
+		case repo.NamespacesDir:
+			gvk := obj.GetObjectKind().GroupVersionKind()
+			if gvk == kinds.Namespace() || gvk == kinds.NamespaceSelector() {
+				scoped.Cluster = append(scoped.Cluster, obj)
+			} else {
+				scoped.Namespace = append(scoped.Namespace, obj)
+			}
+		default:
+			// The cluster/ and clusterregistry/ objects don't change.
+			result = append(result, obj)
+		}
+	}
+
+	tree, errs := fileobjects.BuildTree(scoped)
+	if errs != nil {
+		return nil, errs
+	}
+	if errs := treehydrate.Inheritance(tree); errs != nil {
+		return nil, errs
+	}
+	selectors := map[string]labels.Selector{}
+	for name, obj := range tree.NamespaceSelectors {
+		s, err := obj.Structured()
+		if err != nil {
+			return nil, err
+		}
+		selector, sErr := metav1.LabelSelectorAsSelector(&s.(*v1.NamespaceSelector).Spec.Selector)
+		if sErr != nil {
+			return nil, sErr
+		}
+		selectors[name] = selector
+	}
+
+	var namespaces []*ast.TreeNode
+	collectNamespaces(tree.Tree, &namespaces)
+
+	// The copies of the objects of abstract namespaces with a legacy
+	// NamespaceSelector, by object.
+	selected := map[string]*selectedObject{}
+	for _, n := range namespaces {
+		var nsLabels labels.Set
+		for _, obj := range n.Objects {
+			if obj.GetObjectKind().GroupVersionKind() == kinds.Namespace() {
+				nsLabels = obj.GetLabels()
+				result = append(result, obj)
+			}
+		}
+		for _, obj := range n.Objects {
+			if obj.GetObjectKind().GroupVersionKind() == kinds.Namespace() {
+				continue
+			}
+			inherited := !obj.Dir().Equal(n.Relative)
+			ns := obj.DeepCopy()
+			ns.SetNamespace(n.Name())
+			if inherited {
+				ns.Relative = inheritedPath(n, obj)
+			}
+			selectorName, hasSelector := obj.GetAnnotations()[metadata.NamespaceSelectorAnnotationKey]
+			if !hasSelector {
+				result = append(result, ns)
+				continue
+			}
+			if !selectors[selectorName].Matches(nsLabels) {
+				continue
+			}
+			// An object in a Namespace of an unstructured repository must not
+			// declare a NamespaceSelector.
+			core.RemoveAnnotations(ns, metadata.NamespaceSelectorAnnotationKey)
+			if !inherited {
+				result = append(result, ns)
+				continue
+			}
+			key := obj.SlashPath() + "|" + core.IDOf(obj).String()
+			if selected[key] == nil {
+				selected[key] = &selectedObject{object: obj, selector: selectorName, namespaces: map[string]bool{}}
+			}
+			selected[key].namespaces[n.Name()] = true
+			selected[key].copies = append(selected[key].copies, ns)
+		}
+	}
+
+	var keys []string
+	for key := range selected {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	usedSelectors := map[string]bool{}
+	for _, key := range keys {
+		s := selected[key]
+		if sameNamespaces(s.namespaces, selectedNamespaces(namespaces, selectors[s.selector])) {
+			// The NamespaceSelector selects the same Namespaces in the
+			// unstructured repository, where it isn't limited to the
+			// Namespaces of the abstract namespace.
+			result = append(result, s.object)
+			usedSelectors[s.selector] = true
+		} else {
+			result = append(result, s.copies...)
+		}
+	}
+
+	var selectorNames []string
+	for name := range usedSelectors {
+		selectorNames = append(selectorNames, name)
+	}
+	sort.Strings(selectorNames)
+	for _, name := range selectorNames {
+		obj := tree.NamespaceSelectors[name]
+		if err := unstructured.SetNestedField(obj.Object, selectorMode, "spec", "mode"); err != nil {
+			return nil, err
+		}
+		result = append(result, obj)
+	}
+	// Don't print the empty metadata left by the removed annotations.
+	removeEmptyMetadata(result)
+	return result, nil
+}
+
+func removeEmptyMetadata(objs []ast.FileObject) {
+	for _, obj := range objs {
+		if len(obj.GetAnnotations()) == 0 {
+			obj.SetAnnotations(nil)
+		}
+		if len(obj.GetLabels()) == 0 {
+			obj.SetLabels(nil)
+		}
+	}
+}
+
+// selectedObject is an object of an abstract namespace with a legacy
+// NamespaceSelector.
+type selectedObject struct {
+	object   ast.FileObject
+	selector string
+	// namespaces are the names of the Namespaces the object is copied into in
+	// the hierarchy.
+	namespaces map[string]bool
+	// copies are the copies of the object in the Namespaces.
+	copies []ast.FileObject
+}
+
+func collectNamespaces(n *ast.TreeNode, namespaces *[]*ast.TreeNode) {
+	if n.Type == node.Namespace {
+		*namespaces = append(*namespaces, n)
+		return
+	}
+	for _, child := range n.Children {
+		collectNamespaces(child, namespaces)
+	}
+}
+
+// selectedNamespaces returns the names of all the Namespaces of the repository
+// the selector selects.
+func selectedNamespaces(namespaces []*ast.TreeNode, selector labels.Selector) map[string]bool {
+	result := map[string]bool{}
+	for _, n := range namespaces {
+		for _, obj := range n.Objects {
+			if obj.GetObjectKind().GroupVersionKind() == kinds.Namespace() && selector.Matches(labels.Set(obj.GetLabels())) {
+				result[n.Name()] = true
+			}
+		}
+	}
+	return result
+}
+
+func sameNamespaces(a, b map[string]bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for ns := range a {
+		if !b[ns] {
+			return false
+		}
+	}
+	return true
+}
+
+// inheritedPath returns the path of the copy of an object inherited by the
+// Namespace: the path of the object relative to namespaces/, under the
+// inherited/ directory of the Namespace.
+func inheritedPath(n *ast.TreeNode, obj ast.FileObject) cmpath.Relative {
+	rel := strings.TrimPrefix(obj.SlashPath(), repo.NamespacesDir+"/")
+	return cmpath.RelativeSlash(path.Join(n.SlashPath(), inheritedDir, rel))
+}
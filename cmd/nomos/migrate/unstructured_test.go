@@ -0,0 +1,135 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"testing"
+
+	v1 "github.com/GoogleContainerTools/config-sync/pkg/api/configmanagement/v1"
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast"
+	"github.com/GoogleContainerTools/config-sync/pkg/kinds"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func nsSelector(name, env, mode string) ast.FileObject {
+	return k8sobjects.NamespaceSelectorAtPath("namespaces/eng/selector.yaml", core.Name(name),
+		func(o client.Object) {
+			nss := o.(*v1.NamespaceSelector)
+			nss.Spec.Selector.MatchLabels = map[string]string{"env": env}
+			nss.Spec.Mode = mode
+		})
+}
+
+func TestConvertToUnstructured(t *testing.T) {
+	testCases := []struct {
+		name         string
+		selectorMode string
+		objs         []ast.FileObject
+		want         []ast.FileObject
+	}{
+		{
+			name:         "copy inherited objects into namespaces",
+			selectorMode: v1.NSSelectorStaticMode,
+			objs: []ast.FileObject{
+				k8sobjects.Repo(),
+				k8sobjects.ClusterRole(core.Name("admin")),
+				k8sobjects.Namespace("namespaces/eng/backend"),
+				k8sobjects.Namespace("namespaces/eng/frontend"),
+				k8sobjects.RoleAtPath("namespaces/eng/role.yaml", core.Name("reader")),
+				k8sobjects.RoleAtPath("namespaces/eng/backend/role.yaml", core.Name("writer"),
+					core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/eng/backend/role.yaml")),
+			},
+			want: []ast.FileObject{
+				k8sobjects.ClusterRole(core.Name("admin")),
+				k8sobjects.Namespace("namespaces/eng/backend"),
+				k8sobjects.RoleAtPath("namespaces/eng/backend/role.yaml", core.Name("writer"),
+					core.Namespace("backend")),
+				k8sobjects.RoleAtPath("namespaces/eng/backend/inherited/eng/role.yaml", core.Name("reader"),
+					core.Namespace("backend")),
+				k8sobjects.Namespace("namespaces/eng/frontend"),
+				k8sobjects.RoleAtPath("namespaces/eng/frontend/inherited/eng/role.yaml", core.Name("reader"),
+					core.Namespace("frontend")),
+			},
+		},
+		{
+			name:         "inherit Namespace labels",
+			selectorMode: v1.NSSelectorStaticMode,
+			objs: []ast.FileObject{
+				k8sobjects.HierarchyConfig(
+					k8sobjects.HierarchyConfigKind(v1.HierarchyModeInherit, kinds.Namespace())),
+				k8sobjects.Namespace("namespaces/eng",
+					core.Label("org", "eng"), core.Label("env", "prod")),
+				k8sobjects.Namespace("namespaces/eng/backend",
+					core.Label("env", "dev")),
+			},
+			want: []ast.FileObject{
+				k8sobjects.Namespace("namespaces/eng/backend",
+					core.Label("org", "eng"), core.Label("env", "dev")),
+			},
+		},
+		{
+			name:         "keep NamespaceSelectors which select the same Namespaces",
+			selectorMode: v1.NSSelectorDynamicMode,
+			objs: []ast.FileObject{
+				nsSelector("dev", "dev", ""),
+				k8sobjects.Namespace("namespaces/eng/backend", core.Label("env", "dev")),
+				k8sobjects.Namespace("namespaces/eng/frontend", core.Label("env", "prod")),
+				k8sobjects.RoleAtPath("namespaces/eng/role.yaml", core.Name("reader"),
+					core.Annotation(metadata.NamespaceSelectorAnnotationKey, "dev")),
+			},
+			want: []ast.FileObject{
+				k8sobjects.Namespace("namespaces/eng/backend", core.Label("env", "dev")),
+				k8sobjects.Namespace("namespaces/eng/frontend", core.Label("env", "prod")),
+				k8sobjects.RoleAtPath("namespaces/eng/role.yaml", core.Name("reader"),
+					core.Annotation(metadata.NamespaceSelectorAnnotationKey, "dev")),
+				nsSelector("dev", "dev", v1.NSSelectorDynamicMode),
+			},
+		},
+		{
+			name:         "copy the objects of NamespaceSelectors limited to the abstract namespace",
+			selectorMode: v1.NSSelectorStaticMode,
+			objs: []ast.FileObject{
+				nsSelector("dev", "dev", ""),
+				k8sobjects.Namespace("namespaces/eng/backend", core.Label("env", "dev")),
+				k8sobjects.Namespace("namespaces/ops", core.Label("env", "dev")),
+				k8sobjects.RoleAtPath("namespaces/eng/role.yaml", core.Name("reader"),
+					core.Annotation(metadata.NamespaceSelectorAnnotationKey, "dev")),
+			},
+			want: []ast.FileObject{
+				k8sobjects.Namespace("namespaces/eng/backend", core.Label("env", "dev")),
+				k8sobjects.Namespace("namespaces/ops", core.Label("env", "dev")),
+				k8sobjects.RoleAtPath("namespaces/eng/backend/inherited/eng/role.yaml", core.Name("reader"),
+					core.Namespace("backend")),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := convertToUnstructured(tc.objs, tc.selectorMode)
+			require.NoError(t, err)
+			// The test objects declare empty labels and annotations.
+			removeEmptyMetadata(tc.want)
+			if diff := cmp.Diff(tc.want, got, ast.CompareFileObject); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
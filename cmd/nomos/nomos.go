@@ -24,8 +24,11 @@ import (
 	"github.com/GoogleContainerTools/config-sync/cmd/nomos/bugreport"
 	"github.com/GoogleContainerTools/config-sync/cmd/nomos/hydrate"
 	"github.com/GoogleContainerTools/config-sync/cmd/nomos/initialize"
+	"github.com/GoogleContainerTools/config-sync/cmd/nomos/inventory"
 	"github.com/GoogleContainerTools/config-sync/cmd/nomos/migrate"
+	"github.com/GoogleContainerTools/config-sync/cmd/nomos/push"
 	"github.com/GoogleContainerTools/config-sync/cmd/nomos/status"
+	"github.com/GoogleContainerTools/config-sync/cmd/nomos/tree"
 	"github.com/GoogleContainerTools/config-sync/cmd/nomos/version"
 	"github.com/GoogleContainerTools/config-sync/cmd/nomos/vet"
 	"github.com/GoogleContainerTools/config-sync/pkg/api/configmanagement"
@@ -63,6 +66,9 @@ func init() {
 	rootCmd.AddCommand(status.Cmd)
 	rootCmd.AddCommand(bugreport.Cmd)
 	rootCmd.AddCommand(migrate.Cmd)
+	rootCmd.AddCommand(push.Cmd)
+	rootCmd.AddCommand(inventory.Cmd)
+	rootCmd.AddCommand(tree.Cmd)
 }
 
 func main() {
@@ -0,0 +1,107 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/GoogleContainerTools/config-sync/cmd/nomos/flags"
+	"github.com/GoogleContainerTools/config-sync/pkg/oci"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/spf13/cobra"
+)
+
+var (
+	image       string
+	source      string
+	revision    string
+	annotations map[string]string
+)
+
+func init() {
+	flags.AddPath(Cmd)
+	Cmd.Flags().StringVar(&image, "image", "",
+		`OCI image reference to push the artifact to, e.g. "us-docker.pkg.dev/my-project/configs/prod:v1".`)
+	Cmd.Flags().StringVar(&source, "source", "",
+		`URL of the source the configuration was built from, recorded in the "org.opencontainers.image.source" annotation.`)
+	Cmd.Flags().StringVar(&revision, "revision", "",
+		`Revision of the source the configuration was built from, recorded in the "org.opencontainers.image.revision" annotation.`)
+	Cmd.Flags().StringToStringVar(&annotations, "annotation", nil,
+		`Accepts a comma-separated list of KEY=VALUE annotations to add to the artifact manifest.`)
+}
+
+// Cmd is the Cobra object representing the nomos push command.
+var Cmd = &cobra.Command{
+	Use:   "push",
+	Short: "Pushes a local directory to an OCI registry as a configuration artifact.",
+	Long: `Pushes a local directory to an OCI registry as a configuration artifact.
+
+The artifact holds every file under --path, except .git directories, in a single
+gzipped tarball layer with the "application/vnd.configsync.content.v1.tar+gzip"
+media type. Config Sync extracts this layer when syncing from the image with
+sourceType: oci. Pushing the same files twice produces the same digest.
+
+Registry credentials are read from the Docker config file, as configured by
+"docker login" or "gcloud auth configure-docker".`,
+	Example: `  nomos push --path=./config --image=us-docker.pkg.dev/my-project/configs/prod:v1 \
+    --source=https://github.com/my-org/config --revision=$(git rev-parse HEAD)`,
+	Args: cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if image == "" {
+			return fmt.Errorf("--image must be specified")
+		}
+		info, err := os.Stat(flags.Path)
+		if err != nil {
+			return fmt.Errorf("failed to read --path %q: %w", flags.Path, err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("--path %q must be a directory", flags.Path)
+		}
+		// Don't show usage on error, as argument validation passed.
+		cmd.SilenceUsage = true
+
+		manifestAnnotations := map[string]string{}
+		for k, v := range annotations {
+			manifestAnnotations[k] = v
+		}
+		if source != "" {
+			manifestAnnotations[oci.SourceAnnotation] = source
+		}
+		if revision != "" {
+			manifestAnnotations[oci.RevisionAnnotation] = revision
+		}
+
+		artifact, err := oci.BuildArtifact(flags.Path, manifestAnnotations)
+		if err != nil {
+			return err
+		}
+		ref, err := name.ParseReference(image)
+		if err != nil {
+			return fmt.Errorf("failed to parse --image %q: %w", image, err)
+		}
+		authenticator, err := authn.DefaultKeychain.Resolve(ref.Context())
+		if err != nil {
+			return fmt.Errorf("failed to resolve credentials for %q: %w", image, err)
+		}
+		digest, err := oci.PushArtifact(cmd.Context(), artifact, image, authenticator)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Pushed %s@%s\n", image, digest)
+		return nil
+	},
+}
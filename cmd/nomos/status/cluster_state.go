@@ -25,6 +25,7 @@ import (
 	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
 	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync/v1beta1"
 	kptv1alpha1 "github.com/GoogleContainerTools/config-sync/pkg/api/kpt.dev/v1alpha1"
+	"github.com/GoogleContainerTools/config-sync/pkg/bucket"
 	"github.com/GoogleContainerTools/config-sync/pkg/reposync"
 	"github.com/GoogleContainerTools/config-sync/pkg/rootsync"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -78,6 +79,8 @@ type RepoState struct {
 	sourceType        configsync.SourceType
 	git               *v1beta1.Git
 	oci               *v1beta1.Oci
+	http              *v1beta1.HTTP
+	bucket            *v1beta1.Bucket
 	helm              *v1beta1.HelmBase
 	status            string
 	commit            string
@@ -89,7 +92,7 @@ type RepoState struct {
 }
 
 func (r *RepoState) printRows(writer io.Writer) {
-	util.MustFprintf(writer, "%s%s:%s\t%s\t\n", util.Indent, r.scope, r.syncName, sourceString(r.sourceType, r.git, r.oci, r.helm))
+	util.MustFprintf(writer, "%s%s:%s\t%s\t\n", util.Indent, r.scope, r.syncName, sourceString(r.sourceType, r.git, r.oci, r.http, r.bucket, r.helm))
 	if r.status == syncedMsg {
 		util.MustFprintf(writer, "%s%s @ %v\t%s\t\n", util.Indent, r.status, r.lastSyncTimestamp, r.commit)
 	} else {
@@ -133,10 +136,14 @@ func (r *RepoState) printRows(writer io.Writer) {
 	}
 }
 
-func sourceString(sourceType configsync.SourceType, git *v1beta1.Git, oci *v1beta1.Oci, helm *v1beta1.HelmBase) string {
+func sourceString(sourceType configsync.SourceType, git *v1beta1.Git, oci *v1beta1.Oci, http *v1beta1.HTTP, bucket *v1beta1.Bucket, helm *v1beta1.HelmBase) string {
 	switch sourceType {
 	case configsync.OciSource:
 		return ociString(oci)
+	case configsync.HTTPSource:
+		return httpString(http)
+	case configsync.BucketSource:
+		return bucketString(bucket)
 	case configsync.HelmSource:
 		return helmString(helm)
 	case configsync.GitSource:
@@ -182,6 +189,27 @@ func ociString(oci *v1beta1.Oci) string {
 	return ociStr
 }
 
+func httpString(http *v1beta1.HTTP) string {
+	if http == nil {
+		return "N/A"
+	}
+	if http.Dir == "" || http.Dir == "." || http.Dir == "/" {
+		return http.URL
+	}
+	return http.URL + "/" + path.Clean(strings.TrimPrefix(http.Dir, "/"))
+}
+
+func bucketString(b *v1beta1.Bucket) string {
+	if b == nil {
+		return "N/A"
+	}
+	bucketStr := bucket.URL(b.Endpoint, b.Name, b.Prefix, b.Insecure)
+	if b.Dir == "" || b.Dir == "." || b.Dir == "/" {
+		return bucketStr
+	}
+	return strings.TrimSuffix(bucketStr, "/") + "/" + path.Clean(strings.TrimPrefix(b.Dir, "/"))
+}
+
 func helmString(helm *v1beta1.HelmBase) string {
 	var helmStr string
 	if helm == nil {
@@ -204,6 +232,8 @@ func namespaceRepoStatus(rs *v1beta1.RepoSync, rg *kptv1alpha1.ResourceGroup, sy
 		sourceType: rs.Spec.SourceType,
 		git:        rs.Spec.Git,
 		oci:        rs.Spec.Oci,
+		http:       rs.Spec.HTTP,
+		bucket:     rs.Spec.Bucket,
 		helm:       reposync.GetHelmBase(rs.Spec.Helm),
 		commit:     emptyCommit,
 	}
@@ -306,6 +336,8 @@ func RootRepoStatus(rs *v1beta1.RootSync, rg *kptv1alpha1.ResourceGroup, syncing
 		sourceType: rs.Spec.SourceType,
 		git:        rs.Spec.Git,
 		oci:        rs.Spec.Oci,
+		http:       rs.Spec.HTTP,
+		bucket:     rs.Spec.Bucket,
 		helm:       rootsync.GetHelmBase(rs.Spec.Helm),
 		commit:     emptyCommit,
 	}
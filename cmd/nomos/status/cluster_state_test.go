@@ -294,6 +294,40 @@ func TestRepoState_PrintRows(t *testing.T) {
 			},
 			"  bookstore:repo-sync\tus-docker.pkg.dev/test-project/test-ar-repo/sample/test\t\n  ERROR\tabc123\t\n  TotalErrorCount: 2\n  Error:\terror1\t\n  Error:\terror2\t\n",
 		},
+		{
+			"HTTP archive synced",
+			&RepoState{
+				scope:      "bookstore",
+				syncName:   "repo-sync",
+				sourceType: configsync.HTTPSource,
+				http: &v1beta1.HTTP{
+					URL: "https://artifacts.example.com/configs.tar.gz",
+					Dir: "prod",
+				},
+				status:            "SYNCED",
+				commit:            "abc123",
+				lastSyncTimestamp: metav1.Time{Time: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)},
+			},
+			"  bookstore:repo-sync\thttps://artifacts.example.com/configs.tar.gz/prod\t\n  SYNCED @ 2026-01-02 03:04:05 +0000 UTC\tabc123\t\n",
+		},
+		{
+			"Bucket synced",
+			&RepoState{
+				scope:      "bookstore",
+				syncName:   "repo-sync",
+				sourceType: configsync.BucketSource,
+				bucket: &v1beta1.Bucket{
+					Endpoint: "storage.googleapis.com",
+					Name:     "configs",
+					Prefix:   "platform/",
+					Dir:      "prod",
+				},
+				status:            "SYNCED",
+				commit:            "abc123",
+				lastSyncTimestamp: metav1.Time{Time: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)},
+			},
+			"  bookstore:repo-sync\thttps://storage.googleapis.com/configs/platform/prod\t\n  SYNCED @ 2026-01-02 03:04:05 +0000 UTC\tabc123\t\n",
+		},
 		{
 			"Helm repo with source error",
 			&RepoState{
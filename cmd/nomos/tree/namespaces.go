@@ -0,0 +1,145 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/hydrate"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast"
+	"github.com/GoogleContainerTools/config-sync/pkg/kinds"
+)
+
+// namespaceView is the effective configuration of a Namespace of a
+// hierarchical repository.
+type namespaceView struct {
+	// Name is the name of the Namespace.
+	Name string
+	// Dir is the directory of the Namespace, relative to the repository root.
+	Dir string
+	// Labels are the effective labels of the Namespace, without the Config
+	// Sync labels.
+	Labels map[string]string
+	// Annotations are the effective annotations of the Namespace, without the
+	// Config Sync annotations.
+	Annotations map[string]string
+	// Objects are the objects in the Namespace, sorted by ID.
+	Objects []objectView
+}
+
+// objectView is an object in a Namespace of a hierarchical repository.
+type objectView struct {
+	ID core.ID
+	// Dir is the directory the object is declared in, relative to the
+	// repository root. It is an ancestor of the Namespace directory if the
+	// object is inherited.
+	Dir string
+}
+
+// Inherited returns true if the object is inherited from an abstract
+// namespace.
+func (o objectView) Inherited(ns *namespaceView) bool {
+	return o.Dir != ns.Dir
+}
+
+// namespaceTree returns the effective configuration of the Namespaces of the
+// hydrated objects of a hierarchical repository, sorted by directory.
+func namespaceTree(objs []ast.FileObject) []*namespaceView {
+	namespaces := map[string]*namespaceView{}
+	var namespaced []ast.FileObject
+	for _, obj := range objs {
+		if obj.GetObjectKind().GroupVersionKind() != kinds.Namespace() {
+			if obj.GetNamespace() != "" {
+				namespaced = append(namespaced, obj)
+			}
+			continue
+		}
+		// Only print the labels and annotations declared in the repository.
+		obj = obj.DeepCopy()
+		hydrate.Clean([]ast.FileObject{obj})
+		namespaces[obj.GetName()] = &namespaceView{
+			Name:        obj.GetName(),
+			Dir:         obj.Dir().SlashPath(),
+			Labels:      obj.GetLabels(),
+			Annotations: obj.GetAnnotations(),
+		}
+	}
+	for _, obj := range namespaced {
+		ns, found := namespaces[obj.GetNamespace()]
+		if !found {
+			continue
+		}
+		ns.Objects = append(ns.Objects, objectView{
+			ID:  core.IDOf(obj),
+			Dir: obj.Dir().SlashPath(),
+		})
+	}
+
+	var result []*namespaceView
+	for _, ns := range namespaces {
+		sort.Slice(ns.Objects, func(i, j int) bool {
+			return ns.Objects[i].ID.String() < ns.Objects[j].ID.String()
+		})
+		result = append(result, ns)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Dir < result[j].Dir
+	})
+	return result
+}
+
+// printNamespaces prints the effective configuration of the Namespaces.
+func printNamespaces(w io.Writer, namespaces []*namespaceView) error {
+	var b strings.Builder
+	for i, ns := range namespaces {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s (Namespace %s)\n", ns.Dir, ns.Name)
+		printMap(&b, "labels", ns.Labels)
+		printMap(&b, "annotations", ns.Annotations)
+		if len(ns.Objects) > 0 {
+			b.WriteString("  objects:\n")
+		}
+		for _, obj := range ns.Objects {
+			fmt.Fprintf(&b, "    %s/%s", obj.ID.GroupKind, obj.ID.Name)
+			if obj.Inherited(ns) {
+				fmt.Fprintf(&b, " (inherited from %s)", obj.Dir)
+			}
+			b.WriteString("\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func printMap(b *strings.Builder, name string, m map[string]string) {
+	if len(m) == 0 {
+		return
+	}
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fmt.Fprintf(b, "  %s:\n", name)
+	for _, k := range keys {
+		fmt.Fprintf(b, "    %s: %s\n", k, m[k])
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintNamespaces(t *testing.T) {
+	objs := []ast.FileObject{
+		k8sobjects.Namespace("namespaces/eng/backend",
+			core.Label("org", "eng"),
+			core.Label("backend.tree.hnc.x-k8s.io/depth", "0"),
+			core.Label(metadata.DeclaredVersionLabel, "v1"),
+			core.Annotation("owner", "backend"),
+			core.Annotation(metadata.SourcePathAnnotationKey, "namespaces/eng/backend/namespace.yaml")),
+		k8sobjects.RoleAtPath("namespaces/eng/role.yaml",
+			core.Name("reader"), core.Namespace("backend")),
+		k8sobjects.Deployment("namespaces/eng/backend",
+			core.Name("api"), core.Namespace("backend")),
+		k8sobjects.Namespace("namespaces/eng/frontend"),
+		k8sobjects.RoleAtPath("namespaces/eng/role.yaml",
+			core.Name("reader"), core.Namespace("frontend")),
+		k8sobjects.ClusterRole(core.Name("admin")),
+	}
+
+	var out strings.Builder
+	require.NoError(t, printNamespaces(&out, namespaceTree(objs)))
+	want := `namespaces/eng/backend (Namespace backend)
+  labels:
+    org: eng
+  annotations:
+    owner: backend
+  objects:
+    Deployment.apps/api
+    Role.rbac.authorization.k8s.io/reader (inherited from namespaces/eng)
+
+namespaces/eng/frontend (Namespace frontend)
+  objects:
+    Role.rbac.authorization.k8s.io/reader (inherited from namespaces/eng)
+`
+	assert.Equal(t, want, out.String())
+	// The objects are not modified.
+	assert.Equal(t, "v1", objs[0].GetLabels()[metadata.DeclaredVersionLabel])
+}
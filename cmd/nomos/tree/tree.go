@@ -0,0 +1,146 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/GoogleContainerTools/config-sync/cmd/nomos/flags"
+	nomosparse "github.com/GoogleContainerTools/config-sync/cmd/nomos/parse"
+	"github.com/GoogleContainerTools/config-sync/cmd/nomos/util"
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/hydrate"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/filesystem"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/filesystem/cmpath"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/reader"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	flags.AddClusters(Cmd)
+	flags.AddPath(Cmd)
+	flags.AddSkipAPIServerCheck(Cmd)
+	flags.AddAPIServerTimeout(Cmd)
+	flags.AddSubstitutionValues(Cmd)
+}
+
+// Cmd is the Cobra object representing the nomos tree command.
+var Cmd = &cobra.Command{
+	Use:   "tree",
+	Short: "Print the effective configuration of each Namespace of a hierarchical repository.",
+	Long: `Print the effective configuration of each Namespace of a hierarchical repository.
+
+For each Namespace, prints its directory, its effective labels and annotations,
+including the ones inherited from the Namespaces of abstract namespaces, and its
+objects, including the ones inherited from abstract namespaces, with the
+directory they are inherited from. If the repository declares any Cluster
+resources, prints the Namespaces of each Cluster.`,
+	Example: `  nomos tree
+  nomos tree --path=my/directory
+  nomos tree --clusters=prod`,
+	Args: cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		// Don't show usage on error, as argument validation passed.
+		cmd.SilenceUsage = true
+
+		rootDir, _, err := hydrate.ValidateHydrateFlags(configsync.SourceFormatHierarchy)
+		if err != nil {
+			return err
+		}
+		files, err := nomosparse.FindFiles(rootDir)
+		if err != nil {
+			return err
+		}
+		files = filesystem.FilterHierarchyFiles(rootDir, files)
+
+		validateOpts, err := hydrate.ValidateOptions(rootDir, flags.APIServerTimeout)
+		if err != nil {
+			return err
+		}
+		validateOpts.FieldManager = util.FieldManager
+
+		parseOpts := hydrate.ParseOptions{
+			Parser:       filesystem.NewParser(&reader.File{}),
+			SourceFormat: configsync.SourceFormatHierarchy,
+			FilePaths: reader.FilePaths{
+				RootDir:   rootDir,
+				PolicyDir: cmpath.RelativeOS(rootDir.OSPath()),
+				Files:     files,
+			},
+		}
+
+		clusterObjects := map[string][]ast.FileObject{}
+		encounteredError := false
+		hydrate.ForEachCluster(cmd.Context(), parseOpts, validateOpts, func(clusterName string, fileObjects []ast.FileObject, err status.MultiError) {
+			if !clusterEnabled(clusterName) {
+				return
+			}
+			if err != nil {
+				name := clusterName
+				if name == "" {
+					name = nomosparse.UnregisteredCluster
+				}
+				util.PrintErrOrDie(fmt.Errorf("errors for Cluster %q: %w", name, err))
+				encounteredError = true
+				if status.HasBlockingErrors(err) {
+					return
+				}
+			}
+			clusterObjects[clusterName] = fileObjects
+		})
+
+		var clusters []string
+		for cluster := range clusterObjects {
+			clusters = append(clusters, cluster)
+		}
+		sort.Strings(clusters)
+		for i, cluster := range clusters {
+			if len(clusters) > 1 {
+				if i > 0 {
+					fmt.Fprintln(cmd.OutOrStdout())
+				}
+				name := cluster
+				if name == "" {
+					name = nomosparse.UnregisteredCluster
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Cluster %s:\n", name)
+			}
+			if err := printNamespaces(cmd.OutOrStdout(), namespaceTree(clusterObjects[cluster])); err != nil {
+				return err
+			}
+		}
+
+		if encounteredError {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+func clusterEnabled(clusterName string) bool {
+	if flags.AllClusters() {
+		return true
+	}
+	for _, cluster := range flags.Clusters {
+		if clusterName == cluster {
+			return true
+		}
+	}
+	return false
+}
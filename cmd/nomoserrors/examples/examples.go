@@ -43,6 +43,7 @@ import (
 	"github.com/GoogleContainerTools/config-sync/pkg/status"
 	"github.com/GoogleContainerTools/config-sync/pkg/syncer/client"
 	"github.com/GoogleContainerTools/config-sync/pkg/util/clusterconfig"
+	"github.com/GoogleContainerTools/config-sync/pkg/validate/raw/hydrate"
 	"github.com/GoogleContainerTools/config-sync/pkg/validate/raw/validate"
 	rsyncvalidate "github.com/GoogleContainerTools/config-sync/pkg/validate/rsync/validate"
 	"github.com/GoogleContainerTools/config-sync/pkg/vet"
@@ -323,6 +324,19 @@ func Generate() AllExamples {
 	// 1070
 	result.add(system.MaxObjectCountError(system.DefaultMaxObjectCount, system.DefaultMaxObjectCount+1))
 
+	// 1071
+	result.add(hydrate.UndefinedVariableError(k8sobjects.ConfigMapObject(), "values.region"))
+
+	// 1072
+	result.add(validate.IllegalDriftPolicyAnnotationError(k8sobjects.Role(), "ignore"))
+
+	// 1073
+	result.add(validate.IllegalTransferToAnnotationError(k8sobjects.Role(), "a/b/c",
+		`invalid sync "a/b/c": must be NAME for a RootSync or NAMESPACE/NAME for a RepoSync`))
+
+	// 1074
+	result.add(validate.IllegalFieldOwnershipAnnotationError(k8sobjects.Role(), "exclusive"))
+
 	// 2001
 	result.add(status.PathWrapError(errors.New("error creating directory"), "namespaces/foo"))
 
@@ -381,6 +395,9 @@ func Generate() AllExamples {
 	// 2017
 	result.add(selectors.ListNamespaceError(errors.New("k8s api List error")))
 
+	// 2018
+	result.add(applier.PruneSafeguardError("1234567", 20, 25, configsync.RootSyncKind))
+
 	// 9998
 	result.add(status.InternalError("we made a mistake"))
 
@@ -52,6 +52,10 @@ var flOneTime = flag.Bool("one-time", util.EnvBool("OCI_SYNC_ONE_TIME", false),
 	"exit after the first sync")
 var flMaxSyncFailures = flag.Int("max-sync-failures", util.EnvInt("OCI_SYNC_MAX_SYNC_FAILURES", 0),
 	"the number of consecutive failures allowed before aborting (the first sync must succeed, -1 will retry forever after the initial sync)")
+var flLayerMediaType = flag.String("layer-media-type", util.EnvString(reconcilermanager.OciSyncLayerMediaType, ""),
+	"the media type of the OCI artifact layers to extract (defaults to \"\", extracting every supported layer)")
+var flLayerAnnotation = flag.String("layer-annotation", util.EnvString(reconcilermanager.OciSyncLayerAnnotation, ""),
+	"the annotation, in the form KEY or KEY=VALUE, of the OCI artifact layers to extract (defaults to \"\", extracting every supported layer)")
 var flUsername = flag.String("username", util.EnvString("OCI_SYNC_USERNAME", ""),
 	"the username to use for oci authentication")
 var flPassword = flag.String("password", util.EnvString("OCI_SYNC_PASSWORD", ""),
@@ -64,7 +68,8 @@ func main() {
 	log.Info("pulling OCI image with arguments", "--image", *flImage,
 		"--auth", *flAuth, "--root", *flRoot, "--dest", *flDest, "--wait", *flWait,
 		"--error-file", *flErrorFile, "--timeout", *flSyncTimeout,
-		"--one-time", *flOneTime, "--max-sync-failures", *flMaxSyncFailures)
+		"--one-time", *flOneTime, "--max-sync-failures", *flMaxSyncFailures,
+		"--layer-media-type", *flLayerMediaType, "--layer-annotation", *flLayerAnnotation)
 
 	if *flImage == "" {
 		utillog.HandleError(log, true, "ERROR: --image must be specified")
@@ -106,6 +111,10 @@ func main() {
 
 	fetcher := &oci.Fetcher{
 		Authenticator: authenticator,
+		LayerSelector: oci.LayerSelector{
+			MediaType:  *flLayerMediaType,
+			Annotation: *flLayerAnnotation,
+		},
 	}
 
 	for {
@@ -16,12 +16,16 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
 	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync/v1beta1"
+	"github.com/GoogleContainerTools/config-sync/pkg/applier"
 	"github.com/GoogleContainerTools/config-sync/pkg/declared"
 	"github.com/GoogleContainerTools/config-sync/pkg/importer/filesystem"
 	"github.com/GoogleContainerTools/config-sync/pkg/importer/filesystem/cmpath"
@@ -31,9 +35,11 @@ import (
 	"github.com/GoogleContainerTools/config-sync/pkg/reconciler"
 	"github.com/GoogleContainerTools/config-sync/pkg/reconcilermanager"
 	"github.com/GoogleContainerTools/config-sync/pkg/reconcilermanager/controllers"
+	"github.com/GoogleContainerTools/config-sync/pkg/remediator/queue"
 	"github.com/GoogleContainerTools/config-sync/pkg/status"
 	"github.com/GoogleContainerTools/config-sync/pkg/util"
 	"github.com/GoogleContainerTools/config-sync/pkg/util/log"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/klog/v2"
 	"k8s.io/klog/v2/textlogger"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -42,6 +48,22 @@ import (
 var (
 	clusterName = flag.String(flags.clusterName, os.Getenv(reconcilermanager.ClusterNameKey),
 		"Cluster name to use for Cluster selection")
+	clusterLabels = flag.String(flags.clusterLabels, os.Getenv(reconcilermanager.ClusterLabelsKey),
+		"Comma-separated key=value labels of the cluster to use for Cluster selection, in addition to the labels of the Cluster object.")
+	substitutionEnabled = flag.Bool("substitution-enabled", util.EnvBool(reconcilermanager.SubstitutionEnabledKey, false),
+		"Substitute the ${cluster.*} and ${values.*} variables in the resources.")
+	substitutionValues = flag.String(flags.substitutionValues, os.Getenv(reconcilermanager.SubstitutionValuesKey),
+		"JSON object of the values of the ${values.KEY} variables substituted in the resources.")
+	driftPolicy = flag.String(flags.driftPolicy, os.Getenv(reconcilermanager.DriftPolicyKey),
+		"Default drift policy of the managed resources, either 'correct' or 'report'.")
+	prunePolicy = flag.String(flags.prunePolicy, os.Getenv(reconcilermanager.PrunePolicyKey),
+		"JSON object of the prune policy of the managed resources removed from the source of truth.")
+	forceConflicts = flag.Bool("force-conflicts", util.EnvBool(reconcilermanager.ForceConflictsKey, true),
+		"Take the ownership of the declared fields managed by other field managers when applying and remediating resources with server-side apply.")
+	driftAuditLog = flag.Bool("drift-audit-log", util.EnvBool(reconcilermanager.DriftAuditLogKey, false),
+		"Write an audit record of every drift corrected by the remediator to stdout, as a JSON line.")
+	inventorySelfHeal = flag.Bool("inventory-self-heal", util.EnvBool(reconcilermanager.InventorySelfHealKey, false),
+		"Rebuild the ResourceGroup inventory from the managed objects on the cluster, when the ResourceGroup doesn't exist.")
 	scopeStr = flag.String("scope", os.Getenv(reconcilermanager.ScopeKey),
 		"Scope of the reconciler, either a namespace or ':root'.")
 	syncName = flag.String("sync-name", os.Getenv(reconcilermanager.SyncNameKey),
@@ -52,9 +74,9 @@ var (
 	// source configuration flags. These values originate in the ConfigManagement and
 	// configure git-sync/oci-sync to clone the desired repository/reference we want.
 	sourceType = flag.String("source-type", os.Getenv(reconcilermanager.SourceTypeKey),
-		"The type of repo being synced, must be git or oci or helm.")
+		"The type of repo being synced, must be git or oci or helm or http or bucket.")
 	sourceRepo = flag.String("source-repo", os.Getenv(reconcilermanager.SourceRepoKey),
-		"The URL of the git or OCI repo being synced.")
+		"The URL of the git or OCI repo, or the HTTP archive or bucket, being synced.")
 	sourceBranch = flag.String("source-branch", os.Getenv(reconcilermanager.SourceBranchKey),
 		"The branch of the git repo being synced.")
 	sourceRev = flag.String("source-rev", os.Getenv(reconcilermanager.SourceRevKey),
@@ -78,6 +100,8 @@ var (
 		"Period of time between forced re-syncs from source (even without a new commit).")
 	workers = flag.Int("workers", 1,
 		"Number of concurrent remediator workers to run at once.")
+	remediatorPriorities = flag.String(flags.remediatorPriorities, os.Getenv(reconcilermanager.RemediatorPrioritiesKey),
+		"Comma-separated Kind.group=priority pairs. Objects of kinds with a higher priority are remediated first. Other kinds have priority 0.")
 	pollingPeriod = flag.Duration("filesystem-polling-period",
 		controllers.PollingPeriod(reconcilermanager.ReconcilerPollingPeriod, configsync.DefaultReconcilerPollingPeriod),
 		"Period of time between checking the filesystem for source updates to sync.")
@@ -97,7 +121,9 @@ var (
 		"Enable debug mode, panicking in many scenarios where normally an InternalError would be logged. "+
 			"Do not use in production.")
 
-	renderingEnabled  = flag.Bool("rendering-enabled", util.EnvBool(reconcilermanager.RenderingEnabled, false), "")
+	renderingEnabled        = flag.Bool("rendering-enabled", util.EnvBool(reconcilermanager.RenderingEnabled, false), "")
+	functionPipelineEnabled = flag.Bool("function-pipeline-enabled", util.EnvBool(reconcilermanager.FunctionPipelineEnabled, false),
+		"Require rendering when the Kptfile of the sync directory declares a KRM function pipeline.")
 	namespaceStrategy = flag.String(flags.namespaceStrategy, util.EnvString(reconcilermanager.NamespaceStrategy, ""),
 		fmt.Sprintf("Set the namespace strategy for the reconciler. Must be %s or %s. Default: %s.",
 			configsync.NamespaceStrategyImplicit, configsync.NamespaceStrategyExplicit, configsync.NamespaceStrategyImplicit))
@@ -107,28 +133,42 @@ var (
 	webhookEnabled       = flag.Bool("webhook-enabled", util.EnvBool(reconcilermanager.WebhookEnabled, false), "")
 	reconcilerSignalsDir = flag.String(flags.reconcilerSignalDir, "/reconciler-signals",
 		"The absolute path in the container that contains reconciler signals that unblock the rendering phase, for example, the latest image digest that is ready to render.")
+	hydrationSignalsDir = flag.String(flags.hydrationSignalDir, "/hydration-signals",
+		"The absolute path in the container that contains the socket of the hydration-controller, which serves the render requests and status.")
 )
 
 var flags = struct {
-	sourceDir           string
-	repoRootDir         string
-	hydratedRootDir     string
-	reconcilerSignalDir string
-	clusterName         string
-	sourceFormat        string
-	statusMode          string
-	reconcileTimeout    string
-	namespaceStrategy   string
+	sourceDir            string
+	repoRootDir          string
+	hydratedRootDir      string
+	reconcilerSignalDir  string
+	hydrationSignalDir   string
+	clusterName          string
+	clusterLabels        string
+	substitutionValues   string
+	remediatorPriorities string
+	prunePolicy          string
+	driftPolicy          string
+	sourceFormat         string
+	statusMode           string
+	reconcileTimeout     string
+	namespaceStrategy    string
 }{
-	repoRootDir:         "repo-root",
-	sourceDir:           "source-dir",
-	hydratedRootDir:     "hydrated-root",
-	reconcilerSignalDir: "reconciler-signals",
-	clusterName:         "cluster-name",
-	sourceFormat:        reconcilermanager.SourceFormat,
-	statusMode:          "status-mode",
-	reconcileTimeout:    "reconcile-timeout",
-	namespaceStrategy:   "namespace-strategy",
+	repoRootDir:          "repo-root",
+	sourceDir:            "source-dir",
+	hydratedRootDir:      "hydrated-root",
+	reconcilerSignalDir:  "reconciler-signals",
+	hydrationSignalDir:   "hydration-signals",
+	clusterName:          "cluster-name",
+	clusterLabels:        "cluster-labels",
+	substitutionValues:   "substitution-values",
+	remediatorPriorities: "remediator-priorities",
+	prunePolicy:          "prune-policy",
+	driftPolicy:          "drift-policy",
+	sourceFormat:         reconcilermanager.SourceFormat,
+	statusMode:           "status-mode",
+	reconcileTimeout:     "reconcile-timeout",
+	namespaceStrategy:    "namespace-strategy",
 }
 
 func main() {
@@ -168,6 +208,11 @@ func main() {
 		klog.Fatalf("%s must be an absolute path: %v", flags.reconcilerSignalDir, err)
 	}
 
+	absHydrationSignalDir, err := cmpath.AbsoluteOS(*hydrationSignalsDir)
+	if err != nil {
+		klog.Fatalf("%s must be an absolute path: %v", flags.hydrationSignalDir, err)
+	}
+
 	// Normalize syncDirRelative.
 	// Some users specify the directory as if the root of the repository is "/".
 	// Strip this from the front of the passed directory so behavior is as
@@ -179,6 +224,40 @@ func main() {
 		klog.Fatalf("%s must be an absolute path: %v", flags.sourceDir, err)
 	}
 
+	parsedClusterLabels, err := labels.ConvertSelectorToLabelsMap(*clusterLabels)
+	if err != nil {
+		klog.Fatalf("%s must be a comma-separated list of key=value labels: %v", flags.clusterLabels, err)
+	}
+
+	var parsedSubstitutionValues map[string]string
+	if *substitutionValues != "" {
+		if err := json.Unmarshal([]byte(*substitutionValues), &parsedSubstitutionValues); err != nil {
+			klog.Fatalf("%s must be a JSON object of string values: %v", flags.substitutionValues, err)
+		}
+	}
+
+	parsedRemediatorPriorities, err := queue.ParsePriorities(*remediatorPriorities)
+	if err != nil {
+		klog.Fatalf("%s must be a comma-separated list of Kind.group=priority pairs: %v", flags.remediatorPriorities, err)
+	}
+
+	var parsedPrunePolicy v1beta1.PrunePolicy
+	if *prunePolicy != "" {
+		if err := json.Unmarshal([]byte(*prunePolicy), &parsedPrunePolicy); err != nil {
+			klog.Fatalf("%s must be a JSON object of prune rules: %v", flags.prunePolicy, err)
+		}
+	}
+	applierPrunePolicy, err := applier.NewPrunePolicy(&parsedPrunePolicy)
+	if err != nil {
+		klog.Fatalf("Invalid %s: %v", flags.prunePolicy, err)
+	}
+
+	switch metadata.DriftPolicy(*driftPolicy) {
+	case "", metadata.DriftPolicyCorrect, metadata.DriftPolicyReport:
+	default:
+		klog.Fatalf("%s must be either %q or %q", flags.driftPolicy, metadata.DriftPolicyCorrect, metadata.DriftPolicyReport)
+	}
+
 	scope := declared.Scope(*scopeStr)
 	err = scope.Validate()
 	if err != nil {
@@ -189,11 +268,25 @@ func main() {
 		klog.Fatal(err)
 	}
 
+	var driftAuditLogWriter io.Writer
+	if *driftAuditLog {
+		driftAuditLogWriter = os.Stdout
+	}
+
 	opts := reconciler.Options{
 		Logger:                   logger,
 		ClusterName:              *clusterName,
+		ClusterLabels:            parsedClusterLabels,
+		SubstitutionEnabled:      *substitutionEnabled,
+		SubstitutionValues:       parsedSubstitutionValues,
+		DriftPolicy:              metadata.DriftPolicy(*driftPolicy),
+		DriftAuditLog:            driftAuditLogWriter,
+		ForceConflicts:           *forceConflicts,
+		PrunePolicy:              applierPrunePolicy,
+		InventorySelfHeal:        *inventorySelfHeal,
 		FightDetectionThreshold:  *fightDetectionThreshold,
 		NumWorkers:               *workers,
+		RemediatorPriorities:     parsedRemediatorPriorities,
 		ReconcilerScope:          scope,
 		FullSyncPeriod:           *fullSyncPeriod,
 		PollingPeriod:            *pollingPeriod,
@@ -214,9 +307,11 @@ func main() {
 		ReconcileTimeout:         *reconcileTimeout,
 		APIServerTimeout:         *apiServerTimeout,
 		RenderingEnabled:         *renderingEnabled,
+		FunctionPipelineEnabled:  *functionPipelineEnabled,
 		DynamicNSSelectorEnabled: *dynamicNSSelectorEnabled,
 		WebhookEnabled:           *webhookEnabled,
 		ReconcilerSignalsDir:     absReconcilerSignalDir,
+		HydrationSignalsDir:      absHydrationSignalDir,
 	}
 
 	if scope == declared.RootScope {
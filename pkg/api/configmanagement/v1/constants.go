@@ -96,6 +96,8 @@ const (
 	// HierarchyModeInherit indicates that the resource can appear in abstract namespace directories
 	// and will be inherited by any descendent namespaces. Without this value on the Sync, resources
 	// must not appear in abstract namespaces.
+	// For Namespaces, the labels and annotations of a Namespace declared in an
+	// abstract namespace directory are inherited by the descendent Namespaces.
 	HierarchyModeInherit = HierarchyModeType("inherit")
 	// HierarchyModeNone indicates that the resource cannot appear in abstract namespace directories.
 	// For most resource types, this is the same as default, and it's not necessary to specify this
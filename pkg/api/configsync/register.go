@@ -29,6 +29,11 @@ const (
 
 	// ControllerNamespace is the Namespace used for Nomos controllers
 	ControllerNamespace = "config-management-system"
+
+	// ClusterInfoConfigMapName is the name of the ConfigMap in the
+	// ControllerNamespace whose data are the labels of the cluster, used for
+	// Cluster selection.
+	ClusterInfoConfigMapName = "cluster-info"
 )
 
 // API type constants
@@ -129,6 +134,31 @@ const (
 
 	// HelmSource represents the source type is Helm repository.
 	HelmSource SourceType = "helm"
+
+	// HTTPSource represents the source type is an archive served over HTTP(S).
+	HTTPSource SourceType = "http"
+
+	// BucketSource represents the source type is an S3-compatible object
+	// storage bucket.
+	BucketSource SourceType = "bucket"
+)
+
+// PruneAction specifies what happens to a managed resource that was removed
+// from the source of truth.
+type PruneAction string
+
+const (
+	// PruneActionPrune deletes the resource from the cluster.
+	PruneActionPrune PruneAction = "prune"
+
+	// PruneActionOrphan leaves the resource on the cluster, and stops managing
+	// it.
+	PruneActionOrphan PruneAction = "orphan"
+
+	// PruneActionBlock leaves the resource on the cluster, keeps managing it,
+	// and reports an error until the resource is deleted manually or added
+	// back to the source of truth.
+	PruneActionBlock PruneAction = "block"
 )
 
 // AuthType specifies the type to authenticate to a repository.
@@ -0,0 +1,87 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Bucket contains configuration specific to importing resources from an
+// S3-compatible object storage bucket, like Amazon S3, Google Cloud Storage,
+// or MinIO.
+type Bucket struct {
+	// endpoint is the host, and optionally port, of the S3-compatible API.
+	// e.g. `s3.amazonaws.com`, `storage.googleapis.com`, or `minio.minio:9000`.
+	// Required
+	Endpoint string `json:"endpoint"`
+
+	// name is the name of the bucket to sync from.
+	// Required
+	Name string `json:"name"`
+
+	// prefix is the prefix of the object keys to sync. Only objects whose keys
+	// start with the prefix are synced, with the prefix removed from their
+	// path. Default: sync every object in the bucket.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// region is the region of the bucket, used to sign requests.
+	// Default: us-east-1.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// insecure specifies whether to connect to the endpoint over plain HTTP
+	// instead of HTTPS. Default: false.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// dir is the absolute path of the directory that contains
+	// the local resources.  Default: the root directory of the prefix.
+	// +optional
+	Dir string `json:"dir,omitempty"`
+
+	// period is the time duration between consecutive syncs. Default: 15s.
+	// Note to developers that customers specify this value using
+	// string (https://golang.org/pkg/time/#Duration.String) like "3s"
+	// in their Custom Resource YAML. However, time.Duration is at a nanosecond
+	// granularity, and it is easy to introduce a bug where it looks like the
+	// code is dealing with seconds but its actually nanoseconds (or vice versa).
+	// +optional
+	Period metav1.Duration `json:"period,omitempty"`
+
+	// auth is the type of secret configured for access to the bucket.
+	// Must be one of token, or none.
+	// The validation of this is case-sensitive. Required.
+	//
+	// +kubebuilder:validation:Enum=token;none
+	Auth configsync.AuthType `json:"auth"`
+
+	// caCertSecretRef specifies the name of the secret where the CA certificate is stored.
+	// The creation of the secret should be done out of band by the user and should store the
+	// certificate in a key named "cert". For RepoSync resources, the secret must be
+	// created in the same namespace as the RepoSync. For RootSync resource, the secret
+	// must be created in the config-management-system namespace.
+	// +nullable
+	// +optional
+	CACertSecretRef *SecretReference `json:"caCertSecretRef,omitempty"`
+
+	// secretRef holds the authentication secret for accessing the bucket.
+	// The secret must store an "accessKeyID" and a "secretAccessKey", like an
+	// AWS access key or a Google Cloud Storage HMAC key.
+	// +nullable
+	// +optional
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DriftRecord describes a managed resource that drifted from its declared
+// state and was not corrected, because its drift policy is `report`.
+type DriftRecord struct {
+	// resource is the drifted resource.
+	Resource ResourceRef `json:"resource"`
+
+	// operation is the correction that was skipped: `create` if the resource
+	// was deleted, or `update` if its declared fields were modified.
+	Operation string `json:"operation"`
+
+	// fields are the paths of the declared fields whose values drifted, like
+	// `spec.replicas`. Empty if the resource was deleted.
+	// +optional
+	Fields []string `json:"fields,omitempty"`
+
+	// actor is the field manager that most recently modified the drifted
+	// fields, according to the managed fields of the resource.
+	// +optional
+	Actor string `json:"actor,omitempty"`
+
+	// detectedAt is the timestamp of when the drift was first detected.
+	DetectedAt metav1.Time `json:"detectedAt"`
+}
@@ -120,6 +120,24 @@ type HelmBase struct {
 	// +nullable
 	// +optional
 	CACertSecretRef *SecretReference `json:"caCertSecretRef,omitempty"`
+
+	// postRender specifies the kustomize patches applied to the manifests
+	// rendered from the chart, before they are synced.
+	// +optional
+	PostRender *HelmPostRender `json:"postRender,omitempty"`
+
+	// apiVersions are the Kubernetes API versions available to the chart
+	// templates in `.Capabilities.APIVersions`, in the format of
+	// "group/version" or "group/version/Kind". This is equivalent to the
+	// `--api-versions` flag of Helm CLI.
+	// +optional
+	APIVersions []string `json:"apiVersions,omitempty"`
+
+	// kubeVersion is the Kubernetes version available to the chart templates
+	// in `.Capabilities.KubeVersion`. This is equivalent to the `--kube-version`
+	// flag of Helm CLI. Default: the default version of Helm CLI.
+	// +optional
+	KubeVersion string `json:"kubeVersion,omitempty"`
 }
 
 // ValuesFileRef references a ConfigMap object that contains a values file to use for
@@ -132,3 +150,73 @@ type ValuesFileRef struct {
 	// +optional
 	DataKey string `json:"dataKey,omitempty"`
 }
+
+// HelmPostRender contains the kustomize patches applied to the manifests
+// rendered from a Helm chart. The patches from `patchesFrom` are applied
+// first, followed by `patches`.
+type HelmPostRender struct {
+	// patches are the kustomize patches applied in order.
+	// +optional
+	Patches []HelmPatch `json:"patches,omitempty"`
+
+	// patchesFrom references a ConfigMap holding a list of kustomize patches,
+	// in the format of the `patches` field of a Kustomization. The ConfigMap
+	// must be in the same namespace as the RootSync/RepoSync.
+	// +optional
+	PatchesFrom *HelmPatchesFrom `json:"patchesFrom,omitempty"`
+}
+
+// HelmPatch is a kustomize patch applied to the manifests rendered from a
+// Helm chart.
+type HelmPatch struct {
+	// patch is a strategic merge patch or a JSON6902 patch. Required.
+	Patch string `json:"patch"`
+
+	// target selects the resources to patch. A strategic merge patch
+	// without target patches the resource with its kind and name.
+	// +optional
+	Target *HelmPatchTarget `json:"target,omitempty"`
+}
+
+// HelmPatchTarget selects the resources a kustomize patch applies to.
+type HelmPatchTarget struct {
+	// group is the API group of the resources.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// version is the API version of the resources.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// kind is the kind of the resources.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// name is a regular expression matching the names of the resources.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// namespace is a regular expression matching the namespaces of the
+	// resources.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// labelSelector is a label selector of the resources.
+	// +optional
+	LabelSelector string `json:"labelSelector,omitempty"`
+
+	// annotationSelector is an annotation selector of the resources.
+	// +optional
+	AnnotationSelector string `json:"annotationSelector,omitempty"`
+}
+
+// HelmPatchesFrom references a ConfigMap holding kustomize patches.
+type HelmPatchesFrom struct {
+	// name is the name of the ConfigMap. Required.
+	Name string `json:"name,omitempty"`
+
+	// dataKey is the data key of the ConfigMap to read the patches from.
+	// Default: `patches.yaml`
+	// +optional
+	DataKey string `json:"dataKey,omitempty"`
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HTTP contains configuration specific to importing resources from an archive
+// served over HTTP(S).
+type HTTP struct {
+	// url is the HTTP(S) URL of the archive to sync from.
+	// The archive must be a tarball (`.tar`), a gzipped tarball (`.tar.gz` or
+	// `.tgz`), or a zip file (`.zip`).
+	// e.g. `https://artifacts.example.com/configs/platform.tar.gz`.
+	// Required
+	URL string `json:"url"`
+
+	// dir is the absolute path of the directory that contains
+	// the local resources.  Default: the root directory of the archive.
+	// +optional
+	Dir string `json:"dir,omitempty"`
+
+	// period is the time duration between consecutive syncs. Default: 15s.
+	// Note to developers that customers specify this value using
+	// string (https://golang.org/pkg/time/#Duration.String) like "3s"
+	// in their Custom Resource YAML. However, time.Duration is at a nanosecond
+	// granularity, and it is easy to introduce a bug where it looks like the
+	// code is dealing with seconds but its actually nanoseconds (or vice versa).
+	// +optional
+	Period metav1.Duration `json:"period,omitempty"`
+
+	// checksum is the expected SHA-256 checksum of the archive, in the form
+	// `sha256:HEX`. If specified, archives that don't match are rejected, and
+	// the archive is not fetched again once it has been synced.
+	// +kubebuilder:validation:Pattern=`^(sha256:[a-f0-9]{64})?$`
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
+
+	// auth is the type of secret configured for access to the HTTP server.
+	// Must be one of token, or none.
+	// The validation of this is case-sensitive. Required.
+	//
+	// +kubebuilder:validation:Enum=token;none
+	Auth configsync.AuthType `json:"auth"`
+
+	// caCertSecretRef specifies the name of the secret where the CA certificate is stored.
+	// The creation of the secret should be done out of band by the user and should store the
+	// certificate in a key named "cert". For RepoSync resources, the secret must be
+	// created in the same namespace as the RepoSync. For RootSync resource, the secret
+	// must be created in the config-management-system namespace.
+	// +nullable
+	// +optional
+	CACertSecretRef *SecretReference `json:"caCertSecretRef,omitempty"`
+
+	// secretRef holds the authentication secret for accessing the HTTP server.
+	// The secret must store either a "username" and a "password" for basic
+	// authentication, or a "token" for bearer token authentication.
+	// +nullable
+	// +optional
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+}
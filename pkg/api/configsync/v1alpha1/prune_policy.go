@@ -0,0 +1,90 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PrunePolicy contains the rules that decide what happens to the managed
+// resources that were removed from the source of truth.
+type PrunePolicy struct {
+	// rules are evaluated in order, and the first rule that matches a resource
+	// decides its action. Resources that don't match any rule are pruned.
+	// +optional
+	Rules []PruneRule `json:"rules,omitempty"`
+
+	// safeguard halts the sync of a commit that would prune too many
+	// resources, until the commit is acknowledged.
+	// +optional
+	Safeguard *PruneSafeguard `json:"safeguard,omitempty"`
+}
+
+// PruneRule matches managed resources by GroupKind, namespace, and labels, and
+// specifies their prune action.
+type PruneRule struct {
+	// group is the API group of the resources, like `apps`. Empty for the
+	// core group. Ignored if kind is not specified.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// kind is the kind of the resources, like `Deployment`. Matches all the
+	// kinds if not specified.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// namespaces are the namespaces of the resources. Matches the resources
+	// in all the namespaces, and the cluster-scoped resources, if not
+	// specified.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// labelSelector selects the resources by their labels on the cluster.
+	// Matches all the resources if not specified.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// action is what happens to the matching resources when they are removed
+	// from the source of truth.
+	//
+	// Must be one of prune, orphan, block. `prune` deletes the resources.
+	// `orphan` leaves the resources on the cluster and stops managing them.
+	// `block` leaves the resources on the cluster, keeps managing them, and
+	// reports an error until they are deleted manually or added back to the
+	// source of truth.
+	// +kubebuilder:validation:Enum=prune;orphan;block
+	// +kubebuilder:validation:Type:=string
+	Action configsync.PruneAction `json:"action"`
+}
+
+// PruneSafeguard limits how many resources a commit may prune. A commit that
+// exceeds a limit is not synced, until it is acknowledged with the
+// `configsync.gke.io/prune-safeguard-acknowledged-commit` annotation on the
+// RootSync or RepoSync.
+type PruneSafeguard struct {
+	// maxDeletions is the maximum number of resources a commit may prune.
+	// Unlimited if not specified.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxDeletions *int32 `json:"maxDeletions,omitempty"`
+
+	// maxDeletionPercent is the maximum percentage of the managed resources a
+	// commit may prune. Unlimited if not specified.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxDeletionPercent *int32 `json:"maxDeletionPercent,omitempty"`
+}
@@ -57,8 +57,8 @@ type RepoSyncSpec struct {
 
 	// sourceType specifies the type of the source of truth.
 	//
-	// Must be one of git, oci, helm. Optional. Set to git if not specified.
-	// +kubebuilder:validation:Pattern=^(git|oci|helm)$
+	// Must be one of git, oci, helm, http, bucket. Optional. Set to git if not specified.
+	// +kubebuilder:validation:Pattern=^(git|oci|helm|http|bucket)$
 	// +kubebuilder:default:=git
 	// +kubebuilder:validation:Type:=string
 	// +optional
@@ -72,10 +72,31 @@ type RepoSyncSpec struct {
 	// +optional
 	Oci *Oci `json:"oci,omitempty"`
 
+	// http contains configuration specific to importing resources from an
+	// archive served over HTTP(S).
+	// +optional
+	HTTP *HTTP `json:"http,omitempty"`
+
+	// bucket contains configuration specific to importing resources from an
+	// S3-compatible object storage bucket.
+	// +optional
+	Bucket *Bucket `json:"bucket,omitempty"`
+
 	// helm contains configuration specific to importing resources from a Helm repo.
 	// +optional
 	Helm *HelmRepoSync `json:"helm,omitempty"`
 
+	// substitution contains configuration of the variables, like
+	// `${cluster.name}`, substituted in the resources before they are validated.
+	// Variables are substituted only when substitution is set.
+	// +optional
+	Substitution *Substitution `json:"substitution,omitempty"`
+
+	// prunePolicy contains the rules that decide what happens to the managed
+	// resources that were removed from the source of truth.
+	// +optional
+	PrunePolicy *PrunePolicy `json:"prunePolicy,omitempty"`
+
 	// override allows to override the settings for a reconciler.
 	// +nullable
 	// +optional
@@ -132,9 +132,9 @@ type RepoSyncOverrideSpec struct {
 // ContainerResourcesSpec allows to override the resource requirements for a container
 type ContainerResourcesSpec struct {
 	// containerName specifies the name of a container whose resource requirements will be overridden.
-	// Must be "reconciler", "git-sync", "hydration-controller", "oci-sync", or "helm-sync".
+	// Must be "reconciler", "git-sync", "hydration-controller", "oci-sync", "helm-sync", "http-sync", or "bucket-sync".
 	//
-	// +kubebuilder:validation:Pattern=^(reconciler|git-sync|hydration-controller|oci-sync|helm-sync|gcenode-askpass-sidecar|otel-agent)$
+	// +kubebuilder:validation:Pattern=^(reconciler|git-sync|hydration-controller|oci-sync|helm-sync|http-sync|bucket-sync|gcenode-askpass-sidecar|otel-agent)$
 	// +optional
 	ContainerName string `json:"containerName,omitempty"`
 	// cpuRequest allows one to override the CPU request of a container
@@ -154,10 +154,10 @@ type ContainerResourcesSpec struct {
 // ContainerLogLevelOverride specifies the container name and log level override value
 type ContainerLogLevelOverride struct {
 	// containerName specifies the name of the reconciler deployment container for which log level will be overridden.
-	// Must be one of the following: "reconciler", "git-sync", "hydration-controller", "oci-sync", or "helm-sync".
+	// Must be one of the following: "reconciler", "git-sync", "hydration-controller", "oci-sync", "helm-sync", "http-sync", or "bucket-sync".
 	//
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Pattern=^(reconciler|git-sync|hydration-controller|oci-sync|helm-sync|gcenode-askpass-sidecar|otel-agent)$
+	// +kubebuilder:validation:Pattern=^(reconciler|git-sync|hydration-controller|oci-sync|helm-sync|http-sync|bucket-sync|gcenode-askpass-sidecar|otel-agent)$
 	ContainerName string `json:"containerName"`
 
 	// logLevel specifies the verbosity level of the logging for a specific container.
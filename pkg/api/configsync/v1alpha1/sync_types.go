@@ -32,7 +32,8 @@ type Status struct {
 	Reconciler string `json:"reconciler,omitempty"`
 
 	// lastSyncedCommit describes the most recent hash that is successfully synced.
-	// It can be a git commit hash, or an OCI image digest.
+	// It can be a git commit hash, an OCI image digest, an HTTP archive checksum,
+	// or a bucket object listing hash.
 	// +optional
 	LastSyncedCommit string `json:"lastSyncedCommit,omitempty"`
 
@@ -62,12 +63,21 @@ type SourceStatus struct {
 	// +optional
 	Oci *OciStatus `json:"ociStatus,omitempty"`
 
+	// httpStatus contains fields describing the status of an HTTP source of truth.
+	// +optional
+	HTTP *HTTPStatus `json:"httpStatus,omitempty"`
+
+	// bucketStatus contains fields describing the status of a bucket source of truth.
+	// +optional
+	Bucket *BucketStatus `json:"bucketStatus,omitempty"`
+
 	// helmStatus contains fields describing the status of a Helm source of truth.
 	// +optional
 	Helm *HelmStatus `json:"helmStatus,omitempty"`
 
 	// hash of the source of truth that is rendered.
-	// It can be a git commit hash, or an OCI image digest.
+	// It can be a git commit hash, an OCI image digest, an HTTP archive checksum,
+	// or a bucket object listing hash.
 	// +optional
 	Commit string `json:"commit,omitempty"`
 
@@ -96,12 +106,21 @@ type RenderingStatus struct {
 	// +optional
 	Oci *OciStatus `json:"ociStatus,omitempty"`
 
+	// httpStatus contains fields describing the status of an HTTP source of truth.
+	// +optional
+	HTTP *HTTPStatus `json:"httpStatus,omitempty"`
+
+	// bucketStatus contains fields describing the status of a bucket source of truth.
+	// +optional
+	Bucket *BucketStatus `json:"bucketStatus,omitempty"`
+
 	// helmStatus contains fields describing the status of a Helm source of truth.
 	// +optional
 	Helm *HelmStatus `json:"helmStatus,omitempty"`
 
 	// hash of the source of truth that is rendered.
-	// It can be a git commit hash, or an OCI image digest.
+	// It can be a git commit hash, an OCI image digest, an HTTP archive checksum,
+	// or a bucket object listing hash.
 	// +optional
 	Commit string `json:"commit,omitempty"`
 
@@ -133,12 +152,21 @@ type SyncStatus struct {
 	// +optional
 	Oci *OciStatus `json:"ociStatus,omitempty"`
 
+	// httpStatus contains fields describing the status of an HTTP source of truth.
+	// +optional
+	HTTP *HTTPStatus `json:"httpStatus,omitempty"`
+
+	// bucketStatus contains fields describing the status of a bucket source of truth.
+	// +optional
+	Bucket *BucketStatus `json:"bucketStatus,omitempty"`
+
 	// helmStatus contains fields describing the status of a Helm source of truth.
 	// +optional
 	Helm *HelmStatus `json:"helmStatus,omitempty"`
 
 	// hash of the source of truth that is rendered.
-	// It can be a git commit hash, or an OCI image digest.
+	// It can be a git commit hash, an OCI image digest, an HTTP archive checksum,
+	// or a bucket object listing hash.
 	// +optional
 	Commit string `json:"commit,omitempty"`
 
@@ -156,6 +184,16 @@ type SyncStatus struct {
 	// errorSummary summarizes the errors encountered during the process of syncing the resources.
 	// +optional
 	ErrorSummary *ErrorSummary `json:"errorSummary,omitempty"`
+
+	// drift is a list of the managed resources that drifted from their declared
+	// state and were not corrected, because their drift policy is `report`.
+	// +optional
+	Drift []DriftRecord `json:"drift,omitempty"`
+
+	// transfers is a list of the managed resources that are being handed over
+	// to another RootSync or RepoSync, and were not adopted yet.
+	// +optional
+	Transfers []TransferRecord `json:"transfers,omitempty"`
 }
 
 // GitStatus describes the status of a Git source of truth.
@@ -184,6 +222,27 @@ type OciStatus struct {
 	Dir string `json:"dir"`
 }
 
+// HTTPStatus describes the status of the source of truth of an HTTP archive.
+type HTTPStatus struct {
+	// url is the URL of the archive to sync from.
+	URL string `json:"url"`
+
+	// dir is the absolute path of the directory that contains the local resources.
+	// Default: the root directory of the archive
+	Dir string `json:"dir"`
+}
+
+// BucketStatus describes the status of the source of truth of a bucket.
+type BucketStatus struct {
+	// url is the path-style URL of the bucket prefix being synced from.
+	// e.g. `https://storage.googleapis.com/my-bucket/configs/`.
+	URL string `json:"url"`
+
+	// dir is the absolute path of the directory that contains the local resources.
+	// Default: the root directory of the prefix
+	Dir string `json:"dir"`
+}
+
 // HelmStatus describes the status of a Helm source of truth.
 type HelmStatus struct {
 	// repo is the helm repository URL being synced from.
@@ -23,6 +23,26 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*Bucket)(nil), (*v1beta1.Bucket)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Bucket_To_v1beta1_Bucket(a.(*Bucket), b.(*v1beta1.Bucket), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.Bucket)(nil), (*Bucket)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Bucket_To_v1alpha1_Bucket(a.(*v1beta1.Bucket), b.(*Bucket), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*BucketStatus)(nil), (*v1beta1.BucketStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_BucketStatus_To_v1beta1_BucketStatus(a.(*BucketStatus), b.(*v1beta1.BucketStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.BucketStatus)(nil), (*BucketStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_BucketStatus_To_v1alpha1_BucketStatus(a.(*v1beta1.BucketStatus), b.(*BucketStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ConfigSyncError)(nil), (*v1beta1.ConfigSyncError)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ConfigSyncError_To_v1beta1_ConfigSyncError(a.(*ConfigSyncError), b.(*v1beta1.ConfigSyncError), scope)
 	}); err != nil {
@@ -53,6 +73,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DriftRecord)(nil), (*v1beta1.DriftRecord)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DriftRecord_To_v1beta1_DriftRecord(a.(*DriftRecord), b.(*v1beta1.DriftRecord), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DriftRecord)(nil), (*DriftRecord)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DriftRecord_To_v1alpha1_DriftRecord(a.(*v1beta1.DriftRecord), b.(*DriftRecord), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ErrorSummary)(nil), (*v1beta1.ErrorSummary)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ErrorSummary_To_v1beta1_ErrorSummary(a.(*ErrorSummary), b.(*v1beta1.ErrorSummary), scope)
 	}); err != nil {
@@ -83,11 +113,71 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*HTTP)(nil), (*v1beta1.HTTP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_HTTP_To_v1beta1_HTTP(a.(*HTTP), b.(*v1beta1.HTTP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.HTTP)(nil), (*HTTP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_HTTP_To_v1alpha1_HTTP(a.(*v1beta1.HTTP), b.(*HTTP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*HTTPStatus)(nil), (*v1beta1.HTTPStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_HTTPStatus_To_v1beta1_HTTPStatus(a.(*HTTPStatus), b.(*v1beta1.HTTPStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.HTTPStatus)(nil), (*HTTPStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_HTTPStatus_To_v1alpha1_HTTPStatus(a.(*v1beta1.HTTPStatus), b.(*HTTPStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*HelmBase)(nil), (*v1beta1.HelmBase)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_HelmBase_To_v1beta1_HelmBase(a.(*HelmBase), b.(*v1beta1.HelmBase), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*HelmPatch)(nil), (*v1beta1.HelmPatch)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_HelmPatch_To_v1beta1_HelmPatch(a.(*HelmPatch), b.(*v1beta1.HelmPatch), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.HelmPatch)(nil), (*HelmPatch)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_HelmPatch_To_v1alpha1_HelmPatch(a.(*v1beta1.HelmPatch), b.(*HelmPatch), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*HelmPatchTarget)(nil), (*v1beta1.HelmPatchTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_HelmPatchTarget_To_v1beta1_HelmPatchTarget(a.(*HelmPatchTarget), b.(*v1beta1.HelmPatchTarget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.HelmPatchTarget)(nil), (*HelmPatchTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_HelmPatchTarget_To_v1alpha1_HelmPatchTarget(a.(*v1beta1.HelmPatchTarget), b.(*HelmPatchTarget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*HelmPatchesFrom)(nil), (*v1beta1.HelmPatchesFrom)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_HelmPatchesFrom_To_v1beta1_HelmPatchesFrom(a.(*HelmPatchesFrom), b.(*v1beta1.HelmPatchesFrom), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.HelmPatchesFrom)(nil), (*HelmPatchesFrom)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_HelmPatchesFrom_To_v1alpha1_HelmPatchesFrom(a.(*v1beta1.HelmPatchesFrom), b.(*HelmPatchesFrom), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*HelmPostRender)(nil), (*v1beta1.HelmPostRender)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_HelmPostRender_To_v1beta1_HelmPostRender(a.(*HelmPostRender), b.(*v1beta1.HelmPostRender), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.HelmPostRender)(nil), (*HelmPostRender)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_HelmPostRender_To_v1alpha1_HelmPostRender(a.(*v1beta1.HelmPostRender), b.(*HelmPostRender), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*HelmRepoSync)(nil), (*v1beta1.HelmRepoSync)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_HelmRepoSync_To_v1beta1_HelmRepoSync(a.(*HelmRepoSync), b.(*v1beta1.HelmRepoSync), scope)
 	}); err != nil {
@@ -133,6 +223,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*OciLayerSelector)(nil), (*v1beta1.OciLayerSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OciLayerSelector_To_v1beta1_OciLayerSelector(a.(*OciLayerSelector), b.(*v1beta1.OciLayerSelector), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.OciLayerSelector)(nil), (*OciLayerSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OciLayerSelector_To_v1alpha1_OciLayerSelector(a.(*v1beta1.OciLayerSelector), b.(*OciLayerSelector), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*OciStatus)(nil), (*v1beta1.OciStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_OciStatus_To_v1beta1_OciStatus(a.(*OciStatus), b.(*v1beta1.OciStatus), scope)
 	}); err != nil {
@@ -153,6 +253,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PrunePolicy)(nil), (*v1beta1.PrunePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PrunePolicy_To_v1beta1_PrunePolicy(a.(*PrunePolicy), b.(*v1beta1.PrunePolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.PrunePolicy)(nil), (*PrunePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PrunePolicy_To_v1alpha1_PrunePolicy(a.(*v1beta1.PrunePolicy), b.(*PrunePolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PruneRule)(nil), (*v1beta1.PruneRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PruneRule_To_v1beta1_PruneRule(a.(*PruneRule), b.(*v1beta1.PruneRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.PruneRule)(nil), (*PruneRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PruneRule_To_v1alpha1_PruneRule(a.(*v1beta1.PruneRule), b.(*PruneRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PruneSafeguard)(nil), (*v1beta1.PruneSafeguard)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PruneSafeguard_To_v1beta1_PruneSafeguard(a.(*PruneSafeguard), b.(*v1beta1.PruneSafeguard), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.PruneSafeguard)(nil), (*PruneSafeguard)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PruneSafeguard_To_v1alpha1_PruneSafeguard(a.(*v1beta1.PruneSafeguard), b.(*PruneSafeguard), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RenderingStatus)(nil), (*v1beta1.RenderingStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_RenderingStatus_To_v1beta1_RenderingStatus(a.(*RenderingStatus), b.(*v1beta1.RenderingStatus), scope)
 	}); err != nil {
@@ -333,6 +463,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*Substitution)(nil), (*v1beta1.Substitution)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Substitution_To_v1beta1_Substitution(a.(*Substitution), b.(*v1beta1.Substitution), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.Substitution)(nil), (*Substitution)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Substitution_To_v1alpha1_Substitution(a.(*v1beta1.Substitution), b.(*Substitution), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SubstitutionValuesFrom)(nil), (*v1beta1.SubstitutionValuesFrom)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SubstitutionValuesFrom_To_v1beta1_SubstitutionValuesFrom(a.(*SubstitutionValuesFrom), b.(*v1beta1.SubstitutionValuesFrom), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.SubstitutionValuesFrom)(nil), (*SubstitutionValuesFrom)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SubstitutionValuesFrom_To_v1alpha1_SubstitutionValuesFrom(a.(*v1beta1.SubstitutionValuesFrom), b.(*SubstitutionValuesFrom), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*SyncStatus)(nil), (*v1beta1.SyncStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_SyncStatus_To_v1beta1_SyncStatus(a.(*SyncStatus), b.(*v1beta1.SyncStatus), scope)
 	}); err != nil {
@@ -343,6 +493,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*TransferRecord)(nil), (*v1beta1.TransferRecord)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TransferRecord_To_v1beta1_TransferRecord(a.(*TransferRecord), b.(*v1beta1.TransferRecord), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.TransferRecord)(nil), (*TransferRecord)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_TransferRecord_To_v1alpha1_TransferRecord(a.(*v1beta1.TransferRecord), b.(*TransferRecord), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ValuesFileRef)(nil), (*v1beta1.ValuesFileRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ValuesFileRef_To_v1beta1_ValuesFileRef(a.(*ValuesFileRef), b.(*v1beta1.ValuesFileRef), scope)
 	}); err != nil {
@@ -366,6 +526,66 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha1_Bucket_To_v1beta1_Bucket(in *Bucket, out *v1beta1.Bucket, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.Name = in.Name
+	out.Prefix = in.Prefix
+	out.Region = in.Region
+	out.Insecure = in.Insecure
+	out.Dir = in.Dir
+	out.Period = in.Period
+	out.Auth = configsync.AuthType(in.Auth)
+	out.CACertSecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.CACertSecretRef))
+	out.SecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.SecretRef))
+	return nil
+}
+
+// Convert_v1alpha1_Bucket_To_v1beta1_Bucket is an autogenerated conversion function.
+func Convert_v1alpha1_Bucket_To_v1beta1_Bucket(in *Bucket, out *v1beta1.Bucket, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Bucket_To_v1beta1_Bucket(in, out, s)
+}
+
+func autoConvert_v1beta1_Bucket_To_v1alpha1_Bucket(in *v1beta1.Bucket, out *Bucket, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.Name = in.Name
+	out.Prefix = in.Prefix
+	out.Region = in.Region
+	out.Insecure = in.Insecure
+	out.Dir = in.Dir
+	out.Period = in.Period
+	out.Auth = configsync.AuthType(in.Auth)
+	out.CACertSecretRef = (*SecretReference)(unsafe.Pointer(in.CACertSecretRef))
+	out.SecretRef = (*SecretReference)(unsafe.Pointer(in.SecretRef))
+	return nil
+}
+
+// Convert_v1beta1_Bucket_To_v1alpha1_Bucket is an autogenerated conversion function.
+func Convert_v1beta1_Bucket_To_v1alpha1_Bucket(in *v1beta1.Bucket, out *Bucket, s conversion.Scope) error {
+	return autoConvert_v1beta1_Bucket_To_v1alpha1_Bucket(in, out, s)
+}
+
+func autoConvert_v1alpha1_BucketStatus_To_v1beta1_BucketStatus(in *BucketStatus, out *v1beta1.BucketStatus, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Dir = in.Dir
+	return nil
+}
+
+// Convert_v1alpha1_BucketStatus_To_v1beta1_BucketStatus is an autogenerated conversion function.
+func Convert_v1alpha1_BucketStatus_To_v1beta1_BucketStatus(in *BucketStatus, out *v1beta1.BucketStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_BucketStatus_To_v1beta1_BucketStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_BucketStatus_To_v1alpha1_BucketStatus(in *v1beta1.BucketStatus, out *BucketStatus, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Dir = in.Dir
+	return nil
+}
+
+// Convert_v1beta1_BucketStatus_To_v1alpha1_BucketStatus is an autogenerated conversion function.
+func Convert_v1beta1_BucketStatus_To_v1alpha1_BucketStatus(in *v1beta1.BucketStatus, out *BucketStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_BucketStatus_To_v1alpha1_BucketStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_ConfigSyncError_To_v1beta1_ConfigSyncError(in *ConfigSyncError, out *v1beta1.ConfigSyncError, s conversion.Scope) error {
 	out.Code = in.Code
 	out.ErrorMessage = in.ErrorMessage
@@ -440,6 +660,38 @@ func Convert_v1beta1_ContainerResourcesSpec_To_v1alpha1_ContainerResourcesSpec(i
 	return autoConvert_v1beta1_ContainerResourcesSpec_To_v1alpha1_ContainerResourcesSpec(in, out, s)
 }
 
+func autoConvert_v1alpha1_DriftRecord_To_v1beta1_DriftRecord(in *DriftRecord, out *v1beta1.DriftRecord, s conversion.Scope) error {
+	if err := Convert_v1alpha1_ResourceRef_To_v1beta1_ResourceRef(&in.Resource, &out.Resource, s); err != nil {
+		return err
+	}
+	out.Operation = in.Operation
+	out.Fields = *(*[]string)(unsafe.Pointer(&in.Fields))
+	out.Actor = in.Actor
+	out.DetectedAt = in.DetectedAt
+	return nil
+}
+
+// Convert_v1alpha1_DriftRecord_To_v1beta1_DriftRecord is an autogenerated conversion function.
+func Convert_v1alpha1_DriftRecord_To_v1beta1_DriftRecord(in *DriftRecord, out *v1beta1.DriftRecord, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DriftRecord_To_v1beta1_DriftRecord(in, out, s)
+}
+
+func autoConvert_v1beta1_DriftRecord_To_v1alpha1_DriftRecord(in *v1beta1.DriftRecord, out *DriftRecord, s conversion.Scope) error {
+	if err := Convert_v1beta1_ResourceRef_To_v1alpha1_ResourceRef(&in.Resource, &out.Resource, s); err != nil {
+		return err
+	}
+	out.Operation = in.Operation
+	out.Fields = *(*[]string)(unsafe.Pointer(&in.Fields))
+	out.Actor = in.Actor
+	out.DetectedAt = in.DetectedAt
+	return nil
+}
+
+// Convert_v1beta1_DriftRecord_To_v1alpha1_DriftRecord is an autogenerated conversion function.
+func Convert_v1beta1_DriftRecord_To_v1alpha1_DriftRecord(in *v1beta1.DriftRecord, out *DriftRecord, s conversion.Scope) error {
+	return autoConvert_v1beta1_DriftRecord_To_v1alpha1_DriftRecord(in, out, s)
+}
+
 func autoConvert_v1alpha1_ErrorSummary_To_v1beta1_ErrorSummary(in *ErrorSummary, out *v1beta1.ErrorSummary, s conversion.Scope) error {
 	out.TotalCount = in.TotalCount
 	out.Truncated = in.Truncated
@@ -530,6 +782,60 @@ func Convert_v1beta1_GitStatus_To_v1alpha1_GitStatus(in *v1beta1.GitStatus, out
 	return autoConvert_v1beta1_GitStatus_To_v1alpha1_GitStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_HTTP_To_v1beta1_HTTP(in *HTTP, out *v1beta1.HTTP, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Dir = in.Dir
+	out.Period = in.Period
+	out.Checksum = in.Checksum
+	out.Auth = configsync.AuthType(in.Auth)
+	out.CACertSecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.CACertSecretRef))
+	out.SecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.SecretRef))
+	return nil
+}
+
+// Convert_v1alpha1_HTTP_To_v1beta1_HTTP is an autogenerated conversion function.
+func Convert_v1alpha1_HTTP_To_v1beta1_HTTP(in *HTTP, out *v1beta1.HTTP, s conversion.Scope) error {
+	return autoConvert_v1alpha1_HTTP_To_v1beta1_HTTP(in, out, s)
+}
+
+func autoConvert_v1beta1_HTTP_To_v1alpha1_HTTP(in *v1beta1.HTTP, out *HTTP, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Dir = in.Dir
+	out.Period = in.Period
+	out.Checksum = in.Checksum
+	out.Auth = configsync.AuthType(in.Auth)
+	out.CACertSecretRef = (*SecretReference)(unsafe.Pointer(in.CACertSecretRef))
+	out.SecretRef = (*SecretReference)(unsafe.Pointer(in.SecretRef))
+	return nil
+}
+
+// Convert_v1beta1_HTTP_To_v1alpha1_HTTP is an autogenerated conversion function.
+func Convert_v1beta1_HTTP_To_v1alpha1_HTTP(in *v1beta1.HTTP, out *HTTP, s conversion.Scope) error {
+	return autoConvert_v1beta1_HTTP_To_v1alpha1_HTTP(in, out, s)
+}
+
+func autoConvert_v1alpha1_HTTPStatus_To_v1beta1_HTTPStatus(in *HTTPStatus, out *v1beta1.HTTPStatus, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Dir = in.Dir
+	return nil
+}
+
+// Convert_v1alpha1_HTTPStatus_To_v1beta1_HTTPStatus is an autogenerated conversion function.
+func Convert_v1alpha1_HTTPStatus_To_v1beta1_HTTPStatus(in *HTTPStatus, out *v1beta1.HTTPStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_HTTPStatus_To_v1beta1_HTTPStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_HTTPStatus_To_v1alpha1_HTTPStatus(in *v1beta1.HTTPStatus, out *HTTPStatus, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Dir = in.Dir
+	return nil
+}
+
+// Convert_v1beta1_HTTPStatus_To_v1alpha1_HTTPStatus is an autogenerated conversion function.
+func Convert_v1beta1_HTTPStatus_To_v1alpha1_HTTPStatus(in *v1beta1.HTTPStatus, out *HTTPStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_HTTPStatus_To_v1alpha1_HTTPStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_HelmBase_To_v1beta1_HelmBase(in *HelmBase, out *v1beta1.HelmBase, s conversion.Scope) error {
 	out.Repo = in.Repo
 	out.Chart = in.Chart
@@ -543,6 +849,9 @@ func autoConvert_v1alpha1_HelmBase_To_v1beta1_HelmBase(in *HelmBase, out *v1beta
 	out.GCPServiceAccountEmail = in.GCPServiceAccountEmail
 	out.SecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.SecretRef))
 	out.CACertSecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.CACertSecretRef))
+	out.PostRender = (*v1beta1.HelmPostRender)(unsafe.Pointer(in.PostRender))
+	out.APIVersions = *(*[]string)(unsafe.Pointer(&in.APIVersions))
+	out.KubeVersion = in.KubeVersion
 	return nil
 }
 
@@ -564,9 +873,110 @@ func autoConvert_v1beta1_HelmBase_To_v1alpha1_HelmBase(in *v1beta1.HelmBase, out
 	out.GCPServiceAccountEmail = in.GCPServiceAccountEmail
 	out.SecretRef = (*SecretReference)(unsafe.Pointer(in.SecretRef))
 	out.CACertSecretRef = (*SecretReference)(unsafe.Pointer(in.CACertSecretRef))
+	out.PostRender = (*HelmPostRender)(unsafe.Pointer(in.PostRender))
+	out.APIVersions = *(*[]string)(unsafe.Pointer(&in.APIVersions))
+	out.KubeVersion = in.KubeVersion
+	return nil
+}
+
+func autoConvert_v1alpha1_HelmPatch_To_v1beta1_HelmPatch(in *HelmPatch, out *v1beta1.HelmPatch, s conversion.Scope) error {
+	out.Patch = in.Patch
+	out.Target = (*v1beta1.HelmPatchTarget)(unsafe.Pointer(in.Target))
 	return nil
 }
 
+// Convert_v1alpha1_HelmPatch_To_v1beta1_HelmPatch is an autogenerated conversion function.
+func Convert_v1alpha1_HelmPatch_To_v1beta1_HelmPatch(in *HelmPatch, out *v1beta1.HelmPatch, s conversion.Scope) error {
+	return autoConvert_v1alpha1_HelmPatch_To_v1beta1_HelmPatch(in, out, s)
+}
+
+func autoConvert_v1beta1_HelmPatch_To_v1alpha1_HelmPatch(in *v1beta1.HelmPatch, out *HelmPatch, s conversion.Scope) error {
+	out.Patch = in.Patch
+	out.Target = (*HelmPatchTarget)(unsafe.Pointer(in.Target))
+	return nil
+}
+
+// Convert_v1beta1_HelmPatch_To_v1alpha1_HelmPatch is an autogenerated conversion function.
+func Convert_v1beta1_HelmPatch_To_v1alpha1_HelmPatch(in *v1beta1.HelmPatch, out *HelmPatch, s conversion.Scope) error {
+	return autoConvert_v1beta1_HelmPatch_To_v1alpha1_HelmPatch(in, out, s)
+}
+
+func autoConvert_v1alpha1_HelmPatchTarget_To_v1beta1_HelmPatchTarget(in *HelmPatchTarget, out *v1beta1.HelmPatchTarget, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Version = in.Version
+	out.Kind = in.Kind
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	out.LabelSelector = in.LabelSelector
+	out.AnnotationSelector = in.AnnotationSelector
+	return nil
+}
+
+// Convert_v1alpha1_HelmPatchTarget_To_v1beta1_HelmPatchTarget is an autogenerated conversion function.
+func Convert_v1alpha1_HelmPatchTarget_To_v1beta1_HelmPatchTarget(in *HelmPatchTarget, out *v1beta1.HelmPatchTarget, s conversion.Scope) error {
+	return autoConvert_v1alpha1_HelmPatchTarget_To_v1beta1_HelmPatchTarget(in, out, s)
+}
+
+func autoConvert_v1beta1_HelmPatchTarget_To_v1alpha1_HelmPatchTarget(in *v1beta1.HelmPatchTarget, out *HelmPatchTarget, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Version = in.Version
+	out.Kind = in.Kind
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	out.LabelSelector = in.LabelSelector
+	out.AnnotationSelector = in.AnnotationSelector
+	return nil
+}
+
+// Convert_v1beta1_HelmPatchTarget_To_v1alpha1_HelmPatchTarget is an autogenerated conversion function.
+func Convert_v1beta1_HelmPatchTarget_To_v1alpha1_HelmPatchTarget(in *v1beta1.HelmPatchTarget, out *HelmPatchTarget, s conversion.Scope) error {
+	return autoConvert_v1beta1_HelmPatchTarget_To_v1alpha1_HelmPatchTarget(in, out, s)
+}
+
+func autoConvert_v1alpha1_HelmPatchesFrom_To_v1beta1_HelmPatchesFrom(in *HelmPatchesFrom, out *v1beta1.HelmPatchesFrom, s conversion.Scope) error {
+	out.Name = in.Name
+	out.DataKey = in.DataKey
+	return nil
+}
+
+// Convert_v1alpha1_HelmPatchesFrom_To_v1beta1_HelmPatchesFrom is an autogenerated conversion function.
+func Convert_v1alpha1_HelmPatchesFrom_To_v1beta1_HelmPatchesFrom(in *HelmPatchesFrom, out *v1beta1.HelmPatchesFrom, s conversion.Scope) error {
+	return autoConvert_v1alpha1_HelmPatchesFrom_To_v1beta1_HelmPatchesFrom(in, out, s)
+}
+
+func autoConvert_v1beta1_HelmPatchesFrom_To_v1alpha1_HelmPatchesFrom(in *v1beta1.HelmPatchesFrom, out *HelmPatchesFrom, s conversion.Scope) error {
+	out.Name = in.Name
+	out.DataKey = in.DataKey
+	return nil
+}
+
+// Convert_v1beta1_HelmPatchesFrom_To_v1alpha1_HelmPatchesFrom is an autogenerated conversion function.
+func Convert_v1beta1_HelmPatchesFrom_To_v1alpha1_HelmPatchesFrom(in *v1beta1.HelmPatchesFrom, out *HelmPatchesFrom, s conversion.Scope) error {
+	return autoConvert_v1beta1_HelmPatchesFrom_To_v1alpha1_HelmPatchesFrom(in, out, s)
+}
+
+func autoConvert_v1alpha1_HelmPostRender_To_v1beta1_HelmPostRender(in *HelmPostRender, out *v1beta1.HelmPostRender, s conversion.Scope) error {
+	out.Patches = *(*[]v1beta1.HelmPatch)(unsafe.Pointer(&in.Patches))
+	out.PatchesFrom = (*v1beta1.HelmPatchesFrom)(unsafe.Pointer(in.PatchesFrom))
+	return nil
+}
+
+// Convert_v1alpha1_HelmPostRender_To_v1beta1_HelmPostRender is an autogenerated conversion function.
+func Convert_v1alpha1_HelmPostRender_To_v1beta1_HelmPostRender(in *HelmPostRender, out *v1beta1.HelmPostRender, s conversion.Scope) error {
+	return autoConvert_v1alpha1_HelmPostRender_To_v1beta1_HelmPostRender(in, out, s)
+}
+
+func autoConvert_v1beta1_HelmPostRender_To_v1alpha1_HelmPostRender(in *v1beta1.HelmPostRender, out *HelmPostRender, s conversion.Scope) error {
+	out.Patches = *(*[]HelmPatch)(unsafe.Pointer(&in.Patches))
+	out.PatchesFrom = (*HelmPatchesFrom)(unsafe.Pointer(in.PatchesFrom))
+	return nil
+}
+
+// Convert_v1beta1_HelmPostRender_To_v1alpha1_HelmPostRender is an autogenerated conversion function.
+func Convert_v1beta1_HelmPostRender_To_v1alpha1_HelmPostRender(in *v1beta1.HelmPostRender, out *HelmPostRender, s conversion.Scope) error {
+	return autoConvert_v1beta1_HelmPostRender_To_v1alpha1_HelmPostRender(in, out, s)
+}
+
 func autoConvert_v1alpha1_HelmRepoSync_To_v1beta1_HelmRepoSync(in *HelmRepoSync, out *v1beta1.HelmRepoSync, s conversion.Scope) error {
 	if err := Convert_v1alpha1_HelmBase_To_v1beta1_HelmBase(&in.HelmBase, &out.HelmBase, s); err != nil {
 		return err
@@ -666,6 +1076,7 @@ func autoConvert_v1alpha1_Oci_To_v1beta1_Oci(in *Oci, out *v1beta1.Oci, s conver
 	out.GCPServiceAccountEmail = in.GCPServiceAccountEmail
 	out.CACertSecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.CACertSecretRef))
 	out.SecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.SecretRef))
+	out.LayerSelector = (*v1beta1.OciLayerSelector)(unsafe.Pointer(in.LayerSelector))
 	return nil
 }
 
@@ -682,6 +1093,7 @@ func autoConvert_v1beta1_Oci_To_v1alpha1_Oci(in *v1beta1.Oci, out *Oci, s conver
 	out.GCPServiceAccountEmail = in.GCPServiceAccountEmail
 	out.CACertSecretRef = (*SecretReference)(unsafe.Pointer(in.CACertSecretRef))
 	out.SecretRef = (*SecretReference)(unsafe.Pointer(in.SecretRef))
+	out.LayerSelector = (*OciLayerSelector)(unsafe.Pointer(in.LayerSelector))
 	return nil
 }
 
@@ -690,6 +1102,28 @@ func Convert_v1beta1_Oci_To_v1alpha1_Oci(in *v1beta1.Oci, out *Oci, s conversion
 	return autoConvert_v1beta1_Oci_To_v1alpha1_Oci(in, out, s)
 }
 
+func autoConvert_v1alpha1_OciLayerSelector_To_v1beta1_OciLayerSelector(in *OciLayerSelector, out *v1beta1.OciLayerSelector, s conversion.Scope) error {
+	out.MediaType = in.MediaType
+	out.Annotation = in.Annotation
+	return nil
+}
+
+// Convert_v1alpha1_OciLayerSelector_To_v1beta1_OciLayerSelector is an autogenerated conversion function.
+func Convert_v1alpha1_OciLayerSelector_To_v1beta1_OciLayerSelector(in *OciLayerSelector, out *v1beta1.OciLayerSelector, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OciLayerSelector_To_v1beta1_OciLayerSelector(in, out, s)
+}
+
+func autoConvert_v1beta1_OciLayerSelector_To_v1alpha1_OciLayerSelector(in *v1beta1.OciLayerSelector, out *OciLayerSelector, s conversion.Scope) error {
+	out.MediaType = in.MediaType
+	out.Annotation = in.Annotation
+	return nil
+}
+
+// Convert_v1beta1_OciLayerSelector_To_v1alpha1_OciLayerSelector is an autogenerated conversion function.
+func Convert_v1beta1_OciLayerSelector_To_v1alpha1_OciLayerSelector(in *v1beta1.OciLayerSelector, out *OciLayerSelector, s conversion.Scope) error {
+	return autoConvert_v1beta1_OciLayerSelector_To_v1alpha1_OciLayerSelector(in, out, s)
+}
+
 func autoConvert_v1alpha1_OciStatus_To_v1beta1_OciStatus(in *OciStatus, out *v1beta1.OciStatus, s conversion.Scope) error {
 	out.Image = in.Image
 	out.Dir = in.Dir
@@ -744,9 +1178,83 @@ func Convert_v1beta1_OverrideSpec_To_v1alpha1_OverrideSpec(in *v1beta1.OverrideS
 	return autoConvert_v1beta1_OverrideSpec_To_v1alpha1_OverrideSpec(in, out, s)
 }
 
+func autoConvert_v1alpha1_PrunePolicy_To_v1beta1_PrunePolicy(in *PrunePolicy, out *v1beta1.PrunePolicy, s conversion.Scope) error {
+	out.Rules = *(*[]v1beta1.PruneRule)(unsafe.Pointer(&in.Rules))
+	out.Safeguard = (*v1beta1.PruneSafeguard)(unsafe.Pointer(in.Safeguard))
+	return nil
+}
+
+// Convert_v1alpha1_PrunePolicy_To_v1beta1_PrunePolicy is an autogenerated conversion function.
+func Convert_v1alpha1_PrunePolicy_To_v1beta1_PrunePolicy(in *PrunePolicy, out *v1beta1.PrunePolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PrunePolicy_To_v1beta1_PrunePolicy(in, out, s)
+}
+
+func autoConvert_v1beta1_PrunePolicy_To_v1alpha1_PrunePolicy(in *v1beta1.PrunePolicy, out *PrunePolicy, s conversion.Scope) error {
+	out.Rules = *(*[]PruneRule)(unsafe.Pointer(&in.Rules))
+	out.Safeguard = (*PruneSafeguard)(unsafe.Pointer(in.Safeguard))
+	return nil
+}
+
+// Convert_v1beta1_PrunePolicy_To_v1alpha1_PrunePolicy is an autogenerated conversion function.
+func Convert_v1beta1_PrunePolicy_To_v1alpha1_PrunePolicy(in *v1beta1.PrunePolicy, out *PrunePolicy, s conversion.Scope) error {
+	return autoConvert_v1beta1_PrunePolicy_To_v1alpha1_PrunePolicy(in, out, s)
+}
+
+func autoConvert_v1alpha1_PruneRule_To_v1beta1_PruneRule(in *PruneRule, out *v1beta1.PruneRule, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.LabelSelector = (*metav1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
+	out.Action = configsync.PruneAction(in.Action)
+	return nil
+}
+
+// Convert_v1alpha1_PruneRule_To_v1beta1_PruneRule is an autogenerated conversion function.
+func Convert_v1alpha1_PruneRule_To_v1beta1_PruneRule(in *PruneRule, out *v1beta1.PruneRule, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PruneRule_To_v1beta1_PruneRule(in, out, s)
+}
+
+func autoConvert_v1beta1_PruneRule_To_v1alpha1_PruneRule(in *v1beta1.PruneRule, out *PruneRule, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.LabelSelector = (*metav1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
+	out.Action = configsync.PruneAction(in.Action)
+	return nil
+}
+
+// Convert_v1beta1_PruneRule_To_v1alpha1_PruneRule is an autogenerated conversion function.
+func Convert_v1beta1_PruneRule_To_v1alpha1_PruneRule(in *v1beta1.PruneRule, out *PruneRule, s conversion.Scope) error {
+	return autoConvert_v1beta1_PruneRule_To_v1alpha1_PruneRule(in, out, s)
+}
+
+func autoConvert_v1alpha1_PruneSafeguard_To_v1beta1_PruneSafeguard(in *PruneSafeguard, out *v1beta1.PruneSafeguard, s conversion.Scope) error {
+	out.MaxDeletions = (*int32)(unsafe.Pointer(in.MaxDeletions))
+	out.MaxDeletionPercent = (*int32)(unsafe.Pointer(in.MaxDeletionPercent))
+	return nil
+}
+
+// Convert_v1alpha1_PruneSafeguard_To_v1beta1_PruneSafeguard is an autogenerated conversion function.
+func Convert_v1alpha1_PruneSafeguard_To_v1beta1_PruneSafeguard(in *PruneSafeguard, out *v1beta1.PruneSafeguard, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PruneSafeguard_To_v1beta1_PruneSafeguard(in, out, s)
+}
+
+func autoConvert_v1beta1_PruneSafeguard_To_v1alpha1_PruneSafeguard(in *v1beta1.PruneSafeguard, out *PruneSafeguard, s conversion.Scope) error {
+	out.MaxDeletions = (*int32)(unsafe.Pointer(in.MaxDeletions))
+	out.MaxDeletionPercent = (*int32)(unsafe.Pointer(in.MaxDeletionPercent))
+	return nil
+}
+
+// Convert_v1beta1_PruneSafeguard_To_v1alpha1_PruneSafeguard is an autogenerated conversion function.
+func Convert_v1beta1_PruneSafeguard_To_v1alpha1_PruneSafeguard(in *v1beta1.PruneSafeguard, out *PruneSafeguard, s conversion.Scope) error {
+	return autoConvert_v1beta1_PruneSafeguard_To_v1alpha1_PruneSafeguard(in, out, s)
+}
+
 func autoConvert_v1alpha1_RenderingStatus_To_v1beta1_RenderingStatus(in *RenderingStatus, out *v1beta1.RenderingStatus, s conversion.Scope) error {
 	out.Git = (*v1beta1.GitStatus)(unsafe.Pointer(in.Git))
 	out.Oci = (*v1beta1.OciStatus)(unsafe.Pointer(in.Oci))
+	out.HTTP = (*v1beta1.HTTPStatus)(unsafe.Pointer(in.HTTP))
+	out.Bucket = (*v1beta1.BucketStatus)(unsafe.Pointer(in.Bucket))
 	out.Helm = (*v1beta1.HelmStatus)(unsafe.Pointer(in.Helm))
 	out.Commit = in.Commit
 	out.Message = in.Message
@@ -764,6 +1272,8 @@ func Convert_v1alpha1_RenderingStatus_To_v1beta1_RenderingStatus(in *RenderingSt
 func autoConvert_v1beta1_RenderingStatus_To_v1alpha1_RenderingStatus(in *v1beta1.RenderingStatus, out *RenderingStatus, s conversion.Scope) error {
 	out.Git = (*GitStatus)(unsafe.Pointer(in.Git))
 	out.Oci = (*OciStatus)(unsafe.Pointer(in.Oci))
+	out.HTTP = (*HTTPStatus)(unsafe.Pointer(in.HTTP))
+	out.Bucket = (*BucketStatus)(unsafe.Pointer(in.Bucket))
 	out.Helm = (*HelmStatus)(unsafe.Pointer(in.Helm))
 	out.Commit = in.Commit
 	out.LastUpdate = in.LastUpdate
@@ -919,6 +1429,8 @@ func autoConvert_v1alpha1_RepoSyncSpec_To_v1beta1_RepoSyncSpec(in *RepoSyncSpec,
 	out.SourceType = configsync.SourceType(in.SourceType)
 	out.Git = (*v1beta1.Git)(unsafe.Pointer(in.Git))
 	out.Oci = (*v1beta1.Oci)(unsafe.Pointer(in.Oci))
+	out.HTTP = (*v1beta1.HTTP)(unsafe.Pointer(in.HTTP))
+	out.Bucket = (*v1beta1.Bucket)(unsafe.Pointer(in.Bucket))
 	if in.Helm != nil {
 		in, out := &in.Helm, &out.Helm
 		*out = new(v1beta1.HelmRepoSync)
@@ -928,6 +1440,8 @@ func autoConvert_v1alpha1_RepoSyncSpec_To_v1beta1_RepoSyncSpec(in *RepoSyncSpec,
 	} else {
 		out.Helm = nil
 	}
+	out.Substitution = (*v1beta1.Substitution)(unsafe.Pointer(in.Substitution))
+	out.PrunePolicy = (*v1beta1.PrunePolicy)(unsafe.Pointer(in.PrunePolicy))
 	out.Override = (*v1beta1.RepoSyncOverrideSpec)(unsafe.Pointer(in.Override))
 	out.Monitoring = (*v1beta1.MonitoringSpec)(unsafe.Pointer(in.Monitoring))
 	return nil
@@ -943,6 +1457,8 @@ func autoConvert_v1beta1_RepoSyncSpec_To_v1alpha1_RepoSyncSpec(in *v1beta1.RepoS
 	out.SourceType = configsync.SourceType(in.SourceType)
 	out.Git = (*Git)(unsafe.Pointer(in.Git))
 	out.Oci = (*Oci)(unsafe.Pointer(in.Oci))
+	out.HTTP = (*HTTP)(unsafe.Pointer(in.HTTP))
+	out.Bucket = (*Bucket)(unsafe.Pointer(in.Bucket))
 	if in.Helm != nil {
 		in, out := &in.Helm, &out.Helm
 		*out = new(HelmRepoSync)
@@ -952,6 +1468,8 @@ func autoConvert_v1beta1_RepoSyncSpec_To_v1alpha1_RepoSyncSpec(in *v1beta1.RepoS
 	} else {
 		out.Helm = nil
 	}
+	out.Substitution = (*Substitution)(unsafe.Pointer(in.Substitution))
+	out.PrunePolicy = (*PrunePolicy)(unsafe.Pointer(in.PrunePolicy))
 	out.Override = (*RepoSyncOverrideSpec)(unsafe.Pointer(in.Override))
 	out.Monitoring = (*MonitoringSpec)(unsafe.Pointer(in.Monitoring))
 	return nil
@@ -1183,6 +1701,8 @@ func autoConvert_v1alpha1_RootSyncSpec_To_v1beta1_RootSyncSpec(in *RootSyncSpec,
 	out.SourceType = configsync.SourceType(in.SourceType)
 	out.Git = (*v1beta1.Git)(unsafe.Pointer(in.Git))
 	out.Oci = (*v1beta1.Oci)(unsafe.Pointer(in.Oci))
+	out.HTTP = (*v1beta1.HTTP)(unsafe.Pointer(in.HTTP))
+	out.Bucket = (*v1beta1.Bucket)(unsafe.Pointer(in.Bucket))
 	if in.Helm != nil {
 		in, out := &in.Helm, &out.Helm
 		*out = new(v1beta1.HelmRootSync)
@@ -1192,6 +1712,8 @@ func autoConvert_v1alpha1_RootSyncSpec_To_v1beta1_RootSyncSpec(in *RootSyncSpec,
 	} else {
 		out.Helm = nil
 	}
+	out.Substitution = (*v1beta1.Substitution)(unsafe.Pointer(in.Substitution))
+	out.PrunePolicy = (*v1beta1.PrunePolicy)(unsafe.Pointer(in.PrunePolicy))
 	out.Override = (*v1beta1.RootSyncOverrideSpec)(unsafe.Pointer(in.Override))
 	out.Monitoring = (*v1beta1.MonitoringSpec)(unsafe.Pointer(in.Monitoring))
 	return nil
@@ -1207,6 +1729,8 @@ func autoConvert_v1beta1_RootSyncSpec_To_v1alpha1_RootSyncSpec(in *v1beta1.RootS
 	out.SourceType = configsync.SourceType(in.SourceType)
 	out.Git = (*Git)(unsafe.Pointer(in.Git))
 	out.Oci = (*Oci)(unsafe.Pointer(in.Oci))
+	out.HTTP = (*HTTP)(unsafe.Pointer(in.HTTP))
+	out.Bucket = (*Bucket)(unsafe.Pointer(in.Bucket))
 	if in.Helm != nil {
 		in, out := &in.Helm, &out.Helm
 		*out = new(HelmRootSync)
@@ -1216,6 +1740,8 @@ func autoConvert_v1beta1_RootSyncSpec_To_v1alpha1_RootSyncSpec(in *v1beta1.RootS
 	} else {
 		out.Helm = nil
 	}
+	out.Substitution = (*Substitution)(unsafe.Pointer(in.Substitution))
+	out.PrunePolicy = (*PrunePolicy)(unsafe.Pointer(in.PrunePolicy))
 	out.Override = (*RootSyncOverrideSpec)(unsafe.Pointer(in.Override))
 	out.Monitoring = (*MonitoringSpec)(unsafe.Pointer(in.Monitoring))
 	return nil
@@ -1275,6 +1801,8 @@ func Convert_v1beta1_SecretReference_To_v1alpha1_SecretReference(in *v1beta1.Sec
 func autoConvert_v1alpha1_SourceStatus_To_v1beta1_SourceStatus(in *SourceStatus, out *v1beta1.SourceStatus, s conversion.Scope) error {
 	out.Git = (*v1beta1.GitStatus)(unsafe.Pointer(in.Git))
 	out.Oci = (*v1beta1.OciStatus)(unsafe.Pointer(in.Oci))
+	out.HTTP = (*v1beta1.HTTPStatus)(unsafe.Pointer(in.HTTP))
+	out.Bucket = (*v1beta1.BucketStatus)(unsafe.Pointer(in.Bucket))
 	out.Helm = (*v1beta1.HelmStatus)(unsafe.Pointer(in.Helm))
 	out.Commit = in.Commit
 	out.LastUpdate = in.LastUpdate
@@ -1291,6 +1819,8 @@ func Convert_v1alpha1_SourceStatus_To_v1beta1_SourceStatus(in *SourceStatus, out
 func autoConvert_v1beta1_SourceStatus_To_v1alpha1_SourceStatus(in *v1beta1.SourceStatus, out *SourceStatus, s conversion.Scope) error {
 	out.Git = (*GitStatus)(unsafe.Pointer(in.Git))
 	out.Oci = (*OciStatus)(unsafe.Pointer(in.Oci))
+	out.HTTP = (*HTTPStatus)(unsafe.Pointer(in.HTTP))
+	out.Bucket = (*BucketStatus)(unsafe.Pointer(in.Bucket))
 	out.Helm = (*HelmStatus)(unsafe.Pointer(in.Helm))
 	out.Commit = in.Commit
 	out.LastUpdate = in.LastUpdate
@@ -1346,14 +1876,58 @@ func Convert_v1beta1_Status_To_v1alpha1_Status(in *v1beta1.Status, out *Status,
 	return autoConvert_v1beta1_Status_To_v1alpha1_Status(in, out, s)
 }
 
+func autoConvert_v1alpha1_Substitution_To_v1beta1_Substitution(in *Substitution, out *v1beta1.Substitution, s conversion.Scope) error {
+	out.ValuesFrom = (*v1beta1.SubstitutionValuesFrom)(unsafe.Pointer(in.ValuesFrom))
+	return nil
+}
+
+// Convert_v1alpha1_Substitution_To_v1beta1_Substitution is an autogenerated conversion function.
+func Convert_v1alpha1_Substitution_To_v1beta1_Substitution(in *Substitution, out *v1beta1.Substitution, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Substitution_To_v1beta1_Substitution(in, out, s)
+}
+
+func autoConvert_v1beta1_Substitution_To_v1alpha1_Substitution(in *v1beta1.Substitution, out *Substitution, s conversion.Scope) error {
+	out.ValuesFrom = (*SubstitutionValuesFrom)(unsafe.Pointer(in.ValuesFrom))
+	return nil
+}
+
+// Convert_v1beta1_Substitution_To_v1alpha1_Substitution is an autogenerated conversion function.
+func Convert_v1beta1_Substitution_To_v1alpha1_Substitution(in *v1beta1.Substitution, out *Substitution, s conversion.Scope) error {
+	return autoConvert_v1beta1_Substitution_To_v1alpha1_Substitution(in, out, s)
+}
+
+func autoConvert_v1alpha1_SubstitutionValuesFrom_To_v1beta1_SubstitutionValuesFrom(in *SubstitutionValuesFrom, out *v1beta1.SubstitutionValuesFrom, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha1_SubstitutionValuesFrom_To_v1beta1_SubstitutionValuesFrom is an autogenerated conversion function.
+func Convert_v1alpha1_SubstitutionValuesFrom_To_v1beta1_SubstitutionValuesFrom(in *SubstitutionValuesFrom, out *v1beta1.SubstitutionValuesFrom, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SubstitutionValuesFrom_To_v1beta1_SubstitutionValuesFrom(in, out, s)
+}
+
+func autoConvert_v1beta1_SubstitutionValuesFrom_To_v1alpha1_SubstitutionValuesFrom(in *v1beta1.SubstitutionValuesFrom, out *SubstitutionValuesFrom, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1beta1_SubstitutionValuesFrom_To_v1alpha1_SubstitutionValuesFrom is an autogenerated conversion function.
+func Convert_v1beta1_SubstitutionValuesFrom_To_v1alpha1_SubstitutionValuesFrom(in *v1beta1.SubstitutionValuesFrom, out *SubstitutionValuesFrom, s conversion.Scope) error {
+	return autoConvert_v1beta1_SubstitutionValuesFrom_To_v1alpha1_SubstitutionValuesFrom(in, out, s)
+}
+
 func autoConvert_v1alpha1_SyncStatus_To_v1beta1_SyncStatus(in *SyncStatus, out *v1beta1.SyncStatus, s conversion.Scope) error {
 	out.Git = (*v1beta1.GitStatus)(unsafe.Pointer(in.Git))
 	out.Oci = (*v1beta1.OciStatus)(unsafe.Pointer(in.Oci))
+	out.HTTP = (*v1beta1.HTTPStatus)(unsafe.Pointer(in.HTTP))
+	out.Bucket = (*v1beta1.BucketStatus)(unsafe.Pointer(in.Bucket))
 	out.Helm = (*v1beta1.HelmStatus)(unsafe.Pointer(in.Helm))
 	out.Commit = in.Commit
 	out.LastUpdate = in.LastUpdate
 	out.Errors = *(*[]v1beta1.ConfigSyncError)(unsafe.Pointer(&in.Errors))
 	out.ErrorSummary = (*v1beta1.ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
+	out.Drift = *(*[]v1beta1.DriftRecord)(unsafe.Pointer(&in.Drift))
+	out.Transfers = *(*[]v1beta1.TransferRecord)(unsafe.Pointer(&in.Transfers))
 	return nil
 }
 
@@ -1365,11 +1939,15 @@ func Convert_v1alpha1_SyncStatus_To_v1beta1_SyncStatus(in *SyncStatus, out *v1be
 func autoConvert_v1beta1_SyncStatus_To_v1alpha1_SyncStatus(in *v1beta1.SyncStatus, out *SyncStatus, s conversion.Scope) error {
 	out.Git = (*GitStatus)(unsafe.Pointer(in.Git))
 	out.Oci = (*OciStatus)(unsafe.Pointer(in.Oci))
+	out.HTTP = (*HTTPStatus)(unsafe.Pointer(in.HTTP))
+	out.Bucket = (*BucketStatus)(unsafe.Pointer(in.Bucket))
 	out.Helm = (*HelmStatus)(unsafe.Pointer(in.Helm))
 	out.Commit = in.Commit
 	out.LastUpdate = in.LastUpdate
 	out.Errors = *(*[]ConfigSyncError)(unsafe.Pointer(&in.Errors))
 	out.ErrorSummary = (*ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
+	out.Drift = *(*[]DriftRecord)(unsafe.Pointer(&in.Drift))
+	out.Transfers = *(*[]TransferRecord)(unsafe.Pointer(&in.Transfers))
 	return nil
 }
 
@@ -1378,6 +1956,32 @@ func Convert_v1beta1_SyncStatus_To_v1alpha1_SyncStatus(in *v1beta1.SyncStatus, o
 	return autoConvert_v1beta1_SyncStatus_To_v1alpha1_SyncStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_TransferRecord_To_v1beta1_TransferRecord(in *TransferRecord, out *v1beta1.TransferRecord, s conversion.Scope) error {
+	if err := Convert_v1alpha1_ResourceRef_To_v1beta1_ResourceRef(&in.Resource, &out.Resource, s); err != nil {
+		return err
+	}
+	out.To = in.To
+	return nil
+}
+
+// Convert_v1alpha1_TransferRecord_To_v1beta1_TransferRecord is an autogenerated conversion function.
+func Convert_v1alpha1_TransferRecord_To_v1beta1_TransferRecord(in *TransferRecord, out *v1beta1.TransferRecord, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TransferRecord_To_v1beta1_TransferRecord(in, out, s)
+}
+
+func autoConvert_v1beta1_TransferRecord_To_v1alpha1_TransferRecord(in *v1beta1.TransferRecord, out *TransferRecord, s conversion.Scope) error {
+	if err := Convert_v1beta1_ResourceRef_To_v1alpha1_ResourceRef(&in.Resource, &out.Resource, s); err != nil {
+		return err
+	}
+	out.To = in.To
+	return nil
+}
+
+// Convert_v1beta1_TransferRecord_To_v1alpha1_TransferRecord is an autogenerated conversion function.
+func Convert_v1beta1_TransferRecord_To_v1alpha1_TransferRecord(in *v1beta1.TransferRecord, out *TransferRecord, s conversion.Scope) error {
+	return autoConvert_v1beta1_TransferRecord_To_v1alpha1_TransferRecord(in, out, s)
+}
+
 func autoConvert_v1alpha1_ValuesFileRef_To_v1beta1_ValuesFileRef(in *ValuesFileRef, out *v1beta1.ValuesFileRef, s conversion.Scope) error {
 	out.Name = in.Name
 	out.DataKey = in.DataKey
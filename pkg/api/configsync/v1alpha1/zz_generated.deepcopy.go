@@ -11,6 +11,49 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Bucket) DeepCopyInto(out *Bucket) {
+	*out = *in
+	out.Period = in.Period
+	if in.CACertSecretRef != nil {
+		in, out := &in.CACertSecretRef, &out.CACertSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bucket.
+func (in *Bucket) DeepCopy() *Bucket {
+	if in == nil {
+		return nil
+	}
+	out := new(Bucket)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketStatus) DeepCopyInto(out *BucketStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketStatus.
+func (in *BucketStatus) DeepCopy() *BucketStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigSyncError) DeepCopyInto(out *ConfigSyncError) {
 	*out = *in
@@ -68,6 +111,29 @@ func (in *ContainerResourcesSpec) DeepCopy() *ContainerResourcesSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftRecord) DeepCopyInto(out *DriftRecord) {
+	*out = *in
+	out.Resource = in.Resource
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.DetectedAt.DeepCopyInto(&out.DetectedAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftRecord.
+func (in *DriftRecord) DeepCopy() *DriftRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ErrorSummary) DeepCopyInto(out *ErrorSummary) {
 	*out = *in
@@ -127,6 +193,49 @@ func (in *GitStatus) DeepCopy() *GitStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTP) DeepCopyInto(out *HTTP) {
+	*out = *in
+	out.Period = in.Period
+	if in.CACertSecretRef != nil {
+		in, out := &in.CACertSecretRef, &out.CACertSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTP.
+func (in *HTTP) DeepCopy() *HTTP {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPStatus) DeepCopyInto(out *HTTPStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPStatus.
+func (in *HTTPStatus) DeepCopy() *HTTPStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HelmBase) DeepCopyInto(out *HelmBase) {
 	*out = *in
@@ -151,6 +260,16 @@ func (in *HelmBase) DeepCopyInto(out *HelmBase) {
 		*out = new(SecretReference)
 		**out = **in
 	}
+	if in.PostRender != nil {
+		in, out := &in.PostRender, &out.PostRender
+		*out = new(HelmPostRender)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIVersions != nil {
+		in, out := &in.APIVersions, &out.APIVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -164,6 +283,87 @@ func (in *HelmBase) DeepCopy() *HelmBase {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmPatch) DeepCopyInto(out *HelmPatch) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(HelmPatchTarget)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmPatch.
+func (in *HelmPatch) DeepCopy() *HelmPatch {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmPatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmPatchTarget) DeepCopyInto(out *HelmPatchTarget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmPatchTarget.
+func (in *HelmPatchTarget) DeepCopy() *HelmPatchTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmPatchTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmPatchesFrom) DeepCopyInto(out *HelmPatchesFrom) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmPatchesFrom.
+func (in *HelmPatchesFrom) DeepCopy() *HelmPatchesFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmPatchesFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmPostRender) DeepCopyInto(out *HelmPostRender) {
+	*out = *in
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]HelmPatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PatchesFrom != nil {
+		in, out := &in.PatchesFrom, &out.PatchesFrom
+		*out = new(HelmPatchesFrom)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmPostRender.
+func (in *HelmPostRender) DeepCopy() *HelmPostRender {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmPostRender)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HelmRepoSync) DeepCopyInto(out *HelmRepoSync) {
 	*out = *in
@@ -249,6 +449,11 @@ func (in *Oci) DeepCopyInto(out *Oci) {
 		*out = new(SecretReference)
 		**out = **in
 	}
+	if in.LayerSelector != nil {
+		in, out := &in.LayerSelector, &out.LayerSelector
+		*out = new(OciLayerSelector)
+		**out = **in
+	}
 	return
 }
 
@@ -262,6 +467,22 @@ func (in *Oci) DeepCopy() *Oci {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OciLayerSelector) DeepCopyInto(out *OciLayerSelector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OciLayerSelector.
+func (in *OciLayerSelector) DeepCopy() *OciLayerSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(OciLayerSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OciStatus) DeepCopyInto(out *OciStatus) {
 	*out = *in
@@ -326,6 +547,86 @@ func (in *OverrideSpec) DeepCopy() *OverrideSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrunePolicy) DeepCopyInto(out *PrunePolicy) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]PruneRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Safeguard != nil {
+		in, out := &in.Safeguard, &out.Safeguard
+		*out = new(PruneSafeguard)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrunePolicy.
+func (in *PrunePolicy) DeepCopy() *PrunePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PrunePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PruneRule) DeepCopyInto(out *PruneRule) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PruneRule.
+func (in *PruneRule) DeepCopy() *PruneRule {
+	if in == nil {
+		return nil
+	}
+	out := new(PruneRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PruneSafeguard) DeepCopyInto(out *PruneSafeguard) {
+	*out = *in
+	if in.MaxDeletions != nil {
+		in, out := &in.MaxDeletions, &out.MaxDeletions
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxDeletionPercent != nil {
+		in, out := &in.MaxDeletionPercent, &out.MaxDeletionPercent
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PruneSafeguard.
+func (in *PruneSafeguard) DeepCopy() *PruneSafeguard {
+	if in == nil {
+		return nil
+	}
+	out := new(PruneSafeguard)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RenderingStatus) DeepCopyInto(out *RenderingStatus) {
 	*out = *in
@@ -339,6 +640,16 @@ func (in *RenderingStatus) DeepCopyInto(out *RenderingStatus) {
 		*out = new(OciStatus)
 		**out = **in
 	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPStatus)
+		**out = **in
+	}
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(BucketStatus)
+		**out = **in
+	}
 	if in.Helm != nil {
 		in, out := &in.Helm, &out.Helm
 		*out = new(HelmStatus)
@@ -496,11 +807,31 @@ func (in *RepoSyncSpec) DeepCopyInto(out *RepoSyncSpec) {
 		*out = new(Oci)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(Bucket)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Helm != nil {
 		in, out := &in.Helm, &out.Helm
 		*out = new(HelmRepoSync)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Substitution != nil {
+		in, out := &in.Substitution, &out.Substitution
+		*out = new(Substitution)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrunePolicy != nil {
+		in, out := &in.PrunePolicy, &out.PrunePolicy
+		*out = new(PrunePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Override != nil {
 		in, out := &in.Override, &out.Override
 		*out = new(RepoSyncOverrideSpec)
@@ -712,11 +1043,31 @@ func (in *RootSyncSpec) DeepCopyInto(out *RootSyncSpec) {
 		*out = new(Oci)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(Bucket)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Helm != nil {
 		in, out := &in.Helm, &out.Helm
 		*out = new(HelmRootSync)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Substitution != nil {
+		in, out := &in.Substitution, &out.Substitution
+		*out = new(Substitution)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrunePolicy != nil {
+		in, out := &in.PrunePolicy, &out.PrunePolicy
+		*out = new(PrunePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Override != nil {
 		in, out := &in.Override, &out.Override
 		*out = new(RootSyncOverrideSpec)
@@ -793,6 +1144,16 @@ func (in *SourceStatus) DeepCopyInto(out *SourceStatus) {
 		*out = new(OciStatus)
 		**out = **in
 	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPStatus)
+		**out = **in
+	}
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(BucketStatus)
+		**out = **in
+	}
 	if in.Helm != nil {
 		in, out := &in.Helm, &out.Helm
 		*out = new(HelmStatus)
@@ -843,6 +1204,43 @@ func (in *Status) DeepCopy() *Status {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Substitution) DeepCopyInto(out *Substitution) {
+	*out = *in
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = new(SubstitutionValuesFrom)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Substitution.
+func (in *Substitution) DeepCopy() *Substitution {
+	if in == nil {
+		return nil
+	}
+	out := new(Substitution)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubstitutionValuesFrom) DeepCopyInto(out *SubstitutionValuesFrom) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubstitutionValuesFrom.
+func (in *SubstitutionValuesFrom) DeepCopy() *SubstitutionValuesFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(SubstitutionValuesFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 	*out = *in
@@ -856,6 +1254,16 @@ func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 		*out = new(OciStatus)
 		**out = **in
 	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPStatus)
+		**out = **in
+	}
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(BucketStatus)
+		**out = **in
+	}
 	if in.Helm != nil {
 		in, out := &in.Helm, &out.Helm
 		*out = new(HelmStatus)
@@ -874,6 +1282,18 @@ func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 		*out = new(ErrorSummary)
 		**out = **in
 	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]DriftRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Transfers != nil {
+		in, out := &in.Transfers, &out.Transfers
+		*out = make([]TransferRecord, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -887,6 +1307,23 @@ func (in *SyncStatus) DeepCopy() *SyncStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransferRecord) DeepCopyInto(out *TransferRecord) {
+	*out = *in
+	out.Resource = in.Resource
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransferRecord.
+func (in *TransferRecord) DeepCopy() *TransferRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(TransferRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValuesFileRef) DeepCopyInto(out *ValuesFileRef) {
 	*out = *in
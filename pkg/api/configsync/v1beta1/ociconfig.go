@@ -70,4 +70,27 @@ type Oci struct {
 	// +nullable
 	// +optional
 	SecretRef *SecretReference `json:"secretRef,omitempty"`
+
+	// layerSelector selects the layers of the OCI artifact to extract.
+	// By default, every layer of a container image is extracted and merged,
+	// and only the layers with a supported configuration media type are
+	// extracted from an OCI artifact.
+	// +nullable
+	// +optional
+	LayerSelector *OciLayerSelector `json:"layerSelector,omitempty"`
+}
+
+// OciLayerSelector selects layers of an OCI artifact. A layer is selected if
+// it matches all the specified fields.
+type OciLayerSelector struct {
+	// mediaType selects the layers with this media type.
+	// e.g. `application/vnd.configsync.content.v1.tar+gzip`.
+	// +optional
+	MediaType string `json:"mediaType,omitempty"`
+
+	// annotation selects the layers with this annotation, in the form KEY or
+	// KEY=VALUE. When only KEY is specified, any annotation value matches.
+	// e.g. `org.opencontainers.image.title=prod`.
+	// +optional
+	Annotation string `json:"annotation,omitempty"`
 }
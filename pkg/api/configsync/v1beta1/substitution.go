@@ -0,0 +1,31 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+// Substitution contains the configuration of the variables substituted in the
+// resources of the source of truth.
+type Substitution struct {
+	// valuesFrom is a reference to a ConfigMap whose data provides the values
+	// of the `${values.KEY}` variables. The ConfigMap must be in the same
+	// namespace as the RootSync or RepoSync.
+	// +optional
+	ValuesFrom *SubstitutionValuesFrom `json:"valuesFrom,omitempty"`
+}
+
+// SubstitutionValuesFrom references a ConfigMap that holds substitution values.
+type SubstitutionValuesFrom struct {
+	// name is the name of the ConfigMap. Required.
+	Name string `json:"name,omitempty"`
+}
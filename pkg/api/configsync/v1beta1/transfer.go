@@ -0,0 +1,27 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+// TransferRecord describes a managed resource that is being handed over to
+// another RootSync or RepoSync, with the `configsync.gke.io/transfer-to`
+// annotation. The resource was released, but not adopted yet.
+type TransferRecord struct {
+	// resource is the transferred resource.
+	Resource ResourceRef `json:"resource"`
+
+	// to is the RootSync, formatted as NAME, or RepoSync, formatted as
+	// NAMESPACE/NAME, the resource is transferred to.
+	To string `json:"to"`
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/GoogleContainerTools/config-sync/pkg/kinds"
 	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
 	m "github.com/GoogleContainerTools/config-sync/pkg/metrics"
+	"github.com/GoogleContainerTools/config-sync/pkg/remediator/drift"
 	"github.com/GoogleContainerTools/config-sync/pkg/status"
 	"github.com/GoogleContainerTools/config-sync/pkg/syncer/differ"
 	"github.com/GoogleContainerTools/config-sync/pkg/syncer/metrics"
@@ -91,6 +92,8 @@ type EventType string
 const (
 	// ErrorEventType is the type of the ErrorEvent
 	ErrorEventType EventType = "ErrorEvent"
+	// TransferEventType is the type of the TransferEvent
+	TransferEventType EventType = "TransferEvent"
 )
 
 // Event is sent to the eventHandler by the supervisor.
@@ -110,6 +113,18 @@ func (e ErrorEvent) Type() EventType {
 	return ErrorEventType
 }
 
+// TransferEvent is sent after the supervisor has released the objects with
+// the transfer-to annotation. It lists the transfers which were not adopted
+// yet by the other reconcilers.
+type TransferEvent struct {
+	Transfers []TransferRecord
+}
+
+// Type returns the type of the event.
+func (e TransferEvent) Type() EventType {
+	return TransferEventType
+}
+
 // Supervisor is a bulk client for applying and deleting a mutable set of
 // resource objects. Managed objects are tracked in a ResourceGroup inventory
 // object.
@@ -141,6 +156,23 @@ type supervisor struct {
 	syncNamespace string
 	// reconcileTimeout controls the reconcile and prune timeout
 	reconcileTimeout time.Duration
+	// forceConflicts is whether server-side apply takes the ownership of the
+	// declared fields managed by other field managers
+	forceConflicts bool
+	// prunePolicy decides which removed objects are pruned. Nil prunes all of
+	// them.
+	prunePolicy *PrunePolicy
+	// scope is the scope of the RSync object
+	scope declared.Scope
+	// inventorySelfHeal is whether the inventory is rebuilt from the managed
+	// objects on the cluster when it doesn't exist
+	inventorySelfHeal bool
+	// driftHandler reports the drift of the objects whose drift policy is
+	// report, instead of reverting it. Nil reverts the drift of all objects.
+	driftHandler drift.Handler
+	// driftSkipped is the set of objects skipped by the current apply,
+	// because of their drift
+	driftSkipped map[core.ID]struct{}
 
 	// execMux prevents concurrent Apply/Destroy calls
 	execMux sync.Mutex
@@ -152,7 +184,7 @@ var _ Supervisor = &supervisor{}
 
 // NewSupervisor constructs either a cluster-level or namespace-level Supervisor,
 // based on the specified scope.
-func NewSupervisor(cs *ClientSet, scope declared.Scope, syncName string, reconcileTimeout time.Duration) Supervisor {
+func NewSupervisor(cs *ClientSet, scope declared.Scope, syncName string, reconcileTimeout time.Duration, forceConflicts bool, prunePolicy *PrunePolicy, inventorySelfHeal bool, driftHandler drift.Handler) Supervisor {
 	syncKind := scope.SyncKind()
 	syncNamespace := scope.SyncNamespace()
 	invInfo := inventory.NewSingleObjectInfo(
@@ -167,13 +199,18 @@ func NewSupervisor(cs *ClientSet, scope declared.Scope, syncName string, reconci
 		policy = inventory.PolicyAdoptAll
 	}
 	a := &supervisor{
-		invInfo:          invInfo,
-		clientSet:        cs,
-		policy:           policy,
-		syncKind:         syncKind,
-		syncName:         syncName,
-		syncNamespace:    syncNamespace,
-		reconcileTimeout: reconcileTimeout,
+		invInfo:           invInfo,
+		clientSet:         cs,
+		policy:            policy,
+		syncKind:          syncKind,
+		syncName:          syncName,
+		syncNamespace:     syncNamespace,
+		reconcileTimeout:  reconcileTimeout,
+		forceConflicts:    forceConflicts,
+		prunePolicy:       prunePolicy,
+		scope:             scope,
+		inventorySelfHeal: inventorySelfHeal,
+		driftHandler:      driftHandler,
 	}
 	klog.V(4).Infof("%s Supervisor %s/%s is initialized", syncKind, syncNamespace, syncName)
 	return a
@@ -317,6 +354,12 @@ func (s *supervisor) handleApplySkippedEvent(ctx context.Context, obj *unstructu
 		// For applies this is desired behavior, not unexpected. The following logic
 		// re-applies just the CS metadata to ensure metadata does not drift.
 		klog.Info("Got AnnotationPreventedUpdateError")
+		if _, found := s.driftSkipped[id]; found {
+			// The annotation was only set to skip the apply of the drifted
+			// object: it is not declared.
+			obj = obj.DeepCopy()
+			core.RemoveAnnotations(obj, metadata.LifecycleMutationAnnotation)
+		}
 		if err := s.updateObjectMetadata(ctx, obj); err != nil {
 			return SkipErrorForResource(
 				fmt.Errorf("updating Config Sync metadata for ignore mutation object: %w", err),
@@ -542,11 +585,21 @@ func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), d
 
 	syncStats := stats.NewSyncStats()
 	objStatusMap := make(ObjectStatusMap)
+	if err := s.healInventory(ctx, declaredResources); err != nil {
+		sendErrorEvent(err, eventHandler)
+		return objStatusMap, syncStats
+	}
 	objs := declaredResources.DeclaredObjects()
 
 	// disabledObjs are objects for which the management are disabled
 	// through annotation.
 	enabledObjs, disabledObjs := partitionObjs(objs)
+	// transferredObjs are objects handed over to another reconciler through
+	// annotation.
+	enabledObjs, transferredObjs := partitionTransfers(enabledObjs)
+	// sharedObjs are objects managed by another reconciler, whose declared
+	// fields are co-managed through annotation.
+	enabledObjs, sharedObjs := partitionShared(enabledObjs)
 	if len(disabledObjs) > 0 {
 		klog.Infof("%v objects to be disabled: %v", len(disabledObjs), core.GKNNs(disabledObjs))
 		disabledCount, err := s.handleDisabledObjects(ctx, disabledObjs)
@@ -559,6 +612,35 @@ func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), d
 			Succeeded: disabledCount,
 		}
 	}
+	if len(transferredObjs) > 0 {
+		klog.Infof("%v objects to be transferred: %v", len(transferredObjs), core.GKNNs(transferredObjs))
+		if err := s.untrackTransfers(ctx, transferredObjs); err != nil {
+			sendErrorEvent(err, eventHandler)
+			return objStatusMap, syncStats
+		}
+		transfers, errs := s.handleTransfers(ctx, transferredObjs)
+		eventHandler(TransferEvent{Transfers: transfers})
+		if errs != nil {
+			// Send the errors one by one, so that the management conflicts
+			// are recorded as such.
+			for _, err := range errs.Errors() {
+				sendErrorEvent(err, eventHandler)
+			}
+		}
+	}
+
+	releasedObjs, err := s.untrackSharedObjects(ctx, objs, sharedObjs)
+	if err != nil {
+		sendErrorEvent(err, eventHandler)
+		return objStatusMap, syncStats
+	}
+	if len(sharedObjs) > 0 || len(releasedObjs) > 0 {
+		klog.Infof("%v objects with shared fields to be applied: %v", len(sharedObjs), core.GKNNs(sharedObjs))
+	}
+	sharedRefs, errs := s.handleSharedObjects(ctx, sharedObjs, releasedObjs)
+	if errs != nil {
+		sendErrorEvent(errs, eventHandler)
+	}
 
 	klog.Infof("%v objects to be applied: %v", len(enabledObjs), core.GKNNs(enabledObjs))
 	resources, err := toUnstructured(enabledObjs)
@@ -566,12 +648,28 @@ func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), d
 		sendErrorEvent(err, eventHandler)
 		return objStatusMap, syncStats
 	}
+	s.driftSkipped = s.skipDriftedObjects(ctx, resources)
+
+	plan, err := s.planPrunes(ctx, objs)
+	if err != nil {
+		sendErrorEvent(err, eventHandler)
+		return objStatusMap, syncStats
+	}
+	if err := s.checkPruneSafeguard(ctx, plan, declaredResources.Commit()); err != nil {
+		sendErrorEvent(err, eventHandler)
+		return objStatusMap, syncStats
+	}
+	blockedObjs, errs := s.applyPrunePolicy(ctx, plan)
+	if errs != nil {
+		sendErrorEvent(errs, eventHandler)
+		return objStatusMap, syncStats
+	}
 
 	unknownTypeResources := make(map[core.ID]struct{})
 	options := apply.ApplierOptions{
 		ServerSideOptions: common.ServerSideOptions{
 			ServerSideApply: true,
-			ForceConflicts:  true,
+			ForceConflicts:  s.forceConflicts,
 			FieldManager:    configsync.FieldManager,
 		},
 		InventoryPolicy: s.policy,
@@ -653,6 +751,13 @@ func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), d
 		}
 	}
 
+	if errs := s.restoreBlockedObjects(ctx, blockedObjs); errs != nil {
+		sendErrorEvent(errs, eventHandler)
+	}
+	if err := s.trackSharedObjects(ctx, sharedRefs); err != nil {
+		sendErrorEvent(err, eventHandler)
+	}
+
 	return objStatusMap, syncStats
 }
 
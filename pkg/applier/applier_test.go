@@ -374,9 +374,10 @@ func TestApply(t *testing.T) {
 				KptApplier: newFakeKptApplier(tc.events),
 				Client:     fakeClient,
 				Mapper:     fakeClient.RESTMapper(),
+				InvClient:  inventory.NewFakeClient(nil),
 				// TODO: Add tests to cover status mode
 			}
-			applier := NewSupervisor(cs, syncScope, syncName, 5*time.Minute)
+			applier := NewSupervisor(cs, syncScope, syncName, 5*time.Minute, true, nil, false, nil)
 
 			var errs status.MultiError
 			eventHandler := func(event Event) {
@@ -448,7 +449,7 @@ func TestNewSupervisor(t *testing.T) {
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			s := NewSupervisor(nil, tc.scope, tc.syncName, 5*time.Minute)
+			s := NewSupervisor(nil, tc.scope, tc.syncName, 5*time.Minute, true, nil, false, nil)
 			ts := s.(*supervisor)
 			require.Equal(t, tc.wantInventoryPolicy, ts.policy)
 			require.Equal(t, tc.wantSyncKind, ts.syncKind)
@@ -503,7 +504,7 @@ func TestHandleDisabledObjects(t *testing.T) {
 		InvClient:  fakeInvClient,
 		ApplySetID: applySetID,
 	}
-	applier := NewSupervisor(cs, syncScope, syncName, 5*time.Minute)
+	applier := NewSupervisor(cs, syncScope, syncName, 5*time.Minute, true, nil, false, nil)
 
 	// Validates that the inventory has 2 objects before disabling
 	require.Len(t, fakeInvClient.Inv.GetObjectRefs(), 2, "expected inventory to contain 2 objects")
@@ -610,10 +611,11 @@ func TestUpdateStatusMode(t *testing.T) {
 				KptApplier: fakeApplier,
 				Client:     fakeClient,
 				Mapper:     fakeClient.RESTMapper(),
+				InvClient:  inventory.NewFakeClient(nil),
 				StatusMode: tc.newStatusMode,
 			}
 
-			applier := NewSupervisor(cs, syncScope, syncName, 5*time.Minute)
+			applier := NewSupervisor(cs, syncScope, syncName, 5*time.Minute, true, nil, false, nil)
 
 			err := applier.UpdateStatusMode(context.Background())
 			require.NoError(t, err)
@@ -783,8 +785,9 @@ func TestProcessApplyEvent(t *testing.T) {
 		KptApplier: newFakeKptApplier(nil),
 		Client:     fakeClient,
 		Mapper:     fakeClient.RESTMapper(),
+		InvClient:  inventory.NewFakeClient(nil),
 	}
-	s := NewSupervisor(cs, declared.RootScope, "sync-name", 5*time.Minute).(*supervisor)
+	s := NewSupervisor(cs, declared.RootScope, "sync-name", 5*time.Minute, true, nil, false, nil).(*supervisor)
 
 	resourceMap := make(map[core.ID]client.Object)
 	resourceMap[deploymentObjID] = deploymentObj
@@ -255,7 +255,7 @@ func TestDestroy(t *testing.T) {
 				// TODO: Add tests to cover disabling objects
 				// TODO: Add tests to cover status mode
 			}
-			destroyer := NewSupervisor(cs, "test-namespace", "rs", 5*time.Minute)
+			destroyer := NewSupervisor(cs, "test-namespace", "rs", 5*time.Minute, true, nil, false, nil)
 
 			var errs status.MultiError
 			eventHandler := func(event Event) {
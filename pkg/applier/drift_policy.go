@@ -0,0 +1,99 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"time"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/diff"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/remediator/drift"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// skipDriftedObjects reports, instead of reverting, the drift of the objects
+// whose drift policy is report. The drift policy is read from the declared
+// object, and defaults to the drift policy of the reconciler.
+//
+// An object has drifted when the fields that differ from the declared values
+// are owned by another field manager on the cluster. The declared fields
+// changed in the source of truth are owned by Config Sync, so they are still
+// applied. The drifted objects are annotated to be skipped by the applier,
+// which then only updates their Config Sync metadata. Returns the IDs of the
+// objects skipped because of their drift.
+//
+// Objects deleted from the cluster are not skipped: they are re-created.
+func (s *supervisor) skipDriftedObjects(ctx context.Context, objs []*unstructured.Unstructured) map[core.ID]struct{} {
+	if s.driftHandler == nil {
+		return nil
+	}
+	skipped := make(map[core.ID]struct{})
+	for _, obj := range objs {
+		policy := metadata.GetDriftPolicy(obj)
+		if policy == "" {
+			policy = s.driftHandler.Policy()
+		}
+		if policy != metadata.DriftPolicyReport {
+			continue
+		}
+		if core.GetAnnotation(obj, metadata.LifecycleMutationAnnotation) == metadata.IgnoreMutation {
+			// The object is never updated by the applier.
+			continue
+		}
+		id := core.IDOf(obj)
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(obj.GroupVersionKind())
+		if err := s.clientSet.Client.Get(ctx, client.ObjectKeyFromObject(obj), live); err != nil {
+			if !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+				klog.Warningf("Failed to get %s to check its drift: %v", id, err)
+			}
+			continue
+		}
+		paths := diff.DriftedFields(obj, live)
+		if len(paths) == 0 {
+			s.driftHandler.RemoveDrift(id)
+			continue
+		}
+		drifted := diff.FieldsOwnedByOthers(live, paths)
+		if len(drifted) == 0 {
+			continue
+		}
+		fields := make([]string, len(drifted))
+		for i, path := range drifted {
+			fields[i] = path.String()
+		}
+		s.driftHandler.AddDrift(live, drift.Record{
+			ID:         id,
+			Version:    obj.GroupVersionKind().Version,
+			SourcePath: status.GetSourceAnnotation(obj),
+			Operation:  drift.OperationUpdate,
+			Fields:     fields,
+			Actor:      diff.DriftActor(live, drifted),
+			DetectedAt: time.Now(),
+		})
+		klog.Infof("Skipping the apply of %s: its drift policy is %s, and fields were modified on the cluster: %v",
+			id, metadata.DriftPolicyReport, fields)
+		core.SetAnnotation(obj, metadata.LifecycleMutationAnnotation, metadata.IgnoreMutation)
+		skipped[id] = struct{}{}
+	}
+	return skipped
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/declared"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/remediator/drift"
+	testingfake "github.com/GoogleContainerTools/config-sync/pkg/syncer/syncertest/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSkipDriftedObjects(t *testing.T) {
+	declaredObj := func(name string, opts ...core.MetaMutator) *unstructured.Unstructured {
+		obj := newConfigMapObj(name)
+		for _, opt := range opts {
+			opt(obj)
+		}
+		require.NoError(t, unstructured.SetNestedField(obj.Object, "declared", "data", "key"))
+		return obj
+	}
+	liveObj := func(name, value, manager string) *unstructured.Unstructured {
+		obj := newConfigMapObj(name)
+		require.NoError(t, unstructured.SetNestedField(obj.Object, value, "data", "key"))
+		obj.SetManagedFields([]metav1.ManagedFieldsEntry{{
+			Manager:    manager,
+			Operation:  metav1.ManagedFieldsOperationUpdate,
+			APIVersion: "v1",
+			Time:       &metav1.Time{Time: time.Now()},
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:key":{}}}`)},
+		}})
+		return obj
+	}
+
+	testCases := []struct {
+		name        string
+		policy      metadata.DriftPolicy
+		declared    *unstructured.Unstructured
+		live        *unstructured.Unstructured
+		wantSkipped bool
+	}{
+		{
+			name:        "field modified by another manager",
+			policy:      metadata.DriftPolicyReport,
+			declared:    declaredObj("cm"),
+			live:        liveObj("cm", "drifted", "kubectl-edit"),
+			wantSkipped: true,
+		},
+		{
+			name:     "field modified by another manager, with the correct policy",
+			policy:   metadata.DriftPolicyCorrect,
+			declared: declaredObj("cm"),
+			live:     liveObj("cm", "drifted", "kubectl-edit"),
+		},
+		{
+			name:        "drift policy annotation overrides the default policy",
+			policy:      metadata.DriftPolicyCorrect,
+			declared:    declaredObj("cm", metadata.WithDriftPolicy(metadata.DriftPolicyReport)),
+			live:        liveObj("cm", "drifted", "kubectl-edit"),
+			wantSkipped: true,
+		},
+		{
+			name:     "field changed in the source of truth",
+			policy:   metadata.DriftPolicyReport,
+			declared: declaredObj("cm"),
+			live:     liveObj("cm", "previous", configsync.FieldManager),
+		},
+		{
+			name:     "no drift",
+			policy:   metadata.DriftPolicyReport,
+			declared: declaredObj("cm"),
+			live:     liveObj("cm", "declared", "kubectl-edit"),
+		},
+		{
+			name:     "deleted object is re-created",
+			policy:   metadata.DriftPolicyReport,
+			declared: declaredObj("cm"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var objs []client.Object
+			if tc.live != nil {
+				objs = append(objs, tc.live)
+			}
+			fakeClient := testingfake.NewClient(t, core.Scheme, objs...)
+			cs := &ClientSet{
+				Client:    fakeClient,
+				Mapper:    fakeClient.RESTMapper(),
+				InvClient: inventory.NewFakeClient(nil),
+			}
+			handler := drift.NewHandler(tc.policy, nil, nil)
+			s := NewSupervisor(cs, declared.RootScope, "root-sync", 5*time.Minute, true, nil, false, handler).(*supervisor)
+
+			skipped := s.skipDriftedObjects(context.Background(), []*unstructured.Unstructured{tc.declared})
+
+			id := core.IDOf(tc.declared)
+			_, found := skipped[id]
+			assert.Equal(t, tc.wantSkipped, found)
+			if tc.wantSkipped {
+				assert.Equal(t, metadata.IgnoreMutation, core.GetAnnotation(tc.declared, metadata.LifecycleMutationAnnotation))
+				records := handler.DriftRecords()
+				require.Len(t, records, 1)
+				assert.Equal(t, id, records[0].ID)
+				assert.Equal(t, drift.OperationUpdate, records[0].Operation)
+				assert.Equal(t, []string{"data.key"}, records[0].Fields)
+				assert.Equal(t, "kubectl-edit", records[0].Actor)
+			} else {
+				assert.Empty(t, core.GetAnnotation(tc.declared, metadata.LifecycleMutationAnnotation))
+				assert.Empty(t, handler.DriftRecords())
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/declared"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// InventoryRepairOptions configures RepairInventory.
+type InventoryRepairOptions struct {
+	// GVKs are the kinds of objects scanned for managed objects. Inventory
+	// references of the other kinds are kept as is.
+	GVKs []schema.GroupVersionKind
+	// AdoptFrom is the manager of another RootSync or RepoSync, as returned by
+	// declared.ResourceManager, whose objects are adopted. Empty to adopt none.
+	AdoptFrom string
+	// Commit is the last commit synced by the RootSync or RepoSync. The managed
+	// objects with a different sync token are reported as not declared. Empty
+	// to skip the check.
+	Commit string
+	// Declared are the IDs of the declared objects. When set, the managed
+	// objects that are not declared are reported as not declared, and left out
+	// of the inventory, so that they are never pruned. Nil to track all the
+	// managed objects.
+	Declared map[core.ID]struct{}
+	// DryRun computes the result without updating the objects or the inventory.
+	DryRun bool
+}
+
+// InventoryRepairResult is the outcome of RepairInventory.
+type InventoryRepairResult struct {
+	// Added are the managed objects that were missing from the inventory.
+	Added object.ObjMetadataSet
+	// Removed are the inventory references of objects that don't exist or are
+	// not managed by the RootSync or RepoSync anymore.
+	Removed object.ObjMetadataSet
+	// Adopted are the objects that were moved from another inventory.
+	Adopted object.ObjMetadataSet
+	// Undeclared are the managed objects that were not applied by the last
+	// synced commit, which means they are not declared anymore.
+	Undeclared object.ObjMetadataSet
+}
+
+// Changed returns true if the repair changed the inventory or the objects.
+func (r *InventoryRepairResult) Changed() bool {
+	return len(r.Added) > 0 || len(r.Removed) > 0 || len(r.Adopted) > 0
+}
+
+// RepairInventory rebuilds the ResourceGroup inventory of a RootSync or
+// RepoSync from the objects on the cluster annotated as managed by it, when the
+// inventory was lost or corrupted. The ResourceGroup is created if it doesn't
+// exist.
+func RepairInventory(ctx context.Context, c client.Client, invClient inventory.Client, scope declared.Scope, syncName string, opts InventoryRepairOptions) (*InventoryRepairResult, error) {
+	syncNamespace := scope.SyncNamespace()
+	invID := InventoryID(syncName, syncNamespace)
+	invInfo := inventory.NewSingleObjectInfo(inventory.ID(invID),
+		types.NamespacedName{Name: syncName, Namespace: syncNamespace})
+	inv, err := invClient.Get(ctx, invInfo, inventory.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		klog.Infof("ResourceGroup %s/%s not found: creating it", syncNamespace, syncName)
+		inv, err = invClient.NewInventory(invInfo)
+	}
+	if err != nil {
+		return nil, err
+	}
+	manager := declared.ResourceManager(scope, syncName)
+
+	result := &InventoryRepairResult{}
+	var found object.ObjMetadataSet
+	scanned := make(map[schema.GroupKind]struct{}, len(opts.GVKs))
+	for _, gvk := range opts.GVKs {
+		scanned[gvk.GroupKind()] = struct{}{}
+		objs, err := listManagedObjects(ctx, c, gvk, scope)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objs {
+			objManager := core.GetAnnotation(obj, metadata.ResourceManagerKey)
+			if objManager != manager && (opts.AdoptFrom == "" || objManager != opts.AdoptFrom) {
+				continue
+			}
+			ref := object.UnstructuredToObjMetadata(obj)
+			if opts.Declared != nil {
+				if _, ok := opts.Declared[core.IDOf(obj)]; !ok {
+					result.Undeclared = append(result.Undeclared, ref)
+					continue
+				}
+			}
+			if objManager != manager || core.GetAnnotation(obj, metadata.OwningInventoryKey) != invID {
+				if !opts.DryRun {
+					if err := adoptObject(ctx, c, obj, manager, invID); err != nil {
+						return nil, err
+					}
+				}
+				result.Adopted = append(result.Adopted, ref)
+			}
+			if opts.Commit != "" && core.GetAnnotation(obj, metadata.SyncTokenAnnotationKey) != opts.Commit {
+				result.Undeclared = append(result.Undeclared, ref)
+			}
+			found = append(found, ref)
+		}
+	}
+
+	oldRefs := inv.GetObjectRefs()
+	var newRefs object.ObjMetadataSet
+	for _, ref := range oldRefs {
+		if _, ok := scanned[ref.GroupKind]; ok && !found.Contains(ref) {
+			result.Removed = append(result.Removed, ref)
+			continue
+		}
+		newRefs = append(newRefs, ref)
+	}
+	result.Added = found.Diff(oldRefs)
+	newRefs = newRefs.Union(result.Added)
+
+	if opts.DryRun || (len(result.Added) == 0 && len(result.Removed) == 0) {
+		return result, nil
+	}
+	inv.SetObjectRefs(newRefs)
+	if err := invClient.CreateOrUpdate(ctx, inv, inventory.UpdateOptions{}); err != nil {
+		return nil, fmt.Errorf("updating ResourceGroup %s/%s: %w", syncNamespace, syncName, err)
+	}
+	return result, nil
+}
+
+// healInventory rebuilds the inventory from the declared objects managed on
+// the cluster, if inventory self-heal is enabled and the inventory doesn't
+// exist. Only the kinds of the declared objects are scanned. The managed
+// objects which are not declared anymore are left out of the inventory, so
+// that they are not pruned, and reported in the logs.
+func (s *supervisor) healInventory(ctx context.Context, declaredResources *declared.Resources) status.Error {
+	if !s.inventorySelfHeal {
+		return nil
+	}
+	_, err := s.clientSet.InvClient.Get(ctx, s.invInfo, inventory.GetOptions{})
+	if err == nil {
+		return nil
+	} else if !apierrors.IsNotFound(err) {
+		return Error(err)
+	}
+	gvkSet, _ := declaredResources.DeclaredGVKs()
+	gvks := make([]schema.GroupVersionKind, 0, len(gvkSet))
+	for gvk := range gvkSet {
+		gvks = append(gvks, gvk)
+	}
+	sort.Slice(gvks, func(i, j int) bool {
+		return gvks[i].String() < gvks[j].String()
+	})
+	declaredIDs := make(map[core.ID]struct{})
+	for _, obj := range declaredResources.DeclaredObjects() {
+		declaredIDs[core.IDOf(obj)] = struct{}{}
+	}
+	result, err := RepairInventory(ctx, s.clientSet.Client, s.clientSet.InvClient, s.scope, s.syncName,
+		InventoryRepairOptions{GVKs: gvks, Declared: declaredIDs})
+	if err != nil {
+		return Error(fmt.Errorf("failed to repair the inventory: %w", err))
+	}
+	klog.Infof("Repaired the inventory of %s %s/%s: tracked %d managed objects",
+		s.syncKind, s.syncNamespace, s.syncName, len(result.Added))
+	if len(result.Undeclared) > 0 {
+		klog.Warningf("Left %d managed objects which are not declared anymore out of the inventory of %s %s/%s: "+
+			"they are not pruned, and must be deleted manually: %v",
+			len(result.Undeclared), s.syncKind, s.syncNamespace, s.syncName, result.Undeclared)
+	}
+	return nil
+}
+
+// listManagedObjects lists the objects of the kind which have the Config Sync
+// labels, in the namespace of a RepoSync, or in all the namespaces of a
+// RootSync. Returns nil if the kind doesn't exist.
+func listManagedObjects(ctx context.Context, c client.Client, gvk schema.GroupVersionKind, scope declared.Scope) ([]*unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	opts := []client.ListOption{
+		client.MatchingLabels{metadata.ManagedByKey: metadata.ManagedByValue},
+	}
+	if scope != declared.RootScope {
+		opts = append(opts, client.InNamespace(scope.SyncNamespace()))
+	}
+	if err := c.List(ctx, list, opts...); err != nil {
+		if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("listing %s: %w", gvk.Kind, err)
+	}
+	objs := make([]*unstructured.Unstructured, len(list.Items))
+	for i := range list.Items {
+		objs[i] = &list.Items[i]
+	}
+	return objs, nil
+}
+
+// adoptObject sets the manager and owning inventory of the object to the
+// specified RootSync or RepoSync.
+func adoptObject(ctx context.Context, c client.Client, obj *unstructured.Unstructured, manager, invID string) error {
+	existing := obj.DeepCopy()
+	core.SetAnnotation(obj, metadata.ResourceManagerKey, manager)
+	core.SetAnnotation(obj, metadata.OwningInventoryKey, invID)
+	if err := c.Patch(ctx, obj, client.MergeFrom(existing), client.FieldOwner(configsync.FieldManager)); err != nil {
+		return fmt.Errorf("adopting %v: %w", core.IDOf(obj), err)
+	}
+	klog.Infof("Adopted %v into inventory %s", core.IDOf(obj), invID)
+	return nil
+}
@@ -0,0 +1,249 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
+	"github.com/GoogleContainerTools/config-sync/pkg/declared"
+	"github.com/GoogleContainerTools/config-sync/pkg/kinds"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	testingfake "github.com/GoogleContainerTools/config-sync/pkg/syncer/syncertest/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRepairInventory(t *testing.T) {
+	syncName := "root-sync"
+	invID := InventoryID(syncName, declared.RootScope.SyncNamespace())
+	oldSyncName := "old-sync"
+	oldManager := declared.ResourceManager(declared.RootScope, oldSyncName)
+	commit := "new-commit"
+
+	managed := metadata.ConfigSyncMetadata{
+		ManagerValue: declared.ResourceManager(declared.RootScope, syncName),
+		SourceHash:   commit,
+		InventoryID:  invID,
+	}
+	moved := metadata.ConfigSyncMetadata{
+		ManagerValue: oldManager,
+		SourceHash:   commit,
+		InventoryID:  InventoryID(oldSyncName, declared.RootScope.SyncNamespace()),
+	}
+	undeclared := metadata.ConfigSyncMetadata{
+		ManagerValue: declared.ResourceManager(declared.RootScope, syncName),
+		SourceHash:   "old-commit",
+		InventoryID:  invID,
+	}
+	other := metadata.ConfigSyncMetadata{
+		ManagerValue: declared.ResourceManager("other-namespace", "repo-sync"),
+		SourceHash:   commit,
+		InventoryID:  InventoryID("repo-sync", "other-namespace"),
+	}
+	newCM := func(name string, csm metadata.ConfigSyncMetadata) client.Object {
+		obj := k8sobjects.ConfigMapObject(core.Namespace("test-namespace"), core.Name(name))
+		csm.SetConfigSyncMetadata(obj)
+		return obj
+	}
+	trackedCM := newCM("tracked", managed)
+	untrackedCM := newCM("untracked", managed)
+	movedCM := newCM("moved", moved)
+	undeclaredCM := newCM("undeclared", undeclared)
+	otherCM := newCM("other", other)
+	deletedCM := k8sobjects.ConfigMapObject(core.Namespace("test-namespace"), core.Name("deleted"))
+	deployment := k8sobjects.DeploymentObject(core.Namespace("test-namespace"), core.Name("deployment"))
+
+	refOf := func(obj client.Object) object.ObjMetadata {
+		ref, err := object.RuntimeToObjMeta(obj)
+		require.NoError(t, err)
+		return ref
+	}
+
+	testCases := []struct {
+		name        string
+		opts        InventoryRepairOptions
+		wantResult  *InventoryRepairResult
+		wantRefs    object.ObjMetadataSet
+		wantManager string
+	}{
+		{
+			name: "dry run",
+			opts: InventoryRepairOptions{
+				GVKs:      []schema.GroupVersionKind{kinds.ConfigMap()},
+				AdoptFrom: oldManager,
+				Commit:    commit,
+				DryRun:    true,
+			},
+			wantResult: &InventoryRepairResult{
+				Added:      object.ObjMetadataSet{refOf(untrackedCM), refOf(movedCM), refOf(undeclaredCM)},
+				Removed:    object.ObjMetadataSet{refOf(deletedCM)},
+				Adopted:    object.ObjMetadataSet{refOf(movedCM)},
+				Undeclared: object.ObjMetadataSet{refOf(undeclaredCM)},
+			},
+			wantRefs:    object.ObjMetadataSet{refOf(trackedCM), refOf(deletedCM), refOf(deployment)},
+			wantManager: oldManager,
+		},
+		{
+			name: "repair and adopt",
+			opts: InventoryRepairOptions{
+				GVKs:      []schema.GroupVersionKind{kinds.ConfigMap()},
+				AdoptFrom: oldManager,
+			},
+			wantResult: &InventoryRepairResult{
+				Added:   object.ObjMetadataSet{refOf(untrackedCM), refOf(movedCM), refOf(undeclaredCM)},
+				Removed: object.ObjMetadataSet{refOf(deletedCM)},
+				Adopted: object.ObjMetadataSet{refOf(movedCM)},
+			},
+			wantRefs: object.ObjMetadataSet{refOf(trackedCM), refOf(deployment),
+				refOf(untrackedCM), refOf(movedCM), refOf(undeclaredCM)},
+			wantManager: managed.ManagerValue,
+		},
+		{
+			name: "repair without adoption",
+			opts: InventoryRepairOptions{
+				GVKs: []schema.GroupVersionKind{kinds.ConfigMap()},
+			},
+			wantResult: &InventoryRepairResult{
+				Added:   object.ObjMetadataSet{refOf(untrackedCM), refOf(undeclaredCM)},
+				Removed: object.ObjMetadataSet{refOf(deletedCM)},
+			},
+			wantRefs: object.ObjMetadataSet{refOf(trackedCM), refOf(deployment),
+				refOf(untrackedCM), refOf(undeclaredCM)},
+			wantManager: oldManager,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := testingfake.NewClient(t, core.Scheme,
+				trackedCM, untrackedCM, movedCM, undeclaredCM, otherCM)
+			fakeInvClient := inventory.NewFakeClient(object.ObjMetadataSet{
+				refOf(trackedCM), refOf(deletedCM), refOf(deployment),
+			})
+
+			result, err := RepairInventory(context.Background(), fakeClient, fakeInvClient,
+				declared.RootScope, syncName, tc.opts)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tc.wantResult.Added, result.Added, "Added")
+			assert.ElementsMatch(t, tc.wantResult.Removed, result.Removed, "Removed")
+			assert.ElementsMatch(t, tc.wantResult.Adopted, result.Adopted, "Adopted")
+			assert.ElementsMatch(t, tc.wantResult.Undeclared, result.Undeclared, "Undeclared")
+			assert.ElementsMatch(t, tc.wantRefs, fakeInvClient.Inv.GetObjectRefs())
+
+			gotCM := movedCM.DeepCopyObject().(client.Object)
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(movedCM), gotCM))
+			assert.Equal(t, tc.wantManager, core.GetAnnotation(gotCM, metadata.ResourceManagerKey))
+		})
+	}
+}
+
+// notFoundInvClient is an inventory client whose inventory doesn't exist until
+// it is created.
+type notFoundInvClient struct {
+	*inventory.FakeClient
+	created bool
+}
+
+func (c *notFoundInvClient) Get(ctx context.Context, id inventory.Info, opts inventory.GetOptions) (inventory.Inventory, error) {
+	if !c.created {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Group: "kpt.dev", Resource: "resourcegroups"}, id.GetID().String())
+	}
+	return c.FakeClient.Get(ctx, id, opts)
+}
+
+func (c *notFoundInvClient) CreateOrUpdate(ctx context.Context, inv inventory.Inventory, opts inventory.UpdateOptions) error {
+	c.created = true
+	return c.FakeClient.CreateOrUpdate(ctx, inv, opts)
+}
+
+func TestRepairInventoryCreatesInventory(t *testing.T) {
+	syncName := "repo-sync"
+	scope := declared.Scope("test-namespace")
+	csm := metadata.ConfigSyncMetadata{
+		ManagerValue: declared.ResourceManager(scope, syncName),
+		InventoryID:  InventoryID(syncName, scope.SyncNamespace()),
+	}
+	cm := k8sobjects.ConfigMapObject(core.Namespace("test-namespace"), core.Name("cm"))
+	csm.SetConfigSyncMetadata(cm)
+	fakeClient := testingfake.NewClient(t, core.Scheme, cm)
+	fakeInvClient := &notFoundInvClient{FakeClient: inventory.NewFakeClient(nil)}
+
+	result, err := RepairInventory(context.Background(), fakeClient, fakeInvClient, scope, syncName,
+		InventoryRepairOptions{GVKs: []schema.GroupVersionKind{kinds.ConfigMap()}})
+	require.NoError(t, err)
+	ref, err := object.RuntimeToObjMeta(cm)
+	require.NoError(t, err)
+	assert.Equal(t, object.ObjMetadataSet{ref}, result.Added)
+	assert.True(t, fakeInvClient.created)
+	assert.Equal(t, object.ObjMetadataSet{ref}, fakeInvClient.Inv.GetObjectRefs())
+}
+
+func TestApplyHealsInventory(t *testing.T) {
+	syncName := "repo-sync"
+	scope := declared.Scope("test-namespace")
+	csm := metadata.ConfigSyncMetadata{
+		ManagerValue: declared.ResourceManager(scope, syncName),
+		InventoryID:  InventoryID(syncName, scope.SyncNamespace()),
+	}
+	declaredCM := k8sobjects.ConfigMapObject(core.Namespace("test-namespace"), core.Name("declared"))
+	undeclaredCM := k8sobjects.ConfigMapObject(core.Namespace("test-namespace"), core.Name("undeclared"))
+	var serverObjs []client.Object
+	var refs object.ObjMetadataSet
+	for _, obj := range []client.Object{declaredCM, undeclaredCM} {
+		serverObj := obj.DeepCopyObject().(client.Object)
+		csm.SetConfigSyncMetadata(serverObj)
+		serverObjs = append(serverObjs, serverObj)
+		ref, err := object.RuntimeToObjMeta(obj)
+		require.NoError(t, err)
+		refs = append(refs, ref)
+	}
+	fakeClient := testingfake.NewClient(t, core.Scheme, serverObjs...)
+	fakeInvClient := &notFoundInvClient{FakeClient: inventory.NewFakeClient(nil)}
+	cs := &ClientSet{
+		KptApplier: newFakeKptApplier(nil),
+		Client:     fakeClient,
+		Mapper:     fakeClient.RESTMapper(),
+		InvClient:  fakeInvClient,
+	}
+	applier := NewSupervisor(cs, scope, syncName, 5*time.Minute, true, nil, true, nil)
+
+	var errs status.MultiError
+	eventHandler := func(event Event) {
+		if errEvent, ok := event.(ErrorEvent); ok {
+			errs = status.Append(errs, errEvent.Error)
+		}
+	}
+	resources := &declared.Resources{}
+	_, err := resources.UpdateDeclared(context.Background(), []client.Object{declaredCM}, "commit")
+	require.NoError(t, err)
+
+	applier.Apply(context.Background(), eventHandler, resources)
+
+	assert.Nil(t, errs)
+	// The undeclared object is left out of the inventory, so that the applier
+	// doesn't prune it.
+	assert.True(t, fakeInvClient.created)
+	assert.Equal(t, object.ObjMetadataSet{refs[0]}, fakeInvClient.Inv.GetObjectRefs())
+}
@@ -0,0 +1,371 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync/v1beta1"
+	kptv1alpha1 "github.com/GoogleContainerTools/config-sync/pkg/api/kpt.dev/v1alpha1"
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	nomosutil "github.com/GoogleContainerTools/config-sync/pkg/util"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PrunePolicy decides what happens to the managed objects that were removed
+// from the source of truth, according to the rules of the `spec.prunePolicy`
+// of a RootSync or RepoSync.
+type PrunePolicy struct {
+	rules []pruneRule
+	// maxDeletions is the maximum number of objects a commit may prune, or
+	// nil if unlimited.
+	maxDeletions *int32
+	// maxDeletionPercent is the maximum percentage of the inventory a commit
+	// may prune, or nil if unlimited.
+	maxDeletionPercent *int32
+}
+
+type pruneRule struct {
+	// groupKind is nil if the rule matches all the kinds.
+	groupKind  *schema.GroupKind
+	namespaces sets.Set[string]
+	// selector is nil if the rule matches all the labels.
+	selector labels.Selector
+	action   configsync.PruneAction
+}
+
+// NewPrunePolicy returns the PrunePolicy of the specified rules and safeguard.
+// Returns nil if there are neither, which means all the removed objects are
+// pruned.
+func NewPrunePolicy(policy *v1beta1.PrunePolicy) (*PrunePolicy, error) {
+	if policy == nil || (len(policy.Rules) == 0 && policy.Safeguard == nil) {
+		return nil, nil
+	}
+	p := &PrunePolicy{}
+	if policy.Safeguard != nil {
+		p.maxDeletions = policy.Safeguard.MaxDeletions
+		p.maxDeletionPercent = policy.Safeguard.MaxDeletionPercent
+	}
+	for i, rule := range policy.Rules {
+		switch rule.Action {
+		case configsync.PruneActionPrune, configsync.PruneActionOrphan, configsync.PruneActionBlock:
+		default:
+			return nil, fmt.Errorf("rule %d: invalid action %q: must be one of %q, %q, %q", i, rule.Action,
+				configsync.PruneActionPrune, configsync.PruneActionOrphan, configsync.PruneActionBlock)
+		}
+		r := pruneRule{action: rule.Action}
+		if rule.Kind != "" {
+			r.groupKind = &schema.GroupKind{Group: rule.Group, Kind: rule.Kind}
+		}
+		if len(rule.Namespaces) > 0 {
+			r.namespaces = sets.New(rule.Namespaces...)
+		}
+		if rule.LabelSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(rule.LabelSelector)
+			if err != nil {
+				return nil, fmt.Errorf("rule %d: invalid labelSelector: %w", i, err)
+			}
+			r.selector = selector
+		}
+		p.rules = append(p.rules, r)
+	}
+	return p, nil
+}
+
+// Action returns the action of the first rule that matches the object, or
+// prune if no rule matches.
+func (p *PrunePolicy) Action(obj client.Object) configsync.PruneAction {
+	if p == nil {
+		return configsync.PruneActionPrune
+	}
+	gk := obj.GetObjectKind().GroupVersionKind().GroupKind()
+	for _, rule := range p.rules {
+		if rule.groupKind != nil && *rule.groupKind != gk {
+			continue
+		}
+		if rule.namespaces != nil && !rule.namespaces.Has(obj.GetNamespace()) {
+			continue
+		}
+		if rule.selector != nil && !rule.selector.Matches(labels.Set(obj.GetLabels())) {
+			continue
+		}
+		return rule.action
+	}
+	return configsync.PruneActionPrune
+}
+
+// PruneBlockedErrorForResource indicates that the prune policy blocked the
+// prune of the given resource.
+func PruneBlockedErrorForResource(id core.ID) status.Error {
+	return applierErrorBuilder.Wrap(fmt.Errorf("skipped prune of %v: blocked by the prune policy: "+
+		"delete the object manually or add it back to the source of truth", id)).Build()
+}
+
+// prunePlan is what happens to the objects in the inventory that are not
+// declared anymore, which are the objects the applier would prune.
+type prunePlan struct {
+	// inv is the inventory the plan was computed from.
+	inv inventory.Inventory
+	// pruned are the objects that will be pruned.
+	pruned object.ObjMetadataSet
+	// orphaned are the objects that will be abandoned instead of pruned.
+	orphaned []*unstructured.Unstructured
+	// blocked are the objects that are kept managed instead of pruned.
+	blocked object.ObjMetadataSet
+	// pending are the blocked objects of a previous apply, which were not
+	// added back to the inventory.
+	pending object.ObjMetadataSet
+}
+
+// planPrunes evaluates the prune policy for the objects in the inventory that
+// are not declared anymore, including the blocked objects of a previous
+// apply that were not added back to the inventory. Returns nil if there is
+// neither a prune policy nor such objects, or no inventory.
+func (s *supervisor) planPrunes(ctx context.Context, declaredObjs []client.Object) (*prunePlan, status.Error) {
+	pending, err := s.getBlockedRefs(ctx)
+	if err != nil {
+		return nil, Error(err)
+	}
+	if s.prunePolicy == nil && len(pending) == 0 {
+		return nil, nil
+	}
+	inv, err := s.clientSet.InvClient.Get(ctx, s.invInfo, inventory.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, Error(err)
+	}
+	declaredIDs := make(map[core.ID]struct{}, len(declaredObjs))
+	for _, obj := range declaredObjs {
+		declaredIDs[core.IDOf(obj)] = struct{}{}
+	}
+
+	if len(pending) > 0 {
+		klog.Infof("Restoring the blocked objects of a previous apply to the inventory: %v", pending)
+		inv.SetObjectRefs(inv.GetObjectRefs().Union(pending))
+	}
+
+	plan := &prunePlan{inv: inv, pending: pending}
+	for _, ref := range inv.GetObjectRefs() {
+		id := idFrom(ref)
+		if _, found := declaredIDs[id]; found {
+			continue
+		}
+		if s.prunePolicy == nil || len(s.prunePolicy.rules) == 0 {
+			// Without rules, the objects don't need to be fetched.
+			plan.pruned = append(plan.pruned, ref)
+			continue
+		}
+		obj, err := s.getInventoryObject(ctx, ref)
+		if err != nil {
+			return nil, Error(err)
+		}
+		if obj == nil {
+			// Already deleted. Let the applier remove it from the inventory.
+			continue
+		}
+		switch s.prunePolicy.Action(obj) {
+		case configsync.PruneActionOrphan:
+			klog.Infof("Prune policy orphans %v", id)
+			plan.orphaned = append(plan.orphaned, obj)
+		case configsync.PruneActionBlock:
+			klog.Infof("Prune policy blocks the prune of %v", id)
+			plan.blocked = append(plan.blocked, ref)
+		default:
+			plan.pruned = append(plan.pruned, ref)
+		}
+	}
+	return plan, nil
+}
+
+// applyPrunePolicy removes the objects that must not be pruned from the
+// inventory, so that the applier doesn't prune them.
+//
+// The orphaned objects are abandoned first, and only the objects successfully
+// abandoned are removed from the inventory, so that the others are abandoned
+// again by the next apply. The blocked objects are recorded on the
+// ResourceGroup before they are removed from the inventory, and returned, so
+// that they can be added back to the inventory after the apply with
+// restoreBlockedObjects, or by the next apply if the reconciler restarts
+// in-between.
+func (s *supervisor) applyPrunePolicy(ctx context.Context, plan *prunePlan) (object.ObjMetadataSet, status.MultiError) {
+	if plan == nil || (len(plan.orphaned) == 0 && len(plan.blocked) == 0 && len(plan.pending) == 0) {
+		return nil, nil
+	}
+	if err := s.setBlockedRefs(ctx, plan.blocked); err != nil {
+		return nil, Error(err)
+	}
+
+	var errs status.MultiError
+	var skipped object.ObjMetadataSet
+	skipped = append(skipped, plan.blocked...)
+	for _, obj := range plan.orphaned {
+		id := core.IDOf(obj)
+		err := s.abandonObject(ctx, obj)
+		handleMetrics(ctx, "unmanage", err)
+		if err != nil {
+			err = fmt.Errorf("failed to remove the Config Sync metadata from %v (prune policy: %s): %v",
+				id, configsync.PruneActionOrphan, err)
+			klog.Warning(err)
+			errs = status.Append(errs, Error(err))
+			continue
+		}
+		klog.V(4).Infof("removed the Config Sync metadata from %v (prune policy: %s)",
+			id, configsync.PruneActionOrphan)
+		skipped = append(skipped, object.UnstructuredToObjMetadata(obj))
+	}
+
+	inv := plan.inv
+	inv.SetObjectRefs(inv.GetObjectRefs().Diff(skipped))
+	if err := s.clientSet.InvClient.CreateOrUpdate(ctx, inv, inventory.UpdateOptions{}); err != nil {
+		if nomosutil.IsRequestTooLargeError(err) {
+			return nil, largeResourceGroupError(err, coreIDFromInventoryInfo(s.invInfo))
+		}
+		return nil, Error(err)
+	}
+	return plan.blocked, errs
+}
+
+// restoreBlockedObjects adds the objects whose prune was blocked by the prune
+// policy back to the inventory, so that they are still managed, and returns
+// an error for each of them.
+func (s *supervisor) restoreBlockedObjects(ctx context.Context, blocked object.ObjMetadataSet) status.MultiError {
+	if len(blocked) == 0 {
+		return nil
+	}
+	inv, err := s.clientSet.InvClient.Get(ctx, s.invInfo, inventory.GetOptions{})
+	if err != nil {
+		return Error(err)
+	}
+	inv.SetObjectRefs(inv.GetObjectRefs().Union(blocked))
+	if err := s.clientSet.InvClient.CreateOrUpdate(ctx, inv, inventory.UpdateOptions{}); err != nil {
+		if nomosutil.IsRequestTooLargeError(err) {
+			return largeResourceGroupError(err, coreIDFromInventoryInfo(s.invInfo))
+		}
+		return Error(err)
+	}
+	if err := s.setBlockedRefs(ctx, nil); err != nil {
+		return Error(err)
+	}
+	var errs status.MultiError
+	for _, ref := range blocked {
+		errs = status.Append(errs, PruneBlockedErrorForResource(idFrom(ref)))
+	}
+	return errs
+}
+
+// getBlockedRefs returns the blocked objects recorded on the ResourceGroup,
+// which were not added back to the inventory yet.
+func (s *supervisor) getBlockedRefs(ctx context.Context) (object.ObjMetadataSet, error) {
+	rg := &kptv1alpha1.ResourceGroup{}
+	key := client.ObjectKey{Name: s.syncName, Namespace: s.syncNamespace}
+	if err := s.clientSet.Client.Get(ctx, key, rg); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	value := core.GetAnnotation(rg, metadata.PruneBlockedAnnotationKey)
+	if value == "" {
+		return nil, nil
+	}
+	var strs []string
+	if err := json.Unmarshal([]byte(value), &strs); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", metadata.PruneBlockedAnnotationKey, err)
+	}
+	var refs object.ObjMetadataSet
+	for _, str := range strs {
+		ref, err := object.ParseObjMetadata(str)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %w", metadata.PruneBlockedAnnotationKey, err)
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
+// setBlockedRefs records the blocked objects on the ResourceGroup, or removes
+// the record if there are none.
+func (s *supervisor) setBlockedRefs(ctx context.Context, refs object.ObjMetadataSet) error {
+	var value string
+	if len(refs) > 0 {
+		strs := make([]string, len(refs))
+		for i, ref := range refs {
+			strs[i] = ref.String()
+		}
+		bytes, err := json.Marshal(strs)
+		if err != nil {
+			return err
+		}
+		value = string(bytes)
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		rg := &kptv1alpha1.ResourceGroup{}
+		key := client.ObjectKey{Name: s.syncName, Namespace: s.syncNamespace}
+		if err := s.clientSet.Client.Get(ctx, key, rg); err != nil {
+			if apierrors.IsNotFound(err) && value == "" {
+				return nil
+			}
+			return err
+		}
+		var updated bool
+		if value == "" {
+			updated = core.RemoveAnnotations(rg, metadata.PruneBlockedAnnotationKey)
+		} else {
+			updated = core.SetAnnotation(rg, metadata.PruneBlockedAnnotationKey, value)
+		}
+		if !updated {
+			return nil
+		}
+		return s.clientSet.Client.Update(ctx, rg, client.FieldOwner(configsync.FieldManager))
+	})
+}
+
+// getInventoryObject gets the object of the inventory reference from the
+// cluster. Returns nil if the object or its kind doesn't exist.
+func (s *supervisor) getInventoryObject(ctx context.Context, ref object.ObjMetadata) (*unstructured.Unstructured, error) {
+	mapping, err := s.clientSet.Mapper.RESTMapping(ref.GroupKind)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(mapping.GroupVersionKind)
+	if err := s.clientSet.Client.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return obj, nil
+}
@@ -0,0 +1,260 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync/v1beta1"
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
+	"github.com/GoogleContainerTools/config-sync/pkg/declared"
+	"github.com/GoogleContainerTools/config-sync/pkg/kinds"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	testingfake "github.com/GoogleContainerTools/config-sync/pkg/syncer/syncertest/fake"
+	"github.com/GoogleContainerTools/config-sync/pkg/testing/testerrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestPrunePolicyAction(t *testing.T) {
+	pvc := schema.GroupVersionKind{Version: "v1", Kind: "PersistentVolumeClaim"}
+	policy, err := NewPrunePolicy(&v1beta1.PrunePolicy{
+		Rules: []v1beta1.PruneRule{
+			{
+				Kind:   "Namespace",
+				Action: configsync.PruneActionBlock,
+			},
+			{
+				Kind:   "PersistentVolumeClaim",
+				Action: configsync.PruneActionOrphan,
+			},
+			{
+				Namespaces: []string{"prod"},
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"tier": "data"},
+				},
+				Action: configsync.PruneActionBlock,
+			},
+			{
+				Group:  "apps",
+				Kind:   "Deployment",
+				Action: configsync.PruneActionPrune,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		obj  client.Object
+		want configsync.PruneAction
+	}{
+		{
+			name: "kind in the core group",
+			obj:  k8sobjects.UnstructuredObject(kinds.Namespace(), core.Name("prod")),
+			want: configsync.PruneActionBlock,
+		},
+		{
+			name: "first matching rule wins",
+			obj: k8sobjects.UnstructuredObject(pvc, core.Namespace("prod"), core.Name("data"),
+				core.Label("tier", "data")),
+			want: configsync.PruneActionOrphan,
+		},
+		{
+			name: "namespace and labels",
+			obj: k8sobjects.UnstructuredObject(kinds.ConfigMap(), core.Namespace("prod"), core.Name("settings"),
+				core.Label("tier", "data")),
+			want: configsync.PruneActionBlock,
+		},
+		{
+			name: "other namespace",
+			obj: k8sobjects.UnstructuredObject(kinds.ConfigMap(), core.Namespace("dev"), core.Name("settings"),
+				core.Label("tier", "data")),
+			want: configsync.PruneActionPrune,
+		},
+		{
+			name: "no matching rule",
+			obj:  k8sobjects.UnstructuredObject(kinds.ClusterRole(), core.Name("viewer")),
+			want: configsync.PruneActionPrune,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, policy.Action(tc.obj))
+		})
+	}
+}
+
+func TestNewPrunePolicy(t *testing.T) {
+	policy, err := NewPrunePolicy(&v1beta1.PrunePolicy{})
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+	assert.Equal(t, configsync.PruneActionPrune,
+		policy.Action(k8sobjects.UnstructuredObject(kinds.Namespace(), core.Name("prod"))))
+
+	_, err = NewPrunePolicy(&v1beta1.PrunePolicy{
+		Rules: []v1beta1.PruneRule{{Kind: "Namespace", Action: "keep"}},
+	})
+	assert.Error(t, err)
+
+	_, err = NewPrunePolicy(&v1beta1.PrunePolicy{
+		Rules: []v1beta1.PruneRule{{
+			LabelSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Near"}},
+			},
+			Action: configsync.PruneActionBlock,
+		}},
+	})
+	assert.Error(t, err)
+}
+
+func TestApplyPrunePolicy(t *testing.T) {
+	syncScope := declared.Scope("test-namespace")
+	syncName := "rs"
+
+	csm := metadata.ConfigSyncMetadata{
+		ManagerValue: declared.ResourceManager(syncScope, syncName),
+		SourceHash:   "commit-hash",
+		InventoryID:  "inventory-id",
+	}
+	declaredCM := k8sobjects.ConfigMapObject(core.Namespace("test-namespace"), core.Name("declared"))
+	removedCM := k8sobjects.ConfigMapObject(core.Namespace("test-namespace"), core.Name("removed"))
+	removedSecret := k8sobjects.SecretObject("removed", core.Namespace("test-namespace"))
+	removedRole := k8sobjects.RoleObject(core.Namespace("test-namespace"), core.Name("removed"))
+	var serverObjs []client.Object
+	var refs object.ObjMetadataSet
+	for _, obj := range []client.Object{declaredCM, removedCM, removedSecret, removedRole} {
+		serverObj := obj.DeepCopyObject().(client.Object)
+		csm.SetConfigSyncMetadata(serverObj)
+		serverObjs = append(serverObjs, serverObj)
+		ref, err := object.RuntimeToObjMeta(obj)
+		require.NoError(t, err)
+		refs = append(refs, ref)
+	}
+	rg := k8sobjects.ResourceGroupObject(syncScope.SyncNamespace(), syncName)
+	fakeClient := testingfake.NewClient(t, core.Scheme, append(serverObjs, rg)...)
+	fakeInvClient := inventory.NewFakeClient(refs)
+
+	prunePolicy, err := NewPrunePolicy(&v1beta1.PrunePolicy{
+		Rules: []v1beta1.PruneRule{
+			{Kind: "ConfigMap", Action: configsync.PruneActionBlock},
+			{Kind: "Secret", Action: configsync.PruneActionOrphan},
+		},
+	})
+	require.NoError(t, err)
+
+	cs := &ClientSet{
+		KptApplier: newFakeKptApplier(nil),
+		Client:     fakeClient,
+		Mapper:     fakeClient.RESTMapper(),
+		InvClient:  fakeInvClient,
+	}
+	applier := NewSupervisor(cs, syncScope, syncName, 5*time.Minute, true, prunePolicy, false, nil)
+
+	var errs status.MultiError
+	eventHandler := func(event Event) {
+		if errEvent, ok := event.(ErrorEvent); ok {
+			errs = status.Append(errs, errEvent.Error)
+		}
+	}
+	resources := &declared.Resources{}
+	_, err = resources.UpdateDeclared(context.Background(), []client.Object{declaredCM}, "")
+	require.NoError(t, err)
+
+	applier.Apply(context.Background(), eventHandler, resources)
+
+	testerrors.AssertEqual(t, PruneBlockedErrorForResource(core.IDOf(removedCM)), errs)
+	// The blocked object is still in the inventory, while the orphaned object
+	// was removed. The fake applier doesn't prune the other objects.
+	assert.ElementsMatch(t, object.ObjMetadataSet{refs[0], refs[1], refs[3]}, fakeInvClient.Inv.GetObjectRefs())
+
+	// The orphaned object is not managed anymore.
+	gotSecret := removedSecret.DeepCopy()
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(removedSecret), gotSecret))
+	assert.False(t, metadata.HasConfigSyncMetadata(gotSecret))
+	gotCM := removedCM.DeepCopy()
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(removedCM), gotCM))
+	assert.True(t, metadata.HasConfigSyncMetadata(gotCM))
+
+	// The blocked objects are not recorded anymore once restored.
+	gotRG := rg.DeepCopy()
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(rg), gotRG))
+	assert.Empty(t, core.GetAnnotation(gotRG, metadata.PruneBlockedAnnotationKey))
+}
+
+func TestPlanPrunesRestoresPendingBlockedObjects(t *testing.T) {
+	syncScope := declared.Scope("test-namespace")
+	syncName := "rs"
+
+	declaredCM := k8sobjects.ConfigMapObject(core.Namespace("test-namespace"), core.Name("declared"))
+	blockedCM := k8sobjects.ConfigMapObject(core.Namespace("test-namespace"), core.Name("blocked"))
+	declaredRef, err := object.RuntimeToObjMeta(declaredCM)
+	require.NoError(t, err)
+	blockedRef, err := object.RuntimeToObjMeta(blockedCM)
+	require.NoError(t, err)
+
+	// The reconciler restarted after the blocked object was removed from the
+	// inventory, and before it was added back.
+	rg := k8sobjects.ResourceGroupObject(syncScope.SyncNamespace(), syncName,
+		core.Annotation(metadata.PruneBlockedAnnotationKey, `["`+blockedRef.String()+`"]`))
+	fakeClient := testingfake.NewClient(t, core.Scheme, declaredCM, blockedCM, rg)
+	fakeInvClient := inventory.NewFakeClient(object.ObjMetadataSet{declaredRef})
+
+	prunePolicy, err := NewPrunePolicy(&v1beta1.PrunePolicy{
+		Rules: []v1beta1.PruneRule{
+			{Kind: "ConfigMap", Namespaces: []string{"test-namespace"}, Action: configsync.PruneActionBlock},
+		},
+	})
+	require.NoError(t, err)
+	cs := &ClientSet{
+		KptApplier: newFakeKptApplier(nil),
+		Client:     fakeClient,
+		Mapper:     fakeClient.RESTMapper(),
+		InvClient:  fakeInvClient,
+	}
+	s := NewSupervisor(cs, syncScope, syncName, 5*time.Minute, true, prunePolicy, false, nil).(*supervisor)
+
+	plan, err := s.planPrunes(context.Background(), []client.Object{declaredCM})
+	require.NoError(t, err)
+	require.NotNil(t, plan)
+	assert.Equal(t, object.ObjMetadataSet{blockedRef}, plan.blocked)
+	assert.Equal(t, object.ObjMetadataSet{blockedRef}, plan.pending)
+
+	blocked, errs := s.applyPrunePolicy(context.Background(), plan)
+	require.NoError(t, errs)
+	assert.Equal(t, object.ObjMetadataSet{blockedRef}, blocked)
+	// The blocked object is still recorded while it is out of the inventory.
+	gotRG := rg.DeepCopy()
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(rg), gotRG))
+	assert.Equal(t, `["`+blockedRef.String()+`"]`, core.GetAnnotation(gotRG, metadata.PruneBlockedAnnotationKey))
+	assert.Equal(t, object.ObjMetadataSet{declaredRef}, fakeInvClient.Inv.GetObjectRefs())
+
+	errs = s.restoreBlockedObjects(context.Background(), blocked)
+	testerrors.AssertEqual(t, PruneBlockedErrorForResource(core.IDOf(blockedCM)), errs)
+	assert.ElementsMatch(t, object.ObjMetadataSet{declaredRef, blockedRef}, fakeInvClient.Inv.GetObjectRefs())
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(rg), gotRG))
+	assert.Empty(t, core.GetAnnotation(gotRG, metadata.PruneBlockedAnnotationKey))
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/kinds"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PruneSafeguardErrorCode is the error code for a PruneSafeguardError.
+const PruneSafeguardErrorCode = "2018"
+
+var pruneSafeguardErrorBuilder = status.NewErrorBuilder(PruneSafeguardErrorCode)
+
+// PruneSafeguardError reports that a commit would prune more objects than
+// allowed by the prune safeguard, and how to acknowledge the commit.
+func PruneSafeguardError(commit string, prunes, total int, syncKind string) status.Error {
+	return pruneSafeguardErrorBuilder.Sprintf(
+		"New commit %q would prune %d of the %d managed objects, which exceeds the prune safeguard. "+
+			"If this is not a mistake, acknowledge the commit by setting the annotation %q to %q on the %s.",
+		commit, prunes, total, metadata.PruneSafeguardAcknowledgedCommitAnnotationKey, commit, syncKind).Build()
+}
+
+// exceedsSafeguard returns true if pruning the number of objects out of the
+// total number of objects in the inventory exceeds any limit of the safeguard.
+func (p *PrunePolicy) exceedsSafeguard(prunes, total int) bool {
+	if p == nil || prunes == 0 {
+		return false
+	}
+	if p.maxDeletions != nil && prunes > int(*p.maxDeletions) {
+		return true
+	}
+	if p.maxDeletionPercent != nil && prunes*100 > int(*p.maxDeletionPercent)*total {
+		return true
+	}
+	return false
+}
+
+// checkPruneSafeguard returns an error if the plan prunes more objects than
+// allowed by the prune safeguard, unless the commit was acknowledged on the
+// RootSync or RepoSync.
+func (s *supervisor) checkPruneSafeguard(ctx context.Context, plan *prunePlan, commit string) status.Error {
+	if plan == nil {
+		return nil
+	}
+	prunes := len(plan.pruned)
+	total := len(plan.inv.GetObjectRefs())
+	if !s.prunePolicy.exceedsSafeguard(prunes, total) {
+		return nil
+	}
+	acknowledged, err := s.acknowledgedCommit(ctx)
+	if err != nil {
+		return Error(err)
+	}
+	if commit != "" && acknowledged == commit {
+		klog.Infof("Prune safeguard acknowledged for commit %q: pruning %d of the %d managed objects", commit, prunes, total)
+		return nil
+	}
+	klog.Warningf("Prune safeguard halted the sync of commit %q: it would prune %d of the %d managed objects", commit, prunes, total)
+	return PruneSafeguardError(commit, prunes, total, s.syncKind)
+}
+
+// acknowledgedCommit returns the commit acknowledged by the
+// prune-safeguard-acknowledged-commit annotation of the RootSync or RepoSync.
+func (s *supervisor) acknowledgedCommit(ctx context.Context) (string, error) {
+	rs := &unstructured.Unstructured{}
+	if s.syncKind == kinds.RootSyncV1Beta1().Kind {
+		rs.SetGroupVersionKind(kinds.RootSyncV1Beta1())
+	} else {
+		rs.SetGroupVersionKind(kinds.RepoSyncV1Beta1())
+	}
+	if err := s.clientSet.Client.Get(ctx, client.ObjectKey{Namespace: s.syncNamespace, Name: s.syncName}, rs); err != nil {
+		return "", err
+	}
+	return core.GetAnnotation(rs, metadata.PruneSafeguardAcknowledgedCommitAnnotationKey), nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync/v1beta1"
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
+	"github.com/GoogleContainerTools/config-sync/pkg/declared"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	testingfake "github.com/GoogleContainerTools/config-sync/pkg/syncer/syncertest/fake"
+	"github.com/GoogleContainerTools/config-sync/pkg/testing/testerrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestPrunePolicyExceedsSafeguard(t *testing.T) {
+	testCases := []struct {
+		name      string
+		safeguard *v1beta1.PruneSafeguard
+		prunes    int
+		total     int
+		want      bool
+	}{
+		{
+			name:      "no limits",
+			safeguard: &v1beta1.PruneSafeguard{},
+			prunes:    10,
+			total:     10,
+			want:      false,
+		},
+		{
+			name:      "no prunes",
+			safeguard: &v1beta1.PruneSafeguard{MaxDeletions: ptr.To[int32](0)},
+			prunes:    0,
+			total:     10,
+			want:      false,
+		},
+		{
+			name:      "at max deletions",
+			safeguard: &v1beta1.PruneSafeguard{MaxDeletions: ptr.To[int32](3)},
+			prunes:    3,
+			total:     10,
+			want:      false,
+		},
+		{
+			name:      "above max deletions",
+			safeguard: &v1beta1.PruneSafeguard{MaxDeletions: ptr.To[int32](3)},
+			prunes:    4,
+			total:     10,
+			want:      true,
+		},
+		{
+			name:      "at max deletion percent",
+			safeguard: &v1beta1.PruneSafeguard{MaxDeletionPercent: ptr.To[int32](25)},
+			prunes:    1,
+			total:     4,
+			want:      false,
+		},
+		{
+			name:      "above max deletion percent",
+			safeguard: &v1beta1.PruneSafeguard{MaxDeletionPercent: ptr.To[int32](25)},
+			prunes:    2,
+			total:     4,
+			want:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := NewPrunePolicy(&v1beta1.PrunePolicy{Safeguard: tc.safeguard})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, policy.exceedsSafeguard(tc.prunes, tc.total))
+		})
+	}
+}
+
+func TestApplyPruneSafeguard(t *testing.T) {
+	syncName := "root-sync"
+	commit := "new-commit"
+
+	csm := metadata.ConfigSyncMetadata{
+		ManagerValue: declared.ResourceManager(declared.RootScope, syncName),
+		SourceHash:   "old-commit",
+		InventoryID:  "inventory-id",
+	}
+	declaredCM := k8sobjects.ConfigMapObject(core.Namespace("test-namespace"), core.Name("declared"))
+	var serverObjs []client.Object
+	var refs object.ObjMetadataSet
+	for _, obj := range []client.Object{
+		declaredCM,
+		k8sobjects.ConfigMapObject(core.Namespace("test-namespace"), core.Name("removed-1")),
+		k8sobjects.ConfigMapObject(core.Namespace("test-namespace"), core.Name("removed-2")),
+	} {
+		serverObj := obj.DeepCopyObject().(client.Object)
+		csm.SetConfigSyncMetadata(serverObj)
+		serverObjs = append(serverObjs, serverObj)
+		ref, err := object.RuntimeToObjMeta(obj)
+		require.NoError(t, err)
+		refs = append(refs, ref)
+	}
+	rs := k8sobjects.RootSyncObjectV1Beta1(syncName)
+	serverObjs = append(serverObjs, rs)
+	fakeClient := testingfake.NewClient(t, core.Scheme, serverObjs...)
+
+	prunePolicy, err := NewPrunePolicy(&v1beta1.PrunePolicy{
+		Safeguard: &v1beta1.PruneSafeguard{MaxDeletionPercent: ptr.To[int32](50)},
+	})
+	require.NoError(t, err)
+
+	cs := &ClientSet{
+		KptApplier: newFakeKptApplier(nil),
+		Client:     fakeClient,
+		Mapper:     fakeClient.RESTMapper(),
+		InvClient:  inventory.NewFakeClient(refs),
+	}
+	applier := NewSupervisor(cs, declared.RootScope, syncName, 5*time.Minute, true, prunePolicy, false, nil)
+
+	var errs status.MultiError
+	eventHandler := func(event Event) {
+		if errEvent, ok := event.(ErrorEvent); ok {
+			errs = status.Append(errs, errEvent.Error)
+		}
+	}
+	resources := &declared.Resources{}
+	_, err = resources.UpdateDeclared(context.Background(), []client.Object{declaredCM}, commit)
+	require.NoError(t, err)
+
+	// Pruning 2 of the 3 objects exceeds the safeguard.
+	applier.Apply(context.Background(), eventHandler, resources)
+	testerrors.AssertEqual(t, PruneSafeguardError(commit, 2, 3, configsync.RootSyncKind), errs)
+
+	// Acknowledging another commit doesn't unblock the sync.
+	core.SetAnnotation(rs, metadata.PruneSafeguardAcknowledgedCommitAnnotationKey, "old-commit")
+	require.NoError(t, fakeClient.Update(context.Background(), rs, client.FieldOwner(testingfake.FieldManager)))
+	errs = nil
+	applier.Apply(context.Background(), eventHandler, resources)
+	testerrors.AssertEqual(t, PruneSafeguardError(commit, 2, 3, configsync.RootSyncKind), errs)
+
+	// Acknowledging the commit unblocks the sync.
+	core.SetAnnotation(rs, metadata.PruneSafeguardAcknowledgedCommitAnnotationKey, commit)
+	require.NoError(t, fakeClient.Update(context.Background(), rs, client.FieldOwner(testingfake.FieldManager)))
+	errs = nil
+	applier.Apply(context.Background(), eventHandler, resources)
+	assert.Nil(t, errs)
+}
@@ -0,0 +1,252 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/declared"
+	"github.com/GoogleContainerTools/config-sync/pkg/diff"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	nomosutil "github.com/GoogleContainerTools/config-sync/pkg/util"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SharedFieldManager returns the server-side apply field manager of the
+// fields co-managed by the specified reconciler. Each reconciler co-managing
+// an object uses its own field manager, so that it never takes the ownership
+// of the fields of the manager of the object, or of the other co-managers.
+func SharedFieldManager(reconcilerName string) string {
+	return configsync.FieldManager + "/" + reconcilerName
+}
+
+// partitionShared splits the objects with shared field ownership from the
+// rest of the objects.
+func partitionShared(objs []client.Object) ([]client.Object, []client.Object) {
+	var kept []client.Object
+	var shared []client.Object
+	for _, obj := range objs {
+		if metadata.IsSharedFieldOwnership(obj) {
+			shared = append(shared, obj)
+		} else {
+			kept = append(kept, obj)
+		}
+	}
+	return kept, shared
+}
+
+// untrackSharedObjects removes the objects with shared field ownership from
+// the inventory, so that the applier neither applies nor prunes them, as well
+// as the objects co-managed by this reconciler which are no longer declared.
+// Returns the latter, whose fields must be released with handleSharedObjects.
+func (s *supervisor) untrackSharedObjects(ctx context.Context, declaredObjs, sharedObjs []client.Object) ([]*unstructured.Unstructured, status.MultiError) {
+	inv, err := s.clientSet.InvClient.Get(ctx, s.invInfo, inventory.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, Error(err)
+	}
+
+	declaredIDs := make(map[core.ID]struct{}, len(declaredObjs))
+	for _, obj := range declaredObjs {
+		declaredIDs[core.IDOf(obj)] = struct{}{}
+	}
+	sharedIDs := make(map[core.ID]struct{}, len(sharedObjs))
+	for _, obj := range sharedObjs {
+		sharedIDs[core.IDOf(obj)] = struct{}{}
+	}
+
+	reconciler := declared.ReconcilerNameFromScope(s.scope, s.syncName)
+	var untracked object.ObjMetadataSet
+	var released []*unstructured.Unstructured
+	for _, ref := range inv.GetObjectRefs() {
+		id := idFrom(ref)
+		if _, found := sharedIDs[id]; found {
+			untracked = append(untracked, ref)
+			continue
+		}
+		if _, found := declaredIDs[id]; found {
+			continue
+		}
+		uObj, err := s.getInventoryObject(ctx, ref)
+		if err != nil {
+			return nil, Error(err)
+		}
+		if uObj != nil && isCoManagedBy(uObj, reconciler) {
+			// The object is no longer declared: release its fields, instead
+			// of pruning it.
+			untracked = append(untracked, ref)
+			released = append(released, uObj)
+		}
+	}
+	if len(untracked) == 0 {
+		return nil, nil
+	}
+	inv.SetObjectRefs(inv.GetObjectRefs().Diff(untracked))
+	if err := s.clientSet.InvClient.CreateOrUpdate(ctx, inv, inventory.UpdateOptions{}); err != nil {
+		if nomosutil.IsRequestTooLargeError(err) {
+			return nil, largeResourceGroupError(err, coreIDFromInventoryInfo(s.invInfo))
+		}
+		return nil, Error(err)
+	}
+	return released, nil
+}
+
+// handleSharedObjects applies the declared fields of the objects with shared
+// field ownership, which are managed by another reconciler, and releases the
+// fields of the objects that are no longer declared. The objects must have
+// been removed from the inventory with untrackSharedObjects first.
+// Returns the inventory references to add back to the inventory after the
+// apply with trackSharedObjects, so that the fields are released once the
+// objects are removed from the source of truth, and any errors encountered.
+func (s *supervisor) handleSharedObjects(ctx context.Context, sharedObjs []client.Object, releasedObjs []*unstructured.Unstructured) (object.ObjMetadataSet, status.MultiError) {
+	var tracked object.ObjMetadataSet
+	var errs status.MultiError
+	for _, uObj := range releasedObjs {
+		if err := s.releaseSharedFields(ctx, uObj); err != nil {
+			klog.Warning(err)
+			errs = status.Append(errs, err)
+			// Retry on the next apply.
+			tracked = append(tracked, object.UnstructuredToObjMetadata(uObj))
+		}
+	}
+	for _, obj := range sharedObjs {
+		if err := s.applySharedFields(ctx, obj); err != nil {
+			klog.Warning(err)
+			errs = status.Append(errs, err)
+		}
+		id := core.IDOf(obj)
+		tracked = append(tracked, object.ObjMetadata{GroupKind: id.GroupKind, Namespace: id.Namespace, Name: id.Name})
+	}
+	return tracked, errs
+}
+
+// isCoManagedBy returns whether the object is managed by another reconciler,
+// and the specified reconciler co-manages some of its fields.
+func isCoManagedBy(obj client.Object, reconciler string) bool {
+	if _, found := obj.GetAnnotations()[metadata.SharedFieldsAnnotationKey(reconciler)]; !found {
+		return false
+	}
+	manager := core.GetAnnotation(obj, metadata.ResourceManagerKey)
+	syncScope, syncName := declared.ManagerScopeAndName(manager)
+	return declared.ReconcilerNameFromScope(syncScope, syncName) != reconciler
+}
+
+// applySharedFields applies the declared fields of the object with shared
+// field ownership with the shared field manager of this reconciler, after
+// checking that they don't overlap the fields of the manager of the object,
+// or of the other co-managers.
+func (s *supervisor) applySharedFields(ctx context.Context, obj client.Object) status.Error {
+	id := core.IDOf(obj)
+	fields, err := diff.SharedFields(obj)
+	if err != nil {
+		return SharedFieldsErrorForResource(id, err)
+	}
+	ref := object.ObjMetadata{GroupKind: id.GroupKind, Namespace: id.Namespace, Name: id.Name}
+	uObj, err := s.getInventoryObject(ctx, ref)
+	if err != nil {
+		return Error(err)
+	}
+	if uObj == nil {
+		return SharedFieldsErrorForResource(id,
+			errors.New("the object does not exist: it must be created by the RootSync or RepoSync managing it"))
+	}
+	reconciler := declared.ReconcilerNameFromScope(s.scope, s.syncName)
+	if err := diff.ValidateSharedFields(reconciler, uObj, fields); err != nil {
+		return SharedFieldsErrorForResource(id, err)
+	}
+	fieldsJSON, err := fields.ToJSON()
+	if err != nil {
+		return SharedFieldsErrorForResource(id, err)
+	}
+
+	resources, errs := toUnstructured([]client.Object{obj})
+	if errs != nil {
+		return errs.Errors()[0]
+	}
+	applyObj := resources[0].DeepCopy()
+	metadata.RemoveConfigSyncMetadata(applyObj)
+	metadata.RemoveApplySetPartOfLabel(applyObj, s.clientSet.ApplySetID)
+	core.RemoveAnnotations(applyObj, metadata.LifecycleMutationAnnotation)
+	core.SetAnnotation(applyObj, metadata.SharedFieldsAnnotationKey(reconciler), string(fieldsJSON))
+
+	opts := []client.PatchOption{client.FieldOwner(SharedFieldManager(reconciler))}
+	if s.forceConflicts {
+		opts = append(opts, client.ForceOwnership)
+	}
+	err = s.clientSet.Client.Patch(ctx, applyObj, client.Apply, opts...)
+	handleMetrics(ctx, "update", err)
+	if err != nil {
+		return Error(fmt.Errorf("failed to apply the shared fields of %v: %w", id, err))
+	}
+	klog.V(4).Infof("Applied the shared fields of %v", id)
+	return nil
+}
+
+// releaseSharedFields releases the fields co-managed by this reconciler, and
+// its shared fields annotation, by applying an empty object with its shared
+// field manager.
+func (s *supervisor) releaseSharedFields(ctx context.Context, uObj *unstructured.Unstructured) status.Error {
+	id := core.IDOf(uObj)
+	applyObj := &unstructured.Unstructured{}
+	applyObj.SetGroupVersionKind(uObj.GroupVersionKind())
+	applyObj.SetNamespace(uObj.GetNamespace())
+	applyObj.SetName(uObj.GetName())
+	reconciler := declared.ReconcilerNameFromScope(s.scope, s.syncName)
+	err := s.clientSet.Client.Patch(ctx, applyObj, client.Apply,
+		client.FieldOwner(SharedFieldManager(reconciler)))
+	handleMetrics(ctx, "update", err)
+	if err != nil {
+		return Error(fmt.Errorf("failed to release the shared fields of %v: %w", id, err))
+	}
+	klog.Infof("Released the shared fields of %v", id)
+	return nil
+}
+
+// trackSharedObjects adds the objects with shared field ownership back to
+// the inventory after the apply.
+func (s *supervisor) trackSharedObjects(ctx context.Context, refs object.ObjMetadataSet) status.Error {
+	if len(refs) == 0 {
+		return nil
+	}
+	inv, err := s.clientSet.InvClient.Get(ctx, s.invInfo, inventory.GetOptions{})
+	if err != nil {
+		return Error(err)
+	}
+	inv.SetObjectRefs(inv.GetObjectRefs().Union(refs))
+	if err := s.clientSet.InvClient.CreateOrUpdate(ctx, inv, inventory.UpdateOptions{}); err != nil {
+		if nomosutil.IsRequestTooLargeError(err) {
+			return largeResourceGroupError(err, coreIDFromInventoryInfo(s.invInfo))
+		}
+		return Error(err)
+	}
+	return nil
+}
+
+// SharedFieldsErrorForResource indicates that the shared fields of the given
+// resource could not be applied.
+func SharedFieldsErrorForResource(id core.ID, err error) status.Error {
+	return applierErrorBuilder.Wrap(fmt.Errorf("skipped apply of the shared fields of %v: %w", id, err)).Build()
+}
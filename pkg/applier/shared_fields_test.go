@@ -0,0 +1,147 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
+	"github.com/GoogleContainerTools/config-sync/pkg/declared"
+	"github.com/GoogleContainerTools/config-sync/pkg/diff"
+	"github.com/GoogleContainerTools/config-sync/pkg/kinds"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	testingfake "github.com/GoogleContainerTools/config-sync/pkg/syncer/syncertest/fake"
+	"github.com/GoogleContainerTools/config-sync/pkg/testing/testerrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
+)
+
+func TestApplySharedFields(t *testing.T) {
+	syncName := "repo-sync"
+	scope := declared.Scope("bookstore")
+	reconciler := declared.ReconcilerNameFromScope(scope, syncName)
+	self := metadata.ConfigSyncMetadata{
+		ManagerValue: declared.ResourceManager(scope, syncName),
+		InventoryID:  InventoryID(syncName, scope.SyncNamespace()),
+	}
+	root := metadata.ConfigSyncMetadata{
+		ManagerValue: declared.ResourceManager(declared.RootScope, "root-sync"),
+		InventoryID:  InventoryID("root-sync", declared.RootScope.SyncNamespace()),
+	}
+	newCM := func(name string, opts ...core.MetaMutator) client.Object {
+		return k8sobjects.ConfigMapObject(append([]core.MetaMutator{
+			core.Namespace("bookstore"), core.Name(name)}, opts...)...)
+	}
+	liveCM := func(obj client.Object, csm metadata.ConfigSyncMetadata, opts ...core.MetaMutator) client.Object {
+		liveObj := obj.DeepCopyObject().(client.Object)
+		core.RemoveAnnotations(liveObj, metadata.FieldOwnershipAnnotationKey)
+		csm.SetConfigSyncMetadata(liveObj)
+		for _, opt := range opts {
+			opt(liveObj)
+		}
+		return liveObj
+	}
+	refOf := func(obj client.Object) object.ObjMetadata {
+		ref, err := object.RuntimeToObjMeta(obj)
+		require.NoError(t, err)
+		return ref
+	}
+	declaredData := core.Annotation(metadata.DeclaredFieldsKey, `{"f:data":{"f:key":{}}}`)
+
+	keptCM := newCM("kept")
+	sharedCM := newCM("shared",
+		core.Label("team", "bookstore"),
+		metadata.WithFieldOwnership(metadata.FieldOwnershipShared),
+		core.Annotation(metadata.DeclaredFieldsKey, `{"f:metadata":{"f:labels":{"f:team":{}}}}`))
+	conflictCM := newCM("conflict",
+		metadata.WithFieldOwnership(metadata.FieldOwnershipShared),
+		declaredData)
+	releasedCM := newCM("released")
+
+	conflictLive := liveCM(conflictCM, root, declaredData)
+	fakeClient := testingfake.NewClient(t, core.Scheme,
+		liveCM(keptCM, self),
+		liveCM(sharedCM, root, declaredData),
+		conflictLive,
+		liveCM(releasedCM, root, declaredData,
+			core.Annotation(metadata.SharedFieldsAnnotationKey(reconciler), `{"f:data":{"f:other":{}}}`)))
+	fakeClient.Storage().SetAllowedFieldManagers(
+		sets.New(configsync.FieldManager, SharedFieldManager(reconciler)))
+	fakeInvClient := inventory.NewFakeClient(object.ObjMetadataSet{
+		refOf(keptCM), refOf(sharedCM), refOf(releasedCM),
+	})
+	kptApplier := newFakeKptApplier(nil)
+	cs := &ClientSet{
+		KptApplier: kptApplier,
+		Client:     fakeClient,
+		Mapper:     fakeClient.RESTMapper(),
+		InvClient:  fakeInvClient,
+	}
+	applier := NewSupervisor(cs, scope, syncName, 5*time.Minute, true, nil, false, nil)
+
+	var errs status.MultiError
+	eventHandler := func(event Event) {
+		if errEvent, ok := event.(ErrorEvent); ok {
+			errs = status.Append(errs, errEvent.Error)
+		}
+	}
+	resources := &declared.Resources{}
+	_, err := resources.UpdateDeclared(context.Background(), []client.Object{keptCM, sharedCM, conflictCM}, "commit")
+	require.NoError(t, err)
+
+	applier.Apply(context.Background(), eventHandler, resources)
+
+	conflictErr := diff.ValidateSharedFields(reconciler, conflictLive, mustSharedFields(t, conflictCM))
+	require.Error(t, conflictErr)
+	testerrors.AssertEqual(t, SharedFieldsErrorForResource(core.IDOf(conflictCM), conflictErr), errs)
+
+	getCM := func(obj client.Object) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(kinds.ConfigMap())
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(obj), u))
+		return u
+	}
+	shared := getCM(sharedCM)
+	assert.Equal(t, "bookstore", shared.GetLabels()["team"])
+	assert.Equal(t, `{"f:metadata":{"f:labels":{"f:team":{}}}}`,
+		shared.GetAnnotations()[metadata.SharedFieldsAnnotationKey(reconciler)])
+	assert.NotContains(t, getCM(releasedCM).GetAnnotations(), metadata.SharedFieldsAnnotationKey(reconciler))
+
+	// The shared objects are neither applied nor pruned by the applier, but
+	// they are tracked in the inventory, so that their fields are released
+	// once they are no longer declared.
+	require.Len(t, kptApplier.objsToApply, 1)
+	assert.Equal(t, core.IDOf(keptCM), core.IDOf(kptApplier.objsToApply[0]))
+	assert.ElementsMatch(t, object.ObjMetadataSet{refOf(keptCM), refOf(sharedCM), refOf(conflictCM)},
+		fakeInvClient.Inv.GetObjectRefs())
+}
+
+func mustSharedFields(t *testing.T, obj client.Object) *fieldpath.Set {
+	t.Helper()
+	fields, err := diff.SharedFields(obj)
+	require.NoError(t, err)
+	return fields
+}
@@ -0,0 +1,163 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/declared"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	nomosutil "github.com/GoogleContainerTools/config-sync/pkg/util"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TransferRecord describes a managed object with the transfer-to annotation,
+// which was released to another RootSync or RepoSync, but not adopted yet.
+type TransferRecord struct {
+	// ID is the ID of the transferred object.
+	ID core.ID
+	// Version is the version of the transferred object.
+	Version string
+	// SourcePath is the repo-relative slash path to where the object is
+	// declared.
+	SourcePath string
+	// To is the RootSync, formatted as NAME, or RepoSync, formatted as
+	// NAMESPACE/NAME, the object is transferred to.
+	To string
+}
+
+// partitionTransfers splits the objects with the transfer-to annotation from
+// the rest of the objects.
+func partitionTransfers(objs []client.Object) ([]client.Object, []client.Object) {
+	var kept []client.Object
+	var transferred []client.Object
+	for _, obj := range objs {
+		if metadata.HasTransferTo(obj) {
+			transferred = append(transferred, obj)
+		} else {
+			kept = append(kept, obj)
+		}
+	}
+	return kept, transferred
+}
+
+// untrackTransfers removes the objects with the transfer-to annotation from
+// the inventory, so that they are never pruned, even if their transfer fails.
+func (s *supervisor) untrackTransfers(ctx context.Context, objs []client.Object) status.Error {
+	if err := s.removeFromInventory(ctx, objs); err != nil {
+		if nomosutil.IsRequestTooLargeError(err) {
+			return largeResourceGroupError(err, coreIDFromInventoryInfo(s.invInfo))
+		}
+		return Error(err)
+	}
+	return nil
+}
+
+// handleTransfers hands the objects with the transfer-to annotation over to
+// the RootSync or RepoSync of the annotation: their manager is switched to
+// the other RootSync or RepoSync in a single patch, so that its reconciler
+// adopts them on its next apply. The objects must have been removed from the
+// inventory with untrackTransfers first.
+// Returns the transfers which were not adopted yet, and any errors
+// encountered.
+func (s *supervisor) handleTransfers(ctx context.Context, objs []client.Object) ([]TransferRecord, status.MultiError) {
+	var transfers []TransferRecord
+	var errs status.MultiError
+	for _, obj := range objs {
+		inFlight, err := s.transferObject(ctx, obj)
+		if err != nil {
+			klog.Warning(err)
+			errs = status.Append(errs, err)
+			continue
+		}
+		if inFlight {
+			transfers = append(transfers, TransferRecord{
+				ID:         core.IDOf(obj),
+				Version:    obj.GetObjectKind().GroupVersionKind().Version,
+				SourcePath: status.GetSourceAnnotation(obj),
+				To:         metadata.GetTransferTo(obj),
+			})
+		}
+	}
+	return transfers, errs
+}
+
+// transferObject hands the object over to the RootSync or RepoSync of its
+// transfer-to annotation, if it is still managed by this reconciler.
+// Returns whether the transfer is still in flight: the object was released,
+// but the other reconciler didn't adopt it yet.
+func (s *supervisor) transferObject(ctx context.Context, obj client.Object) (bool, status.Error) {
+	id := core.IDOf(obj)
+	toScope, toName, err := declared.ParseSyncRef(metadata.GetTransferTo(obj))
+	if err != nil {
+		return false, TransferErrorForResource(id, err)
+	}
+	if toScope != declared.RootScope && obj.GetNamespace() != toScope.String() {
+		return false, TransferErrorForResource(id,
+			fmt.Errorf("a RepoSync can only manage objects in its namespace %q", toScope))
+	}
+	toManager := declared.ResourceManager(toScope, toName)
+	if toManager == declared.ResourceManager(s.scope, s.syncName) {
+		return false, TransferErrorForResource(id,
+			fmt.Errorf("the object is already managed by this %s", s.syncKind))
+	}
+
+	uObj, err := s.getInventoryObject(ctx, object.ObjMetadata{
+		GroupKind: id.GroupKind,
+		Namespace: id.Namespace,
+		Name:      id.Name,
+	})
+	if err != nil {
+		return false, Error(err)
+	}
+	if uObj == nil {
+		// The other reconciler creates the object, if it is declared.
+		return false, nil
+	}
+	toInventoryID := InventoryID(toName, toScope.SyncNamespace())
+	switch core.GetAnnotation(uObj, metadata.ResourceManagerKey) {
+	case toManager:
+		// Already released. The transfer completes when the other reconciler
+		// adopts the object into its inventory.
+		return core.GetAnnotation(uObj, metadata.OwningInventoryKey) != toInventoryID, nil
+	case declared.ResourceManager(s.scope, s.syncName), "":
+		existing := uObj.DeepCopy()
+		core.SetAnnotation(uObj, metadata.ResourceManagerKey, toManager)
+		core.RemoveAnnotations(uObj, metadata.OwningInventoryKey)
+		err := s.clientSet.Client.Patch(ctx, uObj, client.MergeFrom(existing),
+			client.FieldOwner(configsync.FieldManager))
+		handleMetrics(ctx, "transfer", err)
+		if err != nil {
+			return false, Error(fmt.Errorf("failed to transfer %v to %s: %w", id, toManager, err))
+		}
+		klog.Infof("Released %v to the %s reconciler", id, toManager)
+		return true, nil
+	default:
+		return false, status.ManagementConflictErrorWrap(uObj, declared.ResourceManager(s.scope, s.syncName))
+	}
+}
+
+// TransferErrorForResource indicates that the transfer-to annotation of the
+// given resource is invalid.
+func TransferErrorForResource(id core.ID, err error) status.Error {
+	return applierErrorBuilder.Wrap(fmt.Errorf("skipped transfer of %v: invalid %s annotation: %w",
+		id, metadata.TransferToAnnotationKey, err)).Build()
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
+	"github.com/GoogleContainerTools/config-sync/pkg/declared"
+	"github.com/GoogleContainerTools/config-sync/pkg/kinds"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	testingfake "github.com/GoogleContainerTools/config-sync/pkg/syncer/syncertest/fake"
+	"github.com/GoogleContainerTools/config-sync/pkg/testing/testerrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestApplyTransfers(t *testing.T) {
+	syncName := "root-sync"
+	manager := declared.ResourceManager(declared.RootScope, syncName)
+	toManager := declared.ResourceManager("bookstore", "repo-sync")
+	toInvID := InventoryID("repo-sync", "bookstore")
+	otherManager := declared.ResourceManager(declared.RootScope, "other-sync")
+
+	managedBy := func(manager, invID string) metadata.ConfigSyncMetadata {
+		return metadata.ConfigSyncMetadata{ManagerValue: manager, InventoryID: invID}
+	}
+	self := managedBy(manager, InventoryID(syncName, declared.RootScope.SyncNamespace()))
+
+	keptCM := k8sobjects.ConfigMapObject(core.Namespace("bookstore"), core.Name("kept"))
+	releasedCM := k8sobjects.ConfigMapObject(core.Namespace("bookstore"), core.Name("released"),
+		metadata.WithTransferTo("bookstore/repo-sync"))
+	adoptedCM := k8sobjects.ConfigMapObject(core.Namespace("bookstore"), core.Name("adopted"),
+		metadata.WithTransferTo("bookstore/repo-sync"))
+	conflictCM := k8sobjects.ConfigMapObject(core.Namespace("bookstore"), core.Name("conflict"),
+		metadata.WithTransferTo("bookstore/repo-sync"))
+	clusterRole := k8sobjects.ClusterRoleObject(core.Name("cluster-role"),
+		metadata.WithTransferTo("bookstore/repo-sync"))
+
+	serverObjs := map[client.Object]metadata.ConfigSyncMetadata{
+		keptCM:      self,
+		releasedCM:  self,
+		adoptedCM:   managedBy(toManager, toInvID),
+		conflictCM:  managedBy(otherManager, InventoryID("other-sync", declared.RootScope.SyncNamespace())),
+		clusterRole: self,
+	}
+	var objs []client.Object
+	var liveObjs []client.Object
+	var refs object.ObjMetadataSet
+	for obj, csm := range serverObjs {
+		objs = append(objs, obj)
+		liveObj := obj.DeepCopyObject().(client.Object)
+		core.RemoveAnnotations(liveObj, metadata.TransferToAnnotationKey)
+		csm.SetConfigSyncMetadata(liveObj)
+		liveObjs = append(liveObjs, liveObj)
+		if csm == self {
+			ref, err := object.RuntimeToObjMeta(obj)
+			require.NoError(t, err)
+			refs = append(refs, ref)
+		}
+	}
+	keptRef, err := object.RuntimeToObjMeta(keptCM)
+	require.NoError(t, err)
+
+	fakeClient := testingfake.NewClient(t, core.Scheme, liveObjs...)
+	fakeInvClient := inventory.NewFakeClient(refs)
+	kptApplier := newFakeKptApplier(nil)
+	cs := &ClientSet{
+		KptApplier: kptApplier,
+		Client:     fakeClient,
+		Mapper:     fakeClient.RESTMapper(),
+		InvClient:  fakeInvClient,
+	}
+	applier := NewSupervisor(cs, declared.RootScope, syncName, 5*time.Minute, true, nil, false, nil)
+
+	var errs status.MultiError
+	var transfers []TransferRecord
+	eventHandler := func(event Event) {
+		switch e := event.(type) {
+		case ErrorEvent:
+			errs = status.Append(errs, e.Error)
+		case TransferEvent:
+			transfers = append(transfers, e.Transfers...)
+		}
+	}
+	resources := &declared.Resources{}
+	_, err = resources.UpdateDeclared(context.Background(), objs, "commit")
+	require.NoError(t, err)
+
+	applier.Apply(context.Background(), eventHandler, resources)
+
+	conflictLive := &unstructured.Unstructured{}
+	conflictLive.SetGroupVersionKind(kinds.ConfigMap())
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(conflictCM), conflictLive))
+	wantErrs := status.Append(
+		TransferErrorForResource(core.IDOf(clusterRole),
+			errors.New(`a RepoSync can only manage objects in its namespace "bookstore"`)),
+		status.ManagementConflictErrorWrap(conflictLive, manager))
+	testerrors.AssertEqual(t, wantErrs, errs)
+
+	// Only the released object is in flight, until the RepoSync adopts it.
+	assert.Equal(t, []TransferRecord{{
+		ID:      core.IDOf(releasedCM),
+		Version: "v1",
+		To:      "bookstore/repo-sync",
+	}}, transfers)
+	released := &unstructured.Unstructured{}
+	released.SetGroupVersionKind(kinds.ConfigMap())
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(releasedCM), released))
+	assert.Equal(t, toManager, core.GetAnnotation(released, metadata.ResourceManagerKey))
+	assert.NotContains(t, released.GetAnnotations(), metadata.OwningInventoryKey)
+
+	// The transferred objects are neither applied nor pruned.
+	require.Len(t, kptApplier.objsToApply, 1)
+	assert.Equal(t, core.IDOf(keptCM), core.IDOf(kptApplier.objsToApply[0]))
+	assert.Equal(t, object.ObjMetadataSet{keptRef}, fakeInvClient.Inv.GetObjectRefs())
+}
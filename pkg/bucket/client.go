@@ -0,0 +1,198 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bucket fetches objects from S3-compatible object storage buckets.
+package bucket
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// DefaultRegion is the region used to sign requests if none is specified.
+	// S3-compatible servers that don't have regions, like MinIO and the Google
+	// Cloud Storage XML API, accept it.
+	DefaultRegion = "us-east-1"
+
+	// maxErrorBodySize is the maximum number of bytes of an error response to
+	// include in the error message.
+	maxErrorBodySize = 1024
+)
+
+// Object describes an object in a bucket.
+type Object struct {
+	// Key is the object key.
+	Key string
+	// ETag is the entity tag of the object, which changes when the object
+	// content changes.
+	ETag string
+	// Size is the object size in bytes.
+	Size int64
+}
+
+// Client is a minimal client for the S3 API, supporting the ListObjectsV2 and
+// GetObject operations with path-style requests.
+type Client struct {
+	// Endpoint is the host, and optionally port, of the S3-compatible API.
+	Endpoint string
+	// Bucket is the name of the bucket.
+	Bucket string
+	// Region is the region used to sign requests. Defaults to DefaultRegion.
+	Region string
+	// Insecure specifies whether to use plain HTTP instead of HTTPS.
+	Insecure bool
+	// AccessKeyID and SecretAccessKey sign the requests. If AccessKeyID is
+	// empty, requests are sent anonymously.
+	AccessKeyID     string
+	SecretAccessKey string
+	// HTTPClient sends the requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// now returns the signing time. Defaults to time.Now.
+	now func() time.Time
+}
+
+// listBucketResult is the response of the ListObjectsV2 operation.
+type listBucketResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key  string `xml:"Key"`
+		ETag string `xml:"ETag"`
+		Size int64  `xml:"Size"`
+	} `xml:"Contents"`
+}
+
+// ListObjects lists every object in the bucket whose key starts with the
+// prefix, following continuation tokens until the listing is complete.
+func (c *Client) ListObjects(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+	continuationToken := ""
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		if prefix != "" {
+			query.Set("prefix", prefix)
+		}
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+		resp, err := c.do(ctx, "", query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects in bucket %q: %w", c.Bucket, err)
+		}
+		result := &listBucketResult{}
+		err = xml.NewDecoder(resp.Body).Decode(result)
+		closeBody(resp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the object listing of bucket %q: %w", c.Bucket, err)
+		}
+		for _, content := range result.Contents {
+			objects = append(objects, Object{
+				Key:  content.Key,
+				ETag: content.ETag,
+				Size: content.Size,
+			})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+// GetObject writes the content of the object to the writer.
+func (c *Client) GetObject(ctx context.Context, key string, w io.Writer) error {
+	resp, err := c.do(ctx, key, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get object %q from bucket %q: %w", key, c.Bucket, err)
+	}
+	defer closeBody(resp)
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("failed to read object %q from bucket %q: %w", key, c.Bucket, err)
+	}
+	return nil
+}
+
+// do sends a signed GET request for the object key, or for the bucket if the
+// key is empty, and returns the response if it succeeded.
+func (c *Client) do(ctx context.Context, key string, query url.Values) (*http.Response, error) {
+	scheme := "https"
+	if c.Insecure {
+		scheme = "http"
+	}
+	path := "/" + c.Bucket + "/" + key
+	u := &url.URL{
+		Scheme:   scheme,
+		Host:     c.Endpoint,
+		Path:     path,
+		RawPath:  escape(path, false),
+		RawQuery: query.Encode(),
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.AccessKeyID != "" {
+		region := c.Region
+		if region == "" {
+			region = DefaultRegion
+		}
+		now := time.Now
+		if c.now != nil {
+			now = c.now
+		}
+		req.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+		signV4(req, c.AccessKeyID, c.SecretAccessKey, region, "s3", now(), emptyPayloadHash)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer closeBody(resp)
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return nil, fmt.Errorf("unexpected status %q: %s", resp.Status, body)
+	}
+	return resp, nil
+}
+
+func closeBody(resp *http.Response) {
+	if err := resp.Body.Close(); err != nil {
+		klog.Warningf("failed to close response body: %v", err)
+	}
+}
+
+// URL returns the path-style URL of the prefix of the bucket, which identifies
+// the source in the RootSync and RepoSync status.
+func URL(endpoint, bucket, prefix string, insecure bool) string {
+	scheme := "https"
+	if insecure {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/%s/%s", scheme, endpoint, bucket, prefix)
+}
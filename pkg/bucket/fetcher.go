@@ -0,0 +1,137 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/util"
+	"k8s.io/klog/v2"
+)
+
+// Fetcher fetches the objects under a prefix of a bucket.
+type Fetcher struct {
+	// Client is the client of the bucket.
+	Client *Client
+	// Prefix is the prefix of the object keys to fetch. It is a directory:
+	// a trailing slash is added if missing.
+	Prefix string
+}
+
+// dirPrefix returns the prefix with a trailing slash, so that the objects of
+// sibling prefixes, like configs-other/ for configs, are not fetched.
+func (f *Fetcher) dirPrefix() string {
+	if f.Prefix == "" || strings.HasSuffix(f.Prefix, "/") {
+		return f.Prefix
+	}
+	return f.Prefix + "/"
+}
+
+// FetchObjects downloads the objects under the prefix into a directory under
+// root named after the listing hash, then points the rev symlink at that
+// directory. Objects are not downloaded if the listing hash hasn't changed.
+func (f *Fetcher) FetchObjects(ctx context.Context, root, rev string) error {
+	objects, err := f.Client.ListObjects(ctx, f.dirPrefix())
+	if err != nil {
+		return err
+	}
+	objects = fileObjects(objects)
+	commit := ListingHash(objects)
+
+	destDir := filepath.Join(root, commit)
+	linkPath := filepath.Join(root, rev)
+	oldDir, err := filepath.EvalSymlinks(linkPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to evaluate the symbolic path %q to the bucket objects: %w", linkPath, err)
+	}
+	if oldDir == destDir {
+		klog.Infof("no update required with the same listing hash %q", commit)
+		return nil
+	}
+
+	// Remove any partial download left over from a failed attempt.
+	if err := os.RemoveAll(destDir); err != nil {
+		return fmt.Errorf("failed to clean up the directory %q: %w", destDir, err)
+	}
+	if err := os.MkdirAll(destDir, os.FileMode(0755)); err != nil {
+		return fmt.Errorf("failed to create directory %q: %w", destDir, err)
+	}
+	for _, object := range objects {
+		if err := f.fetchObject(ctx, object, destDir); err != nil {
+			return err
+		}
+	}
+
+	klog.Infof("fetched %d objects with listing hash %q", len(objects), commit)
+	return util.UpdateSymlink(root, linkPath, destDir, oldDir)
+}
+
+func (f *Fetcher) fetchObject(ctx context.Context, object Object, dir string) error {
+	prefix := f.dirPrefix()
+	relPath, found := strings.CutPrefix(object.Key, prefix)
+	if !found || !filepath.IsLocal(relPath) {
+		return fmt.Errorf("invalid object key %q: must be inside the prefix %q", object.Key, prefix)
+	}
+	path := filepath.Join(dir, relPath)
+	if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0755)); err != nil {
+		return fmt.Errorf("failed to create directory %q: %w", filepath.Dir(path), err)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.FileMode(0644))
+	if err != nil {
+		return fmt.Errorf("failed to create file %q: %w", path, err)
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			klog.Warningf("failed to close file %q: %v", file.Name(), err)
+		}
+	}()
+	return f.Client.GetObject(ctx, object.Key, file)
+}
+
+// fileObjects returns the objects that hold files, dropping the zero-size
+// placeholder objects that some tools create for directories.
+func fileObjects(objects []Object) []Object {
+	var result []Object
+	for _, object := range objects {
+		if strings.HasSuffix(object.Key, "/") {
+			continue
+		}
+		result = append(result, object)
+	}
+	return result
+}
+
+// ListingHash returns the hex-encoded SHA-256 hash of the object keys, ETags,
+// and sizes. The hash changes when any object is added, removed, or modified,
+// so it is used as the commit of the bucket source.
+func ListingHash(objects []Object) string {
+	sorted := make([]Object, len(objects))
+	copy(sorted, objects)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Key < sorted[j].Key
+	})
+	hash := sha256.New()
+	for _, object := range sorted {
+		fmt.Fprintf(hash, "%s\x00%s\x00%d\n", object.Key, object.ETag, object.Size)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
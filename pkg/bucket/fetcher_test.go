@@ -0,0 +1,245 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bucket
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 serves the ListObjectsV2 and GetObject operations for a single
+// bucket, returning at most pageSize objects per listing page.
+type fakeS3 struct {
+	bucket    string
+	objects   map[string]string
+	pageSize  int
+	downloads int
+	authz     string
+}
+
+func (s *fakeS3) etag(content string) string {
+	sum := md5.Sum([]byte(content))
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.authz = r.Header.Get("Authorization")
+	bucketPath := "/" + s.bucket + "/"
+	if !strings.HasPrefix(r.URL.Path, bucketPath) {
+		http.Error(w, "NoSuchBucket", http.StatusNotFound)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, bucketPath)
+	if key != "" {
+		content, found := s.objects[key]
+		if !found {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		s.downloads++
+		_, _ = w.Write([]byte(content))
+		return
+	}
+
+	prefix := r.URL.Query().Get("prefix")
+	var keys []string
+	for k := range s.objects {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	start := 0
+	if token := r.URL.Query().Get("continuation-token"); token != "" {
+		_, _ = fmt.Sscanf(token, "%d", &start)
+	}
+	end := len(keys)
+	if s.pageSize > 0 && start+s.pageSize < end {
+		end = start + s.pageSize
+	}
+	type content struct {
+		Key  string
+		ETag string
+		Size int64
+	}
+	result := struct {
+		XMLName               xml.Name `xml:"ListBucketResult"`
+		IsTruncated           bool
+		NextContinuationToken string `xml:",omitempty"`
+		Contents              []content
+	}{}
+	for _, k := range keys[start:end] {
+		result.Contents = append(result.Contents, content{Key: k, ETag: s.etag(s.objects[k]), Size: int64(len(s.objects[k]))})
+	}
+	if end < len(keys) {
+		result.IsTruncated = true
+		result.NextContinuationToken = fmt.Sprintf("%d", end)
+	}
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+func newTestClient(server *httptest.Server, bucket string) *Client {
+	return &Client{
+		Endpoint:   strings.TrimPrefix(server.URL, "http://"),
+		Bucket:     bucket,
+		Insecure:   true,
+		HTTPClient: server.Client(),
+	}
+}
+
+func TestListObjects(t *testing.T) {
+	s3 := &fakeS3{
+		bucket: "configs",
+		objects: map[string]string{
+			"prod/a.yaml":    "a",
+			"prod/b.yaml":    "bb",
+			"prod/c/d.yaml":  "ddd",
+			"staging/e.yaml": "e",
+		},
+		pageSize: 2,
+	}
+	server := httptest.NewServer(s3)
+	defer server.Close()
+
+	objects, err := newTestClient(server, "configs").ListObjects(context.Background(), "prod/")
+	require.NoError(t, err)
+	var keys []string
+	for _, object := range objects {
+		keys = append(keys, object.Key)
+	}
+	assert.Equal(t, []string{"prod/a.yaml", "prod/b.yaml", "prod/c/d.yaml"}, keys)
+	assert.Equal(t, int64(3), objects[2].Size)
+	assert.Empty(t, s3.authz, "anonymous requests should not be signed")
+}
+
+func TestClientSignsRequests(t *testing.T) {
+	s3 := &fakeS3{bucket: "configs", objects: map[string]string{"a.yaml": "a"}}
+	server := httptest.NewServer(s3)
+	defer server.Close()
+
+	client := newTestClient(server, "configs")
+	client.AccessKeyID = "AKIDEXAMPLE"
+	client.SecretAccessKey = "secret"
+	client.Region = "eu-west-1"
+	_, err := client.ListObjects(context.Background(), "")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(s3.authz, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"), s3.authz)
+	assert.Contains(t, s3.authz, "/eu-west-1/s3/aws4_request")
+	assert.Contains(t, s3.authz, "SignedHeaders=host;x-amz-content-sha256;x-amz-date")
+}
+
+func TestGetObjectError(t *testing.T) {
+	server := httptest.NewServer(&fakeS3{bucket: "configs"})
+	defer server.Close()
+
+	var sb strings.Builder
+	err := newTestClient(server, "configs").GetObject(context.Background(), "missing.yaml", &sb)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+	assert.Contains(t, err.Error(), "NoSuchKey")
+}
+
+func TestFetchObjects(t *testing.T) {
+	s3 := &fakeS3{
+		bucket: "configs",
+		objects: map[string]string{
+			"prod/":             "",
+			"prod/ns.yaml":      "kind: Namespace\n",
+			"prod/apps/cm.yaml": "kind: ConfigMap\n",
+			"staging/ns.yaml":   "kind: Namespace\n",
+		},
+	}
+	server := httptest.NewServer(s3)
+	defer server.Close()
+
+	root := t.TempDir()
+	fetcher := &Fetcher{Client: newTestClient(server, "configs"), Prefix: "prod/"}
+	require.NoError(t, fetcher.FetchObjects(context.Background(), root, "rev"))
+
+	dir, err := filepath.EvalSymlinks(filepath.Join(root, "rev"))
+	require.NoError(t, err)
+	commitV1 := filepath.Base(dir)
+	assert.Len(t, commitV1, 64)
+	content, err := os.ReadFile(filepath.Join(dir, "apps", "cm.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "kind: ConfigMap\n", string(content))
+	_, err = os.Stat(filepath.Join(dir, "staging"))
+	assert.True(t, os.IsNotExist(err), "objects outside the prefix should not be fetched")
+	assert.Equal(t, 2, s3.downloads)
+
+	// An unchanged listing should not download the objects again.
+	require.NoError(t, fetcher.FetchObjects(context.Background(), root, "rev"))
+	assert.Equal(t, 2, s3.downloads)
+
+	// A modified object should produce a new commit.
+	s3.objects["prod/ns.yaml"] = "kind: Namespace\nmetadata:\n  name: prod\n"
+	require.NoError(t, fetcher.FetchObjects(context.Background(), root, "rev"))
+	dir, err = filepath.EvalSymlinks(filepath.Join(root, "rev"))
+	require.NoError(t, err)
+	assert.NotEqual(t, commitV1, filepath.Base(dir))
+	_, err = os.Stat(filepath.Join(root, commitV1))
+	assert.True(t, os.IsNotExist(err), "the previous directory should be removed")
+}
+
+func TestFetchObjectsSiblingPrefix(t *testing.T) {
+	s3 := &fakeS3{
+		bucket: "configs",
+		objects: map[string]string{
+			"configs/ns.yaml":       "kind: Namespace\n",
+			"configs-other/ns.yaml": "kind: Namespace\nmetadata:\n  name: other\n",
+			"configs-other.yaml":    "kind: ConfigMap\n",
+		},
+	}
+	server := httptest.NewServer(s3)
+	defer server.Close()
+
+	root := t.TempDir()
+	fetcher := &Fetcher{Client: newTestClient(server, "configs"), Prefix: "configs"}
+	require.NoError(t, fetcher.FetchObjects(context.Background(), root, "rev"))
+
+	dir, err := filepath.EvalSymlinks(filepath.Join(root, "rev"))
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(dir, "ns.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "kind: Namespace\n", string(content))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "objects of sibling prefixes should not be fetched")
+	assert.Equal(t, 1, s3.downloads)
+}
+
+func TestListingHash(t *testing.T) {
+	a := Object{Key: "a.yaml", ETag: `"1"`, Size: 1}
+	b := Object{Key: "b.yaml", ETag: `"2"`, Size: 2}
+
+	assert.Equal(t, ListingHash([]Object{a, b}), ListingHash([]Object{b, a}), "the order should not matter")
+	assert.NotEqual(t, ListingHash([]Object{a, b}), ListingHash([]Object{a}))
+	changed := b
+	changed.ETag = `"3"`
+	assert.NotEqual(t, ListingHash([]Object{a, b}), ListingHash([]Object{a, changed}))
+}
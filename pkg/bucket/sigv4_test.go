@@ -0,0 +1,70 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bucket
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignV4(t *testing.T) {
+	// The get-vanilla case of the AWS Signature Version 4 test suite.
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	require.NoError(t, err)
+	now := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+
+	signV4(req, "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "us-east-1", "service", now, emptyPayloadHash)
+
+	assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+		"SignedHeaders=host;x-amz-date, "+
+		"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"))
+}
+
+func TestEscape(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       string
+		encodeSlash bool
+		want        string
+	}{
+		{
+			name:  "unreserved characters",
+			input: "AZaz09-_.~",
+			want:  "AZaz09-_.~",
+		},
+		{
+			name:  "path keeps slashes",
+			input: "/bucket/dir/a b+c.yaml",
+			want:  "/bucket/dir/a%20b%2Bc.yaml",
+		},
+		{
+			name:        "query value encodes slashes",
+			input:       "dir/sub=",
+			encodeSlash: true,
+			want:        "dir%2Fsub%3D",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, escape(tc.input, tc.encodeSlash))
+		})
+	}
+}
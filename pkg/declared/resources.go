@@ -139,6 +139,13 @@ func (r *Resources) DeclaredObjects() []client.Object {
 	return objects
 }
 
+// Commit returns the source commit in which the resources were declared.
+func (r *Resources) Commit() string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.commit
+}
+
 // DeclaredGVKs returns the set of all GroupVersionKind found in the source,
 // along with the source commit.
 func (r *Resources) DeclaredGVKs() (map[schema.GroupVersionKind]struct{}, string) {
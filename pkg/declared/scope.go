@@ -15,6 +15,9 @@
 package declared
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/GoogleContainerTools/config-sync/pkg/api/configmanagement"
 	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
 	"github.com/GoogleContainerTools/config-sync/pkg/core"
@@ -92,3 +95,26 @@ func ReconcilerNameFromScope(syncScope Scope, syncName string) string {
 	}
 	return core.NsReconcilerName(syncScope.String(), syncName)
 }
+
+// SyncRef returns the reference to a RootSync, formatted as NAME, or to a
+// RepoSync, formatted as NAMESPACE/NAME.
+func SyncRef(syncScope Scope, syncName string) string {
+	if syncScope == RootScope {
+		return syncName
+	}
+	return syncScope.String() + "/" + syncName
+}
+
+// ParseSyncRef parses the reference to a RootSync, formatted as NAME, or to a
+// RepoSync, formatted as NAMESPACE/NAME, and returns its scope and name.
+func ParseSyncRef(ref string) (Scope, string, error) {
+	parts := strings.Split(ref, "/")
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		return RootScope, parts[0], nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return Scope(parts[0]), parts[1], nil
+	default:
+		return "", "", fmt.Errorf("invalid sync %q: must be NAME for a RootSync or NAMESPACE/NAME for a RepoSync", ref)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package declared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSyncRef(t *testing.T) {
+	testCases := []struct {
+		ref       string
+		wantScope Scope
+		wantName  string
+		wantErr   bool
+	}{
+		{ref: "root-sync", wantScope: RootScope, wantName: "root-sync"},
+		{ref: "bookstore/repo-sync", wantScope: "bookstore", wantName: "repo-sync"},
+		{ref: "", wantErr: true},
+		{ref: "bookstore/", wantErr: true},
+		{ref: "a/b/c", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.ref, func(t *testing.T) {
+			scope, name, err := ParseSyncRef(tc.ref)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantScope, scope)
+			assert.Equal(t, tc.wantName, name)
+			assert.Equal(t, tc.ref, SyncRef(scope, name))
+		})
+	}
+}
@@ -74,6 +74,20 @@ type Diff struct {
 // Operation returns the type of the difference between the repository and the API Server.
 func (d Diff) Operation(scope declared.Scope, syncName string) Operation {
 	switch {
+	case d.Declared != nil && metadata.HasTransferTo(d.Declared):
+		// Transfer Branch.
+		//
+		// The resource is being handed over to another reconciler. The applier
+		// releases it, so the remediator must neither revert nor delete it.
+		return NoOp
+	case d.Declared != nil && metadata.IsSharedFieldOwnership(d.Declared):
+		// Shared Branch.
+		//
+		// The resource is managed by another reconciler, and this reconciler
+		// only co-manages some of its fields. The applier applies them, and
+		// the admission webhook protects them, so the remediator must neither
+		// revert nor delete the resource.
+		return NoOp
 	case d.Declared != nil && d.Actual == nil:
 		// Create Branch.
 		//
@@ -64,7 +64,29 @@ func TestDiffType(t *testing.T) {
 			declared: k8sobjects.RoleObject(),
 			want:     Error,
 		},
+		{
+			name:     "declared + no actual, transfer-to: no op",
+			scope:    declared.RootScope,
+			declared: k8sobjects.RoleObject(syncertest.ManagementEnabled, metadata.WithTransferTo("platform-sync")),
+			want:     NoOp,
+		},
 		// Declared + actual paths.
+		{
+			name:     "declared + actual, transfer-to, root scope / transferred object: no op",
+			scope:    declared.RootScope,
+			declared: k8sobjects.RoleObject(syncertest.ManagementEnabled, metadata.WithTransferTo("platform-sync")),
+			actual: k8sobjects.RoleObject(syncertest.ManagementEnabled,
+				difftest.ManagedBy(declared.RootScope, "platform-sync")),
+			want: NoOp,
+		},
+		{
+			name:     "declared + actual, shared field ownership, root scope / object managed by another sync: no op",
+			scope:    declared.RootScope,
+			declared: k8sobjects.RoleObject(syncertest.ManagementEnabled, metadata.WithFieldOwnership(metadata.FieldOwnershipShared)),
+			actual: k8sobjects.RoleObject(syncertest.ManagementEnabled,
+				difftest.ManagedBy(declared.RootScope, "platform-sync")),
+			want: NoOp,
+		},
 		{
 			name:     "declared + actual, management enabled, no manager annotation, root scope, can manage: update",
 			scope:    declared.RootScope,
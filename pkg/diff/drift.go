@@ -0,0 +1,361 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+)
+
+// driftedMetadataFields are the metadata fields compared when computing drift,
+// with the functions that return true for the keys set by Config Sync, which
+// are not drift. The other metadata fields are set by the API server.
+var driftedMetadataFields = map[string]func(string) bool{
+	"labels":      metadata.IsConfigSyncLabelKey,
+	"annotations": metadata.IsConfigSyncAnnotationKey,
+}
+
+// FieldPath is the path of a field in an object. Every element is either a
+// field name or a list index.
+type FieldPath []interface{}
+
+// String returns the path in a format like `spec.containers[0].image`. Field
+// names that contain dots are quoted, like `metadata.labels["app.kubernetes.io/name"]`.
+func (p FieldPath) String() string {
+	var sb strings.Builder
+	for _, element := range p {
+		switch e := element.(type) {
+		case int:
+			fmt.Fprintf(&sb, "[%d]", e)
+		case string:
+			switch {
+			case strings.Contains(e, "."):
+				fmt.Fprintf(&sb, "[%q]", e)
+			case sb.Len() > 0:
+				sb.WriteString("." + e)
+			default:
+				sb.WriteString(e)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// DriftedFields returns the paths of the fields declared in the declared object
+// whose values differ in the actual object. Fields that are only set in the
+// actual object, like defaults and status, are ignored.
+func DriftedFields(declared, actual *unstructured.Unstructured) []FieldPath {
+	var paths []FieldPath
+	for key, value := range declared.Object {
+		switch key {
+		case "apiVersion", "kind", "status":
+			continue
+		case "metadata":
+			declaredMeta, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			actualMeta, _ := actual.Object["metadata"].(map[string]interface{})
+			for field, isConfigSyncKey := range driftedMetadataFields {
+				declaredValues, _ := declaredMeta[field].(map[string]interface{})
+				actualValues, _ := actualMeta[field].(map[string]interface{})
+				for key, value := range declaredValues {
+					if !isConfigSyncKey(key) {
+						paths = driftedFields(value, actualValues[key], FieldPath{"metadata", field, key}, paths)
+					}
+				}
+			}
+		default:
+			paths = driftedFields(value, actual.Object[key], FieldPath{key}, paths)
+		}
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return paths[i].String() < paths[j].String()
+	})
+	return paths
+}
+
+// driftedFields appends the paths of the fields in declared whose values differ
+// in actual. Maps are compared field by field, and lists are compared item by
+// item if they have the same length. Empty values in declared are treated as
+// unset, since the API server drops or defaults empty optional fields.
+func driftedFields(declared, actual interface{}, path FieldPath, paths []FieldPath) []FieldPath {
+	switch declaredValue := declared.(type) {
+	case map[string]interface{}:
+		actualValue, ok := actual.(map[string]interface{})
+		if !ok && actual != nil {
+			return append(paths, path)
+		}
+		for key, value := range declaredValue {
+			paths = driftedFields(value, actualValue[key], path.child(key), paths)
+		}
+		return paths
+	case []interface{}:
+		actualValue, ok := actual.([]interface{})
+		if (!ok && actual != nil) || len(actualValue) != len(declaredValue) {
+			return append(paths, path)
+		}
+		for i, item := range declaredValue {
+			paths = driftedFields(item, actualValue[i], path.child(i), paths)
+		}
+		return paths
+	default:
+		if isEmpty(declared) {
+			return paths
+		}
+		if !scalarEqual(declared, actual) {
+			return append(paths, path)
+		}
+		return paths
+	}
+}
+
+// child returns a copy of the path with the element appended.
+func (p FieldPath) child(element interface{}) FieldPath {
+	result := make(FieldPath, len(p), len(p)+1)
+	copy(result, p)
+	return append(result, element)
+}
+
+// isEmpty returns true if the scalar value is the empty value of its type.
+func isEmpty(value interface{}) bool {
+	if number, isNumber := toFloat(value); isNumber {
+		return number == 0
+	}
+	return value == nil || value == "" || value == false
+}
+
+// scalarEqual returns true if the scalar values are equal. Numbers are
+// compared by value, since the same number may be decoded as an int64 or a
+// float64.
+func scalarEqual(a, b interface{}) bool {
+	aNumber, aIsNumber := toFloat(a)
+	bNumber, bIsNumber := toFloat(b)
+	if aIsNumber && bIsNumber {
+		return aNumber == bNumber
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch number := value.(type) {
+	case int64:
+		return float64(number), true
+	case int:
+		return float64(number), true
+	case float64:
+		return number, true
+	default:
+		return 0, false
+	}
+}
+
+// FieldValue returns the value of the field at the path in obj, and whether
+// it was found.
+func FieldValue(obj map[string]interface{}, path FieldPath) (interface{}, bool) {
+	var value interface{} = obj
+	for _, element := range path {
+		switch e := element.(type) {
+		case string:
+			m, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			value, ok = m[e]
+			if !ok {
+				return nil, false
+			}
+		case int:
+			list, ok := value.([]interface{})
+			if !ok || e >= len(list) {
+				return nil, false
+			}
+			value = list[e]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// DriftActor returns the field manager that most recently modified any of the
+// drifted fields of the actual object, according to its managed fields. If no
+// manager owns the drifted fields, like when they were removed, it returns the
+// manager of the most recent update. Config Sync itself is never the actor.
+func DriftActor(actual *unstructured.Unstructured, fields []FieldPath) string {
+	var owner, updater *metav1.ManagedFieldsEntry
+	managedFields := actual.GetManagedFields()
+	for i := range managedFields {
+		entry := &managedFields[i]
+		if entry.Manager == configsync.FieldManager {
+			continue
+		}
+		if isMoreRecent(entry, updater) {
+			updater = entry
+		}
+		if isMoreRecent(entry, owner) && ownsAnyField(entry, actual.Object, fields) {
+			owner = entry
+		}
+	}
+	switch {
+	case owner != nil:
+		return owner.Manager
+	case updater != nil:
+		return updater.Manager
+	default:
+		return ""
+	}
+}
+
+// FieldsOwnedByOthers returns the fields, among the given fields of the actual
+// object, that are owned by a field manager other than Config Sync, according
+// to its managed fields. Unlike the fields whose declared value changed in the
+// source of truth, these fields were modified on the cluster.
+func FieldsOwnedByOthers(actual *unstructured.Unstructured, fields []FieldPath) []FieldPath {
+	var sets []map[string]interface{}
+	for _, entry := range actual.GetManagedFields() {
+		if entry.Manager == configsync.FieldManager || entry.FieldsV1 == nil {
+			continue
+		}
+		var set map[string]interface{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &set); err != nil {
+			klog.Warningf("failed to decode the managed fields of %q: %v", entry.Manager, err)
+			continue
+		}
+		sets = append(sets, set)
+	}
+	var owned []FieldPath
+	for _, field := range fields {
+		for _, set := range sets {
+			if ownsField(set, actual.Object, field) {
+				owned = append(owned, field)
+				break
+			}
+		}
+	}
+	return owned
+}
+
+func isMoreRecent(entry, other *metav1.ManagedFieldsEntry) bool {
+	if other == nil {
+		return true
+	}
+	if entry.Time == nil {
+		return false
+	}
+	return other.Time == nil || entry.Time.After(other.Time.Time)
+}
+
+// ownsAnyField returns true if the managed fields entry owns any of the fields.
+func ownsAnyField(entry *metav1.ManagedFieldsEntry, obj map[string]interface{}, fields []FieldPath) bool {
+	if entry.FieldsV1 == nil {
+		return false
+	}
+	var set map[string]interface{}
+	if err := json.Unmarshal(entry.FieldsV1.Raw, &set); err != nil {
+		klog.Warningf("failed to decode the managed fields of %q: %v", entry.Manager, err)
+		return false
+	}
+	for _, field := range fields {
+		if ownsField(set, obj, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// ownsField returns true if the field set, in the FieldsV1 format, contains
+// the path. List items are matched against the keys of the set using the
+// values in obj.
+func ownsField(set map[string]interface{}, obj interface{}, path FieldPath) bool {
+	if len(path) == 0 {
+		return true
+	}
+	switch element := path[0].(type) {
+	case string:
+		child, found := set["f:"+element].(map[string]interface{})
+		if !found {
+			return false
+		}
+		objMap, _ := obj.(map[string]interface{})
+		return ownsField(child, objMap[element], path[1:])
+	case int:
+		list, _ := obj.([]interface{})
+		if element >= len(list) {
+			return false
+		}
+		item := list[element]
+		for key, value := range set {
+			child, ok := value.(map[string]interface{})
+			if ok && listKeyMatches(key, element, item) {
+				return ownsField(child, item, path[1:])
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// listKeyMatches returns true if the FieldsV1 key of a list item, like
+// `k:{"name":"nginx"}`, `v:"value"`, or `i:0`, matches the item at index.
+func listKeyMatches(key string, index int, item interface{}) bool {
+	switch {
+	case strings.HasPrefix(key, "k:"):
+		var fields map[string]interface{}
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(key, "k:")), &fields); err != nil {
+			return false
+		}
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for name, value := range fields {
+			if !scalarEqual(jsonNumberToInt(value), itemMap[name]) {
+				return false
+			}
+		}
+		return true
+	case strings.HasPrefix(key, "v:"):
+		var value interface{}
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(key, "v:")), &value); err != nil {
+			return false
+		}
+		return scalarEqual(jsonNumberToInt(value), item)
+	case strings.HasPrefix(key, "i:"):
+		return strings.TrimPrefix(key, "i:") == strconv.Itoa(index)
+	default:
+		return false
+	}
+}
+
+// jsonNumberToInt converts whole float64 values decoded from JSON to int64,
+// to match the values in unstructured objects.
+func jsonNumberToInt(value interface{}) interface{} {
+	if number, ok := value.(float64); ok && number == float64(int64(number)) {
+		return int64(number)
+	}
+	return value
+}
@@ -0,0 +1,285 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func deployment(replicas int64, image string, labels map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      "web",
+			"namespace": "bookstore",
+			"labels":    labels,
+		},
+		"spec": map[string]interface{}{
+			"replicas": replicas,
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name":  "nginx",
+							"image": image,
+						},
+					},
+				},
+			},
+		},
+	}}
+}
+
+func TestFieldPathString(t *testing.T) {
+	testCases := []struct {
+		name string
+		path FieldPath
+		want string
+	}{
+		{
+			name: "fields",
+			path: FieldPath{"spec", "replicas"},
+			want: "spec.replicas",
+		},
+		{
+			name: "list index",
+			path: FieldPath{"spec", "containers", 0, "image"},
+			want: "spec.containers[0].image",
+		},
+		{
+			name: "field with dots",
+			path: FieldPath{"metadata", "labels", "app.kubernetes.io/name"},
+			want: `metadata.labels["app.kubernetes.io/name"]`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.path.String())
+		})
+	}
+}
+
+func TestDriftedFields(t *testing.T) {
+	testCases := []struct {
+		name     string
+		declared *unstructured.Unstructured
+		actual   *unstructured.Unstructured
+		want     []string
+	}{
+		{
+			name:     "no drift",
+			declared: deployment(3, "nginx:1.25", map[string]interface{}{"app": "web"}),
+			actual:   deployment(3, "nginx:1.25", map[string]interface{}{"app": "web"}),
+		},
+		{
+			name:     "number types are ignored",
+			declared: deployment(3, "nginx:1.25", nil),
+			actual: func() *unstructured.Unstructured {
+				u := deployment(3, "nginx:1.25", nil)
+				u.Object["spec"].(map[string]interface{})["replicas"] = float64(3)
+				return u
+			}(),
+		},
+		{
+			name:     "undeclared fields and Config Sync metadata are ignored",
+			declared: deployment(3, "nginx:1.25", map[string]interface{}{metadata.ManagedByKey: metadata.ManagedByValue}),
+			actual: func() *unstructured.Unstructured {
+				u := deployment(3, "nginx:1.25", map[string]interface{}{"team": "books"})
+				u.Object["status"] = map[string]interface{}{"replicas": int64(1)}
+				u.SetResourceVersion("42")
+				return u
+			}(),
+		},
+		{
+			name: "empty declared fields",
+			declared: func() *unstructured.Unstructured {
+				u := deployment(3, "nginx:1.25", nil)
+				_ = unstructured.SetNestedField(u.Object, "", "spec", "template", "spec", "serviceAccountName")
+				_ = unstructured.SetNestedMap(u.Object, map[string]interface{}{}, "spec", "selector")
+				return u
+			}(),
+			actual: func() *unstructured.Unstructured {
+				u := deployment(3, "nginx:1.25", nil)
+				_ = unstructured.SetNestedField(u.Object, "default", "spec", "template", "spec", "serviceAccountName")
+				return u
+			}(),
+		},
+		{
+			name:     "changed fields",
+			declared: deployment(3, "nginx:1.25", map[string]interface{}{"app.kubernetes.io/name": "web"}),
+			actual:   deployment(5, "nginx:1.26", map[string]interface{}{"app.kubernetes.io/name": "api"}),
+			want: []string{
+				`metadata.labels["app.kubernetes.io/name"]`,
+				"spec.replicas",
+				"spec.template.spec.containers[0].image",
+			},
+		},
+		{
+			name:     "removed list item",
+			declared: deployment(3, "nginx:1.25", nil),
+			actual: func() *unstructured.Unstructured {
+				u := deployment(3, "nginx:1.25", nil)
+				_ = unstructured.SetNestedSlice(u.Object, []interface{}{}, "spec", "template", "spec", "containers")
+				return u
+			}(),
+			want: []string{"spec.template.spec.containers"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, path := range DriftedFields(tc.declared, tc.actual) {
+				got = append(got, path.String())
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFieldValue(t *testing.T) {
+	obj := deployment(3, "nginx:1.25", map[string]interface{}{"app.kubernetes.io/name": "web"}).Object
+	testCases := []struct {
+		name      string
+		path      FieldPath
+		want      interface{}
+		wantFound bool
+	}{
+		{
+			name:      "field",
+			path:      FieldPath{"spec", "replicas"},
+			want:      int64(3),
+			wantFound: true,
+		},
+		{
+			name:      "list item",
+			path:      FieldPath{"spec", "template", "spec", "containers", 0, "image"},
+			want:      "nginx:1.25",
+			wantFound: true,
+		},
+		{
+			name:      "field with dots",
+			path:      FieldPath{"metadata", "labels", "app.kubernetes.io/name"},
+			want:      "web",
+			wantFound: true,
+		},
+		{
+			name: "missing field",
+			path: FieldPath{"spec", "paused"},
+		},
+		{
+			name: "missing list item",
+			path: FieldPath{"spec", "template", "spec", "containers", 1, "image"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, found := FieldValue(obj, tc.path)
+			assert.Equal(t, tc.wantFound, found)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func managedFieldsEntry(manager string, minutesAgo int, fields string) metav1.ManagedFieldsEntry {
+	return metav1.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  metav1.ManagedFieldsOperationUpdate,
+		Time:       &metav1.Time{Time: time.Now().Add(-time.Duration(minutesAgo) * time.Minute)},
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: []byte(fields)},
+	}
+}
+
+func TestDriftActor(t *testing.T) {
+	imagePath := FieldPath{"spec", "template", "spec", "containers", 0, "image"}
+	testCases := []struct {
+		name          string
+		managedFields []metav1.ManagedFieldsEntry
+		fields        []FieldPath
+		want          string
+	}{
+		{
+			name: "no managed fields",
+		},
+		{
+			name: "owner of the drifted field",
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedFieldsEntry("kubectl-edit", 5, `{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"nginx\"}":{"f:image":{}}}}}}}`),
+				managedFieldsEntry("kube-controller-manager", 1, `{"f:status":{"f:replicas":{}}}`),
+			},
+			fields: []FieldPath{imagePath},
+			want:   "kubectl-edit",
+		},
+		{
+			name: "most recent owner",
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedFieldsEntry("kubectl-edit", 5, `{"f:spec":{"f:replicas":{}}}`),
+				managedFieldsEntry("hpa", 2, `{"f:spec":{"f:replicas":{}}}`),
+			},
+			fields: []FieldPath{{"spec", "replicas"}},
+			want:   "hpa",
+		},
+		{
+			name: "most recent updater without an owner",
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedFieldsEntry("kubectl-edit", 5, `{"f:metadata":{"f:labels":{}}}`),
+				managedFieldsEntry("kubectl-patch", 2, `{"f:metadata":{"f:annotations":{}}}`),
+			},
+			fields: []FieldPath{{"spec", "replicas"}},
+			want:   "kubectl-patch",
+		},
+		{
+			name: "config sync is never the actor",
+			managedFields: []metav1.ManagedFieldsEntry{
+				managedFieldsEntry("kubectl-edit", 5, `{"f:metadata":{"f:labels":{}}}`),
+				managedFieldsEntry(configsync.FieldManager, 1, `{"f:spec":{"f:replicas":{}}}`),
+			},
+			fields: []FieldPath{{"spec", "replicas"}},
+			want:   "kubectl-edit",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := deployment(3, "nginx:1.26", nil)
+			actual.SetManagedFields(tc.managedFields)
+			assert.Equal(t, tc.want, DriftActor(actual, tc.fields))
+		})
+	}
+}
+
+func TestFieldsOwnedByOthers(t *testing.T) {
+	imagePath := FieldPath{"spec", "template", "spec", "containers", 0, "image"}
+	replicasPath := FieldPath{"spec", "replicas"}
+	actual := deployment(3, "nginx:1.26", nil)
+	actual.SetManagedFields([]metav1.ManagedFieldsEntry{
+		managedFieldsEntry(configsync.FieldManager, 1, `{"f:spec":{"f:replicas":{}}}`),
+		managedFieldsEntry("kubectl-edit", 5, `{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"nginx\"}":{"f:image":{}}}}}}}`),
+	})
+
+	assert.Equal(t, []FieldPath{imagePath}, FieldsOwnedByOthers(actual, []FieldPath{replicasPath, imagePath}))
+	assert.Empty(t, FieldsOwnedByOthers(actual, []FieldPath{replicasPath}))
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/declared"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
+)
+
+// DeclaredFieldSet returns the fields declared by the manager of the object,
+// from its declared-fields annotation. Returns nil if the annotation is not
+// set, which happens when the admission webhook is disabled.
+func DeclaredFieldSet(obj client.Object) (*fieldpath.Set, error) {
+	value, found := obj.GetAnnotations()[metadata.DeclaredFieldsKey]
+	if !found {
+		return nil, nil
+	}
+	return decodeFieldSet(value)
+}
+
+// SharedFieldSets returns the fields declared by each reconciler co-managing
+// the object, by reconciler name.
+func SharedFieldSets(obj client.Object) (map[string]*fieldpath.Set, error) {
+	sets := make(map[string]*fieldpath.Set)
+	for reconciler, value := range metadata.GetSharedFields(obj) {
+		set, err := decodeFieldSet(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %w", metadata.SharedFieldsAnnotationKey(reconciler), err)
+		}
+		sets[reconciler] = set
+	}
+	return sets, nil
+}
+
+// SharedFields returns the fields a reconciler co-manages, from the
+// declared-fields annotation of an object declared with shared field
+// ownership. The Config Sync labels and annotations are excluded, because
+// they are owned by the manager of the object.
+func SharedFields(obj client.Object) (*fieldpath.Set, error) {
+	set, err := DeclaredFieldSet(obj)
+	if err != nil {
+		return nil, err
+	}
+	if set == nil {
+		return nil, fmt.Errorf("%s annotation is missing from %s", metadata.DeclaredFieldsKey, core.GKNN(obj))
+	}
+	shared := fieldpath.NewSet()
+	set.Iterate(func(path fieldpath.Path) {
+		if !isConfigSyncMetadataPath(path) {
+			shared.Insert(path)
+		}
+	})
+	return shared, nil
+}
+
+// ValidateSharedFields returns nil if the given reconciler is allowed to
+// co-manage the specified fields of the object: the object must be managed
+// by another reconciler, and the fields must not overlap the fields declared
+// by its manager, or by the other reconcilers co-managing it.
+func ValidateSharedFields(reconciler string, obj client.Object, fields *fieldpath.Set) error {
+	id := core.IDOf(obj)
+	manager := core.GetAnnotation(obj, metadata.ResourceManagerKey)
+	if !metadata.IsManagementEnabled(obj) || manager == "" {
+		return fmt.Errorf("config sync %q can not co-manage object %q, which is not managed by config sync",
+			reconciler, id)
+	}
+	syncScope, syncName := declared.ManagerScopeAndName(manager)
+	managerReconciler := declared.ReconcilerNameFromScope(syncScope, syncName)
+	if managerReconciler == reconciler {
+		return fmt.Errorf("config sync %q can not co-manage object %q, which it manages",
+			reconciler, id)
+	}
+
+	// The field sets include the parents of the declared fields, like spec,
+	// which may be shared. Only the leaves must not overlap.
+	leaves := fields.Leaves()
+	declaredSet, err := DeclaredFieldSet(obj)
+	if err != nil {
+		return fmt.Errorf("invalid %s annotation of object %q: %w", metadata.DeclaredFieldsKey, id, err)
+	}
+	if declaredSet != nil {
+		if overlap := leaves.Intersection(declaredSet); !overlap.Empty() {
+			return fmt.Errorf("config sync %q can not co-manage fields of object %q declared by config sync %q: %s",
+				reconciler, id, managerReconciler, overlap.String())
+		}
+	}
+
+	sharedSets, err := SharedFieldSets(obj)
+	if err != nil {
+		return fmt.Errorf("object %q: %w", id, err)
+	}
+	// Sort the reconcilers, for a deterministic error.
+	var others []string
+	for other := range sharedSets {
+		if other != reconciler {
+			others = append(others, other)
+		}
+	}
+	sort.Strings(others)
+	for _, other := range others {
+		if overlap := leaves.Intersection(sharedSets[other]); !overlap.Empty() {
+			return fmt.Errorf("config sync %q can not co-manage fields of object %q declared by config sync %q: %s",
+				reconciler, id, other, overlap.String())
+		}
+	}
+	return nil
+}
+
+func decodeFieldSet(value string) (*fieldpath.Set, error) {
+	set := &fieldpath.Set{}
+	if err := set.FromJSON(strings.NewReader(value)); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// isConfigSyncMetadataPath returns whether the path is a Config Sync label or
+// annotation.
+func isConfigSyncMetadataPath(path fieldpath.Path) bool {
+	if len(path) != 3 || !isField(path[0], "metadata") || path[2].FieldName == nil {
+		return false
+	}
+	key := *path[2].FieldName
+	switch {
+	case isField(path[1], "annotations"):
+		return metadata.IsConfigSyncAnnotationKey(key) || metadata.IsSharedFieldsAnnotationKey(key)
+	case isField(path[1], "labels"):
+		return metadata.IsConfigSyncLabelKey(key)
+	default:
+		return false
+	}
+}
+
+func isField(element fieldpath.PathElement, name string) bool {
+	return element.FieldName != nil && *element.FieldName == name
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
+	"github.com/GoogleContainerTools/config-sync/pkg/declared"
+	"github.com/GoogleContainerTools/config-sync/pkg/diff/difftest"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/syncer/syncertest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
+)
+
+func TestSharedFields(t *testing.T) {
+	obj := k8sobjects.DeploymentObject(
+		core.Annotation(metadata.DeclaredFieldsKey,
+			`{"f:metadata":{"f:annotations":{"f:configsync.gke.io/field-ownership":{},"f:team":{}},"f:labels":{"f:app.kubernetes.io/managed-by":{}}},"f:spec":{"f:paused":{}}}`))
+
+	got, err := SharedFields(obj)
+	require.NoError(t, err)
+	want := fieldpath.NewSet(
+		fieldpath.MakePathOrDie("metadata", "annotations", "team"),
+		fieldpath.MakePathOrDie("spec", "paused"),
+	)
+	assert.True(t, want.Equals(got), "want %s, got %s", want, got)
+
+	_, err = SharedFields(k8sobjects.DeploymentObject())
+	assert.Error(t, err)
+}
+
+func TestValidateSharedFields(t *testing.T) {
+	reconciler := declared.ReconcilerNameFromScope("bookstore", "repo-sync")
+	// The parent of the field is shared with the manager.
+	paused := fieldpath.NewSet(
+		fieldpath.MakePathOrDie("spec"),
+		fieldpath.MakePathOrDie("spec", "paused"),
+	)
+	testCases := []struct {
+		name    string
+		opts    []core.MetaMutator
+		wantErr bool
+	}{
+		{
+			name: "fields not declared by the manager",
+			opts: []core.MetaMutator{
+				syncertest.ManagementEnabled,
+				difftest.ManagedBy(declared.RootScope, "root-sync"),
+				core.Annotation(metadata.DeclaredFieldsKey, `{"f:spec":{".":{},"f:replicas":{}}}`),
+			},
+		},
+		{
+			name: "fields declared by the manager",
+			opts: []core.MetaMutator{
+				syncertest.ManagementEnabled,
+				difftest.ManagedBy(declared.RootScope, "root-sync"),
+				core.Annotation(metadata.DeclaredFieldsKey, `{"f:spec":{"f:paused":{}}}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "fields co-managed by another reconciler",
+			opts: []core.MetaMutator{
+				syncertest.ManagementEnabled,
+				difftest.ManagedBy(declared.RootScope, "root-sync"),
+				core.Annotation(metadata.SharedFieldsAnnotationKey("ns-reconciler-other"), `{"f:spec":{"f:paused":{}}}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "own shared fields",
+			opts: []core.MetaMutator{
+				syncertest.ManagementEnabled,
+				difftest.ManagedBy(declared.RootScope, "root-sync"),
+				core.Annotation(metadata.SharedFieldsAnnotationKey(reconciler), `{"f:spec":{"f:paused":{}}}`),
+			},
+		},
+		{
+			name: "object managed by the reconciler",
+			opts: []core.MetaMutator{
+				syncertest.ManagementEnabled,
+				difftest.ManagedBy("bookstore", "repo-sync"),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "unmanaged object",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := k8sobjects.DeploymentObject(tc.opts...)
+			err := ValidateSharedFields(reconciler, obj, paused)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
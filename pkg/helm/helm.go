@@ -31,6 +31,7 @@ import (
 	semverrange "github.com/Masterminds/semver/v3"
 	"golang.org/x/mod/semver"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/kustomize/api/types"
 	"sigs.k8s.io/kustomize/kyaml/filesys"
 	"sigs.k8s.io/kustomize/kyaml/kio"
 	"sigs.k8s.io/kustomize/kyaml/yaml"
@@ -65,6 +66,13 @@ type Hydrator struct {
 	ValuesFileApplyStrategy string
 	CACertFilePath          string
 	CredentialProvider      auth.CredentialProvider
+	// APIVersions are the API versions used for Capabilities.APIVersions.
+	APIVersions []string
+	// KubeVersion is the Kubernetes version used for Capabilities.KubeVersion.
+	KubeVersion string
+	// PostRenderPatches are the kustomize patches applied to the rendered
+	// chart.
+	PostRenderPatches []types.Patch
 }
 
 func (h *Hydrator) templateArgs(ctx context.Context, destDir string) ([]string, error) {
@@ -100,6 +108,12 @@ func (h *Hydrator) templateArgs(ctx context.Context, destDir string) ([]string,
 	if includeCRDs {
 		args = append(args, "--include-crds")
 	}
+	for _, apiVersion := range h.APIVersions {
+		args = append(args, "--api-versions", apiVersion)
+	}
+	if h.KubeVersion != "" {
+		args = append(args, "--kube-version", h.KubeVersion)
+	}
 	args = append(args, "--output-dir", destDir)
 	return args, nil
 }
@@ -310,6 +324,10 @@ func (h *Hydrator) HelmTemplate(ctx context.Context) error {
 		return fmt.Errorf("failed to create chart directory: %w", err)
 	}
 
+	if err := h.postRender(destDir); err != nil {
+		return fmt.Errorf("failed to apply the post-render patches: %w", err)
+	}
+
 	if err := h.setDeployNamespace(destDir); err != nil {
 		return fmt.Errorf("failed to set the deploy namespace: %w", err)
 	}
@@ -0,0 +1,202 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync/v1beta1"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+	"sigs.k8s.io/kustomize/kyaml/resid"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+const (
+	// postRenderResourcesFile is the file of the rendered resources in the
+	// in-memory kustomization of the post-render step.
+	postRenderResourcesFile = "resources.yaml"
+	// postRenderFile is the file of the resources added by the post-render
+	// patches, relative to the chart directory.
+	postRenderFile = "post-render.yaml"
+)
+
+// ParsePostRenderPatches parses the YAML list of kustomize patches of the
+// post-render step.
+func ParsePostRenderPatches(patchesYAML string) ([]types.Patch, error) {
+	var patches []types.Patch
+	if err := sigsyaml.UnmarshalStrict([]byte(patchesYAML), &patches); err != nil {
+		return nil, fmt.Errorf("invalid post-render patches: %w", err)
+	}
+	for i, patch := range patches {
+		if patch.Path != "" {
+			return nil, fmt.Errorf("invalid post-render patch %d: patches must be inline, path %q is not supported", i, patch.Path)
+		}
+		if patch.Patch == "" {
+			return nil, fmt.Errorf("invalid post-render patch %d: patch must not be empty", i)
+		}
+	}
+	return patches, nil
+}
+
+// PostRenderPatches returns the kustomize patches of the post-render step:
+// the patches of patchesFromData, the data of the ConfigMap referenced by
+// patchesFrom, followed by the inline patches.
+func PostRenderPatches(postRender *v1beta1.HelmPostRender, patchesFromData string) ([]types.Patch, error) {
+	if postRender == nil {
+		return nil, nil
+	}
+	var patches []types.Patch
+	if patchesFromData != "" {
+		var err error
+		if patches, err = ParsePostRenderPatches(patchesFromData); err != nil {
+			return nil, err
+		}
+	}
+	for _, patch := range postRender.Patches {
+		patches = append(patches, kustomizePatch(patch))
+	}
+	return patches, nil
+}
+
+// kustomizePatch converts the post-render patch to a kustomize patch.
+func kustomizePatch(patch v1beta1.HelmPatch) types.Patch {
+	result := types.Patch{Patch: patch.Patch}
+	if target := patch.Target; target != nil {
+		result.Target = &types.Selector{
+			ResId: resid.ResId{
+				Gvk: resid.Gvk{
+					Group:   target.Group,
+					Version: target.Version,
+					Kind:    target.Kind,
+				},
+				Name:      target.Name,
+				Namespace: target.Namespace,
+			},
+			LabelSelector:      target.LabelSelector,
+			AnnotationSelector: target.AnnotationSelector,
+		}
+	}
+	return result
+}
+
+// postRender applies the post-render patches to the rendered chart in destDir
+// with kustomize. The patched resources are written back to the files they
+// were rendered to.
+func (h *Hydrator) postRender(destDir string) error {
+	if len(h.PostRenderPatches) == 0 {
+		return nil
+	}
+
+	pkgReadWriter := kio.LocalPackageReadWriter{
+		PackagePath: destDir,
+		FileSystem:  filesys.FileSystemOrOnDisk{FileSystem: filesys.MakeFsOnDisk()},
+	}
+	nodes, err := pkgReadWriter.Read()
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	// Record the file of each resource, to write the patched resource back to
+	// the same file.
+	paths := make(map[resid.ResId]string, len(nodes))
+	for _, node := range nodes {
+		path, _, err := kioutil.GetFileAnnotations(node)
+		if err != nil {
+			return err
+		}
+		id := resid.NewResIdWithNamespace(resid.GvkFromNode(node), node.GetName(), node.GetNamespace())
+		paths[id] = path
+	}
+
+	var resources bytes.Buffer
+	writer := kio.ByteWriter{
+		Writer:           &resources,
+		ClearAnnotations: []string{kioutil.PathAnnotation, kioutil.LegacyPathAnnotation},
+	}
+	if err := writer.Write(nodes); err != nil {
+		return err
+	}
+	kustomization, err := sigsyaml.Marshal(&types.Kustomization{
+		TypeMeta: types.TypeMeta{
+			APIVersion: types.KustomizationVersion,
+			Kind:       types.KustomizationKind,
+		},
+		Resources: []string{postRenderResourcesFile},
+		Patches:   h.PostRenderPatches,
+	})
+	if err != nil {
+		return err
+	}
+
+	fSys := filesys.MakeFsInMemory()
+	if err := fSys.WriteFile(filepath.Join("/", postRenderResourcesFile), resources.Bytes()); err != nil {
+		return err
+	}
+	if err := fSys.WriteFile(filepath.Join("/", konfig.DefaultKustomizationFileName()), kustomization); err != nil {
+		return err
+	}
+	opts := krusty.MakeDefaultOptions()
+	opts.Reorder = krusty.ReorderOptionNone
+	resMap, err := krusty.MakeKustomizer(opts).Run(fSys, "/")
+	if err != nil {
+		return err
+	}
+
+	// Resources added by the patches, or whose id was changed, are written to
+	// a separate file of the chart.
+	defaultPath := filepath.Join(h.Chart, postRenderFile)
+	indexes := map[string]int{}
+	var newNodes []*yaml.RNode
+	for _, res := range resMap.Resources() {
+		data, err := res.AsYAML()
+		if err != nil {
+			return err
+		}
+		node, err := yaml.Parse(string(data))
+		if err != nil {
+			return err
+		}
+		path, found := paths[res.OrgId()]
+		if !found {
+			path = defaultPath
+		}
+		index := strconv.Itoa(indexes[path])
+		for key, value := range map[string]string{
+			kioutil.PathAnnotation:        path,
+			kioutil.LegacyPathAnnotation:  path,
+			kioutil.IndexAnnotation:       index,
+			kioutil.LegacyIndexAnnotation: index,
+		} {
+			if err := node.PipeE(yaml.SetAnnotation(key, value)); err != nil {
+				return err
+			}
+		}
+		indexes[path]++
+		newNodes = append(newNodes, node)
+	}
+	return pkgReadWriter.Write(newNodes)
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/resid"
+)
+
+const renderedDeployment = `---
+# Source: my-chart/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-app
+  namespace: prod
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: app
+        image: my-app:v1
+`
+
+const renderedService = `---
+# Source: my-chart/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: my-app
+  namespace: prod
+  labels:
+    app: my-app
+spec:
+  ports:
+  - port: 80
+`
+
+func TestPostRender(t *testing.T) {
+	destDir := t.TempDir()
+	templatesDir := filepath.Join(destDir, "my-chart", "templates")
+	require.NoError(t, os.MkdirAll(templatesDir, os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(templatesDir, "deployment.yaml"), []byte(renderedDeployment), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(templatesDir, "service.yaml"), []byte(renderedService), 0644))
+
+	h := &Hydrator{
+		Chart: "my-chart",
+		PostRenderPatches: []types.Patch{
+			{
+				Patch: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-app
+  namespace: prod
+spec:
+  replicas: 3
+`,
+			},
+			{
+				Patch: `- op: add
+  path: /metadata/annotations
+  value:
+    team: storefront
+`,
+				Target: &types.Selector{LabelSelector: "app=my-app"},
+			},
+		},
+	}
+	require.NoError(t, h.postRender(destDir))
+
+	deployment, err := os.ReadFile(filepath.Join(templatesDir, "deployment.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(deployment), "replicas: 3")
+	assert.NotContains(t, string(deployment), "internal.config.kubernetes.io")
+
+	service, err := os.ReadFile(filepath.Join(templatesDir, "service.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(service), "team: storefront")
+
+	_, err = os.Stat(filepath.Join(destDir, "my-chart", postRenderFile))
+	assert.True(t, os.IsNotExist(err), "no resource should be written to %s", postRenderFile)
+}
+
+func TestPostRenderNoPatches(t *testing.T) {
+	destDir := t.TempDir()
+	path := filepath.Join(destDir, "my-chart", "templates", "deployment.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+	require.NoError(t, os.WriteFile(path, []byte(renderedDeployment), 0644))
+
+	h := &Hydrator{Chart: "my-chart"}
+	require.NoError(t, h.postRender(destDir))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, renderedDeployment, string(data))
+}
+
+func TestParsePostRenderPatches(t *testing.T) {
+	testCases := []struct {
+		name    string
+		patches string
+		want    []types.Patch
+		wantErr bool
+	}{
+		{
+			name: "inline patches",
+			patches: `- patch: |-
+    - op: remove
+      path: /spec/replicas
+  target:
+    kind: Deployment
+`,
+			want: []types.Patch{{
+				Patch: "- op: remove\n  path: /spec/replicas",
+				Target: &types.Selector{
+					ResId: resid.ResId{Gvk: resid.Gvk{Kind: "Deployment"}},
+				},
+			}},
+		},
+		{
+			name:    "path is not supported",
+			patches: "- path: patch.yaml\n",
+			wantErr: true,
+		},
+		{
+			name:    "empty patch",
+			patches: "- target:\n    kind: Deployment\n",
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			patches: "- patch: foo\n  options: {}\n  unknown: bar\n",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParsePostRenderPatches(tc.patches)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestTemplateArgsCapabilities(t *testing.T) {
+	h := &Hydrator{
+		Chart:       "my-chart",
+		Repo:        "https://charts.example.com",
+		Version:     "1.0.0",
+		Auth:        configsync.AuthNone,
+		APIVersions: []string{"monitoring.coreos.com/v1", "cert-manager.io/v1/Certificate"},
+		KubeVersion: "1.29.0",
+	}
+	args, err := h.templateArgs(context.Background(), "/dest")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"template", "my-chart",
+		"--repo", "https://charts.example.com",
+		"--namespace", configsync.DefaultHelmReleaseNamespace,
+		"--version", "1.0.0",
+		"--api-versions", "monitoring.coreos.com/v1",
+		"--api-versions", "cert-manager.io/v1/Certificate",
+		"--kube-version", "1.29.0",
+		"--output-dir", "/dest",
+	}, args)
+}
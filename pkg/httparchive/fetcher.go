@@ -0,0 +1,175 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package httparchive fetches configuration archives served over HTTP(S).
+package httparchive
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/util"
+	"github.com/GoogleContainerTools/config-sync/pkg/util/archive"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// NoFurtherSyncsLog is the log message for when no further syncs will occur.
+	// exported as a const for use in testing.
+	NoFurtherSyncsLog = "Archive has been synced, and no further syncs will occur"
+
+	// ChecksumPrefix is the prefix of the archive checksum.
+	ChecksumPrefix = "sha256:"
+)
+
+// Fetcher fetches archives from an HTTP(S) server.
+type Fetcher struct {
+	// Client is the HTTP client used to fetch the archive.
+	// Defaults to http.DefaultClient.
+	Client *http.Client
+	// Username and Password are used for basic authentication, if set.
+	Username string
+	Password string
+	// Token is used for bearer token authentication, if set.
+	Token string
+	// Checksum is the expected checksum of the archive, in the form
+	// `sha256:HEX`. If empty, the checksum is not verified.
+	Checksum string
+
+	// etag is the ETag of the last fetched archive, used to skip downloading
+	// an unchanged archive.
+	etag string
+}
+
+// FetchArchive fetches the archive from the URL and extracts it into a
+// directory under root named after the archive checksum, then points the rev
+// symlink at that directory.
+func (f *Fetcher) FetchArchive(ctx context.Context, url, root, rev string) error {
+	linkPath := filepath.Join(root, rev)
+	oldDir, err := filepath.EvalSymlinks(linkPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to evaluate the symbolic path %q to the archive: %w", linkPath, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request for %q: %w", url, err)
+	}
+	switch {
+	case f.Token != "":
+		req.Header.Set("Authorization", "Bearer "+f.Token)
+	case f.Username != "":
+		req.SetBasicAuth(f.Username, f.Password)
+	}
+	if f.etag != "" && oldDir != "" {
+		req.Header.Set("If-None-Match", f.etag)
+	}
+
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch archive %q: %w", url, err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			klog.Warningf("failed to close response body: %v", err)
+		}
+	}()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		klog.Infof("no update required with the same ETag %q", f.etag)
+		return nil
+	default:
+		return fmt.Errorf("failed to fetch archive %q: unexpected status %q", url, resp.Status)
+	}
+
+	if err := os.MkdirAll(root, os.FileMode(0755)); err != nil {
+		return fmt.Errorf("failed to create directory %q: %w", root, err)
+	}
+	archivePath, checksum, err := download(resp.Body, root)
+	if err != nil {
+		return fmt.Errorf("failed to download archive %q: %w", url, err)
+	}
+	defer func() {
+		if err := os.Remove(archivePath); err != nil {
+			klog.Warningf("failed to remove file %q: %v", archivePath, err)
+		}
+	}()
+	if f.Checksum != "" && f.Checksum != ChecksumPrefix+checksum {
+		return fmt.Errorf("checksum mismatch for archive %q: expected %q, but found %q",
+			url, f.Checksum, ChecksumPrefix+checksum)
+	}
+
+	destDir := filepath.Join(root, checksum)
+	if oldDir == destDir {
+		klog.Infof("no update required with the same archive checksum %q", checksum)
+		f.etag = resp.Header.Get("ETag")
+		return nil
+	}
+
+	// Remove any partial extraction left over from a failed attempt.
+	if err := os.RemoveAll(destDir); err != nil {
+		return fmt.Errorf("failed to clean up the directory %q: %w", destDir, err)
+	}
+	if err := os.MkdirAll(destDir, os.FileMode(0755)); err != nil {
+		return fmt.Errorf("failed to create directory %q: %w", destDir, err)
+	}
+	if err := archive.ExtractFile(archivePath, destDir); err != nil {
+		return fmt.Errorf("failed to extract the archive and write to the directory %q: %w", destDir, err)
+	}
+
+	klog.Infof("fetched archive with checksum %q", checksum)
+	if err := util.UpdateSymlink(root, linkPath, destDir, oldDir); err != nil {
+		return err
+	}
+	f.etag = resp.Header.Get("ETag")
+	return nil
+}
+
+// download writes the content to a temporary file under dir and returns the
+// file path and the hex-encoded SHA-256 checksum of the content.
+func download(content io.Reader, dir string) (string, string, error) {
+	file, err := os.CreateTemp(dir, ".archive-")
+	if err != nil {
+		return "", "", err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			klog.Warningf("failed to close file %q: %v", file.Name(), err)
+		}
+	}()
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(file, hash), content); err != nil {
+		_ = os.Remove(file.Name())
+		return "", "", err
+	}
+	return file.Name(), hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// HasChecksum returns whether the provided checksum is set, in which case the
+// archive content never changes once it has been synced.
+func HasChecksum(checksum string) bool {
+	return strings.HasPrefix(checksum, ChecksumPrefix)
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httparchive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gzippedTarball(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tarWriter.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	return buf.Bytes()
+}
+
+func checksumOf(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// archiveServer serves the archive with an ETag, and counts the requests that
+// downloaded the archive.
+type archiveServer struct {
+	archive   []byte
+	etag      string
+	downloads int
+	authz     string
+}
+
+func (s *archiveServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.authz = r.Header.Get("Authorization")
+	if s.etag != "" && r.Header.Get("If-None-Match") == s.etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	s.downloads++
+	w.Header().Set("ETag", s.etag)
+	_, _ = w.Write(s.archive)
+}
+
+func TestFetchArchive(t *testing.T) {
+	archiveV1 := gzippedTarball(t, map[string]string{"ns.yaml": "kind: Namespace\n"})
+	archiveV2 := gzippedTarball(t, map[string]string{"cm.yaml": "kind: ConfigMap\n"})
+	handler := &archiveServer{archive: archiveV1, etag: `"v1"`}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	root := t.TempDir()
+	fetcher := &Fetcher{Token: "secret-token"}
+	ctx := context.Background()
+
+	require.NoError(t, fetcher.FetchArchive(ctx, server.URL, root, "rev"))
+	assertFileContent(t, filepath.Join(root, "rev", "ns.yaml"), "kind: Namespace\n")
+	assertLinkTarget(t, filepath.Join(root, "rev"), checksumOf(archiveV1))
+	assert.Equal(t, "Bearer secret-token", handler.authz)
+	assert.Equal(t, 1, handler.downloads)
+
+	// The unchanged archive is not downloaded again.
+	require.NoError(t, fetcher.FetchArchive(ctx, server.URL, root, "rev"))
+	assert.Equal(t, 1, handler.downloads)
+
+	handler.archive = archiveV2
+	handler.etag = `"v2"`
+	require.NoError(t, fetcher.FetchArchive(ctx, server.URL, root, "rev"))
+	assertFileContent(t, filepath.Join(root, "rev", "cm.yaml"), "kind: ConfigMap\n")
+	assert.NoFileExists(t, filepath.Join(root, "rev", "ns.yaml"))
+	assertLinkTarget(t, filepath.Join(root, "rev"), checksumOf(archiveV2))
+	assert.NoDirExists(t, filepath.Join(root, checksumOf(archiveV1)))
+	assert.Equal(t, 2, handler.downloads)
+}
+
+func TestFetchArchiveChecksum(t *testing.T) {
+	content := gzippedTarball(t, map[string]string{"ns.yaml": "kind: Namespace\n"})
+	server := httptest.NewServer(&archiveServer{archive: content})
+	defer server.Close()
+
+	testCases := map[string]struct {
+		checksum  string
+		wantError bool
+	}{
+		"matching checksum": {
+			checksum: ChecksumPrefix + checksumOf(content),
+		},
+		"different checksum": {
+			checksum:  ChecksumPrefix + checksumOf([]byte("other")),
+			wantError: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			fetcher := &Fetcher{Checksum: tc.checksum}
+			err := fetcher.FetchArchive(context.Background(), server.URL, root, "rev")
+			if tc.wantError {
+				assert.Error(t, err)
+				assert.NoFileExists(t, filepath.Join(root, "rev"))
+				return
+			}
+			require.NoError(t, err)
+			assertFileContent(t, filepath.Join(root, "rev", "ns.yaml"), "kind: Namespace\n")
+		})
+	}
+}
+
+func TestFetchArchiveBasicAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "user" || password != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write(gzippedTarball(t, map[string]string{"ns.yaml": "kind: Namespace\n"}))
+	}))
+	defer server.Close()
+
+	root := t.TempDir()
+	err := (&Fetcher{}).FetchArchive(context.Background(), server.URL, root, "rev")
+	assert.ErrorContains(t, err, "401 Unauthorized")
+
+	fetcher := &Fetcher{Username: "user", Password: "pass"}
+	require.NoError(t, fetcher.FetchArchive(context.Background(), server.URL, root, "rev"))
+	assertFileContent(t, filepath.Join(root, "rev", "ns.yaml"), "kind: Namespace\n")
+}
+
+func assertFileContent(t *testing.T, path, want string) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, string(got))
+}
+
+func assertLinkTarget(t *testing.T, linkPath, want string) {
+	t.Helper()
+	target, err := filepath.EvalSymlinks(linkPath)
+	require.NoError(t, err)
+	assert.Equal(t, want, filepath.Base(target))
+}
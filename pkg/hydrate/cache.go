@@ -0,0 +1,317 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// RenderCacheFile is the file name of the rendering cache under the
+	// hydrated root directory.
+	RenderCacheFile = "render-cache.json"
+	// renderCacheVersion is part of the content hash, so that the cache is
+	// invalidated when the way the inputs are hashed changes.
+	renderCacheVersion = "v1"
+)
+
+// renderCache records the content hash of the rendering inputs of the last
+// rendered commit. A new commit with the same rendering inputs reuses the
+// hydrated configs of the last rendered commit, instead of rendering them
+// again.
+type renderCache struct {
+	// Commit is the last rendered commit.
+	Commit string `json:"commit"`
+	// Hash is the content hash of the rendering inputs of Commit.
+	Hash string `json:"hash"`
+	// Files are the paths of the files outside the sync directory read
+	// during rendering, relative to the source directory.
+	Files []string `json:"files,omitempty"`
+}
+
+// readRenderCache reads the rendering cache. It returns nil if the cache
+// doesn't exist or is invalid.
+func readRenderCache(path string) *renderCache {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	cache := &renderCache{}
+	if err := json.Unmarshal(data, cache); err != nil {
+		return nil
+	}
+	return cache
+}
+
+// writeRenderCache writes the rendering cache.
+func writeRenderCache(path string, cache *renderCache) error {
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("unable to write the rendering cache %s: %w", path, err)
+	}
+	return nil
+}
+
+// syncDirHash returns the content hash of the sync directory. It is computed
+// before rendering, because rendering may write to the sync directory, for
+// example when pulling Helm charts.
+func syncDirHash(syncDir string) (string, error) {
+	h := sha256.New()
+	if err := hashTree(h, syncDir); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// renderInputsHash returns the content hash of the rendering inputs: the
+// content of the sync directory, and of the files outside the sync
+// directory, relative to the source directory.
+func renderInputsHash(syncDirHash, sourceDir string, files []string) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00", renderCacheVersion, syncDirHash)
+	for _, file := range files {
+		fmt.Fprintf(h, "file\x00%s\x00", file)
+		if err := hashFile(h, filepath.Join(sourceDir, file)); err != nil {
+			if !os.IsNotExist(err) {
+				return "", err
+			}
+			// A deleted file changes the hash.
+			fmt.Fprint(h, "missing\x00")
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashTree writes the paths and content of the files in the directory,
+// except the .git directory, to the hash.
+func hashTree(h io.Writer, dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Name() == ".git" {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			fmt.Fprintf(h, "dir\x00%s\x00", filepath.ToSlash(rel))
+		case d.Type()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(h, "link\x00%s\x00%s\x00", filepath.ToSlash(rel), target)
+		default:
+			fmt.Fprintf(h, "file\x00%s\x00", filepath.ToSlash(rel))
+			return hashFile(h, path)
+		}
+		return nil
+	})
+}
+
+func hashFile(h io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	fh := sha256.New()
+	if _, err := io.Copy(fh, f); err != nil {
+		return fmt.Errorf("unable to read %s: %w", path, err)
+	}
+	fmt.Fprintf(h, "%x\x00", fh.Sum(nil))
+	return nil
+}
+
+// recordingFS is a file system recording the real paths of the regular files
+// read from it.
+type recordingFS struct {
+	filesys.FileSystem
+	files map[string]bool
+}
+
+func newRecordingFS() *recordingFS {
+	return &recordingFS{FileSystem: filesys.MakeFsOnDisk(), files: map[string]bool{}}
+}
+
+// Open records the file and opens it.
+func (fs *recordingFS) Open(path string) (filesys.File, error) {
+	fs.record(path)
+	return fs.FileSystem.Open(path)
+}
+
+// ReadFile records the file and reads it.
+func (fs *recordingFS) ReadFile(path string) ([]byte, error) {
+	fs.record(path)
+	return fs.FileSystem.ReadFile(path)
+}
+
+func (fs *recordingFS) record(path string) {
+	if p, err := filepath.Abs(path); err == nil {
+		if real, err := filepath.EvalSymlinks(p); err == nil {
+			if fi, err := os.Stat(real); err == nil && fi.Mode().IsRegular() {
+				fs.files[real] = true
+			}
+		}
+	}
+}
+
+// inputFiles returns the paths of the files outside the sync directory which
+// are rendering inputs, relative to the source directory.
+// It returns false if the rendering inputs are not all in the source
+// directory, in which case the rendering can not be cached: the kustomization
+// references remote bases, or Helm charts outside the source directory or
+// without a pinned version.
+func (fs *recordingFS) inputFiles(sourceDir, syncDir string) ([]string, bool, error) {
+	inputs := map[string]bool{}
+	for file := range fs.files {
+		inputs[file] = true
+	}
+	for _, name := range validKustomizationFiles {
+		path := filepath.Join(syncDir, name)
+		if _, err := os.Stat(path); err == nil {
+			inputs[path] = true
+		}
+	}
+	var kustomizations []string
+	for file := range inputs {
+		if HasKustomization(filepath.Base(file)) {
+			kustomizations = append(kustomizations, file)
+		}
+	}
+	for _, file := range kustomizations {
+		helmFiles, ok, err := helmInputFiles(file)
+		if err != nil || !ok {
+			return nil, ok, err
+		}
+		for _, helmFile := range helmFiles {
+			inputs[helmFile] = true
+		}
+	}
+	var result []string
+	for file := range inputs {
+		if isWithin(file, syncDir) {
+			// The sync directory is hashed as a whole.
+			continue
+		}
+		if !isWithin(file, sourceDir) {
+			return nil, false, nil
+		}
+		rel, err := filepath.Rel(sourceDir, file)
+		if err != nil {
+			return nil, false, err
+		}
+		result = append(result, rel)
+	}
+	sort.Strings(result)
+	return result, true, nil
+}
+
+// helmInputFiles returns the files read by Helm to inflate the charts of the
+// kustomization, which are not read from the file system of kustomize.
+// It returns false if a chart is pulled without a pinned version.
+func helmInputFiles(kustomizationPath string) ([]string, bool, error) {
+	data, err := os.ReadFile(kustomizationPath)
+	if err != nil {
+		return nil, false, err
+	}
+	k := &types.Kustomization{}
+	if err := yaml.Unmarshal(data, k); err != nil {
+		return nil, false, err
+	}
+	k.FixKustomization()
+	if len(k.HelmCharts) == 0 {
+		return nil, true, nil
+	}
+	root := filepath.Dir(kustomizationPath)
+	chartHome := types.HelmDefaultHome
+	if k.HelmGlobals != nil && k.HelmGlobals.ChartHome != "" {
+		chartHome = k.HelmGlobals.ChartHome
+	}
+	if !filepath.IsAbs(chartHome) {
+		chartHome = filepath.Join(root, chartHome)
+	}
+	var result []string
+	for _, chart := range k.HelmCharts {
+		if chart.Repo != "" && chart.Version == "" {
+			return nil, false, nil
+		}
+		for _, file := range chart.AdditionalValuesFiles {
+			if !filepath.IsAbs(file) {
+				file = filepath.Join(root, file)
+			}
+			result = append(result, file)
+		}
+	}
+	err = filepath.WalkDir(chartHome, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			result = append(result, path)
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, false, err
+	}
+	return result, true, nil
+}
+
+// copyDir copies the files in the src directory to the dest directory.
+func copyDir(src, dest string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, os.FileMode(0755))
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0644)
+	})
+}
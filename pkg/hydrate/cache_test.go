@@ -0,0 +1,172 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/filesystem/cmpath"
+	"github.com/GoogleContainerTools/config-sync/pkg/testing/testmetrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunHydrateWithRenderCache(t *testing.T) {
+	exporter, err := testmetrics.NewTestExporter()
+	require.NoError(t, err)
+	defer exporter.ClearMetrics()
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	sourceRoot := filepath.Join(root, "source")
+	hydratedRoot := filepath.Join(root, "hydrated")
+	require.NoError(t, os.MkdirAll(sourceRoot, 0755))
+
+	hydrator := &Hydrator{
+		SourceRoot:   cmpath.Absolute(sourceRoot),
+		HydratedRoot: cmpath.Absolute(hydratedRoot),
+		SourceLink:   "rev",
+		HydratedLink: "rev",
+		SyncDir:      "app",
+	}
+	// checkout writes the source configs of the commit and points the source
+	// link to it.
+	checkout := func(commit, configMapData string) cmpath.Absolute {
+		commitDir := filepath.Join(sourceRoot, commit)
+		files := map[string]string{
+			"app/kustomization.yaml":  "resources:\n- ../base\n",
+			"base/kustomization.yaml": "resources:\n- configmap.yaml\n",
+			"base/configmap.yaml":     "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\ndata:\n  key: " + configMapData + "\n",
+			"docs/README.md":          "Commit " + commit,
+		}
+		for name, content := range files {
+			path := filepath.Join(commitDir, name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+			require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		}
+		link := filepath.Join(sourceRoot, "rev")
+		require.NoError(t, os.RemoveAll(link))
+		require.NoError(t, os.Symlink(commitDir, link))
+		return cmpath.Absolute(filepath.Join(commitDir, "app"))
+	}
+	// marker is written to the hydrated configs, to check whether they are
+	// reused.
+	marker := func(commit string) string {
+		return filepath.Join(hydratedRoot, commit, "app", "marker")
+	}
+
+	require.NoError(t, hydrator.runHydrate("commit-1", checkout("commit-1", "a")))
+	cache := readRenderCache(hydrator.renderCachePath())
+	require.NotNil(t, cache)
+	assert.Equal(t, "commit-1", cache.Commit)
+	assert.Equal(t, []string{"base/configmap.yaml", "base/kustomization.yaml"}, cache.Files)
+	require.NoError(t, os.WriteFile(marker("commit-1"), nil, 0644))
+
+	// The rendering inputs are unchanged: the rendered configs are reused.
+	require.NoError(t, hydrator.runHydrate("commit-2", checkout("commit-2", "a")))
+	assert.FileExists(t, marker("commit-2"))
+	assert.NoDirExists(t, filepath.Join(hydratedRoot, "commit-1"))
+	linked, err := filepath.EvalSymlinks(filepath.Join(hydratedRoot, "rev"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(hydratedRoot, "commit-2"), linked)
+	cache = readRenderCache(hydrator.renderCachePath())
+	require.NotNil(t, cache)
+	assert.Equal(t, "commit-2", cache.Commit)
+
+	// The base outside the sync directory changed: the configs are rendered.
+	require.NoError(t, hydrator.runHydrate("commit-3", checkout("commit-3", "b")))
+	assert.NoFileExists(t, marker("commit-3"))
+	rendered, err := os.ReadFile(filepath.Join(hydratedRoot, "commit-3", "app", "v1_configmap_settings.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), "key: b")
+}
+
+func TestRecordingFSInputFiles(t *testing.T) {
+	testCases := []struct {
+		name          string
+		files         map[string]string
+		wantFiles     []string
+		wantCacheable bool
+	}{
+		{
+			name: "local bases",
+			files: map[string]string{
+				"app/kustomization.yaml":  "resources:\n- ../base\n",
+				"base/kustomization.yaml": "resources:\n- configmap.yaml\n",
+				"base/configmap.yaml":     "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n",
+			},
+			wantFiles:     []string{"base/configmap.yaml", "base/kustomization.yaml"},
+			wantCacheable: true,
+		},
+		{
+			name: "local Helm chart",
+			files: map[string]string{
+				"app/kustomization.yaml": "helmGlobals:\n  chartHome: ../charts\nhelmCharts:\n- name: web\n  additionalValuesFiles:\n  - ../values/web.yaml\n",
+				"charts/web/Chart.yaml":  "name: web\n",
+				"values/web.yaml":        "replicas: 1\n",
+			},
+			wantFiles:     []string{"charts/web/Chart.yaml", "values/web.yaml"},
+			wantCacheable: true,
+		},
+		{
+			name: "Helm chart without pinned version",
+			files: map[string]string{
+				"app/kustomization.yaml": "helmCharts:\n- name: web\n  repo: https://charts.example.com\n",
+			},
+		},
+		{
+			name: "Helm chart with pinned version",
+			files: map[string]string{
+				"app/kustomization.yaml": "helmCharts:\n- name: web\n  repo: https://charts.example.com\n  version: 1.0.0\n",
+			},
+			wantCacheable: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sourceDir, err := filepath.EvalSymlinks(t.TempDir())
+			require.NoError(t, err)
+			fSys := newRecordingFS()
+			for name, content := range tc.files {
+				path := filepath.Join(sourceDir, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+				if filepath.Dir(name) != "app" {
+					_, err := fSys.ReadFile(path)
+					require.NoError(t, err)
+				}
+			}
+			files, cacheable, err := fSys.inputFiles(sourceDir, filepath.Join(sourceDir, "app"))
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantCacheable, cacheable)
+			assert.Equal(t, tc.wantFiles, files)
+		})
+	}
+
+	t.Run("file outside the source directory", func(t *testing.T) {
+		sourceDir := t.TempDir()
+		outside := filepath.Join(t.TempDir(), "configmap.yaml")
+		require.NoError(t, os.WriteFile(outside, nil, 0644))
+		fSys := newRecordingFS()
+		_, err := fSys.ReadFile(outside)
+		require.NoError(t, err)
+		_, cacheable, err := fSys.inputFiles(sourceDir, filepath.Join(sourceDir, "app"))
+		require.NoError(t, err)
+		assert.False(t, cacheable)
+	})
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast"
+	"github.com/google/go-cmp/cmp"
+)
+
+// absentVariant is the variant of an object which is not declared for a
+// cluster.
+const absentVariant = -1
+
+// ObjectComparison is the comparison of an object hydrated for several
+// clusters.
+type ObjectComparison struct {
+	// ID is the ID of the object.
+	ID core.ID
+	// Variants are the distinct contents of the object, in the order of the
+	// clusters they were first hydrated for.
+	Variants []map[string]interface{}
+	// Clusters maps each cluster to the index of the variant of the object
+	// hydrated for the cluster, or -1 if the object is not declared for the
+	// cluster.
+	Clusters map[string]int
+}
+
+// Differs returns true if the object is not the same for all the clusters.
+func (c *ObjectComparison) Differs() bool {
+	first := true
+	variant := 0
+	for _, v := range c.Clusters {
+		if first {
+			variant = v
+			first = false
+		} else if v != variant {
+			return true
+		}
+	}
+	return false
+}
+
+// ClusterComparison is the comparison of the objects hydrated for several
+// clusters.
+type ClusterComparison struct {
+	// Clusters are the names of the compared clusters, sorted.
+	Clusters []string
+	// Objects are the comparisons of the objects which are not the same for
+	// all the clusters, sorted by ID.
+	Objects []*ObjectComparison
+}
+
+// CompareClusters compares the objects hydrated for each cluster.
+// The objects must be cleaned, so that the Config Sync metadata specific to
+// each cluster doesn't make every object differ.
+func CompareClusters(clusterObjects map[string][]ast.FileObject) *ClusterComparison {
+	result := &ClusterComparison{}
+	for cluster := range clusterObjects {
+		result.Clusters = append(result.Clusters, cluster)
+	}
+	sort.Strings(result.Clusters)
+
+	objects := map[core.ID]*ObjectComparison{}
+	for _, cluster := range result.Clusters {
+		for _, obj := range clusterObjects[cluster] {
+			id := core.IDOf(obj)
+			c, found := objects[id]
+			if !found {
+				c = &ObjectComparison{ID: id, Clusters: map[string]int{}}
+				for _, other := range result.Clusters {
+					c.Clusters[other] = absentVariant
+				}
+				objects[id] = c
+			}
+			c.Clusters[cluster] = c.variant(obj.Unstructured.Object)
+		}
+	}
+
+	for _, c := range objects {
+		if c.Differs() {
+			result.Objects = append(result.Objects, c)
+		}
+	}
+	sort.Slice(result.Objects, func(i, j int) bool {
+		return result.Objects[i].ID.String() < result.Objects[j].ID.String()
+	})
+	return result
+}
+
+// variant returns the index of the variant with the content, adding it if
+// it's new.
+func (c *ObjectComparison) variant(content map[string]interface{}) int {
+	for i, v := range c.Variants {
+		if reflect.DeepEqual(v, content) {
+			return i
+		}
+	}
+	c.Variants = append(c.Variants, content)
+	return len(c.Variants) - 1
+}
+
+// variantName returns the name of the variant in the matrix: "A" for the
+// first variant, "B" for the second, and so on, and "-" if absent.
+func variantName(variant int) string {
+	switch {
+	case variant == absentVariant:
+		return "-"
+	case variant < 26:
+		return string(rune('A' + variant))
+	default:
+		return fmt.Sprintf("V%d", variant+1)
+	}
+}
+
+// variantClusters returns the clusters the variant is hydrated for.
+func (c *ObjectComparison) variantClusters(clusters []string, variant int) []string {
+	var result []string
+	for _, cluster := range clusters {
+		if c.Clusters[cluster] == variant {
+			result = append(result, cluster)
+		}
+	}
+	return result
+}
+
+// Print prints the matrix of the objects which differ between clusters, and
+// the diff of each variant of the objects from their first variant.
+func (c *ClusterComparison) Print(out io.Writer) error {
+	if len(c.Objects) == 0 {
+		_, err := fmt.Fprintf(out, "The hydrated objects are the same for all %d clusters.\n", len(c.Clusters))
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "OBJECT\t%s\n", strings.Join(c.Clusters, "\t"))
+	for _, obj := range c.Objects {
+		row := make([]string, len(c.Clusters))
+		for i, cluster := range c.Clusters {
+			row[i] = variantName(obj.Clusters[cluster])
+		}
+		fmt.Fprintf(w, "%s\t%s\n", obj.ID, strings.Join(row, "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for _, obj := range c.Objects {
+		fmt.Fprintf(out, "\n%s:\n", obj.ID)
+		if absent := obj.variantClusters(c.Clusters, absentVariant); len(absent) > 0 {
+			fmt.Fprintf(out, "  not declared for: %s\n", strings.Join(absent, ", "))
+		}
+		for i := 1; i < len(obj.Variants); i++ {
+			fmt.Fprintf(out, "  %s (%s) compared to %s (%s):\n",
+				variantName(i), strings.Join(obj.variantClusters(c.Clusters, i), ", "),
+				variantName(0), strings.Join(obj.variantClusters(c.Clusters, 0), ", "))
+			diff := cmp.Diff(obj.Variants[0], obj.Variants[i])
+			for _, line := range strings.Split(strings.TrimRight(diff, "\n"), "\n") {
+				fmt.Fprintf(out, "    %s\n", line)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast"
+	"github.com/GoogleContainerTools/config-sync/pkg/kinds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func configMap(name string, opts ...core.MetaMutator) ast.FileObject {
+	opts = append([]core.MetaMutator{core.Name(name), core.Namespace("bookstore")}, opts...)
+	return k8sobjects.Unstructured(kinds.ConfigMap(), opts...)
+}
+
+func TestCompareClusters(t *testing.T) {
+	comparison := CompareClusters(map[string][]ast.FileObject{
+		"prod": {
+			configMap("same"),
+			configMap("labels", core.Label("tier", "gold")),
+			configMap("prod-only"),
+		},
+		"staging": {
+			configMap("same"),
+			configMap("labels", core.Label("tier", "silver")),
+		},
+		"dev": {
+			configMap("same"),
+			configMap("labels", core.Label("tier", "silver")),
+		},
+	})
+
+	assert.Equal(t, []string{"dev", "prod", "staging"}, comparison.Clusters)
+	require.Len(t, comparison.Objects, 2)
+
+	labels := comparison.Objects[0]
+	assert.Equal(t, "labels", labels.ID.Name)
+	assert.Len(t, labels.Variants, 2)
+	assert.Equal(t, map[string]int{"dev": 0, "prod": 1, "staging": 0}, labels.Clusters)
+
+	prodOnly := comparison.Objects[1]
+	assert.Equal(t, "prod-only", prodOnly.ID.Name)
+	assert.Equal(t, map[string]int{"dev": absentVariant, "prod": 0, "staging": absentVariant}, prodOnly.Clusters)
+
+	var out bytes.Buffer
+	require.NoError(t, comparison.Print(&out))
+	assert.Contains(t, out.String(), "ConfigMap, bookstore/labels     A    B     A")
+	assert.Contains(t, out.String(), "ConfigMap, bookstore/prod-only  -    A     -")
+	assert.Contains(t, out.String(), "B (prod) compared to A (dev, staging):")
+	assert.Contains(t, out.String(), `"gold"`)
+	assert.Contains(t, out.String(), "not declared for: dev, staging")
+	assert.NotContains(t, out.String(), "bookstore/same")
+}
+
+func TestCompareClustersSame(t *testing.T) {
+	comparison := CompareClusters(map[string][]ast.FileObject{
+		"prod":    {configMap("same")},
+		"staging": {configMap("same")},
+	})
+	assert.Empty(t, comparison.Objects)
+
+	var out bytes.Buffer
+	require.NoError(t, comparison.Print(&out))
+	assert.Equal(t, "The hydrated objects are the same for all 2 clusters.\n", out.String())
+}
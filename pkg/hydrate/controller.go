@@ -31,6 +31,7 @@ import (
 	"github.com/GoogleContainerTools/config-sync/pkg/util"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
 )
 
 const (
@@ -69,9 +70,29 @@ type Hydrator struct {
 	RehydratePeriod time.Duration
 	// ReconcilerName is the name of the reconciler.
 	ReconcilerName string
+	// FunctionPipelineEnabled indicates whether the KRM function pipeline
+	// declared in the Kptfile of the sync directory is run. Kptfiles are
+	// ignored otherwise.
+	FunctionPipelineEnabled bool
+	// FunctionDirs are the absolute paths to the directories of the exec KRM
+	// functions allowed in the function pipeline.
+	FunctionDirs []string
+	// SignalSocket is the absolute path to the unix socket serving the render
+	// requests of the Reconciler. Only the signal files are used if empty.
+	SignalSocket string
+	// HelmChartCache is the absolute path to the directory caching the Helm
+	// charts with a pinned version pulled for the kustomizations. The charts
+	// are not cached if empty.
+	HelmChartCache string
+	// HelmCredentials are the credentials to pull the Helm charts of the
+	// kustomizations. The charts are pulled anonymously if nil.
+	HelmCredentials *HelmCredentials
+
+	signals *renderSignals
 }
 
-// Run runs the hydration process periodically.
+// Run runs the hydration process periodically, and whenever the Reconciler
+// requests to render a commit.
 func (h *Hydrator) Run(ctx context.Context) {
 	// Use timers, not tickers.
 	// Tickers can cause memory leaks and continuous execution, when execution
@@ -80,6 +101,17 @@ func (h *Hydrator) Run(ctx context.Context) {
 	defer runTimer.Stop()
 	rehydrateTimer := time.NewTimer(h.RehydratePeriod)
 	defer rehydrateTimer.Stop()
+	// renderRequested is nil, and never selected, without signal socket.
+	var renderRequested <-chan struct{}
+	if h.SignalSocket != "" {
+		h.signals = newRenderSignals()
+		renderRequested = h.signals.trigger
+		go func() {
+			if err := h.signals.serveSignals(ctx, h.SignalSocket); err != nil {
+				klog.Errorf("failed to serve the render requests, falling back to the signal files: %v", err)
+			}
+		}()
+	}
 	// sourcePath is the path to which the source was fetched
 	sourcePath := h.sourcePath()
 	// srcCommit is the git commit, oci checksum, or helm version that was fetched
@@ -87,7 +119,6 @@ func (h *Hydrator) Run(ctx context.Context) {
 	// syncPath is the path from which the applier should sync
 	var syncPath cmpath.Absolute
 	var hydrateErr HydrationError
-	var fetchErr error
 	for {
 		select {
 		case <-ctx.Done():
@@ -95,47 +126,119 @@ func (h *Hydrator) Run(ctx context.Context) {
 		case <-rehydrateTimer.C:
 			hydrateErr = h.rehydrateOnError(hydrateErr, srcCommit, syncPath)
 			rehydrateTimer.Reset(h.RehydratePeriod) // Schedule rehydrate attempt
-		case <-runTimer.C:
-			// pull the source commit and directory with retries within 5 minutes.
-			srcCommit, syncPath, fetchErr = SourceCommitAndSyncPathWithRetry(util.SourceRetryBackoff, h.SourceType, sourcePath, h.SyncDir, h.ReconcilerName)
-			if fetchErr != nil {
-				hydrateErr = NewInternalError(fmt.Errorf("failed to get the commit hash and sync directory from the source directory %s: %w", sourcePath.OSPath(), fetchErr))
-				if err := h.complete(srcCommit, hydrateErr); err != nil {
-					klog.Errorf("failed to complete the rendering execution for commit %q: %v",
-						srcCommit, err)
-				}
-			} else {
-				doneCommit := extractCommit(h.DonePath.OSPath())
-				readyToRenderFile := h.ReconcilerSignalDir.Join(cmpath.RelativeSlash(ReadyToRenderFile)).OSPath()
-				readyToRenderCommit := extractCommit(readyToRenderFile)
-				switch {
-				case doneCommit == srcCommit:
-					// no-op
-					// If the commit has been processed before, skip the hydration to avoid repeated execution regardless of success or failure.
-					// The rehydrate ticker will retry on the failed commit.
-				case doneCommit != srcCommit && readyToRenderCommit != srcCommit:
-					klog.Warningf("skip hydration as readyToRenderCommit does not match srcCommit, want %s, got %s", srcCommit, readyToRenderCommit)
-				case doneCommit != srcCommit && readyToRenderCommit == srcCommit:
-					// Only proceed to rendering if the ready-to-render file contains the srcCommit
-					hydrateErr = h.hydrate(srcCommit, syncPath)
-					if err := h.complete(srcCommit, hydrateErr); err != nil {
-						klog.Errorf("failed to complete the rendering execution for commit %q: %v", srcCommit, err)
-					}
+		case <-renderRequested:
+			srcCommit, syncPath, hydrateErr = h.runOnce(sourcePath, hydrateErr)
+			// Stop and drain the timer before resetting it, to postpone the
+			// next run attempt.
+			if !runTimer.Stop() {
+				select {
+				case <-runTimer.C:
+				default:
 				}
 			}
 			runTimer.Reset(h.PollingPeriod) // Schedule re-run attempt
+		case <-runTimer.C:
+			srcCommit, syncPath, hydrateErr = h.runOnce(sourcePath, hydrateErr)
+			runTimer.Reset(h.PollingPeriod) // Schedule re-run attempt
+		}
+	}
+}
+
+// runOnce renders the source commit if it is ready to render and wasn't
+// processed before. It returns the source commit, the sync path, and the
+// hydration error, which is prevErr if the commit was not rendered.
+func (h *Hydrator) runOnce(sourcePath cmpath.Absolute, prevErr HydrationError) (string, cmpath.Absolute, HydrationError) {
+	// pull the source commit and directory with retries within 5 minutes.
+	srcCommit, syncPath, fetchErr := SourceCommitAndSyncPathWithRetry(util.SourceRetryBackoff, h.SourceType, sourcePath, h.SyncDir, h.ReconcilerName)
+	if fetchErr != nil {
+		hydrateErr := NewInternalError(fmt.Errorf("failed to get the commit hash and sync directory from the source directory %s: %w", sourcePath.OSPath(), fetchErr))
+		h.completeAndSignal(srcCommit, hydrateErr)
+		return srcCommit, syncPath, hydrateErr
+	}
+	doneCommit := extractCommit(h.DonePath.OSPath())
+	readyToRenderCommit := h.readyToRenderCommit()
+	switch {
+	case doneCommit == srcCommit:
+		// no-op
+		// If the commit has been processed before, skip the hydration to avoid repeated execution regardless of success or failure.
+		// The rehydrate ticker will retry on the failed commit.
+	case doneCommit != srcCommit && readyToRenderCommit != srcCommit:
+		klog.Warningf("skip hydration as readyToRenderCommit does not match srcCommit, want %s, got %s", srcCommit, readyToRenderCommit)
+	case doneCommit != srcCommit && readyToRenderCommit == srcCommit:
+		// Only proceed to rendering if the ready-to-render file contains the srcCommit
+		h.signalStatus(RenderStatus{Commit: srcCommit, State: RenderInProgress})
+		hydrateErr := h.hydrate(srcCommit, syncPath)
+		h.completeAndSignal(srcCommit, hydrateErr)
+		return srcCommit, syncPath, hydrateErr
+	}
+	return srcCommit, syncPath, prevErr
+}
+
+// readyToRenderCommit returns the commit which passed image verification,
+// from the last render request of the Reconciler, or from the ready-to-render
+// file if the Reconciler doesn't send render requests.
+func (h *Hydrator) readyToRenderCommit() string {
+	if h.signals != nil {
+		if commit, requested := h.signals.readyToRenderCommit(); requested {
+			return commit
 		}
 	}
+	readyToRenderFile := h.ReconcilerSignalDir.Join(cmpath.RelativeSlash(ReadyToRenderFile)).OSPath()
+	return extractCommit(readyToRenderFile)
+}
+
+// completeAndSignal completes the rendering execution for the commit, and
+// signals its status to the Reconciler.
+func (h *Hydrator) completeAndSignal(commit string, hydrationErr HydrationError) {
+	if err := h.complete(commit, hydrationErr); err != nil {
+		klog.Errorf("failed to complete the rendering execution for commit %q: %v", commit, err)
+	}
+	status := RenderStatus{Commit: commit, State: RenderSucceeded}
+	if hydrationErr != nil {
+		status.State = RenderFailed
+		status.Error = newHydrationErrorPayload(hydrationErr)
+	}
+	h.signalStatus(status)
 }
 
-// runHydrate runs `kustomize build` on the source configs.
+// signalStatus updates the render status served to the Reconciler.
+func (h *Hydrator) signalStatus(status RenderStatus) {
+	if h.signals != nil {
+		h.signals.setStatus(status)
+	}
+}
+
+// runHydrate runs `kustomize build` on the source configs, followed by the
+// KRM function pipeline declared in the Kptfile, if any and enabled.
+// Without Kustomization, the function pipeline is run on the source configs.
+// If the rendering inputs of the source commit are unchanged since the last
+// rendered commit, the hydrated configs of the last rendered commit are reused
+// instead.
 func (h *Hydrator) runHydrate(sourceCommit string, syncPath cmpath.Absolute) HydrationError {
 	newHydratedDir := h.HydratedRoot.Join(cmpath.RelativeOS(sourceCommit))
 	dest := newHydratedDir.Join(h.SyncDir).OSPath()
 
 	osSyncPath := syncPath.OSPath()
-	if err := kustomizeBuild(osSyncPath, dest, true); err != nil {
-		return err
+	// The content of the sync directory is hashed before rendering, which
+	// may write to it.
+	dirHash, err := syncDirHash(osSyncPath)
+	if err != nil {
+		klog.Warningf("unable to hash the source configs in %s, rendering without cache: %v", osSyncPath, err)
+	}
+	var newCache *renderCache
+	if dirHash != "" {
+		newCache = h.renderFromCache(sourceCommit, dirHash, newHydratedDir.OSPath())
+	}
+	if newCache != nil {
+		klog.Infof("Reusing the rendered configs for commit %s because the rendering inputs are unchanged", sourceCommit)
+	} else {
+		fSys := newRecordingFS()
+		if err := h.render(fSys, osSyncPath, dest); err != nil {
+			return err
+		}
+		if dirHash != "" {
+			newCache = h.newRenderCache(fSys, sourceCommit, dirHash, osSyncPath)
+		}
 	}
 
 	newCommit, err := ComputeCommit(h.sourcePath())
@@ -148,10 +251,134 @@ func (h *Hydrator) runHydrate(sourceCommit string, syncPath cmpath.Absolute) Hyd
 	if err := updateSymlink(h.HydratedRoot.OSPath(), h.HydratedLink, newHydratedDir.OSPath()); err != nil {
 		return NewInternalError(fmt.Errorf("unable to update the symbolic link to %s: %w", newHydratedDir.OSPath(), err))
 	}
+	h.updateRenderCache(newCache)
 	klog.Infof("Successfully rendered %s for commit %s", osSyncPath, sourceCommit)
 	return nil
 }
 
+// render renders the source configs in the sync directory to the dest
+// directory, reading the Kustomize inputs from fSys.
+func (h *Hydrator) render(fSys filesys.FileSystem, syncPath, dest string) HydrationError {
+	kustomize, err := needsKustomize(syncPath)
+	if err != nil {
+		return NewInternalError(fmt.Errorf("unable to check if rendering is needed for the source directory: %s: %w", syncPath, err))
+	}
+	pipeline, err := h.readPipeline(syncPath)
+	if err != nil {
+		return NewActionableError(err)
+	}
+	input := syncPath
+	if kustomize {
+		if h.HelmChartCache != "" || h.HelmCredentials != nil {
+			if err := prepareHelmCharts(syncPath, h.HelmChartCache, h.HelmCredentials); err != nil {
+				return NewActionableError(err)
+			}
+		}
+		if err := kustomizeBuild(fSys, syncPath, dest, true); err != nil {
+			return err
+		}
+		input = dest
+	}
+	if pipeline != nil {
+		if err := runFunctions(pipeline, syncPath, input, dest, h.FunctionDirs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readPipeline reads the KRM function pipeline from the Kptfile in the sync
+// directory. It returns nil if the function pipeline is not enabled.
+func (h *Hydrator) readPipeline(syncPath string) (*Pipeline, error) {
+	if !h.FunctionPipelineEnabled {
+		return nil, nil
+	}
+	return readPipeline(syncPath)
+}
+
+// renderCachePath returns the path of the rendering cache.
+func (h *Hydrator) renderCachePath() string {
+	return h.HydratedRoot.Join(cmpath.RelativeSlash(RenderCacheFile)).OSPath()
+}
+
+// renderFromCache copies the hydrated configs of the last rendered commit to
+// the hydrated directory of the source commit, if their rendering inputs have
+// the same content hash.
+// It returns the rendering cache of the source commit, or nil if the hydrated
+// configs of the last rendered commit can not be reused.
+func (h *Hydrator) renderFromCache(sourceCommit, dirHash, newHydratedDir string) *renderCache {
+	cache := readRenderCache(h.renderCachePath())
+	if cache == nil || cache.Commit == sourceCommit {
+		return nil
+	}
+	sourceDir, err := h.sourcePath().EvalSymlinks()
+	if err != nil {
+		return nil
+	}
+	hash, err := renderInputsHash(dirHash, sourceDir.OSPath(), cache.Files)
+	if err != nil {
+		klog.Warningf("unable to hash the rendering inputs of commit %s: %v", sourceCommit, err)
+		return nil
+	}
+	if hash != cache.Hash {
+		return nil
+	}
+	cachedDir := h.HydratedRoot.Join(cmpath.RelativeOS(cache.Commit)).OSPath()
+	if _, err := os.Stat(cachedDir); err != nil {
+		return nil
+	}
+	if err := os.RemoveAll(newHydratedDir); err != nil {
+		klog.Warningf("unable to remove the hydrated directory %s: %v", newHydratedDir, err)
+		return nil
+	}
+	if err := copyDir(cachedDir, newHydratedDir); err != nil {
+		klog.Warningf("unable to copy the rendered configs of commit %s: %v", cache.Commit, err)
+		mustDeleteOutput(err, newHydratedDir)
+		return nil
+	}
+	return &renderCache{Commit: sourceCommit, Hash: hash, Files: cache.Files}
+}
+
+// newRenderCache returns the rendering cache of the source commit, from the
+// files read during rendering. It returns nil if the rendering can not be
+// cached.
+func (h *Hydrator) newRenderCache(fSys *recordingFS, sourceCommit, dirHash, syncPath string) *renderCache {
+	sourceDir, err := h.sourcePath().EvalSymlinks()
+	if err != nil {
+		return nil
+	}
+	files, cacheable, err := fSys.inputFiles(sourceDir.OSPath(), syncPath)
+	if err != nil {
+		klog.Warningf("unable to list the rendering inputs of commit %s: %v", sourceCommit, err)
+		return nil
+	}
+	if !cacheable {
+		klog.V(3).Infof("The rendered configs of commit %s are not cached because some rendering inputs are not in the source", sourceCommit)
+		return nil
+	}
+	hash, err := renderInputsHash(dirHash, sourceDir.OSPath(), files)
+	if err != nil {
+		klog.Warningf("unable to hash the rendering inputs of commit %s: %v", sourceCommit, err)
+		return nil
+	}
+	return &renderCache{Commit: sourceCommit, Hash: hash, Files: files}
+}
+
+// updateRenderCache writes the rendering cache, or deletes it if the
+// rendered configs can not be cached.
+func (h *Hydrator) updateRenderCache(cache *renderCache) {
+	path := h.renderCachePath()
+	if cache == nil {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			klog.Warningf("unable to delete the rendering cache %s: %v", path, err)
+		}
+		return
+	}
+	if err := writeRenderCache(path, cache); err != nil {
+		klog.Warning(err)
+	}
+}
+
 // ComputeCommit returns the computed commit from given sourceDir, or error
 // if the sourceDir fails symbolic link evaluation
 func ComputeCommit(sourceDir cmpath.Absolute) (string, error) {
@@ -172,11 +399,15 @@ func (h *Hydrator) sourcePath() cmpath.Absolute {
 // hydrate renders the source git repo to hydrated configs.
 func (h *Hydrator) hydrate(sourceCommit string, syncPath cmpath.Absolute) HydrationError {
 	osSyncPath := syncPath.OSPath()
-	hydrate, err := needsKustomize(osSyncPath)
+	kustomize, err := needsKustomize(osSyncPath)
 	if err != nil {
 		return NewInternalError(fmt.Errorf("unable to check if rendering is needed for the source directory: %s: %w", osSyncPath, err))
 	}
-	if !hydrate {
+	pipeline, err := h.readPipeline(osSyncPath)
+	if err != nil {
+		return NewActionableError(err)
+	}
+	if !kustomize {
 		found, err := hasKustomizeSubdir(osSyncPath)
 		if err != nil {
 			return NewInternalError(err)
@@ -186,7 +417,9 @@ func (h *Hydrator) hydrate(sourceCommit string, syncPath cmpath.Absolute) Hydrat
 				"To fix, either add kustomization.yaml in the sync directory to trigger the rendering process, "+
 				"or remove kustomizaiton.yaml from all sub directories to skip rendering.", osSyncPath))
 		}
-		klog.V(5).Infof("no rendering is needed because of no Kustomization config file in the source configs with commit %s", sourceCommit)
+	}
+	if !kustomize && pipeline == nil {
+		klog.V(5).Infof("no rendering is needed because of no Kustomization config file or function pipeline in the source configs with commit %s", sourceCommit)
 		if err := os.RemoveAll(h.HydratedRoot.OSPath()); err != nil {
 			return NewInternalError(err)
 		}
@@ -213,10 +446,9 @@ func (h *Hydrator) rehydrateOnError(prevErr HydrationError, prevSrcCommit string
 		return prevErr
 	}
 	klog.Infof("retry rendering commit %s", prevSrcCommit)
+	h.signalStatus(RenderStatus{Commit: prevSrcCommit, State: RenderInProgress})
 	hydrationErr := h.runHydrate(prevSrcCommit, prevSyncPath)
-	if err := h.complete(prevSrcCommit, hydrationErr); err != nil {
-		klog.Errorf("failed to complete the re-rendering execution for commit %q: %v", prevSrcCommit, err)
-	}
+	h.completeAndSignal(prevSrcCommit, hydrationErr)
 	return hydrationErr
 }
 
@@ -327,10 +559,7 @@ func exportError(commit, root, errorFile string, hydrationError HydrationError)
 		}
 	}()
 
-	payload := HydrationErrorPayload{
-		Code:  hydrationError.Code(),
-		Error: hydrationError.Error(),
-	}
+	payload := newHydrationErrorPayload(hydrationError)
 
 	jb, err := json.Marshal(payload)
 	if err != nil {
@@ -397,6 +626,10 @@ func SourceCommitAndSyncPath(sourceType configsync.SourceType, sourcePath cmpath
 		containerName = reconcilermanager.GitSync
 	case configsync.HelmSource:
 		containerName = reconcilermanager.HelmSync
+	case configsync.HTTPSource:
+		containerName = reconcilermanager.HTTPSync
+	case configsync.BucketSource:
+		containerName = reconcilermanager.BucketSync
 	}
 
 	content, err := os.ReadFile(errFilePath)
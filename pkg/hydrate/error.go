@@ -75,3 +75,14 @@ type HydrationErrorPayload struct {
 	// Error is the message of the hydration error.
 	Error string
 }
+
+// newHydrationErrorPayload returns the payload of the hydration error.
+// The error code and documentation link of a status.Error are left out of the
+// message, because the Reconciler adds them again.
+func newHydrationErrorPayload(err HydrationError) *HydrationErrorPayload {
+	payload := &HydrationErrorPayload{Code: err.Code(), Error: err.Error()}
+	if statusErr, ok := err.(status.Error); ok {
+		payload.Error = statusErr.Body()
+	}
+	return payload
+}
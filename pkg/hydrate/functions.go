@@ -0,0 +1,376 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/filters/labels"
+	"sigs.k8s.io/kustomize/api/filters/namespace"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/fn/runtime/exec"
+	"sigs.k8s.io/kustomize/kyaml/fn/runtime/runtimeutil"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/kio/filters"
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Kptfile is the name of the file declaring the KRM function pipeline
+	// in the sync directory.
+	Kptfile = "Kptfile"
+	// DefaultFunctionDir is the directory of the exec KRM functions shipped
+	// in the hydration-controller image.
+	DefaultFunctionDir = "/krm-functions"
+
+	setNamespaceFunction = "set-namespace"
+	setLabelsFunction    = "set-labels"
+)
+
+// builtinFunctionRegistries are the registries of the KRM function catalog.
+// The catalog functions with a builtin implementation are run in process,
+// instead of in a container.
+var builtinFunctionRegistries = []string{
+	"gcr.io/kpt-fn/",
+	"ghcr.io/kptdev/krm-functions-catalog/",
+}
+
+// kptfile is the subset of a Kptfile used by the hydration-controller.
+type kptfile struct {
+	Pipeline *Pipeline `json:"pipeline,omitempty"`
+}
+
+// Pipeline is the KRM function pipeline run on the rendered resources.
+type Pipeline struct {
+	// Mutators are run in order, and may modify, add or remove resources.
+	Mutators []Function `json:"mutators,omitempty"`
+	// Validators are run in order after the mutators, and may only fail
+	// the rendering. Their output is discarded.
+	Validators []Function `json:"validators,omitempty"`
+}
+
+// Function is a KRM function of the Pipeline.
+type Function struct {
+	// Name is the name of the function, used in errors.
+	Name string `json:"name,omitempty"`
+	// Image is the image of a KRM function catalog function. Only the
+	// set-namespace and set-labels functions are supported, and they are
+	// run in process.
+	Image string `json:"image,omitempty"`
+	// Exec is the path to an executable KRM function. It must be in one of
+	// the function directories of the hydration-controller. A relative path
+	// is looked up in the function directories.
+	Exec string `json:"exec,omitempty"`
+	// ConfigPath is the path of the function config, relative to the sync
+	// directory.
+	ConfigPath string `json:"configPath,omitempty"`
+	// ConfigMap is the data of the ConfigMap used as the function config.
+	ConfigMap map[string]string `json:"configMap,omitempty"`
+}
+
+func (f Function) String() string {
+	switch {
+	case f.Name != "":
+		return f.Name
+	case f.Exec != "":
+		return f.Exec
+	default:
+		return f.Image
+	}
+}
+
+// readPipeline reads the KRM function pipeline from the Kptfile in the
+// directory. It returns nil if the Kptfile doesn't exist, or declares no
+// function.
+func readPipeline(dir string) (*Pipeline, error) {
+	path := filepath.Join(dir, Kptfile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to read %s: %w", path, err)
+	}
+	kf := &kptfile{}
+	if err := yaml.Unmarshal(data, kf); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	if kf.Pipeline == nil || len(kf.Pipeline.Mutators)+len(kf.Pipeline.Validators) == 0 {
+		return nil, nil
+	}
+	return kf.Pipeline, nil
+}
+
+// HasFunctionPipeline checks if the Kptfile in the directory declares a KRM
+// function pipeline.
+func HasFunctionPipeline(dir string) (bool, error) {
+	p, err := readPipeline(dir)
+	return p != nil, err
+}
+
+// runFunctions runs the KRM function pipeline on the resources in the input
+// directory, and writes the resources to the output directory.
+// The input and output directories can be the same, in which case the
+// directory is replaced with the output of the pipeline.
+// The function configs are read from the sync directory.
+func runFunctions(pipeline *Pipeline, syncDir, input, output string, functionDirs []string) HydrationError {
+	mutators, err := functionFilters(pipeline.Mutators, syncDir, functionDirs, true)
+	if err != nil {
+		return NewActionableError(err)
+	}
+	validators, err := functionFilters(pipeline.Validators, syncDir, functionDirs, false)
+	if err != nil {
+		return NewActionableError(err)
+	}
+
+	nodes, err := kio.LocalPackageReader{
+		PackagePath:        input,
+		IncludeSubpackages: true,
+		FileSkipFunc:       isHiddenPath,
+	}.Read()
+	if err != nil {
+		return NewActionableError(fmt.Errorf("unable to read the resources in %s: %w", input, err))
+	}
+	for i, f := range mutators {
+		nodes, err = f.Filter(nodes)
+		if err != nil {
+			return NewActionableError(functionError("mutator", pipeline.Mutators[i], f, err))
+		}
+	}
+	for i, f := range validators {
+		if _, err := f.Filter(copyNodes(nodes)); err != nil {
+			return NewActionableError(functionError("validator", pipeline.Validators[i], f, err))
+		}
+	}
+	// The function configs are never synced.
+	nodes, err = (&filters.IsLocalConfig{}).Filter(nodes)
+	if err != nil {
+		return NewInternalError(err)
+	}
+
+	if _, err := os.Stat(output); err == nil {
+		mustDeleteOutput(err, output)
+	}
+	if err := os.MkdirAll(output, os.FileMode(0755)); err != nil {
+		return NewInternalError(fmt.Errorf("unable to make directory: %s: %w", output, err))
+	}
+	if err := (kio.LocalPackageWriter{PackagePath: output}).Write(nodes); err != nil {
+		mustDeleteOutput(err, output)
+		return NewInternalError(fmt.Errorf("unable to write the rendered resources to %s: %w", output, err))
+	}
+	return nil
+}
+
+// functionFilters returns the filters running the functions.
+func functionFilters(fns []Function, syncDir string, functionDirs []string, mutator bool) ([]kio.Filter, error) {
+	var result []kio.Filter
+	for _, fn := range fns {
+		config, err := functionConfig(fn, syncDir)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case fn.Exec != "" && fn.Image != "":
+			return nil, fmt.Errorf("function %s must set only one of exec or image", fn)
+		case fn.Exec != "":
+			path, err := resolveExec(fn.Exec, functionDirs)
+			if err != nil {
+				return nil, fmt.Errorf("function %s: %w", fn, err)
+			}
+			result = append(result, &exec.Filter{
+				Path:       path,
+				WorkingDir: syncDir,
+				FunctionFilter: runtimeutil.FunctionFilter{
+					FunctionConfig: config,
+					GlobalScope:    true,
+				},
+			})
+		case fn.Image != "":
+			f, err := builtinFunction(fn, config, mutator)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, f)
+		default:
+			return nil, fmt.Errorf("function %s must set one of exec or image", fn)
+		}
+	}
+	return result, nil
+}
+
+// functionConfig returns the function config of the function. It defaults
+// to an empty ConfigMap.
+func functionConfig(fn Function, syncDir string) (*kyaml.RNode, error) {
+	if fn.ConfigPath != "" && len(fn.ConfigMap) > 0 {
+		return nil, fmt.Errorf("function %s must set only one of configPath or configMap", fn)
+	}
+	if fn.ConfigPath != "" {
+		if filepath.IsAbs(fn.ConfigPath) {
+			return nil, fmt.Errorf("function %s: configPath must be a relative path: %s", fn, fn.ConfigPath)
+		}
+		// Symlinks are resolved before the containment check, so that a
+		// symlink can't point the configPath out of the sync directory.
+		realDir, err := filepath.EvalSymlinks(syncDir)
+		if err != nil {
+			return nil, fmt.Errorf("function %s: unable to resolve the sync directory: %w", fn, err)
+		}
+		path, err := filepath.EvalSymlinks(filepath.Join(syncDir, fn.ConfigPath))
+		if err != nil {
+			return nil, fmt.Errorf("function %s: unable to read the function config %s: %w", fn, fn.ConfigPath, err)
+		}
+		if !isWithin(path, realDir) {
+			return nil, fmt.Errorf("function %s: configPath must be in the sync directory: %s", fn, fn.ConfigPath)
+		}
+		config, err := kyaml.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("function %s: unable to read the function config %s: %w", fn, fn.ConfigPath, err)
+		}
+		return config, nil
+	}
+	config, err := kyaml.FromMap(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name": "function-input",
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(fn.ConfigMap) > 0 {
+		config.SetDataMap(fn.ConfigMap)
+	}
+	return config, nil
+}
+
+// resolveExec returns the path of the executable function, which must be in
+// one of the function directories.
+func resolveExec(name string, functionDirs []string) (string, error) {
+	var candidates []string
+	if filepath.IsAbs(name) {
+		candidates = []string{name}
+	} else {
+		for _, dir := range functionDirs {
+			candidates = append(candidates, filepath.Join(dir, name))
+		}
+	}
+	for _, candidate := range candidates {
+		path, err := filepath.EvalSymlinks(candidate)
+		if err != nil {
+			continue
+		}
+		for _, dir := range functionDirs {
+			realDir, err := filepath.EvalSymlinks(dir)
+			if err != nil || !isWithin(path, realDir) {
+				continue
+			}
+			fi, err := os.Stat(path)
+			if err != nil || !fi.Mode().IsRegular() {
+				continue
+			}
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("exec %q is not an executable in the allowed function directories %s", name, strings.Join(functionDirs, ", "))
+}
+
+// builtinFunction returns the in-process implementation of a KRM function
+// catalog function.
+func builtinFunction(fn Function, config *kyaml.RNode, mutator bool) (kio.Filter, error) {
+	name := catalogFunctionName(fn.Image)
+	if name != setNamespaceFunction && name != setLabelsFunction {
+		return nil, fmt.Errorf("function %s: image %q is not supported because functions can not run in containers, use %s or %s from the function catalog, or an exec function",
+			fn, fn.Image, setNamespaceFunction, setLabelsFunction)
+	}
+	if !mutator {
+		return nil, fmt.Errorf("function %s: %s is a mutator, not a validator", fn, name)
+	}
+	data := config.GetDataMap()
+	if name == setNamespaceFunction {
+		if data["namespace"] == "" {
+			return nil, fmt.Errorf("function %s: the namespace field of the function config is required", fn)
+		}
+		return namespace.Filter{
+			Namespace: data["namespace"],
+			FsSlice:   types.FsSlice{{Path: "metadata/namespace", CreateIfNotPresent: true}},
+		}, nil
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("function %s: the function config must declare at least one label", fn)
+	}
+	fsSlice, err := setLabelsFsSlice()
+	if err != nil {
+		return nil, fmt.Errorf("function %s: invalid label field specs: %w", fn, err)
+	}
+	return labels.Filter{
+		Labels:  data,
+		FsSlice: fsSlice,
+	}, nil
+}
+
+// catalogFunctionName returns the name of the KRM function catalog function
+// of the image, or the empty string if the image isn't from the catalog.
+func catalogFunctionName(image string) string {
+	for _, registry := range builtinFunctionRegistries {
+		if !strings.HasPrefix(image, registry) {
+			continue
+		}
+		name := strings.TrimPrefix(image, registry)
+		if i := strings.IndexAny(name, ":@"); i >= 0 {
+			name = name[:i]
+		}
+		return name
+	}
+	return ""
+}
+
+// functionError returns the error of a failed function, including the
+// results reported by the function.
+func functionError(kind string, fn Function, f kio.Filter, err error) error {
+	if ef, ok := f.(*exec.Filter); ok && ef.Results != nil {
+		if results, rErr := ef.Results.String(); rErr == nil {
+			return fmt.Errorf("%s %s failed: %w\n%s", kind, fn, err, results)
+		}
+	}
+	return fmt.Errorf("%s %s failed: %w", kind, fn, err)
+}
+
+func copyNodes(nodes []*kyaml.RNode) []*kyaml.RNode {
+	result := make([]*kyaml.RNode, len(nodes))
+	for i, n := range nodes {
+		result[i] = n.Copy()
+	}
+	return result
+}
+
+// isHiddenPath returns true if any element of the relative path is hidden.
+func isHiddenPath(relPath string) bool {
+	for _, elem := range strings.Split(filepath.ToSlash(relPath), "/") {
+		if strings.HasPrefix(elem, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// isWithin returns true if the path is the directory, or is in the directory.
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
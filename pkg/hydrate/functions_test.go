@@ -0,0 +1,312 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const functionResources = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: reader
+`
+
+func TestRunFunctions(t *testing.T) {
+	functionDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(functionDir, "deny-all"),
+		[]byte("#!/bin/sh\necho 'denied' >&2\nexit 1\n"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(functionDir, "passthrough"),
+		[]byte("#!/bin/sh\ncat\n"), 0755))
+	otherDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(otherDir, "passthrough"),
+		[]byte("#!/bin/sh\ncat\n"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(otherDir, "labels.yaml"),
+		[]byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: labels\ndata:\n  team: other\n"), 0644))
+
+	testCases := []struct {
+		name    string
+		kptfile string
+		// outsideConfig links outside.yaml to a function config outside of
+		// the sync directory.
+		outsideConfig bool
+		wantErr       string
+		wantOutputs   map[string]string
+	}{
+		{
+			name: "builtin mutators",
+			kptfile: `apiVersion: kpt.dev/v1
+kind: Kptfile
+pipeline:
+  mutators:
+  - image: gcr.io/kpt-fn/set-namespace:v0.4.1
+    configMap:
+      namespace: bookstore
+  - image: ghcr.io/kptdev/krm-functions-catalog/set-labels:latest
+    configPath: labels.yaml
+  validators:
+  - exec: passthrough
+`,
+			wantOutputs: map[string]string{
+				"resources.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: bookstore
+  labels:
+    team: bookstore
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: reader
+  labels:
+    team: bookstore
+`,
+			},
+		},
+		{
+			name: "failing validator",
+			kptfile: `pipeline:
+  validators:
+  - name: deny
+    exec: deny-all
+`,
+			wantErr: "validator deny failed",
+		},
+		{
+			name: "exec outside of the function directories",
+			kptfile: `pipeline:
+  mutators:
+  - exec: ` + filepath.Join(otherDir, "passthrough") + `
+`,
+			wantErr: "is not an executable in the allowed function directories",
+		},
+		{
+			name: "exec escaping the function directories",
+			kptfile: `pipeline:
+  mutators:
+  - exec: ../` + filepath.Base(otherDir) + `/passthrough
+`,
+			wantErr: "is not an executable in the allowed function directories",
+		},
+		{
+			name: "container function",
+			kptfile: `pipeline:
+  mutators:
+  - image: gcr.io/kpt-fn/apply-setters:v0.2
+`,
+			wantErr: "functions can not run in containers",
+		},
+		{
+			name: "builtin validator",
+			kptfile: `pipeline:
+  validators:
+  - image: gcr.io/kpt-fn/set-labels:v0.2
+    configMap:
+      team: bookstore
+`,
+			wantErr: "set-labels is a mutator, not a validator",
+		},
+		{
+			name: "configPath symlink escaping the sync directory",
+			kptfile: `pipeline:
+  mutators:
+  - image: gcr.io/kpt-fn/set-labels:v0.2
+    configPath: outside.yaml
+`,
+			outsideConfig: true,
+			wantErr:       "configPath must be in the sync directory",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			syncDir := t.TempDir()
+			files := map[string]string{
+				Kptfile:          tc.kptfile,
+				"resources.yaml": functionResources,
+				"labels.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: labels
+  annotations:
+    config.kubernetes.io/local-config: "true"
+data:
+  team: bookstore
+`,
+			}
+			for name, content := range files {
+				require.NoError(t, os.WriteFile(filepath.Join(syncDir, name), []byte(content), 0644))
+			}
+			if tc.outsideConfig {
+				require.NoError(t, os.Symlink(filepath.Join(otherDir, "labels.yaml"), filepath.Join(syncDir, "outside.yaml")))
+			}
+			output := filepath.Join(t.TempDir(), "output")
+
+			pipeline, err := readPipeline(syncDir)
+			require.NoError(t, err)
+			require.NotNil(t, pipeline)
+			hydrationErr := runFunctions(pipeline, syncDir, syncDir, output, []string{functionDir})
+			if tc.wantErr != "" {
+				require.Error(t, hydrationErr)
+				assert.Equal(t, status.ActionableHydrationErrorCode, hydrationErr.Code())
+				assert.Contains(t, hydrationErr.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, hydrationErr)
+			entries, err := os.ReadDir(output)
+			require.NoError(t, err)
+			outputs := map[string]string{}
+			for _, entry := range entries {
+				content, err := os.ReadFile(filepath.Join(output, entry.Name()))
+				require.NoError(t, err)
+				outputs[entry.Name()] = string(content)
+			}
+			assert.Equal(t, tc.wantOutputs, outputs)
+		})
+	}
+}
+
+func TestHasFunctionPipeline(t *testing.T) {
+	testCases := []struct {
+		name    string
+		kptfile string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "no Kptfile",
+		},
+		{
+			name:    "Kptfile without pipeline",
+			kptfile: "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: pkg\n",
+		},
+		{
+			name:    "Kptfile with pipeline",
+			kptfile: "pipeline:\n  mutators:\n  - exec: fn\n",
+			want:    true,
+		},
+		{
+			name:    "invalid Kptfile",
+			kptfile: "pipeline: [",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tc.kptfile != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, Kptfile), []byte(tc.kptfile), 0644))
+			}
+			got, err := HasFunctionPipeline(dir)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestHydratorReadPipeline(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, Kptfile), []byte("pipeline:\n  mutators:\n  - exec: fn\n"), 0644))
+
+	// Kptfiles are ignored unless the function pipeline is enabled.
+	pipeline, err := (&Hydrator{}).readPipeline(dir)
+	require.NoError(t, err)
+	assert.Nil(t, pipeline)
+
+	pipeline, err = (&Hydrator{FunctionPipelineEnabled: true}).readPipeline(dir)
+	require.NoError(t, err)
+	assert.NotNil(t, pipeline)
+}
+
+func TestSetLabelsFunction(t *testing.T) {
+	nodes, err := (&kio.ByteReader{Reader: strings.NewReader(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  selector:
+    app: web
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+`), OmitReaderAnnotations: true}).Read()
+	require.NoError(t, err)
+	config, err := kyaml.Parse(`apiVersion: v1
+kind: ConfigMap
+data:
+  team: bookstore
+`)
+	require.NoError(t, err)
+	f, err := builtinFunction(Function{Image: "gcr.io/kpt-fn/set-labels:v0.2"}, config, true)
+	require.NoError(t, err)
+	nodes, err = f.Filter(nodes)
+	require.NoError(t, err)
+
+	want := map[string]string{"app": "web", "team": "bookstore"}
+	fieldLabels := func(node *kyaml.RNode, path ...string) map[string]string {
+		field, err := node.Pipe(kyaml.Lookup(path...))
+		require.NoError(t, err)
+		require.NotNil(t, field, "missing %v", path)
+		labels := map[string]string{}
+		require.NoError(t, field.VisitFields(func(n *kyaml.MapNode) error {
+			labels[n.Key.YNode().Value] = n.Value.YNode().Value
+			return nil
+		}))
+		return labels
+	}
+	deployment, service, configMap := nodes[0], nodes[1], nodes[2]
+	assert.Equal(t, map[string]string{"team": "bookstore"}, deployment.GetLabels())
+	assert.Equal(t, want, fieldLabels(deployment, "spec", "selector", "matchLabels"))
+	assert.Equal(t, want, fieldLabels(deployment, "spec", "template", "metadata", "labels"))
+	assert.Equal(t, want, fieldLabels(service, "spec", "selector"))
+	assert.Equal(t, map[string]string{"team": "bookstore"}, configMap.GetLabels())
+	assert.Nil(t, configMap.Field("spec"))
+}
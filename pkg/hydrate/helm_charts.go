@@ -0,0 +1,330 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/yaml"
+)
+
+// HelmCredentials are the credentials used to pull the Helm charts of the
+// kustomizations from private chart repositories and OCI registries.
+type HelmCredentials struct {
+	// Username is the username of the chart repository.
+	Username string
+	// Password is the password or token of the chart repository.
+	Password string
+	// Hosts are the hosts, with the port if any, of the chart repositories
+	// the credentials are used for, so that they are never sent to the chart
+	// repositories of third parties.
+	Hosts []string
+	// Repo is the source repository of the sync. When Hosts is empty, the
+	// credentials are only used for the charts on the same host.
+	Repo string
+}
+
+// forChart returns the credentials if they can be used to pull the chart,
+// and nil otherwise.
+func (c *HelmCredentials) forChart(chart types.HelmChart) *HelmCredentials {
+	if c == nil || c.Username == "" {
+		return nil
+	}
+	chartHost := repoHost(chart.Repo)
+	if chartHost == "" {
+		return nil
+	}
+	hosts := c.Hosts
+	if len(hosts) == 0 {
+		hosts = []string{c.Repo}
+	}
+	for _, host := range hosts {
+		if repoHost(host) == chartHost {
+			return c
+		}
+	}
+	return nil
+}
+
+// ParseHelmHosts parses the comma-separated hosts of the chart repositories,
+// ignoring the whitespace and the empty entries.
+func ParseHelmHosts(hosts string) []string {
+	var parsed []string
+	for _, host := range strings.Split(hosts, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			parsed = append(parsed, host)
+		}
+	}
+	return parsed
+}
+
+// repoHost returns the lower-cased host, with the port if any, of a
+// repository URL, an OCI image reference, or a scp-like Git URL, like
+// git@github.com:org/repo.
+func repoHost(repo string) string {
+	if u, err := url.Parse(repo); err == nil && u.Host != "" {
+		return strings.ToLower(u.Host)
+	}
+	host, _, _ := strings.Cut(repo, "/")
+	if at := strings.LastIndex(host, "@"); at >= 0 {
+		host = host[at+1:]
+	}
+	if name, port, found := strings.Cut(host, ":"); found && !isDigits(port) {
+		// The path of a scp-like Git URL.
+		host = name
+	}
+	return strings.ToLower(host)
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// helmPull pulls and untars the chart to the dest directory.
+// It is a variable so that it can be replaced in tests.
+var helmPull = pullHelmChart
+
+// prepareHelmCharts pulls the Helm charts of the kustomizations in the sync
+// directory, and puts them in their chart home, where the kustomize Helm
+// inflator finds them instead of pulling them again.
+//
+// The charts with a pinned version are kept in the chart cache directory, if
+// set, so that they are pulled only once and later renders work offline. The charts
+// without a pinned version are pulled on every render.
+// The credentials, if set, authenticate the pulls of the charts on the host of
+// the source repository.
+func prepareHelmCharts(syncDir, cacheDir string, creds *HelmCredentials) error {
+	return filepath.WalkDir(syncDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !HasKustomization(d.Name()) {
+			return nil
+		}
+		return prepareKustomizationCharts(path, cacheDir, creds)
+	})
+}
+
+// prepareKustomizationCharts pulls the remote Helm charts of the
+// kustomization into its chart home.
+func prepareKustomizationCharts(kustomizationPath, cacheDir string, creds *HelmCredentials) error {
+	data, err := os.ReadFile(kustomizationPath)
+	if err != nil {
+		return err
+	}
+	k := &types.Kustomization{}
+	if err := yaml.Unmarshal(data, k); err != nil {
+		// kustomize reports the invalid kustomization.
+		klog.V(3).Infof("skip pulling the Helm charts of the invalid kustomization %s: %v", kustomizationPath, err)
+		return nil
+	}
+	k.FixKustomization()
+	root := filepath.Dir(kustomizationPath)
+	chartHome := types.HelmDefaultHome
+	if k.HelmGlobals != nil && k.HelmGlobals.ChartHome != "" {
+		chartHome = k.HelmGlobals.ChartHome
+	}
+	if !filepath.IsAbs(chartHome) {
+		chartHome = filepath.Join(root, chartHome)
+	}
+	for _, chart := range k.HelmCharts {
+		if chart.Repo == "" || chart.Name == "" {
+			// Local charts are read from the chart home.
+			continue
+		}
+		if err := prepareHelmChart(chart, chartHome, cacheDir, creds); err != nil {
+			return fmt.Errorf("unable to pull the Helm chart %q from %q: %w", chart.Name, chart.Repo, err)
+		}
+	}
+	return nil
+}
+
+// prepareHelmChart puts the remote chart in the directory where the kustomize
+// Helm inflator looks for it: <chartHome>/<name>-<version>/<name> for charts
+// with a pinned version, and <chartHome>/<name> otherwise.
+func prepareHelmChart(chart types.HelmChart, chartHome, cacheDir string, creds *HelmCredentials) error {
+	if chart.Version == "" {
+		dest := filepath.Join(chartHome, chart.Name)
+		if _, err := os.Stat(dest); err == nil {
+			return nil
+		}
+		return helmPull(chart, chartHome, creds)
+	}
+	dest := filepath.Join(chartHome, fmt.Sprintf("%s-%s", chart.Name, chart.Version))
+	if _, err := os.Stat(filepath.Join(dest, chart.Name)); err == nil {
+		return nil
+	}
+	if cacheDir == "" {
+		return helmPull(chart, dest, creds)
+	}
+	cached := filepath.Join(cacheDir, helmChartCacheKey(chart))
+	if _, err := os.Stat(filepath.Join(cached, chart.Name)); err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+		if err := pullToCache(chart, cacheDir, cached, creds); err != nil {
+			return err
+		}
+	} else {
+		klog.V(3).Infof("using the cached Helm chart %s:%s from %s", chart.Name, chart.Version, chart.Repo)
+	}
+	if err := os.MkdirAll(dest, os.FileMode(0755)); err != nil {
+		return err
+	}
+	return copyDir(filepath.Join(cached, chart.Name), filepath.Join(dest, chart.Name))
+}
+
+// pullToCache pulls the chart into a temporary directory of the cache, and
+// renames it to the cached directory, so that an interrupted pull never
+// leaves a partial chart in the cache.
+func pullToCache(chart types.HelmChart, cacheDir, cached string, creds *HelmCredentials) error {
+	if err := os.MkdirAll(cacheDir, os.FileMode(0755)); err != nil {
+		return fmt.Errorf("unable to create the Helm chart cache %s: %w", cacheDir, err)
+	}
+	tmpDir, err := os.MkdirTemp(cacheDir, ".pull-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			klog.Warningf("unable to delete the temporary directory %s: %v", tmpDir, err)
+		}
+	}()
+	if err := helmPull(chart, tmpDir, creds); err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, chart.Name)); err != nil {
+		return fmt.Errorf("the pulled chart has no %s directory: %w", chart.Name, err)
+	}
+	if err := os.RemoveAll(cached); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(cached), os.FileMode(0755)); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpDir, cached); err != nil {
+		return fmt.Errorf("unable to add the chart to the Helm chart cache: %w", err)
+	}
+	klog.Infof("cached the Helm chart %s:%s from %s", chart.Name, chart.Version, chart.Repo)
+	return nil
+}
+
+// helmChartCacheKey returns the path of the chart in the cache, relative to
+// the cache directory. The repository URL is hashed, so that it is a valid
+// directory name.
+func helmChartCacheKey(chart types.HelmChart) string {
+	repo := sha256.Sum256([]byte(strings.TrimSuffix(chart.Repo, "/")))
+	return filepath.Join(hex.EncodeToString(repo[:8]), chart.Name, chart.Version)
+}
+
+// helmRepoName is the name of the chart repository added to the temporary
+// Helm home to pull a chart with credentials.
+const helmRepoName = "config-sync"
+
+// helmLoginArgs returns the arguments of the Helm command which stores the
+// credentials of the chart repository in the Helm home. The password is read
+// from the standard input, so that it never shows in the process arguments.
+func helmLoginArgs(chart types.HelmChart, creds *HelmCredentials) []string {
+	if strings.HasPrefix(chart.Repo, "oci://") {
+		return []string{"registry", "login", repoHost(chart.Repo), "--username", creds.Username, "--password-stdin"}
+	}
+	return []string{"repo", "add", helmRepoName, chart.Repo, "--username", creds.Username, "--password-stdin"}
+}
+
+// helmPullArgs returns the arguments of `helm pull`, the same as the kustomize
+// Helm inflator. A chart repository which requires credentials is pulled
+// from the repository added by helmLoginArgs.
+func helmPullArgs(chart types.HelmChart, dest string, authenticated bool) []string {
+	args := []string{"pull", "--untar", "--untardir", dest}
+	switch {
+	case strings.HasPrefix(chart.Repo, "oci://"):
+		args = append(args, strings.TrimSuffix(chart.Repo, "/")+"/"+chart.Name)
+	case authenticated:
+		args = append(args, helmRepoName+"/"+chart.Name)
+	default:
+		args = append(args, "--repo", chart.Repo, chart.Name)
+	}
+	if chart.Version != "" {
+		args = append(args, "--version", chart.Version)
+	}
+	if chart.Devel {
+		args = append(args, "--devel")
+	}
+	return args
+}
+
+// pullHelmChart runs `helm pull` with a temporary Helm home, so that no
+// credentials or repository index are left behind.
+func pullHelmChart(chart types.HelmChart, dest string, creds *HelmCredentials) error {
+	helmHome, err := os.MkdirTemp("", "helm-home-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.RemoveAll(helmHome); err != nil {
+			klog.Warningf("unable to delete the temporary directory %s: %v", helmHome, err)
+		}
+	}()
+	creds = creds.forChart(chart)
+	if creds != nil {
+		if err := runHelm(helmHome, creds.Password, helmLoginArgs(chart, creds)...); err != nil {
+			return fmt.Errorf("helm login failed: %w", err)
+		}
+	}
+	if err := runHelm(helmHome, "", helmPullArgs(chart, dest, creds != nil)...); err != nil {
+		return fmt.Errorf("helm pull failed: %w", err)
+	}
+	return nil
+}
+
+// runHelm runs the Helm command with the Helm home, and the stdin as the
+// standard input.
+func runHelm(helmHome, stdin string, args ...string) error {
+	cmd := exec.Command(Helm, args...)
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("HELM_CONFIG_HOME=%s", helmHome),
+		fmt.Sprintf("HELM_CACHE_HOME=%s/.cache", helmHome),
+		fmt.Sprintf("HELM_DATA_HOME=%s/.data", helmHome))
+	cmd.Stdin = strings.NewReader(stdin)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
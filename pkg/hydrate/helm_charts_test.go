@@ -0,0 +1,236 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/api/types"
+)
+
+func TestPrepareHelmCharts(t *testing.T) {
+	root := t.TempDir()
+	cacheDir := filepath.Join(root, "helm-charts")
+	creds := &HelmCredentials{Username: "user", Password: "secret"}
+
+	var pulls []string
+	online := true
+	helmPull = func(chart types.HelmChart, dest string, gotCreds *HelmCredentials) error {
+		if !online {
+			return errors.New("no network")
+		}
+		assert.Equal(t, creds, gotCreds)
+		pulls = append(pulls, chart.Name+":"+chart.Version)
+		chartDir := filepath.Join(dest, chart.Name)
+		if err := os.MkdirAll(chartDir, 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(chartDir, "Chart.yaml"), []byte("name: "+chart.Name+"\n"), 0644)
+	}
+	defer func() {
+		helmPull = pullHelmChart
+	}()
+
+	// checkout writes the kustomization of the commit.
+	checkout := func(commit string) string {
+		syncDir := filepath.Join(root, commit)
+		kustomization := `helmCharts:
+- name: pinned
+  repo: oci://registry.example.com/charts
+  version: 1.0.0
+- name: unpinned
+  repo: https://charts.example.com
+- name: local
+`
+		require.NoError(t, os.MkdirAll(syncDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(syncDir, "kustomization.yaml"), []byte(kustomization), 0644))
+		return syncDir
+	}
+
+	syncDir := checkout("commit-1")
+	require.NoError(t, prepareHelmCharts(syncDir, cacheDir, creds))
+	assert.Equal(t, []string{"pinned:1.0.0", "unpinned:"}, pulls)
+	assert.FileExists(t, filepath.Join(syncDir, "charts", "pinned-1.0.0", "pinned", "Chart.yaml"))
+	assert.FileExists(t, filepath.Join(syncDir, "charts", "unpinned", "Chart.yaml"))
+	assert.NoDirExists(t, filepath.Join(syncDir, "charts", "local"))
+
+	// The pinned chart is copied from the cache, without pulling it again.
+	pulls = nil
+	syncDir = checkout("commit-2")
+	require.NoError(t, prepareHelmCharts(syncDir, cacheDir, creds))
+	assert.Equal(t, []string{"unpinned:"}, pulls)
+	assert.FileExists(t, filepath.Join(syncDir, "charts", "pinned-1.0.0", "pinned", "Chart.yaml"))
+
+	// A failed pull leaves nothing in the cache.
+	online = false
+	syncDir = checkout("commit-3")
+	require.NoError(t, os.WriteFile(filepath.Join(syncDir, "kustomization.yaml"), []byte(`helmCharts:
+- name: other
+  repo: oci://registry.example.com/charts
+  version: 2.0.0
+`), 0644))
+	err := prepareHelmCharts(syncDir, cacheDir, creds)
+	assert.ErrorContains(t, err, "no network")
+	assert.NoDirExists(t, filepath.Join(cacheDir, helmChartCacheKey(types.HelmChart{Name: "other", Repo: "oci://registry.example.com/charts", Version: "2.0.0"})))
+}
+
+func TestHelmPullArgs(t *testing.T) {
+	testCases := []struct {
+		name          string
+		chart         types.HelmChart
+		authenticated bool
+		want          []string
+	}{
+		{
+			name:  "chart repository",
+			chart: types.HelmChart{Name: "app", Repo: "https://charts.example.com", Version: "1.0.0"},
+			want:  []string{"pull", "--untar", "--untardir", "/dest", "--repo", "https://charts.example.com", "app", "--version", "1.0.0"},
+		},
+		{
+			name:          "chart repository with credentials",
+			chart:         types.HelmChart{Name: "app", Repo: "https://charts.example.com", Version: "1.0.0"},
+			authenticated: true,
+			want:          []string{"pull", "--untar", "--untardir", "/dest", "config-sync/app", "--version", "1.0.0"},
+		},
+		{
+			name:          "OCI registry with credentials",
+			chart:         types.HelmChart{Name: "app", Repo: "oci://registry.example.com/charts/", Devel: true},
+			authenticated: true,
+			want:          []string{"pull", "--untar", "--untardir", "/dest", "oci://registry.example.com/charts/app", "--devel"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, helmPullArgs(tc.chart, "/dest", tc.authenticated))
+		})
+	}
+}
+
+func TestHelmLoginArgs(t *testing.T) {
+	creds := &HelmCredentials{Username: "user", Password: "secret"}
+	assert.Equal(t,
+		[]string{"registry", "login", "registry.example.com:5000", "--username", "user", "--password-stdin"},
+		helmLoginArgs(types.HelmChart{Name: "app", Repo: "oci://registry.example.com:5000/charts"}, creds))
+	assert.Equal(t,
+		[]string{"repo", "add", "config-sync", "https://charts.example.com", "--username", "user", "--password-stdin"},
+		helmLoginArgs(types.HelmChart{Name: "app", Repo: "https://charts.example.com"}, creds))
+}
+
+func TestParseHelmHosts(t *testing.T) {
+	assert.Nil(t, ParseHelmHosts(""))
+	assert.Equal(t, []string{"charts.example.com", "registry.example.com:5000"},
+		ParseHelmHosts(" charts.example.com,, registry.example.com:5000 ,"))
+}
+
+func TestHelmCredentialsForChart(t *testing.T) {
+	testCases := []struct {
+		name      string
+		repo      string
+		hosts     []string
+		chartRepo string
+		want      bool
+	}{
+		{
+			name:      "Git repository on the same host",
+			repo:      "https://git.example.com/org/repo.git",
+			chartRepo: "https://git.example.com/charts",
+			want:      true,
+		},
+		{
+			name:      "scp-like Git URL on the same host",
+			repo:      "git@git.example.com:org/repo.git",
+			chartRepo: "https://GIT.example.com/charts",
+			want:      true,
+		},
+		{
+			name:      "OCI image on the same registry",
+			repo:      "us-docker.pkg.dev/project/configs/image:v1",
+			chartRepo: "oci://us-docker.pkg.dev/project/charts",
+			want:      true,
+		},
+		{
+			name:      "OCI image on a registry with a port",
+			repo:      "localhost:5000/configs/image",
+			chartRepo: "oci://localhost:5000/charts",
+			want:      true,
+		},
+		{
+			name:      "other host",
+			repo:      "https://git.example.com/org/repo.git",
+			chartRepo: "https://charts.example.com",
+		},
+		{
+			name:      "other port",
+			repo:      "localhost:5000/configs/image",
+			chartRepo: "oci://localhost:5001/charts",
+		},
+		{
+			name:      "no source repository",
+			chartRepo: "https://charts.example.com",
+		},
+		{
+			name:      "listed host",
+			repo:      "https://git.example.com/org/repo.git",
+			hosts:     []string{"charts.example.com", "registry.example.com:5000"},
+			chartRepo: "https://charts.example.com/stable",
+			want:      true,
+		},
+		{
+			name:      "listed host with a port",
+			repo:      "https://git.example.com/org/repo.git",
+			hosts:     []string{"charts.example.com", "registry.example.com:5000"},
+			chartRepo: "oci://registry.example.com:5000/charts",
+			want:      true,
+		},
+		{
+			name:      "listed host as a URL",
+			hosts:     []string{"https://Charts.example.com"},
+			chartRepo: "https://charts.example.com/stable",
+			want:      true,
+		},
+		{
+			name:      "source host not listed",
+			repo:      "https://git.example.com/org/repo.git",
+			hosts:     []string{"charts.example.com"},
+			chartRepo: "https://git.example.com/charts",
+		},
+		{
+			name:      "host not listed",
+			hosts:     []string{"charts.example.com"},
+			chartRepo: "https://charts.example.com.evil.io",
+		},
+		{
+			name:      "local chart",
+			hosts:     []string{"charts.example.com"},
+			chartRepo: "",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			creds := &HelmCredentials{Username: "user", Password: "secret", Hosts: tc.hosts, Repo: tc.repo}
+			got := creds.forChart(types.HelmChart{Name: "app", Repo: tc.chartRepo})
+			if tc.want {
+				assert.Equal(t, creds, got)
+			} else {
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// The reconciler and the hydration-controller communicate through a unix
+// socket in the hydration signals directory, shared by both containers, and
+// only writable by the hydration-controller:
+//   - The reconciler sends a RenderRequest with the commit which is ready to
+//     render, which triggers the rendering immediately, instead of on the next
+//     polling period.
+//   - The reconciler waits for the RenderStatus of the commit, instead of
+//     polling the done file.
+//
+// The ready-to-render, done and error files are still written, so that a
+// reconciler and a hydration-controller of different versions keep working
+// together during upgrades.
+
+const (
+	// SignalSocketFile is the file name of the unix socket of the
+	// hydration-controller, under the hydration signals directory.
+	SignalSocketFile = "hydration.sock"
+
+	renderPath = "/v1/render"
+	statusPath = "/v1/status"
+
+	// signalRequestTimeout is the timeout of the requests which don't wait
+	// for the rendering.
+	signalRequestTimeout = 5 * time.Second
+	// maxSignalWaitTimeout caps the timeout of the status requests, so that
+	// a client can't hold a connection open indefinitely.
+	maxSignalWaitTimeout = time.Minute
+)
+
+// RenderState is the state of the rendering of a commit.
+type RenderState string
+
+const (
+	// RenderInProgress means the commit is being rendered.
+	RenderInProgress RenderState = "InProgress"
+	// RenderSucceeded means the commit was rendered successfully.
+	RenderSucceeded RenderState = "Succeeded"
+	// RenderFailed means the rendering of the commit failed.
+	RenderFailed RenderState = "Failed"
+)
+
+// RenderRequest is the request of the reconciler to render a commit.
+type RenderRequest struct {
+	// Commit is the commit which is ready to render. An empty commit blocks
+	// the rendering, like a missing ready-to-render file.
+	Commit string `json:"commit"`
+}
+
+// RenderStatus is the status of the last rendering of the
+// hydration-controller.
+type RenderStatus struct {
+	// Commit is the commit of the last rendering.
+	Commit string `json:"commit"`
+	// State is the state of the rendering of the commit.
+	State RenderState `json:"state"`
+	// Error is the rendering error, if the rendering failed.
+	Error *HydrationErrorPayload `json:"error,omitempty"`
+}
+
+// Done returns true if the rendering of the commit is complete, regardless
+// of success or failure.
+func (s *RenderStatus) Done(commit string) bool {
+	return s.Commit == commit && (s.State == RenderSucceeded || s.State == RenderFailed)
+}
+
+// renderSignals holds the state shared by the hydration loop and the signal
+// server.
+type renderSignals struct {
+	mux sync.Mutex
+	// requested is true once a RenderRequest was received. Until then, the
+	// ready-to-render file is used.
+	requested   bool
+	readyCommit string
+	status      RenderStatus
+	// changed is closed and replaced when the status changes.
+	changed chan struct{}
+	// trigger triggers the hydration loop.
+	trigger chan struct{}
+}
+
+func newRenderSignals() *renderSignals {
+	return &renderSignals{
+		changed: make(chan struct{}),
+		trigger: make(chan struct{}, 1),
+	}
+}
+
+// readyToRenderCommit returns the commit which is ready to render, and false
+// if no RenderRequest was received yet.
+func (s *renderSignals) readyToRenderCommit() (string, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.readyCommit, s.requested
+}
+
+func (s *renderSignals) request(commit string) RenderStatus {
+	s.mux.Lock()
+	s.requested = true
+	s.readyCommit = commit
+	status := s.status
+	s.mux.Unlock()
+	// Trigger the hydration loop, unless it is already triggered.
+	select {
+	case s.trigger <- struct{}{}:
+	default:
+	}
+	return status
+}
+
+func (s *renderSignals) setStatus(status RenderStatus) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.status = status
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (s *renderSignals) getStatus() (RenderStatus, <-chan struct{}) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.status, s.changed
+}
+
+// waitForStatus waits while the commit is being rendered, until the
+// rendering is done, the timeout expires, or the context is cancelled, and
+// returns the last status. It returns immediately if the commit is not being
+// rendered.
+func (s *renderSignals) waitForStatus(ctx context.Context, commit string, timeout time.Duration) RenderStatus {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		status, changed := s.getStatus()
+		if commit == "" || status.Commit != commit || status.State != RenderInProgress {
+			return status
+		}
+		select {
+		case <-changed:
+		case <-timer.C:
+			return status
+		case <-ctx.Done():
+			return status
+		}
+	}
+}
+
+// serveSignals serves the RenderRequests and RenderStatus on the unix socket
+// until the context is cancelled.
+func (s *renderSignals) serveSignals(ctx context.Context, socketPath string) error {
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("unable to remove the stale socket %s: %w", socketPath, err)
+	}
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("unable to listen on the socket %s: %w", socketPath, err)
+	}
+	// The Reconciler runs as a different user, in the same group.
+	if err := os.Chmod(socketPath, 0660); err != nil {
+		_ = listener.Close()
+		return fmt.Errorf("unable to change permissions on the socket %s: %w", socketPath, err)
+	}
+	server := &http.Server{Handler: s.handler(), ReadHeaderTimeout: signalRequestTimeout}
+	go func() {
+		<-ctx.Done()
+		if err := server.Close(); err != nil {
+			klog.Warningf("unable to close the signal server: %v", err)
+		}
+	}()
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *renderSignals) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(renderPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		req := RenderRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("invalid render request: %v", err), http.StatusBadRequest)
+			return
+		}
+		klog.V(3).Infof("received the render request for commit %q", req.Commit)
+		writeStatus(w, s.request(req.Commit))
+	})
+	mux.HandleFunc(statusPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var timeout time.Duration
+		if v := r.URL.Query().Get("timeout"); v != "" {
+			var err error
+			if timeout, err = time.ParseDuration(v); err != nil {
+				http.Error(w, fmt.Sprintf("invalid timeout: %v", err), http.StatusBadRequest)
+				return
+			}
+		}
+		if timeout > maxSignalWaitTimeout {
+			timeout = maxSignalWaitTimeout
+		}
+		writeStatus(w, s.waitForStatus(r.Context(), r.URL.Query().Get("commit"), timeout))
+	})
+	return mux
+}
+
+func writeStatus(w http.ResponseWriter, status RenderStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		klog.Warningf("unable to write the render status: %v", err)
+	}
+}
+
+// SignalClient is the client of the signal server of the hydration-controller.
+type SignalClient struct {
+	client *http.Client
+}
+
+// NewSignalClient returns a client of the signal server listening on the
+// unix socket.
+func NewSignalClient(socketPath string) *SignalClient {
+	return &SignalClient{
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return (&net.Dialer{}).DialContext(ctx, "unix", socketPath)
+				},
+			},
+		},
+	}
+}
+
+// RequestRender signals the hydration-controller that the commit is ready to
+// render. An empty commit blocks the rendering.
+func (c *SignalClient) RequestRender(ctx context.Context, commit string) error {
+	ctx, cancel := context.WithTimeout(ctx, signalRequestTimeout)
+	defer cancel()
+	body, err := json.Marshal(RenderRequest{Commit: commit})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://hydration-controller"+renderPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	_, err = c.do(req)
+	return err
+}
+
+// WaitForRender waits up to the timeout for the rendering of the commit to be
+// done, and returns the last RenderStatus. It doesn't wait if the commit is
+// not being rendered.
+func (c *SignalClient) WaitForRender(ctx context.Context, commit string, timeout time.Duration) (*RenderStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout+signalRequestTimeout)
+	defer cancel()
+	query := url.Values{"commit": {commit}, "timeout": {timeout.String()}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://hydration-controller"+statusPath+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+func (c *SignalClient) do(req *http.Request) (*RenderStatus, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response from the hydration-controller: %s", resp.Status)
+	}
+	status := &RenderStatus{}
+	if err := json.NewDecoder(resp.Body).Decode(status); err != nil {
+		return nil, fmt.Errorf("invalid response from the hydration-controller: %w", err)
+	}
+	return status, nil
+}
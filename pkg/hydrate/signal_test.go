@@ -0,0 +1,93 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderSignals(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	socketPath := filepath.Join(t.TempDir(), SignalSocketFile)
+	signals := newRenderSignals()
+	served := make(chan error)
+	go func() {
+		served <- signals.serveSignals(ctx, socketPath)
+	}()
+	client := NewSignalClient(socketPath)
+
+	// Wait for the server to listen.
+	require.Eventually(t, func() bool {
+		return client.RequestRender(ctx, "") == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	<-signals.trigger
+	info, err := os.Stat(socketPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0660), info.Mode().Perm())
+	commit, requested := signals.readyToRenderCommit()
+	assert.True(t, requested)
+	assert.Equal(t, "", commit)
+
+	require.NoError(t, client.RequestRender(ctx, "abc123"))
+	select {
+	case <-signals.trigger:
+	default:
+		t.Fatal("the render request did not trigger the hydration loop")
+	}
+	commit, _ = signals.readyToRenderCommit()
+	assert.Equal(t, "abc123", commit)
+
+	// The commit isn't being rendered.
+	start := time.Now()
+	status, err := client.WaitForRender(ctx, "abc123", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, &RenderStatus{}, status)
+	assert.Less(t, time.Since(start), 10*time.Second)
+
+	// The rendering isn't done before the timeout.
+	signals.setStatus(RenderStatus{Commit: "abc123", State: RenderInProgress})
+	status, err = client.WaitForRender(ctx, "abc123", 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, &RenderStatus{Commit: "abc123", State: RenderInProgress}, status)
+
+	// The rendering is done while waiting.
+	failed := RenderStatus{
+		Commit: "abc123",
+		State:  RenderFailed,
+		Error:  &HydrationErrorPayload{Code: NewActionableError(nil).Code(), Error: "invalid kustomization"},
+	}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		signals.setStatus(failed)
+	}()
+	status, err = client.WaitForRender(ctx, "abc123", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, &failed, status)
+	assert.True(t, status.Done("abc123"))
+	assert.False(t, status.Done("def456"))
+
+	cancel()
+	require.NoError(t, <-served)
+	_, err = client.WaitForRender(context.Background(), "abc123", time.Millisecond)
+	assert.Error(t, err)
+}
@@ -16,6 +16,7 @@ package hydrate
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -38,9 +39,17 @@ import (
 	"github.com/GoogleContainerTools/config-sync/pkg/validate/fileobjects"
 	"github.com/GoogleContainerTools/config-sync/pkg/vet"
 	"github.com/Masterminds/semver"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientdiscovery "k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/resource"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
 )
 
 const (
@@ -126,18 +135,36 @@ func mustDeleteOutput(err error, output string) {
 	klog.Fatalf("Attempted to delete the output directory %s for %d times, but all failed. Exiting now...", output, retries)
 }
 
-// kustomizeBuild runs the 'kustomize build' command to render the configs.
-func kustomizeBuild(input, output string, sendMetrics bool) HydrationError {
-	// The `--enable-alpha-plugins` and `--enable-exec` flags are to support rendering
-	// Helm charts using the Helm inflation function.
-	// The `--enable-helm` flag is to enable use of the Helm chart inflator generator.
-	// We decided to enable all the flags so that both the Helm plugin and Helm
-	// inflation function are supported. This provides us with a fallback plan
-	// if the new Helm inflation function is having issues.
-	// It has no side-effect if no Helm chart in the DRY configs.
-	// The `--load-restrictor` flag is to allow files to be loaded from outside the root directory
-	args := []string{"--enable-alpha-plugins", "--enable-exec", "--enable-helm", "--load-restrictor=LoadRestrictionsNone", "--output", output}
+// kustomizeOptions returns the options of the in-process kustomize renderer.
+// They match the flags the kustomize binary used to be run with:
+//   - `--enable-alpha-plugins` and `--enable-exec` support rendering Helm
+//     charts using the Helm inflation function. Exec functions are run as
+//     executables, so the ones which are shell scripts only work in the
+//     hydration-controller image with a shell, which is used when
+//     `enableShellInRendering` is set.
+//   - `--enable-helm` enables the Helm chart inflator generator, which runs
+//     the `helm` binary.
+//   - `--load-restrictor=LoadRestrictionsNone` allows files to be loaded from
+//     outside the root directory.
+//
+// We decided to enable all the plugins so that both the Helm plugin and Helm
+// inflation function are supported. This provides us with a fallback plan
+// if the new Helm inflation function is having issues.
+// It has no side-effect if no Helm chart in the DRY configs.
+func kustomizeOptions() *krusty.Options {
+	opts := krusty.MakeDefaultOptions()
+	// Use the default sort order of `kustomize build`.
+	opts.Reorder = krusty.ReorderOptionUnspecified
+	opts.LoadRestrictions = types.LoadRestrictionsNone
+	opts.PluginConfig = types.EnabledPluginConfig(types.BploUseStaticallyLinked)
+	opts.PluginConfig.FnpLoadingOptions.EnableExec = true
+	opts.PluginConfig.HelmConfig.Command = Helm
+	return opts
+}
 
+// kustomizeBuild renders the configs read from fSys with kustomize in process,
+// and writes each rendered resource to its own file in the output directory.
+func kustomizeBuild(fSys filesys.FileSystem, input, output string, sendMetrics bool) HydrationError {
 	if _, err := os.Stat(output); err == nil {
 		mustDeleteOutput(err, output)
 	}
@@ -147,14 +174,82 @@ func kustomizeBuild(input, output string, sendMetrics bool) HydrationError {
 		return NewInternalError(fmt.Errorf("unable to make directory: %s: %w", output, err))
 	}
 
-	// run kustomize build with the wrapper library
-	out, err := kmetrics.RunKustomizeBuild(context.Background(), sendMetrics, input, args...)
+	m, err := kmetrics.RunKustomizeBuild(context.Background(), sendMetrics, fSys, input, kustomizeOptions())
 	if err != nil {
-		kustomizeErr := fmt.Errorf("failed to run kustomize build in %s, stdout: %s: %w", input, out, err)
+		kustomizeErr := kustomizeBuildError(input, err)
 		mustDeleteOutput(kustomizeErr, output)
-		return NewActionableError(kustomizeErr)
+		return kustomizeErr
 	}
+	if err := writeKustomizeOutput(output, m); err != nil {
+		mustDeleteOutput(err, output)
+		return NewInternalError(err)
+	}
+	return nil
+}
+
+// kustomizeBuildError returns the user actionable error of the failed
+// rendering of the kustomization in the input directory, with the paths of
+// the kustomization file and resource which failed, relative to the input
+// directory.
+func kustomizeBuildError(input string, err error) status.Error {
+	var buildErr *kmetrics.BuildError
+	if !errors.As(err, &buildErr) {
+		return status.KustomizeBuildError(err)
+	}
+	return status.KustomizeBuildError(buildErr.Err,
+		relativeSlashPath(input, buildErr.KustomizationPath),
+		relativeSlashPath(input, buildErr.ResourcePath))
+}
 
+// relativeSlashPath returns the slash path relative to the directory of an
+// absolute path. Other paths, like the URLs of remote resources, are returned
+// as is.
+func relativeSlashPath(dir, path string) string {
+	if !filepath.IsAbs(path) {
+		return path
+	}
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// writeKustomizeOutput writes each rendered resource to its own file in the
+// output directory, with the same file names as `kustomize build --output`.
+func writeKustomizeOutput(output string, m resmap.ResMap) error {
+	byNamespace := m.GroupedByCurrentNamespace()
+	for namespace, resources := range byNamespace {
+		for _, res := range resources {
+			name := kustomizeOutputFileName(res)
+			if len(byNamespace) > 1 {
+				name = strings.ToLower(namespace) + "_" + name
+			}
+			if err := writeKustomizeResource(filepath.Join(output, name), res); err != nil {
+				return err
+			}
+		}
+	}
+	for _, res := range m.ClusterScoped() {
+		if err := writeKustomizeResource(filepath.Join(output, kustomizeOutputFileName(res)), res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func kustomizeOutputFileName(res *resource.Resource) string {
+	return strings.ToLower(res.GetGvk().StringWoEmptyField()) + "_" + strings.ToLower(res.GetName()) + ".yaml"
+}
+
+func writeKustomizeResource(path string, res *resource.Resource) error {
+	b, err := res.AsYAML()
+	if err != nil {
+		return fmt.Errorf("unable to encode %s: %w", res.CurId(), err)
+	}
+	if err := os.WriteFile(path, b, 0644); err != nil {
+		return fmt.Errorf("unable to write file: %s: %w", path, err)
+	}
 	return nil
 }
 
@@ -197,17 +292,6 @@ func getVersion(tool string) (string, error) {
 	return version, nil
 }
 
-func validateKustomize() error {
-	version, err := getVersion(Kustomize)
-	if err != nil {
-		return fmt.Errorf("Kustomization file is detected, but Kustomize is not installed: %v. Please install Kustomize and re-run the command.", err)
-	}
-	if err := validateTool(Kustomize, version, KustomizeVersion); err != nil {
-		fmt.Printf("WARNING: %v\n", err)
-	}
-	return nil
-}
-
 func validateHelm() error {
 	version, err := getVersion(Helm)
 	if err != nil {
@@ -221,27 +305,24 @@ func validateHelm() error {
 	return nil
 }
 
-// ValidateAndRunKustomize validates if the Kustomize and Helm binaries are supported.
-// If supported, it copies the source configs to a temp directory, run 'kustomize build',
-// save the output to another temp directory, and return the output path for further
-// parsing and validation.
+// ValidateAndRunKustomize validates if the Helm binary is supported.
+// If supported, it renders the source configs with kustomize in process,
+// saves the output to a temp directory, and returns the output path for
+// further parsing and validation.
 func ValidateAndRunKustomize(sourcePath string) (cmpath.Absolute, error) {
 	var output cmpath.Absolute
-	if err := validateKustomize(); err != nil {
-		return output, err
-	}
 	if err := validateHelm(); err != nil {
 		return output, err
 	}
 
-	// Save the 'kustomize build' output to a temp directory for further
+	// Save the rendered output to a temp directory for further
 	// parsing or validation.
 	tmpHydratedDir, err := os.MkdirTemp(os.TempDir(), "hydrated-")
 	if err != nil {
 		return output, err
 	}
 
-	if err := kustomizeBuild(sourcePath, tmpHydratedDir, false); err != nil {
+	if err := kustomizeBuild(filesys.MakeFsOnDisk(), sourcePath, tmpHydratedDir, false); err != nil {
 		return output, fmt.Errorf("unable to render the source configs in %s: %w", sourcePath, err)
 	}
 
@@ -250,6 +331,46 @@ func ValidateAndRunKustomize(sourcePath string) (cmpath.Absolute, error) {
 	return cmpath.AbsoluteOS(tmpHydratedDir)
 }
 
+// RenderSource renders the source configs in syncDir the same way as the
+// hydration-controller: with kustomize if the directory has a Kustomization,
+// followed by the KRM function pipeline of its Kptfile, if any and enabled.
+// The rendered configs are saved to a temp directory, whose path is returned.
+// It returns false if the source configs don't need rendering.
+func RenderSource(syncDir string, functionPipelineEnabled bool, functionDirs []string) (cmpath.Absolute, bool, error) {
+	var output cmpath.Absolute
+	kustomize, err := needsKustomize(syncDir)
+	if err != nil {
+		return output, false, err
+	}
+	h := &Hydrator{
+		FunctionPipelineEnabled: functionPipelineEnabled,
+		FunctionDirs:            functionDirs,
+	}
+	pipeline, err := h.readPipeline(syncDir)
+	if err != nil {
+		return output, false, err
+	}
+	if !kustomize && pipeline == nil {
+		return output, false, nil
+	}
+	if kustomize {
+		if err := validateHelm(); err != nil {
+			return output, false, err
+		}
+	}
+
+	tmpHydratedDir, err := os.MkdirTemp(os.TempDir(), "hydrated-")
+	if err != nil {
+		return output, false, err
+	}
+	if err := h.render(filesys.MakeFsOnDisk(), syncDir, tmpHydratedDir); err != nil {
+		_ = os.RemoveAll(tmpHydratedDir)
+		return output, false, fmt.Errorf("unable to render the source configs in %s: %w", syncDir, err)
+	}
+	output, err = cmpath.AbsoluteOS(tmpHydratedDir)
+	return output, true, err
+}
+
 // ValidateHydrateFlags validates the hydrate and vet flags.
 // It returns the absolute path of the source directory, if hydration is needed, and errors.
 func ValidateHydrateFlags(sourceFormat configsync.SourceFormat) (cmpath.Absolute, bool, error) {
@@ -316,9 +437,42 @@ func ValidateOptions(rootDir cmpath.Absolute, apiServerTimeout time.Duration) (v
 	} else if len(flags.SkipAPIServerCheckForGroup) > 0 {
 		options.AllowUnknownKindMatcher = &fileobjects.GroupMatcher{Groups: flags.SkipAPIServerCheckForGroup}
 	}
+	options.SubstitutionEnabled = flags.Substitution || flags.SubstitutionValuesFile != ""
+	if flags.SubstitutionValuesFile != "" {
+		values, err := ReadSubstitutionValues(flags.SubstitutionValuesFile)
+		if err != nil {
+			return options, err
+		}
+		options.SubstitutionValues = values
+	}
 	return options, nil
 }
 
+// ReadSubstitutionValues reads the values of the `${values.KEY}` variables from
+// a file holding either a ConfigMap manifest or a YAML map of strings.
+func ReadSubstitutionValues(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read substitution values: %w", err)
+	}
+	typeMeta := &metav1.TypeMeta{}
+	if err := yaml.Unmarshal(data, typeMeta); err != nil {
+		return nil, fmt.Errorf("substitution values in %q must be a ConfigMap or a map of strings: %w", path, err)
+	}
+	if typeMeta.Kind == "ConfigMap" {
+		cm := &corev1.ConfigMap{}
+		if err := yaml.Unmarshal(data, cm); err != nil {
+			return nil, fmt.Errorf("failed to parse substitution values ConfigMap in %q: %w", path, err)
+		}
+		return cm.Data, nil
+	}
+	values := make(map[string]string)
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("substitution values in %q must be a ConfigMap or a map of strings: %w", path, err)
+	}
+	return values, nil
+}
+
 func newDiscoveryClient(cfg *rest.Config) (clientdiscovery.CachedDiscoveryInterface, error) {
 	cf, err := restconfig.NewConfigFlags(cfg)
 	if err != nil {
@@ -16,7 +16,14 @@ package hydrate
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
 )
 
 func TestValidateTool(t *testing.T) {
@@ -93,3 +100,131 @@ func TestNeedsKustomize(t *testing.T) {
 		})
 	}
 }
+
+func TestReadSubstitutionValues(t *testing.T) {
+	testCases := []struct {
+		name        string
+		content     string
+		want        map[string]string
+		expectedErr string
+	}{
+		{
+			name: "ConfigMap",
+			content: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: values
+data:
+  replicas: "3"
+`,
+			want: map[string]string{"replicas": "3"},
+		},
+		{
+			name:    "map of strings",
+			content: "replicas: \"3\"\ntier: gold\n",
+			want:    map[string]string{"replicas": "3", "tier": "gold"},
+		},
+		{
+			name:        "list",
+			content:     "- replicas\n",
+			expectedErr: "must be a ConfigMap or a map of strings",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "values.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0644))
+
+			got, err := ReadSubstitutionValues(path)
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestKustomizeBuild(t *testing.T) {
+	input := t.TempDir()
+	files := map[string]string{
+		"kustomization.yaml": "resources:\n- resources.yaml\n",
+		"resources.yaml": `apiVersion: v1
+kind: Namespace
+metadata:
+  name: bookstore
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: bookstore
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shipping
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(input, name), []byte(content), 0644))
+	}
+	output := filepath.Join(t.TempDir(), "output")
+
+	require.NoError(t, kustomizeBuild(filesys.MakeFsOnDisk(), input, output, false))
+
+	entries, err := os.ReadDir(output)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{
+		"v1_namespace_bookstore.yaml",
+		"bookstore_v1_configmap_settings.yaml",
+		"shipping_apps_v1_deployment_web.yaml",
+	}, names)
+	cm, err := os.ReadFile(filepath.Join(output, "bookstore_v1_configmap_settings.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(cm), "config.kubernetes.io/origin")
+
+	hydrationErr := kustomizeBuild(filesys.MakeFsOnDisk(), t.TempDir(), output, false)
+	require.Error(t, hydrationErr)
+	assert.Equal(t, status.ActionableHydrationErrorCode, hydrationErr.Code())
+}
+
+func TestKustomizeBuildError(t *testing.T) {
+	input := t.TempDir()
+	files := map[string]string{
+		"kustomization.yaml":      "resources:\n- base\n",
+		"base/kustomization.yaml": "resources:\n- configmap.yaml\n",
+		"base/configmap.yaml":     "kind: [\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(input, name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(input, name), []byte(content), 0644))
+	}
+	output := filepath.Join(t.TempDir(), "output")
+
+	hydrationErr := kustomizeBuild(filesys.MakeFsOnDisk(), input, output, false)
+	require.Error(t, hydrationErr)
+	assert.Equal(t, status.ActionableHydrationErrorCode, hydrationErr.Code())
+	var pathErr status.PathError
+	require.ErrorAs(t, hydrationErr, &pathErr)
+	var paths []string
+	for _, p := range pathErr.RelativePaths() {
+		paths = append(paths, p.SlashPath())
+	}
+	assert.Equal(t, []string{"base/kustomization.yaml", "base/configmap.yaml"}, paths)
+	assert.NoDirExists(t, output)
+
+	// The Reconciler adds the error code again.
+	payload := newHydrationErrorPayload(hydrationErr)
+	assert.Equal(t, status.ActionableHydrationErrorCode, payload.Code)
+	assert.NotContains(t, payload.Error, "KNV")
+	assert.Contains(t, payload.Error, "path: base/configmap.yaml\npath: base/kustomization.yaml")
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2021 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kmetrics
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+var (
+	// kustomizationRootRegex matches the errors of kustomize which name the
+	// absolute root of a nested kustomization.
+	kustomizationRootRegex = regexp.MustCompile(`(?:recursed accumulation of|couldn't make target for) path '([^']*)'`)
+	// resourceRegex matches the errors of kustomize which name a resource of
+	// the kustomization, relative to its root.
+	resourceRegex = regexp.MustCompile(`accumulating resources from '([^']*)'`)
+)
+
+// BuildError is the error of a kustomize build, with the paths of the
+// kustomization file which failed to build, and of its resource which failed
+// to load, if any.
+type BuildError struct {
+	// KustomizationPath is the absolute path of the kustomization file.
+	KustomizationPath string
+	// ResourcePath is the absolute path, or the URL, of the resource of the
+	// kustomization, or empty if the error isn't about a resource.
+	ResourcePath string
+	// Err is the error returned by kustomize.
+	Err error
+}
+
+// Error implements error.
+func (e *BuildError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error returned by kustomize.
+func (e *BuildError) Unwrap() error {
+	return e.Err
+}
+
+// newBuildError returns the BuildError of the kustomization in inputDir.
+// kustomize doesn't return typed errors, so the nested kustomization and the
+// resource which failed are found from the messages kustomize wraps the
+// errors with: the innermost kustomization is the last one named, and its
+// resource is the first one named after it.
+func newBuildError(inputDir string, err error) *BuildError {
+	root, absErr := filepath.Abs(inputDir)
+	if absErr != nil {
+		root = inputDir
+	}
+	msg := err.Error()
+	start := 0
+	for _, match := range kustomizationRootRegex.FindAllStringSubmatchIndex(msg, -1) {
+		root = msg[match[2]:match[3]]
+		start = match[1]
+	}
+	buildErr := &BuildError{KustomizationPath: kustomizationFile(root), Err: err}
+	if match := resourceRegex.FindStringSubmatch(msg[start:]); match != nil {
+		buildErr.ResourcePath = match[1]
+		if !filepath.IsAbs(match[1]) && !strings.Contains(match[1], "://") {
+			buildErr.ResourcePath = filepath.Join(root, match[1])
+		}
+	}
+	return buildErr
+}
+
+// kustomizationFile returns the path of the kustomization file in the root
+// directory, or of the default kustomization file if there is none.
+func kustomizationFile(root string) string {
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		path := filepath.Join(root, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(root, konfig.DefaultKustomizationFileName())
+}
+
+// RunKustomizeBuild renders the kustomization in inputDir in process, with the
+// kustomize API, and returns the rendered resources. opts are the options of
+// the renderer, equivalent to the flags of `kustomize build`. fSys is the file
+// system the configs are read from, usually filesys.MakeFsOnDisk().
+//
+// The argument sendMetrics determines whether to send metrics about kustomize
+// to Google Cloud.
+//
+// By default, we would like to enable the `buildMetadata.originAnnotations`
+// option of the kustomization file. If the kustomization file does not include
+// it already, it is added to the content of the file read by the renderer.
+// The kustomization file itself is never modified.
+//
+// The errors are BuildErrors, with the paths of the kustomization file and the
+// resource which failed.
+func RunKustomizeBuild(ctx context.Context, sendMetrics bool, fSys filesys.FileSystem, inputDir string, opts *krusty.Options) (resmap.ResMap, error) {
+	b, kustPath := readKustomizeFileBytes(inputDir)
+	if b == nil {
+		return nil, newBuildError(inputDir, fmt.Errorf("Error: unable to find one of 'kustomization.yaml', 'kustomization.yml' or 'Kustomization' in directory"))
+	}
+
+	// An invalid kustomization file is reported by the renderer below, with a
+	// better error message.
+	if content, err := addOriginAnnotations(b); err == nil && content != nil {
+		fSys, err = newOverlayFS(fSys, kustPath, content)
+		if err != nil {
+			return nil, newBuildError(inputDir, err)
+		}
+	}
+
+	start := time.Now()
+	m, buildErr := krusty.MakeKustomizer(opts).Run(fSys, inputDir)
+	executionTime := time.Since(start).Nanoseconds()
+
+	if sendMetrics {
+		if buildErr == nil {
+			// Send execution time and resource count metrics to OC collector
+			RecordKustomizeResourceCount(ctx, m.Size())
+			RecordKustomizeExecutionTime(ctx, float64(executionTime))
+		}
+		kt, err := readKustomizeFile(inputDir)
+		if kt != nil && err == nil {
+			fieldMetrics, fieldErr := kustomizeFieldUsage(kt, inputDir)
+			if fieldErr == nil && fieldMetrics != nil {
+				// Send field count metrics to OC collector
+				RecordKustomizeFieldCountData(ctx, fieldMetrics)
+			}
+		}
+	}
+	if buildErr != nil {
+		return nil, newBuildError(inputDir, buildErr)
+	}
+	return m, nil
+}
+
+// addOriginAnnotations returns the content of the kustomization file with the
+// `originAnnotations` build metadata option. Returns nil if the kustomization
+// file already includes it.
+func addOriginAnnotations(b []byte) ([]byte, error) {
+	node, err := yaml.Parse(string(b))
+	if err != nil {
+		return nil, err
+	}
+	buildMetadata, err := node.Pipe(yaml.LookupCreate(yaml.SequenceNode, "buildMetadata"))
+	if err != nil {
+		return nil, err
+	}
+	elements, err := buildMetadata.Elements()
+	if err != nil {
+		return nil, err
+	}
+	for _, element := range elements {
+		if element.YNode().Value == types.OriginAnnotations {
+			return nil, nil
+		}
+	}
+	if _, err := buildMetadata.Pipe(yaml.Append(yaml.NewScalarRNode(types.OriginAnnotations).YNode())); err != nil {
+		return nil, err
+	}
+	content, err := node.String()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(content), nil
+}
+
+// overlayFS is a file system which overrides the content of a single file.
+type overlayFS struct {
+	filesys.FileSystem
+	// path is the absolute path of the file, with its symlinks evaluated.
+	path    string
+	content []byte
+}
+
+func newOverlayFS(fSys filesys.FileSystem, path string, content []byte) (*overlayFS, error) {
+	realPath, err := realPath(path)
+	if err != nil {
+		return nil, err
+	}
+	return &overlayFS{FileSystem: fSys, path: realPath, content: content}, nil
+}
+
+// ReadFile returns the overridden content of the file, or the content of any
+// other file from the underlying file system.
+func (fs *overlayFS) ReadFile(path string) ([]byte, error) {
+	if p, err := realPath(path); err == nil && p == fs.path {
+		return fs.content, nil
+	}
+	return fs.FileSystem.ReadFile(path)
+}
+
+func realPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(abs)
+}
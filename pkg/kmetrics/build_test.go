@@ -18,17 +18,23 @@ package kmetrics
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
 )
 
 func TestRunKustomizeBuild(t *testing.T) {
 	testCases := map[string]struct {
 		inputDir    string
-		flags       []string
 		expected    string
 		expectedErr string
+		// expectedKustomization and expectedResource are the paths of the
+		// BuildError, relative to the input directory.
+		expectedKustomization string
+		expectedResource      string
 	}{
 		"simple": {
 			inputDir: "./testdata/simple",
@@ -48,24 +54,30 @@ spec:
 `,
 		},
 		"missing kustomization": {
-			inputDir:    "./testdata/missingkustomization",
-			expectedErr: "Error: unable to find one of 'kustomization.yaml', 'kustomization.yml' or 'Kustomization' in directory",
+			inputDir:              "./testdata/missingkustomization",
+			expectedErr:           "Error: unable to find one of 'kustomization.yaml', 'kustomization.yml' or 'Kustomization' in directory",
+			expectedKustomization: "kustomization.yaml",
 		},
 		"complex": {
-			inputDir:    "./testdata/complex",
-			expectedErr: "base/deployment.yaml: no such file or directory",
+			inputDir:              "./testdata/complex",
+			expectedErr:           "base/deployment.yaml: no such file or directory",
+			expectedKustomization: "base/kustomization.yaml",
+			expectedResource:      "base/deployment.yaml",
 		},
 		"missing kustomization in base": {
-			inputDir:    "./testdata/missingkustomizationinbase",
-			expectedErr: "unable to find one of 'kustomization.yaml', 'kustomization.yml' or 'Kustomization' in directory",
+			inputDir:              "./testdata/missingkustomizationinbase",
+			expectedErr:           "unable to find one of 'kustomization.yaml', 'kustomization.yml' or 'Kustomization' in directory",
+			expectedKustomization: "base/kustomization.yaml",
 		},
 		"invalid kustomization": {
-			inputDir:    "./testdata/invalidkustomization",
-			expectedErr: "Error: invalid Kustomization: json: cannot unmarshal string into Go struct field Kustomization.resources of type []string",
+			inputDir:              "./testdata/invalidkustomization",
+			expectedErr:           "invalid Kustomization: json: cannot unmarshal string into Go struct field Kustomization.resources of type []string",
+			expectedKustomization: "kustomization.yaml",
 		},
 		"multiple kustomization files": {
-			inputDir:    "./testdata/multiplekustomizationfiles",
-			expectedErr: "Error: Found multiple kustomization files",
+			inputDir:              "./testdata/multiplekustomizationfiles",
+			expectedErr:           "Found multiple kustomization files",
+			expectedKustomization: "kustomization.yaml",
 		},
 		"with generator": {
 			inputDir: "./testdata/withgenerator",
@@ -127,12 +139,23 @@ spec:
 
 	for tn, tc := range testCases {
 		t.Run(tn, func(t *testing.T) {
-			out, err := RunKustomizeBuild(context.Background(), false, tc.inputDir, tc.flags...)
+			// The defaults of `kustomize build`.
+			opts := krusty.MakeDefaultOptions()
+			opts.Reorder = krusty.ReorderOptionUnspecified
+			before, _ := readKustomizeFileBytes(tc.inputDir)
+			m, err := RunKustomizeBuild(context.Background(), false, filesys.MakeFsOnDisk(), tc.inputDir, opts)
+			// The kustomization file is never modified.
+			after, _ := readKustomizeFileBytes(tc.inputDir)
+			assert.Equal(t, string(before), string(after))
 			if tc.expectedErr == "" {
 				if !assert.NoError(t, err) {
 					t.FailNow()
 				}
-				if !assert.Equal(t, tc.expected, out) {
+				out, err := m.AsYaml()
+				if !assert.NoError(t, err) {
+					t.FailNow()
+				}
+				if !assert.Equal(t, tc.expected, string(out)) {
 					t.FailNow()
 				}
 			} else {
@@ -142,6 +165,20 @@ spec:
 				if !assert.Contains(t, err.Error(), tc.expectedErr) {
 					t.FailNow()
 				}
+				var buildErr *BuildError
+				if !assert.ErrorAs(t, err, &buildErr) {
+					t.FailNow()
+				}
+				inputDir, err := filepath.Abs(tc.inputDir)
+				if !assert.NoError(t, err) {
+					t.FailNow()
+				}
+				assert.Equal(t, filepath.Join(inputDir, tc.expectedKustomization), buildErr.KustomizationPath)
+				if tc.expectedResource == "" {
+					assert.Empty(t, buildErr.ResourcePath)
+				} else {
+					assert.Equal(t, filepath.Join(inputDir, tc.expectedResource), buildErr.ResourcePath)
+				}
 			}
 		})
 	}
@@ -0,0 +1,58 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DriftPolicy is the type used to identify value enums to use with the
+// drift-policy annotation.
+type DriftPolicy string
+
+// String returns the string value of the DriftPolicy.
+// Implements the Stringer interface.
+func (p DriftPolicy) String() string {
+	return string(p)
+}
+
+const (
+	// DriftPolicyAnnotationKey is the annotation key set on RootSync/RepoSync
+	// objects, or on managed resources in the source of truth, to indicate
+	// what the remediator should do when a managed resource drifts from its
+	// declared state. The annotation on a managed resource takes precedence
+	// over the annotation on the RootSync/RepoSync.
+	DriftPolicyAnnotationKey = configsync.ConfigSyncPrefix + "drift-policy"
+	// DriftPolicyCorrect indicates that the remediator should revert the drift.
+	// This is the default behavior if the annotation is not specified.
+	DriftPolicyCorrect DriftPolicy = "correct"
+	// DriftPolicyReport indicates that the remediator should record the drift
+	// in the RootSync/RepoSync status and as an Event, without reverting it.
+	DriftPolicyReport DriftPolicy = "report"
+)
+
+// GetDriftPolicy returns the value of the drift-policy annotation of the
+// object, or an empty string if not set.
+func GetDriftPolicy(obj client.Object) DriftPolicy {
+	return DriftPolicy(core.GetAnnotation(obj, DriftPolicyAnnotationKey))
+}
+
+// WithDriftPolicy returns a MetaMutator that sets the DriftPolicy annotation
+// on an Object.
+func WithDriftPolicy(policy DriftPolicy) core.MetaMutator {
+	return core.Annotation(DriftPolicyAnnotationKey, policy.String())
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"strings"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// FieldOwnership is the type used to identify value enums to use with the
+// field-ownership annotation.
+type FieldOwnership string
+
+// String returns the string value of the FieldOwnership.
+// Implements the Stringer interface.
+func (o FieldOwnership) String() string {
+	return string(o)
+}
+
+const (
+	// FieldOwnershipAnnotationKey is the annotation key set on resources in
+	// the source of truth to indicate how the reconciler owns them.
+	FieldOwnershipAnnotationKey = configsync.ConfigSyncPrefix + "field-ownership"
+	// FieldOwnershipShared indicates that the resource is managed by another
+	// RootSync or RepoSync, and that the reconciler only co-manages its
+	// declared fields.
+	FieldOwnershipShared FieldOwnership = "shared"
+
+	// SharedFieldsAnnotationKeyPrefix is the prefix of the annotation keys
+	// set by Config Sync on a managed resource, with the name of a reconciler
+	// co-managing the resource, whose values are the fields declared by the
+	// reconciler.
+	SharedFieldsAnnotationKeyPrefix = "shared-fields." + configsync.ConfigSyncPrefix
+)
+
+// IsSharedFieldOwnership returns whether the object has the field-ownership
+// annotation set to shared.
+func IsSharedFieldOwnership(obj client.Object) bool {
+	return FieldOwnership(core.GetAnnotation(obj, FieldOwnershipAnnotationKey)) == FieldOwnershipShared
+}
+
+// WithFieldOwnership returns a MetaMutator that sets the field-ownership
+// annotation on an Object.
+func WithFieldOwnership(ownership FieldOwnership) core.MetaMutator {
+	return core.Annotation(FieldOwnershipAnnotationKey, ownership.String())
+}
+
+// SharedFieldsAnnotationKey returns the key of the annotation storing the
+// fields declared by the specified reconciler co-managing a resource.
+func SharedFieldsAnnotationKey(reconcilerName string) string {
+	return SharedFieldsAnnotationKeyPrefix + reconcilerName
+}
+
+// IsSharedFieldsAnnotationKey returns whether the annotation key stores the
+// fields declared by a reconciler co-managing a resource.
+func IsSharedFieldsAnnotationKey(k string) bool {
+	return strings.HasPrefix(k, SharedFieldsAnnotationKeyPrefix)
+}
+
+// GetSharedFields returns the fields declared by each reconciler co-managing
+// the object, by reconciler name.
+func GetSharedFields(obj client.Object) map[string]string {
+	shared := make(map[string]string)
+	for k, v := range obj.GetAnnotations() {
+		if IsSharedFieldsAnnotationKey(k) {
+			shared[strings.TrimPrefix(k, SharedFieldsAnnotationKeyPrefix)] = v
+		}
+	}
+	return shared
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"strconv"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ForceConflictsAnnotationKey is the annotation key set on RootSync/RepoSync
+// objects to indicate whether the applier and the remediator take the
+// ownership of the declared fields managed by other field managers, when
+// applying them with server-side apply. If `false`, applying a declared field
+// with a different value than another field manager fails instead.
+// Defaults to `true`.
+const ForceConflictsAnnotationKey = configsync.ConfigSyncPrefix + "force-conflicts"
+
+// GetForceConflicts returns the value of the force-conflicts annotation of
+// the object. It returns true if the annotation is not set, and false if it
+// is invalid, so that an invalid value never takes the ownership of fields.
+func GetForceConflicts(obj client.Object) bool {
+	value, found := obj.GetAnnotations()[ForceConflictsAnnotationKey]
+	if !found {
+		return true
+	}
+	force, err := strconv.ParseBool(value)
+	if err != nil {
+		return false
+	}
+	return force
+}
+
+// WithForceConflicts returns a MetaMutator that sets the force-conflicts
+// annotation on an Object.
+func WithForceConflicts(force bool) core.MetaMutator {
+	return core.Annotation(ForceConflictsAnnotationKey, strconv.FormatBool(force))
+}
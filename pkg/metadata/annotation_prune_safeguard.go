@@ -0,0 +1,32 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+)
+
+// PruneSafeguardAcknowledgedCommitAnnotationKey is the annotation key set on
+// RootSync/RepoSync objects to acknowledge that the commit in its value may
+// prune more resources than allowed by the prune safeguard of the
+// `spec.prunePolicy`.
+const PruneSafeguardAcknowledgedCommitAnnotationKey = configsync.ConfigSyncPrefix + "prune-safeguard-acknowledged-commit"
+
+// WithPruneSafeguardAcknowledgedCommit returns a MetaMutator that sets the
+// prune-safeguard-acknowledged-commit annotation on an Object.
+func WithPruneSafeguardAcknowledgedCommit(commit string) core.MetaMutator {
+	return core.Annotation(PruneSafeguardAcknowledgedCommitAnnotationKey, commit)
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TransferToAnnotationKey is the annotation key set on managed resources in
+// the source of truth, to hand them over to another RootSync, specified as
+// NAME, or RepoSync, specified as NAMESPACE/NAME. The reconciler of the source
+// releases the resource without pruning it, so that the reconciler of the
+// other RootSync or RepoSync can adopt it.
+const TransferToAnnotationKey = configsync.ConfigSyncPrefix + "transfer-to"
+
+// GetTransferTo returns the value of the transfer-to annotation of the
+// object, or an empty string if not set.
+func GetTransferTo(obj client.Object) string {
+	return core.GetAnnotation(obj, TransferToAnnotationKey)
+}
+
+// HasTransferTo returns whether the object has the transfer-to annotation.
+func HasTransferTo(obj client.Object) bool {
+	return GetTransferTo(obj) != ""
+}
+
+// WithTransferTo returns a MetaMutator that sets the transfer-to annotation
+// on an Object.
+func WithTransferTo(sync string) core.MetaMutator {
+	return core.Annotation(TransferToAnnotationKey, sync)
+}
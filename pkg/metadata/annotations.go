@@ -127,6 +127,32 @@ const (
 	// create the reconciler with the Namespace controller in the reconciler container.
 	DynamicNSSelectorEnabledAnnotationKey = configsync.ConfigSyncPrefix + "dynamic-ns-selector-enabled"
 
+	// DriftAuditLogAnnotationKey is the annotation key set on RootSync/RepoSync
+	// objects to indicate whether the reconciler should write an audit record
+	// of every drift corrected by the remediator to its logs, as a JSON line.
+	// The records are always emitted as Events.
+	DriftAuditLogAnnotationKey = configsync.ConfigSyncPrefix + "drift-audit-log"
+
+	// InventorySelfHealAnnotationKey is the annotation key set on
+	// RootSync/RepoSync objects to indicate whether the reconciler should
+	// rebuild the ResourceGroup inventory from the managed objects on the
+	// cluster, when the ResourceGroup doesn't exist.
+	InventorySelfHealAnnotationKey = configsync.ConfigSyncPrefix + "inventory-self-heal"
+
+	// FunctionPipelineAnnotationKey is the annotation key set on
+	// RootSync/RepoSync objects to indicate whether the KRM function pipeline
+	// declared in the Kptfile of the sync directory is run during rendering.
+	// Kptfiles are ignored by default, so that the output of `kpt fn render`
+	// can be synced as-is.
+	FunctionPipelineAnnotationKey = configsync.ConfigSyncPrefix + "function-pipeline"
+
+	// RemediatorPrioritiesAnnotationKey is the annotation key set on
+	// RootSync/RepoSync objects to configure the priorities of the kinds of
+	// objects in the remediator work queue, as comma-separated
+	// `Kind.group=priority` pairs. Objects of kinds with a higher priority are
+	// remediated first.
+	RemediatorPrioritiesAnnotationKey = configsync.ConfigSyncPrefix + "remediator-priorities"
+
 	// ImageToSyncAnnotationKey is the annotation key used to store the full image reference
 	// (including the digest) for OCI and Helm (with oci:// URL) sources.
 	// This annotation is set by Config Sync on the RootSync/RepoSync object
@@ -138,6 +164,13 @@ const (
 	// When the value is set to "disabled", the ResourceGroup controller
 	// ignores the ResourceGroup CR.
 	StatusModeAnnotationKey = configsync.ConfigSyncPrefix + "status"
+
+	// PruneBlockedAnnotationKey annotates a ResourceGroup CR with the objects
+	// whose prune was blocked by the prune policy of the RootSync/RepoSync,
+	// while they are out of the inventory during an apply. The objects are
+	// added back to the inventory by the next apply if the reconciler
+	// restarts before restoring them.
+	PruneBlockedAnnotationKey = configsync.ConfigSyncPrefix + "prune-blocked"
 )
 
 // Lifecycle annotations
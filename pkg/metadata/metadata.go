@@ -56,6 +56,9 @@ var sourceAnnotations = map[string]bool{
 	ManagementModeAnnotationKey:            true,
 	LifecycleMutationAnnotation:            true,
 	DeletionPropagationPolicyAnnotationKey: true,
+	DriftPolicyAnnotationKey:               true,
+	TransferToAnnotationKey:                true,
+	FieldOwnershipAnnotationKey:            true,
 }
 
 // IsSourceAnnotation returns true if the annotation is a ConfigSync source
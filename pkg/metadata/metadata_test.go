@@ -272,3 +272,32 @@ func TestUpdateConfigSyncMetadata(t *testing.T) {
 		})
 	}
 }
+
+func TestGetForceConflicts(t *testing.T) {
+	testcases := map[string]struct {
+		opts []core.MetaMutator
+		want bool
+	}{
+		"not set": {
+			want: true,
+		},
+		"true": {
+			opts: []core.MetaMutator{metadata.WithForceConflicts(true)},
+			want: true,
+		},
+		"false": {
+			opts: []core.MetaMutator{metadata.WithForceConflicts(false)},
+			want: false,
+		},
+		"invalid": {
+			opts: []core.MetaMutator{core.Annotation(metadata.ForceConflictsAnnotationKey, "never")},
+			want: false,
+		},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			rs := k8sobjects.RootSyncObjectV1Beta1("root-sync", tc.opts...)
+			assert.Equal(t, tc.want, metadata.GetForceConflicts(rs))
+		})
+	}
+}
@@ -17,6 +17,7 @@ package metrics
 import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
 	"k8s.io/klog/v2"
 )
 
@@ -49,6 +50,10 @@ const (
 	ResourceConflictsName = "resource_conflicts_total"
 	// InternalErrorsName is the name of internal error count metric
 	InternalErrorsName = "internal_errors_total"
+	// RemediatorQueueDepthName is the name of remediator queue depth metric
+	RemediatorQueueDepthName = "remediator_queue_depth"
+	// RemediatorQueueLatencyName is the name of remediator queue latency metric
+	RemediatorQueueLatencyName = "remediator_queue_latency_seconds"
 )
 
 var (
@@ -96,6 +101,14 @@ var (
 
 	// InternalErrors metric measures the number of unexpected internal errors triggered by defensive checks in Config Sync.
 	InternalErrors metric.Int64Counter
+
+	// RemediatorQueueDepth metric measures the number of objects waiting in the remediator queue.
+	// It defaults to a no-op, because the queue is also used before the metrics are initialized.
+	RemediatorQueueDepth metric.Int64Gauge = noop.Int64Gauge{}
+
+	// RemediatorQueueLatency metric measures how long objects wait in the remediator queue.
+	// It defaults to a no-op, because the queue is also used before the metrics are initialized.
+	RemediatorQueueLatency metric.Float64Histogram = noop.Float64Histogram{}
 )
 
 // InitializeOTelMetrics initializes OpenTelemetry metrics instruments
@@ -155,6 +168,15 @@ func InitializeOTelMetrics() error {
 		return err
 	}
 
+	RemediatorQueueLatency, err = meter.Float64Histogram(
+		RemediatorQueueLatencyName,
+		metric.WithDescription("The duration objects wait in the remediator queue before being processed"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+
 	// Initialize gauge instruments
 	ReconcilerErrors, err = meter.Int64Gauge(
 		ReconcilerErrorsName,
@@ -196,6 +218,14 @@ func InitializeOTelMetrics() error {
 		return err
 	}
 
+	RemediatorQueueDepth, err = meter.Int64Gauge(
+		RemediatorQueueDepthName,
+		metric.WithDescription("The number of objects waiting in the remediator queue"),
+	)
+	if err != nil {
+		return err
+	}
+
 	// Initialize counter instruments
 	ApplyOperations, err = meter.Int64Counter(
 		ApplyOperationsName,
@@ -170,6 +170,25 @@ func RecordRemediateDuration(ctx context.Context, status string, startTime time.
 	RemediateDuration.Record(ctx, duration, metric.WithAttributes(attrs...))
 }
 
+// RecordRemediatorQueueDepth produces measurements for the RemediatorQueueDepth view.
+func RecordRemediatorQueueDepth(ctx context.Context, kind string, depth int64) {
+	attrs := []attribute.KeyValue{
+		KeyKind.String(kind),
+	}
+	klog.V(5).Infof("METRIC DEBUG: Recording RemediatorQueueDepth: kind=%s, depth=%d", kind, depth)
+	RemediatorQueueDepth.Record(ctx, depth, metric.WithAttributes(attrs...))
+}
+
+// RecordRemediatorQueueLatency produces measurements for the RemediatorQueueLatency view.
+func RecordRemediatorQueueLatency(ctx context.Context, kind string, addedTime time.Time) {
+	attrs := []attribute.KeyValue{
+		KeyKind.String(kind),
+	}
+	latency := time.Since(addedTime).Seconds()
+	klog.V(5).Infof("METRIC DEBUG: Recording RemediatorQueueLatency: kind=%s, latency=%.3fs", kind, latency)
+	RemediatorQueueLatency.Record(ctx, latency, metric.WithAttributes(attrs...))
+}
+
 // RecordResourceConflict produces measurements for the ResourceConflicts view.
 func RecordResourceConflict(ctx context.Context, commit string) {
 	attrs := []attribute.KeyValue{
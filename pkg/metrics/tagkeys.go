@@ -70,6 +70,9 @@ var (
 	// TODO: replace with k8s.container.name resource attribute
 	KeyContainer = attribute.Key("container")
 
+	// KeyKind groups metrics by the GroupKind of the objects, like Deployment.apps.
+	KeyKind = attribute.Key("kind")
+
 	// KeyResourceType groups metrics by their resource types. Possible values: cpu, memory.
 	KeyResourceType = attribute.Key("resource")
 )
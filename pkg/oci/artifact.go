@@ -0,0 +1,158 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+const (
+	// ConfigArtifactType is the artifactType and config media type of a
+	// Config Sync configuration artifact, as produced by `nomos push`.
+	ConfigArtifactType types.MediaType = "application/vnd.configsync.config.v1+json"
+
+	// ConfigLayerMediaType is the media type of a gzipped tarball layer holding
+	// configuration files in a Config Sync configuration artifact.
+	ConfigLayerMediaType types.MediaType = "application/vnd.configsync.content.v1.tar+gzip"
+
+	// FluxContentLayerMediaType is the media type of the content layer of a
+	// Flux configuration artifact, as produced by `flux push artifact`.
+	FluxContentLayerMediaType types.MediaType = "application/vnd.cncf.flux.content.v1.tar+gzip"
+
+	// HelmChartConfigMediaType is the config media type of a Helm chart stored
+	// in an OCI registry.
+	HelmChartConfigMediaType types.MediaType = "application/vnd.cncf.helm.config.v1+json"
+
+	// HelmChartContentLayerMediaType is the media type of the layer holding the
+	// chart tarball of a Helm chart stored in an OCI registry.
+	HelmChartContentLayerMediaType types.MediaType = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+
+	// SourceAnnotation is the standard OCI annotation recording the URL of the
+	// source the artifact was built from.
+	SourceAnnotation = "org.opencontainers.image.source"
+
+	// RevisionAnnotation is the standard OCI annotation recording the revision
+	// of the source the artifact was built from.
+	RevisionAnnotation = "org.opencontainers.image.revision"
+
+	// TitleAnnotation is the standard OCI annotation recording the
+	// human-readable title of a layer.
+	TitleAnnotation = "org.opencontainers.image.title"
+)
+
+// configLayerMediaTypes are the layer media types extracted from a
+// configuration artifact when no LayerSelector is specified.
+var configLayerMediaTypes = map[types.MediaType]bool{
+	ConfigLayerMediaType:                 true,
+	FluxContentLayerMediaType:            true,
+	types.OCILayer:                       true,
+	types.OCILayerZStd:                   true,
+	types.OCIUncompressedLayer:           true,
+	types.DockerLayer:                    true,
+	types.DockerUncompressedLayer:        true,
+	types.OCIRestrictedLayer:             true,
+	types.OCIUncompressedRestrictedLayer: true,
+}
+
+// LayerSelector selects the layers of an OCI artifact to extract.
+type LayerSelector struct {
+	// MediaType selects layers with exactly this media type.
+	MediaType string
+	// Annotation selects layers with this annotation, in the form KEY or
+	// KEY=VALUE. When only KEY is specified, any value matches.
+	Annotation string
+}
+
+// IsEmpty returns true if the LayerSelector does not filter any layer.
+func (s LayerSelector) IsEmpty() bool {
+	return s.MediaType == "" && s.Annotation == ""
+}
+
+// String returns a human-readable representation of the LayerSelector.
+func (s LayerSelector) String() string {
+	var parts []string
+	if s.MediaType != "" {
+		parts = append(parts, fmt.Sprintf("mediaType=%q", s.MediaType))
+	}
+	if s.Annotation != "" {
+		parts = append(parts, fmt.Sprintf("annotation=%q", s.Annotation))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Matches returns true if the layer descriptor matches all the specified
+// selector fields.
+func (s LayerSelector) Matches(desc v1.Descriptor) bool {
+	if s.MediaType != "" && string(desc.MediaType) != s.MediaType {
+		return false
+	}
+	if s.Annotation != "" {
+		key, value, hasValue := strings.Cut(s.Annotation, "=")
+		actual, found := desc.Annotations[key]
+		if !found || (hasValue && actual != value) {
+			return false
+		}
+	}
+	return true
+}
+
+// isImageConfig returns true if the media type is the config media type of a
+// container image, as opposed to an artifact.
+func isImageConfig(mediaType types.MediaType) bool {
+	return mediaType == types.OCIConfigJSON || mediaType == types.DockerConfigJSON
+}
+
+// selectLayers returns the descriptors of the layers to extract from an image
+// with the specified manifest.
+//
+// It returns nil, without error, when the manifest describes a container image
+// and no selector is specified, in which case the layers should be flattened
+// like a container filesystem.
+func selectLayers(manifest *v1.Manifest, selector LayerSelector) ([]v1.Descriptor, error) {
+	isArtifact := manifest.ArtifactType != "" || !isImageConfig(manifest.Config.MediaType)
+	if selector.IsEmpty() {
+		if !isArtifact {
+			return nil, nil
+		}
+		if manifest.Config.MediaType == HelmChartConfigMediaType {
+			return nil, fmt.Errorf("the OCI artifact is a Helm chart: use sourceType: helm with an oci:// repo to render it, " +
+				"or specify a layerSelector to extract the chart files as-is")
+		}
+		var layers []v1.Descriptor
+		for _, layer := range manifest.Layers {
+			if configLayerMediaTypes[layer.MediaType] {
+				layers = append(layers, layer)
+			}
+		}
+		if len(layers) == 0 {
+			return nil, fmt.Errorf("the OCI artifact has no layer with a supported media type: specify a layerSelector to choose the layers to extract")
+		}
+		return layers, nil
+	}
+	var layers []v1.Descriptor
+	for _, layer := range manifest.Layers {
+		if selector.Matches(layer) {
+			layers = append(layers, layer)
+		}
+	}
+	if len(layers) == 0 {
+		return nil, fmt.Errorf("no layer of the OCI artifact matches the layer selector (%s)", selector)
+	}
+	return layers, nil
+}
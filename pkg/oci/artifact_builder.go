@@ -0,0 +1,158 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+// BuildArtifact builds a Config Sync configuration artifact from the files in
+// the specified directory.
+//
+// The artifact has a single gzipped tarball layer with the
+// ConfigLayerMediaType, holding every file under the directory except `.git`
+// directories. File timestamps and ownership are omitted from the tarball, so
+// building the same files always produces the same digest.
+//
+// The annotations are added to the manifest of the artifact.
+func BuildArtifact(dir string, annotations map[string]string) (v1.Image, error) {
+	content, err := tarDirectory(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive directory %q: %w", dir, err)
+	}
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(content)), nil
+	}, tarball.WithMediaType(ConfigLayerMediaType))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create layer: %w", err)
+	}
+	image := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	image = mutate.ConfigMediaType(image, ConfigArtifactType)
+	image, err = mutate.Append(image, mutate.Addendum{
+		Layer:     layer,
+		MediaType: ConfigLayerMediaType,
+		Annotations: map[string]string{
+			TitleAnnotation: filepath.Base(filepath.Clean(dir)),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to append layer: %w", err)
+	}
+	if len(annotations) > 0 {
+		image = mutate.Annotations(image, annotations).(v1.Image)
+	}
+	return image, nil
+}
+
+// PushArtifact pushes the artifact to the specified image reference and
+// returns the digest of the pushed artifact.
+func PushArtifact(ctx context.Context, image v1.Image, imageName string, authenticator authn.Authenticator) (v1.Hash, error) {
+	ref, err := name.ParseReference(imageName)
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("failed to parse reference %q: %w", imageName, err)
+	}
+	if err := remote.Write(ref, image, remote.WithContext(ctx), remote.WithAuth(authenticator)); err != nil {
+		return v1.Hash{}, fmt.Errorf("failed to push image %s: %w", imageName, err)
+	}
+	return image.Digest()
+}
+
+// tarDirectory returns a gzipped tarball of the files in the directory, with
+// paths relative to the directory.
+func tarDirectory(dir string) ([]byte, error) {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if relPath == "." {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		var link string
+		if info.Mode()&fs.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(relPath)
+		if d.IsDir() {
+			hdr.Name += "/"
+		}
+		// Drop metadata that would change the digest between builds.
+		hdr.ModTime = time.Unix(0, 0)
+		hdr.AccessTime, hdr.ChangeTime = time.Time{}, time.Time{}
+		hdr.Uid, hdr.Gid, hdr.Uname, hdr.Gname = 0, 0, "", ""
+		if err := tarWriter.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+		_, err = io.Copy(tarWriter, file)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := tarWriter.Close(); err != nil {
+		return nil, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
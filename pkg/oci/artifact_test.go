@@ -0,0 +1,262 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLayerSelectorMatches(t *testing.T) {
+	desc := v1.Descriptor{
+		MediaType: ConfigLayerMediaType,
+		Annotations: map[string]string{
+			TitleAnnotation: "prod",
+		},
+	}
+	testCases := map[string]struct {
+		selector LayerSelector
+		want     bool
+	}{
+		"empty selector": {
+			selector: LayerSelector{},
+			want:     true,
+		},
+		"matching media type": {
+			selector: LayerSelector{MediaType: string(ConfigLayerMediaType)},
+			want:     true,
+		},
+		"different media type": {
+			selector: LayerSelector{MediaType: string(types.OCILayer)},
+			want:     false,
+		},
+		"annotation key": {
+			selector: LayerSelector{Annotation: TitleAnnotation},
+			want:     true,
+		},
+		"annotation key and value": {
+			selector: LayerSelector{Annotation: TitleAnnotation + "=prod"},
+			want:     true,
+		},
+		"different annotation value": {
+			selector: LayerSelector{Annotation: TitleAnnotation + "=dev"},
+			want:     false,
+		},
+		"missing annotation": {
+			selector: LayerSelector{Annotation: "example.com/env"},
+			want:     false,
+		},
+		"media type and different annotation value": {
+			selector: LayerSelector{MediaType: string(ConfigLayerMediaType), Annotation: TitleAnnotation + "=dev"},
+			want:     false,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.selector.Matches(desc))
+		})
+	}
+}
+
+func TestSelectLayers(t *testing.T) {
+	configLayer := v1.Descriptor{MediaType: ConfigLayerMediaType}
+	fluxLayer := v1.Descriptor{MediaType: FluxContentLayerMediaType}
+	readmeLayer := v1.Descriptor{MediaType: "text/markdown"}
+	chartLayer := v1.Descriptor{MediaType: HelmChartContentLayerMediaType}
+	testCases := map[string]struct {
+		manifest  *v1.Manifest
+		selector  LayerSelector
+		want      []v1.Descriptor
+		wantError bool
+	}{
+		"container image is flattened": {
+			manifest: &v1.Manifest{
+				Config: v1.Descriptor{MediaType: types.OCIConfigJSON},
+				Layers: []v1.Descriptor{{MediaType: types.OCILayer}, {MediaType: types.OCILayer}},
+			},
+			want: nil,
+		},
+		"artifact extracts supported layers": {
+			manifest: &v1.Manifest{
+				Config: v1.Descriptor{MediaType: ConfigArtifactType},
+				Layers: []v1.Descriptor{configLayer, readmeLayer, fluxLayer},
+			},
+			want: []v1.Descriptor{configLayer, fluxLayer},
+		},
+		"artifact type with image config extracts supported layers": {
+			manifest: &v1.Manifest{
+				ArtifactType: string(ConfigArtifactType),
+				Config:       v1.Descriptor{MediaType: types.OCIConfigJSON},
+				Layers:       []v1.Descriptor{readmeLayer, configLayer},
+			},
+			want: []v1.Descriptor{configLayer},
+		},
+		"artifact without supported layers": {
+			manifest: &v1.Manifest{
+				Config: v1.Descriptor{MediaType: ConfigArtifactType},
+				Layers: []v1.Descriptor{readmeLayer},
+			},
+			wantError: true,
+		},
+		"helm chart without selector": {
+			manifest: &v1.Manifest{
+				Config: v1.Descriptor{MediaType: HelmChartConfigMediaType},
+				Layers: []v1.Descriptor{chartLayer},
+			},
+			wantError: true,
+		},
+		"helm chart with selector": {
+			manifest: &v1.Manifest{
+				Config: v1.Descriptor{MediaType: HelmChartConfigMediaType},
+				Layers: []v1.Descriptor{chartLayer},
+			},
+			selector: LayerSelector{MediaType: string(HelmChartContentLayerMediaType)},
+			want:     []v1.Descriptor{chartLayer},
+		},
+		"selector on container image": {
+			manifest: &v1.Manifest{
+				Config: v1.Descriptor{MediaType: types.DockerConfigJSON},
+				Layers: []v1.Descriptor{{MediaType: types.DockerLayer}, configLayer},
+			},
+			selector: LayerSelector{MediaType: string(ConfigLayerMediaType)},
+			want:     []v1.Descriptor{configLayer},
+		},
+		"selector without match": {
+			manifest: &v1.Manifest{
+				Config: v1.Descriptor{MediaType: ConfigArtifactType},
+				Layers: []v1.Descriptor{configLayer},
+			},
+			selector:  LayerSelector{Annotation: TitleAnnotation},
+			wantError: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := selectLayers(tc.manifest, tc.selector)
+			if tc.wantError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestBuildArtifactAndExtract(t *testing.T) {
+	srcDir := t.TempDir()
+	writeFile(t, filepath.Join(srcDir, "namespaces", "bookstore", "ns.yaml"), "kind: Namespace\n")
+	writeFile(t, filepath.Join(srcDir, "cluster", "clusterrole.yaml"), "kind: ClusterRole\n")
+	writeFile(t, filepath.Join(srcDir, ".git", "HEAD"), "ref: refs/heads/main\n")
+
+	annotations := map[string]string{RevisionAnnotation: "v1.0.0"}
+	image, err := BuildArtifact(srcDir, annotations)
+	require.NoError(t, err)
+
+	manifest, err := image.Manifest()
+	require.NoError(t, err)
+	assert.Equal(t, types.OCIManifestSchema1, manifest.MediaType)
+	assert.Equal(t, ConfigArtifactType, manifest.Config.MediaType)
+	assert.Equal(t, annotations, manifest.Annotations)
+	require.Len(t, manifest.Layers, 1)
+	assert.Equal(t, ConfigLayerMediaType, manifest.Layers[0].MediaType)
+
+	// Building the same files again produces the same digest.
+	image2, err := BuildArtifact(srcDir, annotations)
+	require.NoError(t, err)
+	digest, err := image.Digest()
+	require.NoError(t, err)
+	digest2, err := image2.Digest()
+	require.NoError(t, err)
+	assert.Equal(t, digest, digest2)
+
+	destDir := t.TempDir()
+	require.NoError(t, extract(image, destDir, LayerSelector{}))
+	assertFileContent(t, filepath.Join(destDir, "namespaces", "bookstore", "ns.yaml"), "kind: Namespace\n")
+	assertFileContent(t, filepath.Join(destDir, "cluster", "clusterrole.yaml"), "kind: ClusterRole\n")
+	assert.NoFileExists(t, filepath.Join(destDir, ".git", "HEAD"))
+}
+
+func TestExtractContainerImage(t *testing.T) {
+	baseDir := t.TempDir()
+	writeFile(t, filepath.Join(baseDir, "base.yaml"), "kind: ConfigMap\n")
+	overlayDir := t.TempDir()
+	writeFile(t, filepath.Join(overlayDir, "overlay.yaml"), "kind: Secret\n")
+
+	var layers []v1.Layer
+	for _, dir := range []string{baseDir, overlayDir} {
+		artifact, err := BuildArtifact(dir, nil)
+		require.NoError(t, err)
+		artifactLayers, err := artifact.Layers()
+		require.NoError(t, err)
+		layers = append(layers, artifactLayers...)
+	}
+	image, err := mutate.AppendLayers(empty.Image, layers...)
+	require.NoError(t, err)
+
+	destDir := t.TempDir()
+	require.NoError(t, extract(image, destDir, LayerSelector{}))
+	assertFileContent(t, filepath.Join(destDir, "base.yaml"), "kind: ConfigMap\n")
+	assertFileContent(t, filepath.Join(destDir, "overlay.yaml"), "kind: Secret\n")
+}
+
+func TestPushArtifactAndFetchPackage(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	imageName := strings.TrimPrefix(server.URL, "http://") + "/configs/prod:v1"
+
+	srcDir := t.TempDir()
+	writeFile(t, filepath.Join(srcDir, "ns.yaml"), "kind: Namespace\n")
+	image, err := BuildArtifact(srcDir, nil)
+	require.NoError(t, err)
+	digest, err := PushArtifact(context.Background(), image, imageName, authn.Anonymous)
+	require.NoError(t, err)
+
+	ociRoot := t.TempDir()
+	fetcher := &Fetcher{Authenticator: authn.Anonymous}
+	require.NoError(t, fetcher.FetchPackage(context.Background(), imageName, ociRoot, "rev"))
+	assertFileContent(t, filepath.Join(ociRoot, "rev", "ns.yaml"), "kind: Namespace\n")
+	linkTarget, err := filepath.EvalSymlinks(filepath.Join(ociRoot, "rev"))
+	require.NoError(t, err)
+	assert.Equal(t, digest.Hex, filepath.Base(linkTarget))
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func assertFileContent(t *testing.T, path, want string) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, string(got))
+}
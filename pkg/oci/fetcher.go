@@ -15,7 +15,6 @@
 package oci
 
 import (
-	"archive/tar"
 	"context"
 	"fmt"
 	"io"
@@ -23,6 +22,7 @@ import (
 	"path/filepath"
 
 	"github.com/GoogleContainerTools/config-sync/pkg/util"
+	"github.com/GoogleContainerTools/config-sync/pkg/util/archive"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
@@ -48,6 +48,10 @@ func HasDigest(imageName string) bool {
 type Fetcher struct {
 	// Authenticator is used to authenticate with the OCI repository.
 	Authenticator authn.Authenticator
+	// LayerSelector selects the layers to extract from the image.
+	// If empty, container images are flattened and every layer with a
+	// supported media type is extracted from configuration artifacts.
+	LayerSelector LayerSelector
 }
 
 // FetchPackage fetches the package from the OCI repository and write it to the destination.
@@ -84,7 +88,7 @@ func (f *Fetcher) FetchPackage(ctx context.Context, imageName, ociRoot, rev stri
 		return fmt.Errorf("failed to check the directory %q: %w", destDir, err)
 	}
 
-	err = extract(image, destDir)
+	err = extract(image, destDir, f.LayerSelector)
 	if err != nil {
 		return fmt.Errorf("failed to extract the image and write to the directory %q: %w", destDir, err)
 	}
@@ -108,55 +112,47 @@ func PullImage(imageName string, options ...remote.Option) (v1.Image, error) {
 }
 
 // extract extracts (untar) image files to target directory.
-func extract(image v1.Image, dir string) error {
-	// Stream image files as if single tar (merged layers)
-	ioReader := mutate.Extract(image)
-	defer func() {
-		if err := ioReader.Close(); err != nil {
-			klog.Warningf("failed to close ioReader: %v", err)
-		}
-	}()
-
-	// Write contents to target dir
-	tarReader := tar.NewReader(ioReader)
-	for {
-		hdr, err := tarReader.Next()
-		if err == io.EOF {
-			break
+//
+// Container images are extracted as if all the layers were merged into a
+// single filesystem. Configuration artifacts only have the layers selected by
+// the LayerSelector extracted, in manifest order.
+func extract(image v1.Image, dir string, selector LayerSelector) error {
+	manifest, err := image.Manifest()
+	if err != nil {
+		return fmt.Errorf("failed to read the image manifest: %w", err)
+	}
+	layers, err := selectLayers(manifest, selector)
+	if err != nil {
+		return err
+	}
+	if layers == nil {
+		// Stream image files as if single tar (merged layers)
+		return extractReader(mutate.Extract(image), dir)
+	}
+	for _, desc := range layers {
+		layer, err := image.LayerByDigest(desc.Digest)
+		if err != nil {
+			return fmt.Errorf("failed to get layer %q: %w", desc.Digest, err)
 		}
+		// Uncompressed detects and decompresses gzip and zstd layers.
+		ioReader, err := layer.Uncompressed()
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to read layer %q: %w", desc.Digest, err)
 		}
-		path := filepath.Join(dir, hdr.Name)
-		switch {
-		case hdr.FileInfo().IsDir():
-			if err := os.MkdirAll(path, hdr.FileInfo().Mode()); err != nil {
-				return err
-			}
-		case hdr.Linkname != "":
-			if err := os.Symlink(hdr.Linkname, path); err != nil {
-				klog.Warning(err)
-			}
-		default:
-			file, err := os.OpenFile(path,
-				os.O_WRONLY|os.O_CREATE|os.O_TRUNC,
-				os.FileMode(hdr.Mode),
-			)
-			if err != nil {
-				return err
-			}
-			defer func() {
-				if err := file.Close(); err != nil {
-					klog.Warningf("failed to close file %q: %v", file.Name(), err)
-				}
-			}()
-
-			_, err = io.Copy(file, tarReader)
-			if err != nil {
-				return err
-			}
+		if err := extractReader(ioReader, dir); err != nil {
+			return fmt.Errorf("failed to extract layer %q: %w", desc.Digest, err)
 		}
 	}
-
 	return nil
 }
+
+// extractReader extracts the tar stream to the target directory and closes
+// the reader.
+func extractReader(ioReader io.ReadCloser, dir string) error {
+	defer func() {
+		if err := ioReader.Close(); err != nil {
+			klog.Warningf("failed to close ioReader: %v", err)
+		}
+	}()
+	return archive.ExtractTar(ioReader, dir)
+}
@@ -41,6 +41,18 @@ type Options struct {
 	// ClusterName is the name of the cluster we're syncing configuration to.
 	ClusterName string
 
+	// ClusterLabels are the labels of the cluster we're syncing configuration
+	// to, sourced from the fleet Membership and the ClusterInfo ConfigMap.
+	ClusterLabels map[string]string
+
+	// SubstitutionEnabled is whether the `${cluster.*}` and `${values.*}`
+	// variables are substituted in the resources.
+	SubstitutionEnabled bool
+
+	// SubstitutionValues are the values of the `${values.KEY}` variables
+	// substituted in the resources.
+	SubstitutionValues map[string]string
+
 	// Client knows how to read objects from a Kubernetes cluster and update
 	// status.
 	Client client.Client
@@ -93,4 +105,8 @@ type ReconcilerOptions struct {
 	// RenderingEnabled indicates whether the hydration-controller is currently
 	// running for this reconciler.
 	RenderingEnabled bool
+
+	// FunctionPipelineEnabled indicates whether a Kptfile declaring a KRM
+	// function pipeline requires rendering. Kptfiles are ignored otherwise.
+	FunctionPipelineEnabled bool
 }
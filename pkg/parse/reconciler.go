@@ -16,6 +16,9 @@ package parse
 
 import (
 	"context"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/hydrate"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/filesystem/cmpath"
 )
 
 // Reconciler reconciles the cluster with config from the source of truth.
@@ -54,6 +57,9 @@ type reconciler struct {
 	syncStatusClient SyncStatusClient
 	parser           Parser
 	reconcilerState  *ReconcilerState
+	// signalClient is the client of the signal socket of the
+	// hydration-controller. It is nil if rendering is disabled.
+	signalClient *hydrate.SignalClient
 }
 
 var _ Reconciler = &reconciler{}
@@ -76,6 +82,7 @@ func NewRootSyncReconciler(recOpts *ReconcilerOptions, parseOpts *RootOptions) R
 		reconcilerState: &ReconcilerState{
 			syncErrorCache: recOpts.SyncErrorCache,
 		},
+		signalClient: newSignalClient(recOpts),
 		syncStatusClient: &rootSyncStatusClient{
 			options: parseOpts.Options,
 		},
@@ -93,6 +100,7 @@ func NewRepoSyncReconciler(recOpts *ReconcilerOptions, parseOpts *Options) Recon
 		reconcilerState: &ReconcilerState{
 			syncErrorCache: recOpts.SyncErrorCache,
 		},
+		signalClient: newSignalClient(recOpts),
 		syncStatusClient: &repoSyncStatusClient{
 			options: parseOpts,
 		},
@@ -101,3 +109,12 @@ func NewRepoSyncReconciler(recOpts *ReconcilerOptions, parseOpts *Options) Recon
 		},
 	}
 }
+
+// newSignalClient returns the client of the signal socket of the
+// hydration-controller, or nil if rendering is disabled.
+func newSignalClient(recOpts *ReconcilerOptions) *hydrate.SignalClient {
+	if !recOpts.RenderingEnabled {
+		return nil
+	}
+	return hydrate.NewSignalClient(recOpts.HydrationSignalsDir.Join(cmpath.RelativeSlash(hydrate.SignalSocketFile)).OSPath())
+}
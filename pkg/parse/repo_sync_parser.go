@@ -51,13 +51,16 @@ func (p *repoSyncParser) ParseSource(ctx context.Context, state *sourceState) ([
 	}
 
 	options := validate.Options{
-		ClusterName:  opts.ClusterName,
-		SyncName:     opts.SyncName,
-		PolicyDir:    opts.SyncDir,
-		PreviousCRDs: crds,
-		BuildScoper:  builder,
-		Converter:    opts.Converter,
-		Scheme:       opts.Client.Scheme(),
+		ClusterName:         opts.ClusterName,
+		ClusterLabels:       opts.ClusterLabels,
+		SubstitutionEnabled: opts.SubstitutionEnabled,
+		SubstitutionValues:  opts.SubstitutionValues,
+		SyncName:            opts.SyncName,
+		PolicyDir:           opts.SyncDir,
+		PreviousCRDs:        crds,
+		BuildScoper:         builder,
+		Converter:           opts.Converter,
+		Scheme:              opts.Client.Scheme(),
 		// Namespaces and NamespaceSelectors should not be declared in a namespace repo.
 		// So disable the API call and dynamic mode of NamespaceSelector.
 		AllowAPICall:             false,
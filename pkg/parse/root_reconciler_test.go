@@ -39,6 +39,7 @@ import (
 	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
 	"github.com/GoogleContainerTools/config-sync/pkg/metrics"
 	"github.com/GoogleContainerTools/config-sync/pkg/remediator/conflict"
+	"github.com/GoogleContainerTools/config-sync/pkg/remediator/drift"
 	remediatorfake "github.com/GoogleContainerTools/config-sync/pkg/remediator/fake"
 	"github.com/GoogleContainerTools/config-sync/pkg/rootsync"
 	"github.com/GoogleContainerTools/config-sync/pkg/status"
@@ -697,7 +698,7 @@ func TestRootReconciler_ParseAndUpdate(t *testing.T) {
 					syncPath: "/",
 					files:    files,
 				},
-				syncErrorCache: NewSyncErrorCache(conflict.NewHandler(), fight.NewHandler()),
+				syncErrorCache: NewSyncErrorCache(conflict.NewHandler(), fight.NewHandler(), drift.NewHandler("", nil, nil)),
 			}
 			opts := &Options{
 				Clock:             fakeClock,
@@ -943,7 +944,7 @@ func TestRootReconciler_Parse_DeclaredFields(t *testing.T) {
 				status:         &ReconcilerStatus{},
 				cache:          cacheForCommit{},
 				source:         &sourceState{},
-				syncErrorCache: NewSyncErrorCache(conflict.NewHandler(), fight.NewHandler()),
+				syncErrorCache: NewSyncErrorCache(conflict.NewHandler(), fight.NewHandler(), drift.NewHandler("", nil, nil)),
 			}
 			opts := &Options{
 				Clock:             clock.RealClock{}, // TODO: Test with fake clock
@@ -1230,7 +1231,7 @@ func TestRootReconciler_Parse_Discovery(t *testing.T) {
 				status:         &ReconcilerStatus{},
 				cache:          cacheForCommit{},
 				source:         &sourceState{},
-				syncErrorCache: NewSyncErrorCache(conflict.NewHandler(), fight.NewHandler()),
+				syncErrorCache: NewSyncErrorCache(conflict.NewHandler(), fight.NewHandler(), drift.NewHandler("", nil, nil)),
 			}
 			opts := &Options{
 				Clock:             clock.RealClock{}, // TODO: Test with fake clock
@@ -1349,7 +1350,7 @@ func TestRootReconciler_Parse_SourceErrorMetricValidation(t *testing.T) {
 				status:         &ReconcilerStatus{},
 				cache:          cacheForCommit{},
 				source:         &sourceState{},
-				syncErrorCache: NewSyncErrorCache(conflict.NewHandler(), fight.NewHandler()),
+				syncErrorCache: NewSyncErrorCache(conflict.NewHandler(), fight.NewHandler(), drift.NewHandler("", nil, nil)),
 			}
 			opts := &Options{
 				Clock:             clock.RealClock{}, // TODO: Test with fake clock
@@ -1466,7 +1467,7 @@ func TestRootReconciler_Update_ApplierErrorMetricValidation(t *testing.T) {
 				status:         &ReconcilerStatus{},
 				cache:          cacheForCommit{},
 				source:         &sourceState{},
-				syncErrorCache: NewSyncErrorCache(conflict.NewHandler(), fight.NewHandler()),
+				syncErrorCache: NewSyncErrorCache(conflict.NewHandler(), fight.NewHandler(), drift.NewHandler("", nil, nil)),
 			}
 			opts := &Options{
 				Clock:             clock.RealClock{}, // TODO: Test with fake clock
@@ -23,10 +23,12 @@ import (
 
 	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
 	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync/v1beta1"
+	"github.com/GoogleContainerTools/config-sync/pkg/applier"
 	"github.com/GoogleContainerTools/config-sync/pkg/core"
 	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
 	"github.com/GoogleContainerTools/config-sync/pkg/metrics"
 	"github.com/GoogleContainerTools/config-sync/pkg/reconciler/namespacecontroller"
+	"github.com/GoogleContainerTools/config-sync/pkg/remediator/drift"
 	"github.com/GoogleContainerTools/config-sync/pkg/rootsync"
 	"github.com/GoogleContainerTools/config-sync/pkg/status"
 	"github.com/GoogleContainerTools/config-sync/pkg/util/compare"
@@ -145,6 +147,8 @@ func setSourceStatusFields(source *v1beta1.SourceStatus, newStatus *SourceStatus
 			Dir:      newSourceSpec.Dir,
 		}
 		source.Oci = nil
+		source.HTTP = nil
+		source.Bucket = nil
 		source.Helm = nil
 	case OCISourceSpec:
 		source.Oci = &v1beta1.OciStatus{
@@ -152,6 +156,26 @@ func setSourceStatusFields(source *v1beta1.SourceStatus, newStatus *SourceStatus
 			Dir:   newSourceSpec.Dir,
 		}
 		source.Git = nil
+		source.HTTP = nil
+		source.Bucket = nil
+		source.Helm = nil
+	case HTTPSourceSpec:
+		source.HTTP = &v1beta1.HTTPStatus{
+			URL: newSourceSpec.URL,
+			Dir: newSourceSpec.Dir,
+		}
+		source.Git = nil
+		source.Oci = nil
+		source.Bucket = nil
+		source.Helm = nil
+	case BucketSourceSpec:
+		source.Bucket = &v1beta1.BucketStatus{
+			URL: newSourceSpec.URL,
+			Dir: newSourceSpec.Dir,
+		}
+		source.Git = nil
+		source.Oci = nil
+		source.HTTP = nil
 		source.Helm = nil
 	case HelmSourceSpec:
 		source.Helm = &v1beta1.HelmStatus{
@@ -161,10 +185,14 @@ func setSourceStatusFields(source *v1beta1.SourceStatus, newStatus *SourceStatus
 		}
 		source.Git = nil
 		source.Oci = nil
+		source.HTTP = nil
+		source.Bucket = nil
 	default:
 		source.Helm = nil
 		source.Git = nil
 		source.Oci = nil
+		source.HTTP = nil
+		source.Bucket = nil
 	}
 	errorSummary := &v1beta1.ErrorSummary{
 		TotalCount:                len(cse),
@@ -302,6 +330,8 @@ func setRenderingStatusFields(rendering *v1beta1.RenderingStatus, newStatus *Ren
 			Dir:      newSourceSpec.Dir,
 		}
 		rendering.Oci = nil
+		rendering.HTTP = nil
+		rendering.Bucket = nil
 		rendering.Helm = nil
 	case OCISourceSpec:
 		rendering.Oci = &v1beta1.OciStatus{
@@ -309,6 +339,26 @@ func setRenderingStatusFields(rendering *v1beta1.RenderingStatus, newStatus *Ren
 			Dir:   newSourceSpec.Dir,
 		}
 		rendering.Git = nil
+		rendering.HTTP = nil
+		rendering.Bucket = nil
+		rendering.Helm = nil
+	case HTTPSourceSpec:
+		rendering.HTTP = &v1beta1.HTTPStatus{
+			URL: newSourceSpec.URL,
+			Dir: newSourceSpec.Dir,
+		}
+		rendering.Git = nil
+		rendering.Oci = nil
+		rendering.Bucket = nil
+		rendering.Helm = nil
+	case BucketSourceSpec:
+		rendering.Bucket = &v1beta1.BucketStatus{
+			URL: newSourceSpec.URL,
+			Dir: newSourceSpec.Dir,
+		}
+		rendering.Git = nil
+		rendering.Oci = nil
+		rendering.HTTP = nil
 		rendering.Helm = nil
 	case HelmSourceSpec:
 		rendering.Helm = &v1beta1.HelmStatus{
@@ -318,10 +368,14 @@ func setRenderingStatusFields(rendering *v1beta1.RenderingStatus, newStatus *Ren
 		}
 		rendering.Git = nil
 		rendering.Oci = nil
+		rendering.HTTP = nil
+		rendering.Bucket = nil
 	default:
 		rendering.Helm = nil
 		rendering.Git = nil
 		rendering.Oci = nil
+		rendering.HTTP = nil
+		rendering.Bucket = nil
 	}
 	rendering.Message = newStatus.Message
 	errorSummary := &v1beta1.ErrorSummary{
@@ -405,6 +459,44 @@ func reconcilerStatusFromRSyncStatus(rsyncStatus v1beta1.Status, sourceType conf
 				Dir:   rsyncStatus.Sync.Oci.Dir,
 			}
 		}
+	case configsync.HTTPSource:
+		if rsyncStatus.Source.HTTP != nil {
+			sourceSpec = HTTPSourceSpec{
+				URL: rsyncStatus.Source.HTTP.URL,
+				Dir: rsyncStatus.Source.HTTP.Dir,
+			}
+		}
+		if rsyncStatus.Rendering.HTTP != nil {
+			renderSpec = HTTPSourceSpec{
+				URL: rsyncStatus.Rendering.HTTP.URL,
+				Dir: rsyncStatus.Rendering.HTTP.Dir,
+			}
+		}
+		if rsyncStatus.Sync.HTTP != nil {
+			syncSpec = HTTPSourceSpec{
+				URL: rsyncStatus.Sync.HTTP.URL,
+				Dir: rsyncStatus.Sync.HTTP.Dir,
+			}
+		}
+	case configsync.BucketSource:
+		if rsyncStatus.Source.Bucket != nil {
+			sourceSpec = BucketSourceSpec{
+				URL: rsyncStatus.Source.Bucket.URL,
+				Dir: rsyncStatus.Source.Bucket.Dir,
+			}
+		}
+		if rsyncStatus.Rendering.Bucket != nil {
+			renderSpec = BucketSourceSpec{
+				URL: rsyncStatus.Rendering.Bucket.URL,
+				Dir: rsyncStatus.Rendering.Bucket.Dir,
+			}
+		}
+		if rsyncStatus.Sync.Bucket != nil {
+			syncSpec = BucketSourceSpec{
+				URL: rsyncStatus.Sync.Bucket.URL,
+				Dir: rsyncStatus.Sync.Bucket.Dir,
+			}
+		}
 	case configsync.HelmSource:
 		if rsyncStatus.Source.Helm != nil {
 			sourceSpec = HelmSourceSpec{
@@ -543,8 +635,12 @@ func setSyncStatusFields(syncStatus *v1beta1.Status, newStatus *SyncStatus, deno
 	syncStatus.Sync.Commit = newStatus.Commit
 	syncStatus.Sync.Git = syncStatus.Source.Git
 	syncStatus.Sync.Oci = syncStatus.Source.Oci
+	syncStatus.Sync.HTTP = syncStatus.Source.HTTP
+	syncStatus.Sync.Bucket = syncStatus.Source.Bucket
 	syncStatus.Sync.Helm = syncStatus.Source.Helm
 	setSyncStatusErrors(syncStatus, cse, denominator)
+	setSyncStatusDrift(syncStatus, newStatus.Drift, denominator)
+	setSyncStatusTransfers(syncStatus, newStatus.Transfers, denominator)
 	syncStatus.Sync.LastUpdate = newStatus.LastUpdate
 }
 
@@ -557,6 +653,63 @@ func setSyncStatusErrors(syncStatus *v1beta1.Status, cse []v1beta1.ConfigSyncErr
 	syncStatus.Sync.Errors = cse[0 : len(cse)/denominator]
 }
 
+// maxDriftRecords is the maximum number of drift records reported in the
+// RSync status, to keep the object size bounded.
+const maxDriftRecords = 100
+
+func setSyncStatusDrift(syncStatus *v1beta1.Status, records []drift.Record, denominator int) {
+	limit := maxDriftRecords / denominator
+	if len(records) > limit {
+		records = records[:limit]
+	}
+	syncStatus.Sync.Drift = nil
+	for _, rec := range records {
+		syncStatus.Sync.Drift = append(syncStatus.Sync.Drift, v1beta1.DriftRecord{
+			Resource: v1beta1.ResourceRef{
+				SourcePath: rec.SourcePath,
+				Name:       rec.ID.Name,
+				Namespace:  rec.ID.Namespace,
+				GVK: metav1.GroupVersionKind{
+					Group:   rec.ID.Group,
+					Version: rec.Version,
+					Kind:    rec.ID.Kind,
+				},
+			},
+			Operation:  rec.Operation,
+			Fields:     rec.Fields,
+			Actor:      rec.Actor,
+			DetectedAt: metav1.NewTime(rec.DetectedAt),
+		})
+	}
+}
+
+// maxTransferRecords is the maximum number of transfer records reported in
+// the RSync status, to keep the object size bounded.
+const maxTransferRecords = 100
+
+func setSyncStatusTransfers(syncStatus *v1beta1.Status, records []applier.TransferRecord, denominator int) {
+	limit := maxTransferRecords / denominator
+	if len(records) > limit {
+		records = records[:limit]
+	}
+	syncStatus.Sync.Transfers = nil
+	for _, rec := range records {
+		syncStatus.Sync.Transfers = append(syncStatus.Sync.Transfers, v1beta1.TransferRecord{
+			Resource: v1beta1.ResourceRef{
+				SourcePath: rec.SourcePath,
+				Name:       rec.ID.Name,
+				Namespace:  rec.ID.Namespace,
+				GVK: metav1.GroupVersionKind{
+					Group:   rec.ID.Group,
+					Version: rec.Version,
+					Kind:    rec.ID.Kind,
+				},
+			},
+			To: rec.To,
+		})
+	}
+}
+
 // summarizeErrorsForCommit summarizes the source, rendering, and sync errors
 // for a specific commit.
 //
@@ -15,10 +15,18 @@
 package parse
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync/v1beta1"
+	"github.com/GoogleContainerTools/config-sync/pkg/applier"
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/remediator/drift"
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestSummarizeErrors(t *testing.T) {
@@ -450,3 +458,98 @@ func TestSummarizeErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestSetSyncStatusDrift(t *testing.T) {
+	detectedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	var records []drift.Record
+	for i := 0; i < maxDriftRecords+1; i++ {
+		records = append(records, drift.Record{
+			ID: core.ID{
+				GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+				ObjectKey: client.ObjectKey{Namespace: "bookstore", Name: fmt.Sprintf("web-%d", i)},
+			},
+			Version:    "v1",
+			SourcePath: "bookstore/web.yaml",
+			Operation:  drift.OperationUpdate,
+			Fields:     []string{"spec.replicas"},
+			Actor:      "kubectl-edit",
+			DetectedAt: detectedAt,
+		})
+	}
+
+	syncStatus := &v1beta1.Status{}
+	setSyncStatusDrift(syncStatus, records, 1)
+	if len(syncStatus.Sync.Drift) != maxDriftRecords {
+		t.Errorf("want %d drift records, got %d", maxDriftRecords, len(syncStatus.Sync.Drift))
+	}
+	want := v1beta1.DriftRecord{
+		Resource: v1beta1.ResourceRef{
+			SourcePath: "bookstore/web.yaml",
+			Name:       "web-0",
+			Namespace:  "bookstore",
+			GVK:        metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		},
+		Operation:  drift.OperationUpdate,
+		Fields:     []string{"spec.replicas"},
+		Actor:      "kubectl-edit",
+		DetectedAt: metav1.NewTime(detectedAt),
+	}
+	if diff := cmp.Diff(want, syncStatus.Sync.Drift[0]); diff != "" {
+		t.Errorf("unexpected drift record (-want +got):\n%s", diff)
+	}
+
+	// Truncated with the errors when the RSync is too large
+	setSyncStatusDrift(syncStatus, records, 4)
+	if len(syncStatus.Sync.Drift) != maxDriftRecords/4 {
+		t.Errorf("want %d drift records, got %d", maxDriftRecords/4, len(syncStatus.Sync.Drift))
+	}
+
+	setSyncStatusDrift(syncStatus, nil, 1)
+	if syncStatus.Sync.Drift != nil {
+		t.Errorf("want no drift records, got %v", syncStatus.Sync.Drift)
+	}
+}
+
+func TestSetSyncStatusTransfers(t *testing.T) {
+	var records []applier.TransferRecord
+	for i := 0; i < maxTransferRecords+1; i++ {
+		records = append(records, applier.TransferRecord{
+			ID: core.ID{
+				GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+				ObjectKey: client.ObjectKey{Namespace: "bookstore", Name: fmt.Sprintf("web-%d", i)},
+			},
+			Version:    "v1",
+			SourcePath: "bookstore/web.yaml",
+			To:         "bookstore/repo-sync",
+		})
+	}
+
+	syncStatus := &v1beta1.Status{}
+	setSyncStatusTransfers(syncStatus, records, 1)
+	if len(syncStatus.Sync.Transfers) != maxTransferRecords {
+		t.Errorf("want %d transfer records, got %d", maxTransferRecords, len(syncStatus.Sync.Transfers))
+	}
+	want := v1beta1.TransferRecord{
+		Resource: v1beta1.ResourceRef{
+			SourcePath: "bookstore/web.yaml",
+			Name:       "web-0",
+			Namespace:  "bookstore",
+			GVK:        metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		},
+		To: "bookstore/repo-sync",
+	}
+	if diff := cmp.Diff(want, syncStatus.Sync.Transfers[0]); diff != "" {
+		t.Errorf("unexpected transfer record (-want +got):\n%s", diff)
+	}
+
+	// Truncated with the errors when the RSync is too large
+	setSyncStatusTransfers(syncStatus, records, 4)
+	if len(syncStatus.Sync.Transfers) != maxTransferRecords/4 {
+		t.Errorf("want %d transfer records, got %d", maxTransferRecords/4, len(syncStatus.Sync.Transfers))
+	}
+
+	setSyncStatusTransfers(syncStatus, nil, 1)
+	if syncStatus.Sync.Transfers != nil {
+		t.Errorf("want no transfer records, got %v", syncStatus.Sync.Transfers)
+	}
+}
@@ -72,13 +72,16 @@ func (p *rootSyncParser) ParseSource(ctx context.Context, state *sourceState) ([
 	}
 
 	options := validate.Options{
-		ClusterName:  opts.ClusterName,
-		SyncName:     opts.SyncName,
-		PolicyDir:    opts.SyncDir,
-		PreviousCRDs: crds,
-		BuildScoper:  builder,
-		Converter:    opts.Converter,
-		Scheme:       opts.Client.Scheme(),
+		ClusterName:         opts.ClusterName,
+		ClusterLabels:       opts.ClusterLabels,
+		SubstitutionEnabled: opts.SubstitutionEnabled,
+		SubstitutionValues:  opts.SubstitutionValues,
+		SyncName:            opts.SyncName,
+		PolicyDir:           opts.SyncDir,
+		PreviousCRDs:        crds,
+		BuildScoper:         builder,
+		Converter:           opts.Converter,
+		Scheme:              opts.Client.Scheme(),
 		// Enable API call so NamespaceSelector can talk to k8s-api-server.
 		AllowAPICall:             true,
 		DynamicNSSelectorEnabled: opts.DynamicNSSelectorEnabled,
@@ -111,11 +114,36 @@ func (p *rootSyncParser) ParseSource(ctx context.Context, state *sourceState) ([
 }
 
 // addImplicitNamespaces hydrates the given FileObjects by injecting implicit
-// namespaces into the list before returning it. Implicit namespaces are those
-// that are declared by an object's metadata namespace field but are not present
-// in the list. The implicit namespace is only added if it doesn't exist.
+// namespaces into the list before returning it. The implicit namespace is only
+// added if it doesn't exist, or if it is managed by this reconciler.
 func (p *rootSyncParser) addImplicitNamespaces(objs []ast.FileObject) ([]ast.FileObject, status.MultiError) {
+	return AddImplicitNamespaces(objs, p.isUnmanagedNamespace)
+}
+
+// isUnmanagedNamespace returns whether the Namespace already exists and is not
+// managed by this reconciler.
+func (p *rootSyncParser) isUnmanagedNamespace(ns string) (bool, error) {
 	opts := p.options
+	existingNs := &corev1.Namespace{}
+	err := opts.Client.Get(context.Background(), types.NamespacedName{Name: ns}, existingNs)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to check the existence of the implicit namespace %q: %w", ns, err)
+	}
+	existingNs.SetGroupVersionKind(kinds.Namespace())
+	// If the namespace already exists and not self-managed, do not add it as an implicit namespace.
+	// This is to avoid conflicts caused by multiple Root reconcilers managing the same implicit namespace.
+	return !diff.IsManager(opts.Scope, opts.SyncName, existingNs), nil
+}
+
+// AddImplicitNamespaces hydrates the given FileObjects by injecting implicit
+// namespaces into the list before returning it. Implicit namespaces are those
+// that are declared by an object's metadata namespace field but are not present
+// in the list. If skip is not nil, the implicit namespaces for which it returns
+// true are not added.
+func AddImplicitNamespaces(objs []ast.FileObject, skip func(ns string) (bool, error)) ([]ast.FileObject, status.MultiError) {
 	var errs status.MultiError
 	// namespaces will track the set of Namespaces we expect to exist, and those
 	// which actually do.
@@ -140,18 +168,15 @@ func (p *rootSyncParser) addImplicitNamespaces(objs []ast.FileObject) ([]ast.Fil
 		if isDeclared || ns == configsync.ControllerNamespace {
 			continue
 		}
-		existingNs := &corev1.Namespace{}
-		err := opts.Client.Get(context.Background(), types.NamespacedName{Name: ns}, existingNs)
-		if err != nil && !apierrors.IsNotFound(err) {
-			errs = status.Append(errs, fmt.Errorf("unable to check the existence of the implicit namespace %q: %w", ns, err))
-			continue
-		}
-
-		existingNs.SetGroupVersionKind(kinds.Namespace())
-		// If the namespace already exists and not self-managed, do not add it as an implicit namespace.
-		// This is to avoid conflicts caused by multiple Root reconcilers managing the same implicit namespace.
-		if err == nil && !diff.IsManager(opts.Scope, opts.SyncName, existingNs) {
-			continue
+		if skip != nil {
+			skipped, err := skip(ns)
+			if err != nil {
+				errs = status.Append(errs, err)
+				continue
+			}
+			if skipped {
+				continue
+			}
 		}
 
 		// Add the implicit namespace if it doesn't exist, or if it is managed by itself.
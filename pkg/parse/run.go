@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"time"
 
 	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
 	"github.com/GoogleContainerTools/config-sync/pkg/core"
@@ -75,6 +76,11 @@ const (
 	RenderingNotRequired string = "Rendering not required but is currently enabled"
 )
 
+// renderWaitTimeout is how long the reconciler waits for the
+// hydration-controller to render a commit which is being rendered, before
+// retrying later.
+const renderWaitTimeout = 5 * time.Second
+
 // ReconcileResult encapsulates the result of a reconciler.Reconcile.
 // This simply allows explicitly naming return values in a way that makes the
 // implementation easier to read.
@@ -219,14 +225,17 @@ func (r *reconciler) fetch(ctx context.Context) (*SourceStatus, cmpath.Absolute,
 		if err := r.syncStatusClient.SetImageToSyncAnnotation(ctx, newSourceStatus.Commit); err != nil {
 			newSourceStatus.Errs = status.Append(newSourceStatus.Errs, err)
 			blockHydration(readyToRenderFile)
+			r.signalHydration(ctx, "")
 		} else if opts.RenderingEnabled {
 			// write the commit into the ready-to-render file in reconciler-signals
 			if err := unblockHydration(newSourceStatus.Commit, readyToRenderFile); err != nil {
 				newSourceStatus.Errs = status.Append(newSourceStatus.Errs, err)
 			}
+			r.signalHydration(ctx, newSourceStatus.Commit)
 		}
 	} else {
 		blockHydration(readyToRenderFile)
+		r.signalHydration(ctx, "")
 	}
 
 	// Generate source spec from Reconciler config
@@ -267,11 +276,7 @@ func (r *reconciler) render(ctx context.Context, sourceStatus *SourceStatus) sta
 	if state.status.RenderingStatus != nil {
 		newRenderStatus.RequiresRendering = state.status.RenderingStatus.RequiresRendering
 	}
-	// Check the done file, created by the hydration-controller.
-	// It should contain the last rendered commit.
-	// ExtractCommit returns the empty string if the done file doesn't exist yet.
-	doneFilePath := opts.RepoRoot.Join(cmpath.RelativeSlash(hydrate.DoneFile)).OSPath()
-	renderedCommit, err := hydrate.ExtractCommit(doneFilePath)
+	renderedCommit, err := r.renderedCommit(ctx, sourceStatus.Commit)
 	if err != nil {
 		newRenderStatus.Message = RenderingFailed
 		newRenderStatus.LastUpdate = nowMeta(opts.Clock)
@@ -439,6 +444,19 @@ func (r *reconciler) readFromSource(ctx context.Context, trigger string, srcStat
 				return newRenderStatus, newSourceStatus
 			}
 		}
+		// Check if the Kptfile declares a function pipeline, if the pipeline
+		// is enabled for the RSync. Kptfiles are ignored otherwise.
+		// An invalid Kptfile is reported by the hydration-controller.
+		if opts.FunctionPipelineEnabled {
+			if found, err := hydrate.HasFunctionPipeline(srcState.syncPath.OSPath()); err != nil || found {
+				// Source of truth requires hydration, but the hydration-controller is not running
+				newRenderStatus.Message = RenderingRequired
+				newRenderStatus.RequiresRendering = true
+				hydrationErr := hydrate.NewTransientError(fmt.Errorf("sync source contains a function pipeline and hydration-controller is not running"))
+				newRenderStatus.Errs = status.HydrationError(hydrationErr.Code(), hydrationErr)
+				return newRenderStatus, newSourceStatus
+			}
+		}
 	}
 
 	if newSourceStatus.Errs != nil {
@@ -465,6 +483,11 @@ func (r *reconciler) parse(ctx context.Context, trigger string) status.MultiErro
 		if !opts.WebhookEnabled {
 			klog.V(3).Infof("Removing %s annotation as Admission Webhook is disabled", metadata.DeclaredFieldsKey)
 			for _, obj := range objs {
+				if metadata.IsSharedFieldOwnership(obj) {
+					// The applier needs the declared fields to co-manage
+					// the object.
+					continue
+				}
 				core.RemoveAnnotations(obj, metadata.DeclaredFieldsKey)
 			}
 		}
@@ -523,6 +546,8 @@ func (r *reconciler) update(ctx context.Context, trigger string) status.MultiErr
 		Syncing:    false,
 		Commit:     state.source.commit,
 		Errs:       syncErrs,
+		Drift:      state.SyncDrift(),
+		Transfers:  state.SyncTransfers(),
 		LastUpdate: nowMeta(opts.Clock),
 	}
 	if statusErr := r.setSyncStatus(ctx, syncStatus); statusErr != nil {
@@ -574,6 +599,8 @@ func (r *reconciler) startAsyncStatusUpdates(ctx context.Context) <-chan struct{
 					Syncing:    true,
 					Commit:     state.source.commit,
 					Errs:       state.SyncErrors(),
+					Drift:      state.SyncDrift(),
+					Transfers:  state.SyncTransfers(),
 					LastUpdate: nowMeta(opts.Clock),
 				}
 				if err := r.setSyncStatus(ctx, syncStatus); err != nil {
@@ -587,6 +614,39 @@ func (r *reconciler) startAsyncStatusUpdates(ctx context.Context) <-chan struct{
 	return doneCh
 }
 
+// renderedCommit returns the last commit rendered by the hydration-controller.
+// It waits for the hydration-controller to render the commit, if the commit is
+// being rendered, and falls back
+// to the done file, created by the hydration-controller, which should contain
+// the last rendered commit.
+// It returns the empty string if the done file doesn't exist yet.
+func (r *reconciler) renderedCommit(ctx context.Context, commit string) (string, error) {
+	opts := r.Options()
+	if r.signalClient != nil {
+		renderStatus, err := r.signalClient.WaitForRender(ctx, commit, renderWaitTimeout)
+		if err != nil {
+			klog.V(3).Infof("unable to get the render status from the hydration-controller, falling back to the done file: %v", err)
+		} else if renderStatus.Done(commit) {
+			return commit, nil
+		}
+	}
+	doneFilePath := opts.RepoRoot.Join(cmpath.RelativeSlash(hydrate.DoneFile)).OSPath()
+	return hydrate.ExtractCommit(doneFilePath)
+}
+
+// signalHydration requests the hydration-controller to render the commit, in
+// addition to the ready-to-render file. An empty commit blocks the rendering.
+// Errors are only logged, because the hydration-controller falls back to the
+// ready-to-render file.
+func (r *reconciler) signalHydration(ctx context.Context, commit string) {
+	if r.signalClient == nil {
+		return
+	}
+	if err := r.signalClient.RequestRender(ctx, commit); err != nil {
+		klog.V(3).Infof("unable to send the render request to the hydration-controller, falling back to the ready-to-render file: %v", err)
+	}
+}
+
 // unblockHydration adds the image digest into the ready-to-render file under the
 // shared directory between reconciler and hydration-controller to inform the
 // hydration-controller to proceed rendering
@@ -644,6 +704,8 @@ func (r *reconciler) UpdateSyncStatus(ctx context.Context) error {
 		Syncing:    false,
 		Commit:     state.status.SyncStatus.Commit,
 		Errs:       state.SyncErrors(),
+		Drift:      state.SyncDrift(),
+		Transfers:  state.SyncTransfers(),
 		LastUpdate: nowMeta(opts.Clock),
 	}
 	return r.setSyncStatus(ctx, syncStatus)
@@ -40,6 +40,7 @@ import (
 	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
 	"github.com/GoogleContainerTools/config-sync/pkg/metrics"
 	"github.com/GoogleContainerTools/config-sync/pkg/remediator/conflict"
+	"github.com/GoogleContainerTools/config-sync/pkg/remediator/drift"
 	remediatorfake "github.com/GoogleContainerTools/config-sync/pkg/remediator/fake"
 	"github.com/GoogleContainerTools/config-sync/pkg/rootsync"
 	"github.com/GoogleContainerTools/config-sync/pkg/status"
@@ -69,7 +70,7 @@ func newRootReconciler(t *testing.T, clock clock.Clock, fakeClient client.Client
 		t.Fatal(err)
 	}
 	state := &ReconcilerState{
-		syncErrorCache: NewSyncErrorCache(conflict.NewHandler(), fight.NewHandler()),
+		syncErrorCache: NewSyncErrorCache(conflict.NewHandler(), fight.NewHandler(), drift.NewHandler("", nil, nil)),
 	}
 	opts := &Options{
 		Clock:             clock,
@@ -113,6 +114,7 @@ func newRootReconciler(t *testing.T, clock clock.Clock, fakeClient client.Client
 			options: rootOpts,
 		},
 		reconcilerState: state,
+		signalClient:    newSignalClient(recOpts),
 	}
 }
 
@@ -52,6 +52,9 @@ type FileSource struct {
 	// SourceRev is the revision of the source repo to sync.
 	SourceRev            string
 	ReconcilerSignalsDir cmpath.Absolute
+	// HydrationSignalsDir is the directory of the socket of the
+	// hydration-controller.
+	HydrationSignalsDir cmpath.Absolute
 }
 
 // Files lists files in a repository and ensures the source repository hasn't been
@@ -17,7 +17,9 @@ package parse
 import (
 	"time"
 
+	"github.com/GoogleContainerTools/config-sync/pkg/applier"
 	"github.com/GoogleContainerTools/config-sync/pkg/importer/filesystem/cmpath"
+	"github.com/GoogleContainerTools/config-sync/pkg/remediator/drift"
 	"github.com/GoogleContainerTools/config-sync/pkg/status"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
@@ -182,3 +184,15 @@ func (s *ReconcilerState) RecordFullSyncStart(now metav1.Time) {
 func (s *ReconcilerState) SyncErrors() status.MultiError {
 	return s.syncErrorCache.Errors()
 }
+
+// SyncDrift returns the drift reported by the remediator, instead of
+// corrected, because of the drift policy.
+func (s *ReconcilerState) SyncDrift() []drift.Record {
+	return s.syncErrorCache.DriftRecords()
+}
+
+// SyncTransfers returns the transfers to other reconcilers reported by the
+// applier, which were not adopted yet.
+func (s *ReconcilerState) SyncTransfers() []applier.TransferRecord {
+	return s.syncErrorCache.Transfers()
+}
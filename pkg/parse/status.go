@@ -15,9 +15,12 @@
 package parse
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/applier"
+	"github.com/GoogleContainerTools/config-sync/pkg/remediator/drift"
 	"github.com/GoogleContainerTools/config-sync/pkg/status"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -112,6 +115,16 @@ func SourceSpecFromFileSource(source FileSource, sourceType configsync.SourceTyp
 			Image: source.SourceRepo,
 			Dir:   source.SyncDir.SlashPath(),
 		}
+	case configsync.HTTPSource:
+		ss = HTTPSourceSpec{
+			URL: source.SourceRepo,
+			Dir: source.SyncDir.SlashPath(),
+		}
+	case configsync.BucketSource:
+		ss = BucketSourceSpec{
+			URL: source.SourceRepo,
+			Dir: source.SyncDir.SlashPath(),
+		}
 	case configsync.HelmSource:
 		ss = HelmSourceSpec{
 			Repo:    source.SourceRepo,
@@ -174,6 +187,40 @@ func (o OCISourceSpec) Equals(other SourceSpec) bool {
 		t.Dir == o.Dir
 }
 
+// HTTPSourceSpec is a SourceSpec for the HTTP SourceType
+type HTTPSourceSpec struct {
+	URL string
+	Dir string
+}
+
+// Equals returns true if the specified SourceSpec equals this
+// HTTPSourceSpec, including type and all field values.
+func (h HTTPSourceSpec) Equals(other SourceSpec) bool {
+	t, ok := other.(HTTPSourceSpec)
+	if !ok {
+		return false
+	}
+	return t.URL == h.URL &&
+		t.Dir == h.Dir
+}
+
+// BucketSourceSpec is a SourceSpec for the Bucket SourceType
+type BucketSourceSpec struct {
+	URL string
+	Dir string
+}
+
+// Equals returns true if the specified SourceSpec equals this
+// BucketSourceSpec, including type and all field values.
+func (b BucketSourceSpec) Equals(other SourceSpec) bool {
+	t, ok := other.(BucketSourceSpec)
+	if !ok {
+		return false
+	}
+	return t.URL == b.URL &&
+		t.Dir == b.Dir
+}
+
 // HelmSourceSpec is a SourceSpec for the Helm SourceType
 type HelmSourceSpec struct {
 	Repo    string
@@ -279,6 +326,8 @@ type SyncStatus struct {
 	Syncing    bool
 	Commit     string
 	Errs       status.MultiError
+	Drift      []drift.Record
+	Transfers  []applier.TransferRecord
 	LastUpdate metav1.Time
 }
 
@@ -292,6 +341,8 @@ func (ss *SyncStatus) DeepCopy() *SyncStatus {
 		Syncing:    ss.Syncing,
 		Commit:     ss.Commit,
 		Errs:       ss.Errs,
+		Drift:      slices.Clone(ss.Drift),
+		Transfers:  slices.Clone(ss.Transfers),
 		LastUpdate: *ss.LastUpdate.DeepCopy(),
 	}
 }
@@ -305,9 +356,24 @@ func (ss *SyncStatus) Equals(other *SyncStatus) bool {
 	return ss.Syncing == other.Syncing &&
 		ss.Commit == other.Commit &&
 		status.DeepEqual(ss.Errs, other.Errs) &&
+		isDriftEqual(ss.Drift, other.Drift) &&
+		slices.Equal(ss.Transfers, other.Transfers) &&
 		isSourceSpecEqual(ss.Spec, other.Spec)
 }
 
+// isDriftEqual returns true if a & b contain the same drift records.
+func isDriftEqual(a, b []drift.Record) bool {
+	return slices.EqualFunc(a, b, func(x, y drift.Record) bool {
+		return x.ID == y.ID &&
+			x.Version == y.Version &&
+			x.SourcePath == y.SourcePath &&
+			x.Operation == y.Operation &&
+			slices.Equal(x.Fields, y.Fields) &&
+			x.Actor == y.Actor &&
+			x.DetectedAt.Equal(y.DetectedAt)
+	})
+}
+
 // isSourceSpecEqual returns true if a & b are Equal, handling nil cases.
 // None of the SourceSpec impls are nillable, but the interface itself is.
 func isSourceSpecEqual(a, b SourceSpec) bool {
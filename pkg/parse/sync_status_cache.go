@@ -17,7 +17,9 @@ package parse
 import (
 	"sync"
 
+	"github.com/GoogleContainerTools/config-sync/pkg/applier"
 	"github.com/GoogleContainerTools/config-sync/pkg/remediator/conflict"
+	"github.com/GoogleContainerTools/config-sync/pkg/remediator/drift"
 	"github.com/GoogleContainerTools/config-sync/pkg/status"
 	"github.com/GoogleContainerTools/config-sync/pkg/syncer/reconcile/fight"
 )
@@ -28,19 +30,24 @@ type SyncErrorCache struct {
 	conflictHandler conflict.Handler
 	// Errors from the Remediator
 	fightHandler fight.Handler
+	// Drift reported by the Remediator
+	driftHandler drift.Handler
 
 	statusMux sync.RWMutex
 	// Errors from the Updater
 	validationErrs status.MultiError
 	applyErrs      status.MultiError
 	watchErrs      status.MultiError
+	// Transfers from the Updater
+	transfers []applier.TransferRecord
 }
 
 // NewSyncErrorCache constructs a new SyncErrorCache with shared handlers
-func NewSyncErrorCache(conflictHandler conflict.Handler, fightHandler fight.Handler) *SyncErrorCache {
+func NewSyncErrorCache(conflictHandler conflict.Handler, fightHandler fight.Handler, driftHandler drift.Handler) *SyncErrorCache {
 	return &SyncErrorCache{
 		conflictHandler: conflictHandler,
 		fightHandler:    fightHandler,
+		driftHandler:    driftHandler,
 	}
 }
 
@@ -54,6 +61,16 @@ func (s *SyncErrorCache) FightHandler() fight.Handler {
 	return s.fightHandler
 }
 
+// DriftHandler returns the thread-safe handler of reported drift
+func (s *SyncErrorCache) DriftHandler() drift.Handler {
+	return s.driftHandler
+}
+
+// DriftRecords returns the latest known drift reported by the remediator.
+func (s *SyncErrorCache) DriftRecords() []drift.Record {
+	return s.driftHandler.DriftRecords()
+}
+
 // Errors returns the latest known set of errors from the updater and remediator.
 func (s *SyncErrorCache) Errors() status.MultiError {
 	s.statusMux.RLock()
@@ -101,3 +118,18 @@ func (s *SyncErrorCache) SetWatchErrs(errs status.MultiError) {
 	defer s.statusMux.Unlock()
 	s.watchErrs = errs
 }
+
+// SetTransfers replaces the cached transfers which were not adopted yet.
+func (s *SyncErrorCache) SetTransfers(transfers []applier.TransferRecord) {
+	s.statusMux.Lock()
+	defer s.statusMux.Unlock()
+	s.transfers = transfers
+}
+
+// Transfers returns the latest known transfers which were not adopted yet,
+// reported by the applier.
+func (s *SyncErrorCache) Transfers() []applier.TransferRecord {
+	s.statusMux.RLock()
+	defer s.statusMux.RUnlock()
+	return s.transfers
+}
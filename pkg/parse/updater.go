@@ -174,7 +174,12 @@ func (u *Updater) declare(ctx context.Context, objs []client.Object, commit stri
 func (u *Updater) apply(ctx context.Context, commit string) status.MultiError {
 	// Collect errors into a MultiError
 	var err status.MultiError
+	// Collect the transfers which were not adopted yet
+	var transfers []applier.TransferRecord
 	eventHandler := func(event applier.Event) {
+		if transferEvent, ok := event.(applier.TransferEvent); ok {
+			transfers = append(transfers, transferEvent.Transfers...)
+		}
 		if errEvent, ok := event.(applier.ErrorEvent); ok {
 			if err == nil {
 				err = errEvent.Error
@@ -192,6 +197,7 @@ func (u *Updater) apply(ctx context.Context, commit string) status.MultiError {
 	start := time.Now()
 	u.SyncErrorCache.ResetApplyErrors()
 	objStatusMap, syncStats := u.Applier.Apply(ctx, eventHandler, u.Resources)
+	u.SyncErrorCache.SetTransfers(transfers)
 	if !syncStats.Empty() {
 		klog.Infof("Applier made new progress: %s", syncStats.String())
 		objStatusMap.Log(klog.V(0))
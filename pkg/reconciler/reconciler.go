@@ -16,6 +16,7 @@ package reconciler
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"time"
 
@@ -36,6 +37,8 @@ import (
 	"github.com/GoogleContainerTools/config-sync/pkg/reconcilermanager/controllers"
 	"github.com/GoogleContainerTools/config-sync/pkg/remediator"
 	"github.com/GoogleContainerTools/config-sync/pkg/remediator/conflict"
+	"github.com/GoogleContainerTools/config-sync/pkg/remediator/drift"
+	"github.com/GoogleContainerTools/config-sync/pkg/remediator/queue"
 	"github.com/GoogleContainerTools/config-sync/pkg/remediator/watch"
 	syncerclient "github.com/GoogleContainerTools/config-sync/pkg/syncer/client"
 	"github.com/GoogleContainerTools/config-sync/pkg/syncer/metrics"
@@ -44,9 +47,13 @@ import (
 	"github.com/GoogleContainerTools/config-sync/pkg/util"
 	utilwatch "github.com/GoogleContainerTools/config-sync/pkg/util/watch"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -61,6 +68,35 @@ type Options struct {
 	Logger logr.Logger
 	// ClusterName is the name of the cluster we are parsing configuration for.
 	ClusterName string
+	// ClusterLabels are the labels of the cluster used for Cluster selection,
+	// sourced from the fleet Membership and the ClusterInfo ConfigMap.
+	ClusterLabels map[string]string
+	// SubstitutionEnabled is whether the `${cluster.*}` and `${values.*}`
+	// variables are substituted in the resources. It is set when the RSync
+	// specifies `spec.substitution`.
+	SubstitutionEnabled bool
+	// SubstitutionValues are the values of the `${values.KEY}` variables,
+	// sourced from the ConfigMap referenced by the RSync.
+	SubstitutionValues map[string]string
+	// DriftPolicy is the default drift policy of the managed resources.
+	// If `report`, the remediator records drift in the RSync status and as
+	// Events, instead of correcting it.
+	DriftPolicy metadata.DriftPolicy
+	// ForceConflicts is whether the applier and the remediator take the
+	// ownership of the declared fields managed by other field managers, when
+	// applying them with server-side apply.
+	ForceConflicts bool
+	// PrunePolicy decides what happens to the managed resources that were
+	// removed from the source of truth. Nil prunes all of them.
+	PrunePolicy *applier.PrunePolicy
+	// InventorySelfHeal is whether the applier rebuilds the ResourceGroup
+	// inventory from the managed objects on the cluster, when the
+	// ResourceGroup doesn't exist.
+	InventorySelfHeal bool
+	// DriftAuditLog is where the audit records of drift corrected by the
+	// remediator are written as JSON lines, in addition to Events.
+	// Nil disables the audit log.
+	DriftAuditLog io.Writer
 	// FightDetectionThreshold is the rate of updates per minute to an API
 	// Resource at which the reconciler will log warnings about too many updates
 	// to the resource.
@@ -69,6 +105,10 @@ type Options struct {
 	// Each worker pulls resources off of the work queue and remediates them one
 	// at a time.
 	NumWorkers int
+	// RemediatorPriorities are the priorities of the GroupKinds in the
+	// remediator work queue. Objects of GroupKinds with a higher priority are
+	// remediated first.
+	RemediatorPriorities queue.Priorities
 	// ReconcilerScope is the scope of resources which the reconciler will manage.
 	// Currently this can either be a namespace or the root scope which allows a
 	// cluster admin to manage the entire cluster.
@@ -122,6 +162,9 @@ type Options struct {
 	// RenderingEnabled indicates whether the reconciler Pod is currently running
 	// with the hydration-controller.
 	RenderingEnabled bool
+	// FunctionPipelineEnabled indicates whether a Kptfile declaring a KRM
+	// function pipeline requires rendering. Kptfiles are ignored otherwise.
+	FunctionPipelineEnabled bool
 	// RootOptions is the set of options to fill in if this is configuring the
 	// Root reconciler.
 	// Unset for Namespace repositories.
@@ -135,6 +178,9 @@ type Options struct {
 	WebhookEnabled bool
 	// ReconcilerSignalsDir is the absolute path to the directory of ready-to-render file shared with hydration-controller
 	ReconcilerSignalsDir cmpath.Absolute
+	// HydrationSignalsDir is the absolute path to the directory of the socket
+	// served by the hydration-controller
+	HydrationSignalsDir cmpath.Absolute
 }
 
 // RootOptions are the options specific to parsing Root repositories.
@@ -189,7 +235,7 @@ func Run(opts Options) {
 	// Configure the Applier.
 	applySetID := applyset.IDFromSync(opts.SyncName, opts.ReconcilerScope)
 	genericClient := syncerclient.New(cl, metrics.APICallDuration)
-	baseApplier, err := reconcile.NewApplierForMultiRepo(cfg, genericClient, applySetID, opts.FightDetectionThreshold)
+	baseApplier, err := reconcile.NewApplierForMultiRepo(cfg, genericClient, applySetID, opts.FightDetectionThreshold, opts.ForceConflicts)
 	if err != nil {
 		klog.Fatalf("Instantiating Applier: %v", err)
 	}
@@ -205,7 +251,18 @@ func Run(opts Options) {
 	if err != nil {
 		klog.Fatalf("Error creating clients: %v", err)
 	}
-	supervisor := applier.NewSupervisor(clientSet, opts.ReconcilerScope, opts.SyncName, reconcileTimeout)
+	// Record Events for drift reported or corrected by the applier and the remediator.
+	kubeClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		klog.Fatalf("Error creating kubernetes clientset: %v", err)
+	}
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+	defer eventBroadcaster.Shutdown()
+	eventRecorder := eventBroadcaster.NewRecorder(core.Scheme, corev1.EventSource{Component: opts.ReconcilerName})
+	driftHandler := drift.NewHandler(opts.DriftPolicy, eventRecorder, opts.DriftAuditLog)
+
+	supervisor := applier.NewSupervisor(clientSet, opts.ReconcilerScope, opts.SyncName, reconcileTimeout, opts.ForceConflicts, opts.PrunePolicy, opts.InventorySelfHeal, driftHandler)
 	if err := supervisor.UpdateStatusMode(signalCtx); err != nil {
 		klog.Fatalf("Error setting status mode on ResourceGroup: %v", err)
 	}
@@ -233,7 +290,7 @@ func Run(opts Options) {
 	conflictHandler := conflict.NewHandler()
 	fightHandler := fight.NewHandler()
 
-	rem, err := remediator.New(opts.ReconcilerScope, opts.SyncName, watcherFactory, mapper, baseApplier, conflictHandler, fightHandler, crdController, decls, opts.NumWorkers)
+	rem, err := remediator.New(opts.ReconcilerScope, opts.SyncName, watcherFactory, mapper, baseApplier, conflictHandler, fightHandler, driftHandler, crdController, decls, opts.NumWorkers, opts.RemediatorPriorities)
 	if err != nil {
 		klog.Fatalf("Instantiating Remediator: %v", err)
 	}
@@ -251,20 +308,24 @@ func Run(opts Options) {
 		SourceBranch:         opts.SourceBranch,
 		SourceRev:            opts.SourceRev,
 		ReconcilerSignalsDir: opts.ReconcilerSignalsDir,
+		HydrationSignalsDir:  opts.HydrationSignalsDir,
 	}
 
 	parseOpts := &parse.Options{
-		Clock:             clock.RealClock{},
-		ConfigParser:      filesystem.NewParser(&reader.File{}),
-		ClusterName:       opts.ClusterName,
-		Client:            cl,
-		ReconcilerName:    opts.ReconcilerName,
-		SyncName:          opts.SyncName,
-		Scope:             opts.ReconcilerScope,
-		DiscoveryClient:   discoveryClient,
-		Files:             parse.Files{FileSource: fs},
-		WebhookEnabled:    opts.WebhookEnabled,
-		DeclaredResources: decls,
+		Clock:               clock.RealClock{},
+		ConfigParser:        filesystem.NewParser(&reader.File{}),
+		ClusterName:         opts.ClusterName,
+		ClusterLabels:       opts.ClusterLabels,
+		SubstitutionEnabled: opts.SubstitutionEnabled,
+		SubstitutionValues:  opts.SubstitutionValues,
+		Client:              cl,
+		ReconcilerName:      opts.ReconcilerName,
+		SyncName:            opts.SyncName,
+		Scope:               opts.ReconcilerScope,
+		DiscoveryClient:     discoveryClient,
+		Files:               parse.Files{FileSource: fs},
+		WebhookEnabled:      opts.WebhookEnabled,
+		DeclaredResources:   decls,
 	}
 	// Only instantiate the converter when the webhook is enabled because the
 	// instantiation pulls fresh schemas from the openapi discovery endpoint.
@@ -296,11 +357,12 @@ func Run(opts Options) {
 			Resources:      decls,
 			Applier:        supervisor,
 			Remediator:     rem,
-			SyncErrorCache: parse.NewSyncErrorCache(conflictHandler, fightHandler),
+			SyncErrorCache: parse.NewSyncErrorCache(conflictHandler, fightHandler, driftHandler),
 		},
-		FullSyncPeriod:     opts.FullSyncPeriod,
-		StatusUpdatePeriod: opts.StatusUpdatePeriod,
-		RenderingEnabled:   opts.RenderingEnabled,
+		FullSyncPeriod:          opts.FullSyncPeriod,
+		StatusUpdatePeriod:      opts.StatusUpdatePeriod,
+		RenderingEnabled:        opts.RenderingEnabled,
+		FunctionPipelineEnabled: opts.FunctionPipelineEnabled,
 	}
 
 	var nsControllerState *namespacecontroller.State
@@ -35,6 +35,45 @@ const (
 	// of the cluster.
 	ClusterNameKey = "CLUSTER_NAME"
 
+	// ClusterLabelsKey is the OS env variable key for the comma-separated
+	// key=value labels of the cluster, used for Cluster selection.
+	ClusterLabelsKey = "CLUSTER_LABELS"
+
+	// PrunePolicyKey is the OS env variable key for the JSON-encoded prune
+	// policy of the managed resources removed from the source of truth.
+	PrunePolicyKey = "PRUNE_POLICY"
+
+	// SubstitutionValuesKey is the OS env variable key for the JSON-encoded
+	// values of the `${values.KEY}` variables substituted in the resources.
+	SubstitutionValuesKey = "SUBSTITUTION_VALUES"
+
+	// SubstitutionEnabledKey tells the reconciler container whether to
+	// substitute the `${cluster.*}` and `${values.*}` variables in the
+	// resources. It is set when the RSync specifies `spec.substitution`.
+	SubstitutionEnabledKey = "SUBSTITUTION_ENABLED"
+
+	// DriftPolicyKey is the OS env variable key for the default drift policy
+	// of the resources managed by the reconciler.
+	DriftPolicyKey = "DRIFT_POLICY"
+
+	// RemediatorPrioritiesKey is the OS env variable key for the priorities of
+	// the GroupKinds in the remediator work queue.
+	RemediatorPrioritiesKey = "REMEDIATOR_PRIORITIES"
+
+	// ForceConflictsKey tells the reconciler container whether server-side
+	// apply takes the ownership of the declared fields managed by other field
+	// managers.
+	ForceConflictsKey = "FORCE_CONFLICTS"
+
+	// DriftAuditLogKey tells the reconciler container whether to write the
+	// audit records of corrected drift to stdout as JSON lines.
+	DriftAuditLogKey = "DRIFT_AUDIT_LOG"
+
+	// InventorySelfHealKey tells the reconciler container whether to rebuild
+	// the ResourceGroup inventory from the managed objects on the cluster,
+	// when the ResourceGroup doesn't exist.
+	InventorySelfHealKey = "INVENTORY_SELF_HEAL"
+
 	// ScopeKey is the OS env variable key for the scope of the
 	// reconciler and hydration controller.
 	ScopeKey = "SCOPE"
@@ -71,6 +110,12 @@ const (
 	// HelmSync is the name of the helm-sync container in reconciler pods.
 	HelmSync = "helm-sync"
 
+	// HTTPSync is the name of the http-sync container in reconciler pods.
+	HTTPSync = "http-sync"
+
+	// BucketSync is the name of the bucket-sync container in reconciler pods.
+	BucketSync = "bucket-sync"
+
 	// HydrationController is the name of the hydration-controller container in reconciler pods.
 	HydrationController = "hydration-controller"
 
@@ -95,6 +140,11 @@ const (
 	// container is running in the Pod.
 	RenderingEnabled = "RENDERING_ENABLED"
 
+	// FunctionPipelineEnabled tells the reconciler and hydration-controller
+	// containers whether to run the KRM function pipeline declared in the
+	// Kptfile of the sync directory.
+	FunctionPipelineEnabled = "FUNCTION_PIPELINE_ENABLED"
+
 	// NamespaceStrategy tells the reconciler container which NamespaceStrategy to
 	// use
 	NamespaceStrategy = "NAMESPACE_STRATEGY"
@@ -110,10 +160,10 @@ const (
 )
 
 const (
-	// SourceTypeKey is the OS env variable key for the type of the source repo, must be git or oci or helm.
+	// SourceTypeKey is the OS env variable key for the type of the source repo, must be git or oci or helm or http or bucket.
 	SourceTypeKey = "SOURCE_TYPE"
 
-	// SourceRepoKey is the OS env variable key for the git or OCI or Helm repo URL.
+	// SourceRepoKey is the OS env variable key for the git or OCI or Helm repo URL, or the HTTP archive or bucket URL.
 	SourceRepoKey = "SOURCE_REPO"
 
 	// SourceBranchKey is the OS env variable key for the git branch name. It doesn't apply to OCI and helm.
@@ -131,6 +181,24 @@ const (
 	// HydrationPollingPeriod defines how often the hydration controller should
 	// poll the filesystem for rendering the DRY configs.
 	HydrationPollingPeriod = "HYDRATION_POLLING_PERIOD"
+
+	// HydrationHelmUsername is the OS env variable key for the username used
+	// by the hydration controller to pull the Helm charts of kustomizations.
+	HydrationHelmUsername = "HYDRATION_HELM_USERNAME"
+
+	// HydrationHelmPassword is the OS env variable key for the password used
+	// by the hydration controller to pull the Helm charts of kustomizations.
+	HydrationHelmPassword = "HYDRATION_HELM_PASSWORD"
+
+	// HydrationHelmRepo is the OS env variable key for the source repository
+	// of the sync. The hydration controller only uses the Helm credentials for
+	// the charts on the host of this repository.
+	HydrationHelmRepo = "HYDRATION_HELM_REPO"
+
+	// HydrationHelmHosts is the OS env variable key for the comma-separated
+	// hosts of the chart repositories the Helm credentials are used for. When
+	// empty, they are only used for the host of the source repository.
+	HydrationHelmHosts = "HYDRATION_HELM_HOSTS"
 )
 
 const (
@@ -143,12 +211,69 @@ const (
 	// OciSyncWait is the OS env variable key for the OCI sync wait period in seconds.
 	OciSyncWait = "OCI_SYNC_WAIT"
 
+	// OciSyncLayerMediaType is the OS env variable key for the media type of
+	// the OCI artifact layers to extract.
+	OciSyncLayerMediaType = "OCI_SYNC_LAYER_MEDIA_TYPE"
+
+	// OciSyncLayerAnnotation is the OS env variable key for the annotation of
+	// the OCI artifact layers to extract.
+	OciSyncLayerAnnotation = "OCI_SYNC_LAYER_ANNOTATION"
+
 	// OciCACert is the OS env variable key for the OCI CA cert file path.
 	// This variable is consumed by the underlying crypto library:
 	// - https://pkg.go.dev/crypto/x509#SystemCertPool
 	OciCACert = "SSL_CERT_FILE"
 )
 
+const (
+	// HTTPSyncURL is the OS env variable key for the HTTP archive URL.
+	HTTPSyncURL = "HTTP_SYNC_URL"
+
+	// HTTPSyncAuth is the OS env variable key for the HTTP sync auth type.
+	HTTPSyncAuth = "HTTP_SYNC_AUTH"
+
+	// HTTPSyncWait is the OS env variable key for the HTTP sync wait period in seconds.
+	HTTPSyncWait = "HTTP_SYNC_WAIT"
+
+	// HTTPSyncChecksum is the OS env variable key for the expected checksum of
+	// the HTTP archive.
+	HTTPSyncChecksum = "HTTP_SYNC_CHECKSUM"
+
+	// HTTPCACert is the OS env variable key for the HTTP sync CA cert file path.
+	// This variable is consumed by the underlying crypto library:
+	// - https://pkg.go.dev/crypto/x509#SystemCertPool
+	HTTPCACert = "SSL_CERT_FILE"
+)
+
+const (
+	// BucketSyncEndpoint is the OS env variable key for the bucket endpoint.
+	BucketSyncEndpoint = "BUCKET_SYNC_ENDPOINT"
+
+	// BucketSyncName is the OS env variable key for the bucket name.
+	BucketSyncName = "BUCKET_SYNC_NAME"
+
+	// BucketSyncPrefix is the OS env variable key for the prefix of the object keys.
+	BucketSyncPrefix = "BUCKET_SYNC_PREFIX"
+
+	// BucketSyncRegion is the OS env variable key for the bucket region.
+	BucketSyncRegion = "BUCKET_SYNC_REGION"
+
+	// BucketSyncInsecure is the OS env variable key for whether to connect to
+	// the bucket endpoint over plain HTTP.
+	BucketSyncInsecure = "BUCKET_SYNC_INSECURE"
+
+	// BucketSyncAuth is the OS env variable key for the bucket sync auth type.
+	BucketSyncAuth = "BUCKET_SYNC_AUTH"
+
+	// BucketSyncWait is the OS env variable key for the bucket sync wait period in seconds.
+	BucketSyncWait = "BUCKET_SYNC_WAIT"
+
+	// BucketCACert is the OS env variable key for the bucket sync CA cert file path.
+	// This variable is consumed by the underlying crypto library:
+	// - https://pkg.go.dev/crypto/x509#SystemCertPool
+	BucketCACert = "SSL_CERT_FILE"
+)
+
 const (
 	// HelmRepo is the OS env variable key for the Helm repository URL.
 	HelmRepo = "HELM_REPO"
@@ -187,6 +312,18 @@ const (
 
 	// HelmCACert is the OS env variable key for the Helm sync CA cert file path.
 	HelmCACert = "HELM_CA_CERT"
+
+	// HelmAPIVersions is the OS env variable key for a comma-separated list of
+	// the API versions available to the chart templates.
+	HelmAPIVersions = "HELM_API_VERSIONS"
+
+	// HelmKubeVersion is the OS env variable key for the Kubernetes version
+	// available to the chart templates.
+	HelmKubeVersion = "HELM_KUBE_VERSION"
+
+	// HelmPostRenderPatches is the OS env variable key for the kustomize patches
+	// applied to the rendered chart, formatted as a yaml list.
+	HelmPostRenderPatches = "HELM_POST_RENDER_PATCHES"
 )
 
 const (
@@ -0,0 +1,75 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// clusterLabels returns the labels of the cluster used for Cluster selection.
+//
+// The labels of the fleet Membership are merged with the data of the
+// ClusterInfo ConfigMap, which take precedence. Invalid labels in the
+// ConfigMap are reported as an error, so they are surfaced on the RSync.
+func (r *reconcilerBase) clusterLabels(ctx context.Context) (map[string]string, error) {
+	result := make(map[string]string)
+	if r.membership != nil {
+		for key, value := range r.membership.Labels {
+			result[key] = value
+		}
+	}
+
+	cm := &corev1.ConfigMap{}
+	cmRef := client.ObjectKey{
+		Namespace: configsync.ControllerNamespace,
+		Name:      configsync.ClusterInfoConfigMapName,
+	}
+	if err := r.client.Get(ctx, cmRef, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return result, nil
+		}
+		return nil, fmt.Errorf("getting ClusterInfo ConfigMap %s: %w", cmRef, err)
+	}
+	for key, value := range cm.Data {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid cluster label key %q in ClusterInfo ConfigMap %s: %s",
+				key, cmRef, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid cluster label value %q for key %q in ClusterInfo ConfigMap %s: %s",
+				value, key, cmRef, strings.Join(errs, "; "))
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+// mapClusterInfoToRSyncs requeues every RSync when the ClusterInfo ConfigMap
+// changes, so the reconcilers are updated with the new cluster labels.
+func (r *reconcilerBase) mapClusterInfoToRSyncs(ctx context.Context, obj client.Object) []reconcile.Request {
+	if obj.GetNamespace() != configsync.ControllerNamespace || obj.GetName() != configsync.ClusterInfoConfigMapName {
+		return nil
+	}
+	return r.requeueAllRSyncs(ctx, obj)
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	hubv1 "github.com/GoogleContainerTools/config-sync/pkg/api/hub/v1"
+	"github.com/GoogleContainerTools/config-sync/pkg/reconcilermanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func clusterInfoConfigMap(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      configsync.ClusterInfoConfigMapName,
+			Namespace: configsync.ControllerNamespace,
+		},
+		Data: data,
+	}
+}
+
+func TestClusterLabels(t *testing.T) {
+	testCases := []struct {
+		name       string
+		membership *hubv1.Membership
+		objs       []client.Object
+		want       map[string]string
+		wantErr    string
+	}{
+		{
+			name: "no membership and no ClusterInfo",
+			want: map[string]string{},
+		},
+		{
+			name: "membership labels",
+			membership: &hubv1.Membership{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   fleetMembershipName,
+					Labels: map[string]string{"env": "prod", "region": "eu"},
+				},
+			},
+			want: map[string]string{"env": "prod", "region": "eu"},
+		},
+		{
+			name: "ClusterInfo takes precedence over membership labels",
+			membership: &hubv1.Membership{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   fleetMembershipName,
+					Labels: map[string]string{"env": "prod", "region": "eu"},
+				},
+			},
+			objs: []client.Object{clusterInfoConfigMap(map[string]string{"env": "staging", "tier": "gold"})},
+			want: map[string]string{"env": "staging", "region": "eu", "tier": "gold"},
+		},
+		{
+			name:    "invalid ClusterInfo label key",
+			objs:    []client.Object{clusterInfoConfigMap(map[string]string{"not a key": "value"})},
+			wantErr: `invalid cluster label key "not a key"`,
+		},
+		{
+			name:    "invalid ClusterInfo label value",
+			objs:    []client.Object{clusterInfoConfigMap(map[string]string{"env": "not a value"})},
+			wantErr: `invalid cluster label value "not a value" for key "env"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, testReconciler := setupRootReconciler(t, tc.objs...)
+			testReconciler.membership = tc.membership
+
+			got, err := testReconciler.clusterLabels(context.Background())
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestReconcilerEnvsClusterLabels(t *testing.T) {
+	opts := reconcilerOptions{
+		sourceType:    configsync.GitSource,
+		gitConfig:     rootSyncWithGit(rootsyncName).Spec.Git,
+		clusterLabels: map[string]string{"region": "eu", "env": "prod"},
+	}
+	envs := reconcilerEnvs(opts)
+	assert.Contains(t, envs, corev1.EnvVar{Name: reconcilermanager.ClusterLabelsKey, Value: "env=prod,region=eu"})
+
+	opts.clusterLabels = nil
+	for _, env := range reconcilerEnvs(opts) {
+		assert.NotEqual(t, reconcilermanager.ClusterLabelsKey, env.Name)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync/v1beta1"
+	"github.com/GoogleContainerTools/config-sync/pkg/helm"
+	"github.com/GoogleContainerTools/config-sync/pkg/validate/rsync/validate"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// helmPostRenderConfigMapName returns the name of the ConfigMap that holds
+// the Helm post-render patches, or an empty string if there is none.
+func helmPostRenderConfigMapName(helmBase *v1beta1.HelmBase) string {
+	if helmBase == nil || helmBase.PostRender == nil || helmBase.PostRender.PatchesFrom == nil {
+		return ""
+	}
+	return helmBase.PostRender.PatchesFrom.Name
+}
+
+// helmPostRenderPatches returns the kustomize patches of the Helm post-render
+// step, serialized as a YAML list: the patches of the ConfigMap in the
+// namespace, followed by the inline patches. A missing ConfigMap or data key
+// is reported as an error, so that the chart isn't synced without its patches.
+func (r *reconcilerBase) helmPostRenderPatches(ctx context.Context, namespace string, helmBase *v1beta1.HelmBase) (string, error) {
+	if helmBase == nil || helmBase.PostRender == nil {
+		return "", nil
+	}
+	var patchesFromData string
+	if name := helmPostRenderConfigMapName(helmBase); name != "" {
+		cm := &corev1.ConfigMap{}
+		cmRef := client.ObjectKey{Namespace: namespace, Name: name}
+		if err := r.client.Get(ctx, cmRef, cm); err != nil {
+			return "", fmt.Errorf("getting Helm post-render patches ConfigMap %s: %w", cmRef, err)
+		}
+		dataKey := validate.HelmPostRenderDataKeyOrDefault(helmBase.PostRender.PatchesFrom.DataKey)
+		data, found := cm.Data[dataKey]
+		if !found {
+			return "", fmt.Errorf("getting Helm post-render patches ConfigMap %s: data key %q not found", cmRef, dataKey)
+		}
+		patchesFromData = data
+	}
+	patches, err := helm.PostRenderPatches(helmBase.PostRender, patchesFromData)
+	if err != nil {
+		return "", fmt.Errorf("parsing Helm post-render patches: %w", err)
+	}
+	if len(patches) == 0 {
+		return "", nil
+	}
+	data, err := yaml.Marshal(patches)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
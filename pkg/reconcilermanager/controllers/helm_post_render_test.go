@@ -0,0 +1,156 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync/v1beta1"
+	"github.com/GoogleContainerTools/config-sync/pkg/reconcilermanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestHelmPostRenderPatches(t *testing.T) {
+	patchesCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "patches",
+			Namespace: configsync.ControllerNamespace,
+		},
+		Data: map[string]string{
+			"patches.yaml": "- patch: |-\n    - op: remove\n      path: /spec/replicas\n  target:\n    kind: Deployment\n",
+			"invalid.yaml": "- path: patch.yaml\n",
+		},
+	}
+	inlinePatch := v1beta1.HelmPatch{
+		Patch: "- op: add\n  path: /metadata/labels/team\n  value: storefront",
+		Target: &v1beta1.HelmPatchTarget{
+			Group:         "apps",
+			Kind:          "Deployment",
+			LabelSelector: "app=bookstore",
+		},
+	}
+	testCases := []struct {
+		name       string
+		postRender *v1beta1.HelmPostRender
+		objs       []client.Object
+		want       string
+		wantErr    string
+	}{
+		{
+			name: "no post-render",
+		},
+		{
+			name: "inline patches",
+			postRender: &v1beta1.HelmPostRender{
+				Patches: []v1beta1.HelmPatch{inlinePatch},
+			},
+			want: `- patch: |-
+    - op: add
+      path: /metadata/labels/team
+      value: storefront
+  target:
+    group: apps
+    kind: Deployment
+    labelSelector: app=bookstore
+`,
+		},
+		{
+			name: "ConfigMap patches before inline patches",
+			postRender: &v1beta1.HelmPostRender{
+				Patches:     []v1beta1.HelmPatch{{Patch: "- op: remove\n  path: /spec/template"}},
+				PatchesFrom: &v1beta1.HelmPatchesFrom{Name: "patches"},
+			},
+			objs: []client.Object{patchesCM},
+			want: `- patch: |-
+    - op: remove
+      path: /spec/replicas
+  target:
+    kind: Deployment
+- patch: |-
+    - op: remove
+      path: /spec/template
+`,
+		},
+		{
+			name: "missing ConfigMap",
+			postRender: &v1beta1.HelmPostRender{
+				PatchesFrom: &v1beta1.HelmPatchesFrom{Name: "patches"},
+			},
+			wantErr: "getting Helm post-render patches ConfigMap config-management-system/patches",
+		},
+		{
+			name: "missing data key",
+			postRender: &v1beta1.HelmPostRender{
+				PatchesFrom: &v1beta1.HelmPatchesFrom{Name: "patches", DataKey: "missing.yaml"},
+			},
+			objs:    []client.Object{patchesCM},
+			wantErr: `data key "missing.yaml" not found`,
+		},
+		{
+			name: "invalid ConfigMap patches",
+			postRender: &v1beta1.HelmPostRender{
+				PatchesFrom: &v1beta1.HelmPatchesFrom{Name: "patches", DataKey: "invalid.yaml"},
+			},
+			objs:    []client.Object{patchesCM},
+			wantErr: `path "patch.yaml" is not supported`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, testReconciler := setupRootReconciler(t, tc.objs...)
+
+			got, err := testReconciler.helmPostRenderPatches(context.Background(), configsync.ControllerNamespace,
+				&v1beta1.HelmBase{PostRender: tc.postRender})
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestHelmSyncEnvsPostRender(t *testing.T) {
+	opts := helmOptions{
+		helmBase: &v1beta1.HelmBase{
+			Repo:        "https://charts.example.com",
+			Chart:       "bookstore",
+			APIVersions: []string{"monitoring.coreos.com/v1", "cert-manager.io/v1"},
+			KubeVersion: "1.29.0",
+		},
+		postRenderPatches: "- patch: foo\n",
+	}
+	envs := helmSyncEnvs(opts)
+	assert.Contains(t, envs, corev1.EnvVar{Name: reconcilermanager.HelmAPIVersions, Value: "monitoring.coreos.com/v1,cert-manager.io/v1"})
+	assert.Contains(t, envs, corev1.EnvVar{Name: reconcilermanager.HelmKubeVersion, Value: "1.29.0"})
+	assert.Contains(t, envs, corev1.EnvVar{Name: reconcilermanager.HelmPostRenderPatches, Value: "- patch: foo\n"})
+
+	opts = helmOptions{helmBase: &v1beta1.HelmBase{Chart: "bookstore"}}
+	for _, env := range helmSyncEnvs(opts) {
+		assert.NotContains(t, []string{
+			reconcilermanager.HelmAPIVersions,
+			reconcilermanager.HelmKubeVersion,
+			reconcilermanager.HelmPostRenderPatches,
+		}, env.Name)
+	}
+}
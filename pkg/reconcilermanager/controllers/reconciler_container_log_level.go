@@ -42,6 +42,14 @@ func ReconcilerContainerLogLevelDefaults() map[string]v1beta1.ContainerLogLevelO
 			ContainerName: reconcilermanager.OciSync,
 			LogLevel:      0,
 		},
+		reconcilermanager.HTTPSync: {
+			ContainerName: reconcilermanager.HTTPSync,
+			LogLevel:      0,
+		},
+		reconcilermanager.BucketSync: {
+			ContainerName: reconcilermanager.BucketSync,
+			LogLevel:      0,
+		},
 		reconcilermanager.HelmSync: {
 			ContainerName: reconcilermanager.HelmSync,
 			LogLevel:      0,
@@ -43,6 +43,16 @@ func ReconcilerContainerResourceDefaults() map[string]v1beta1.ContainerResources
 			CPURequest:    resource.MustParse("25m"),
 			MemoryRequest: resource.MustParse("32Mi"),
 		},
+		reconcilermanager.HTTPSync: {
+			ContainerName: reconcilermanager.HTTPSync,
+			CPURequest:    resource.MustParse("25m"),
+			MemoryRequest: resource.MustParse("32Mi"),
+		},
+		reconcilermanager.BucketSync: {
+			ContainerName: reconcilermanager.BucketSync,
+			CPURequest:    resource.MustParse("25m"),
+			MemoryRequest: resource.MustParse("32Mi"),
+		},
 		reconcilermanager.HelmSync: {
 			ContainerName: reconcilermanager.HelmSync,
 			CPURequest:    resource.MustParse("75m"),
@@ -96,6 +106,20 @@ func ReconcilerContainerResourceDefaultsForAutopilot() map[string]v1beta1.Contai
 			MemoryRequest: resource.MustParse("64Mi"),
 			MemoryLimit:   resource.MustParse("64Mi"),
 		},
+		reconcilermanager.HTTPSync: {
+			ContainerName: reconcilermanager.HTTPSync,
+			CPURequest:    resource.MustParse("50m"),
+			CPULimit:      resource.MustParse("50m"),
+			MemoryRequest: resource.MustParse("64Mi"),
+			MemoryLimit:   resource.MustParse("64Mi"),
+		},
+		reconcilermanager.BucketSync: {
+			ContainerName: reconcilermanager.BucketSync,
+			CPURequest:    resource.MustParse("50m"),
+			CPULimit:      resource.MustParse("50m"),
+			MemoryRequest: resource.MustParse("64Mi"),
+			MemoryLimit:   resource.MustParse("64Mi"),
+		},
 		reconcilermanager.HelmSync: {
 			ContainerName: reconcilermanager.HelmSync,
 			CPURequest:    resource.MustParse("250m"),
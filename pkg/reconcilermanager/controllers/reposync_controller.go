@@ -254,6 +254,10 @@ func (r *RepoSyncReconciler) upsertManagedObjects(ctx context.Context, reconcile
 	case configsync.HelmSource:
 		auth = rs.Spec.Helm.Auth
 		gcpSAEmail = rs.Spec.Helm.GCPServiceAccountEmail
+	case configsync.HTTPSource:
+		auth = rs.Spec.HTTP.Auth
+	case configsync.BucketSource:
+		auth = rs.Spec.Bucket.Auth
 	default:
 		// Should have been caught by validation
 		return fmt.Errorf("invalid source type: %s", rs.Spec.SourceType)
@@ -507,6 +511,10 @@ func (r *RepoSyncReconciler) Register(mgr controllerruntime.Manager, watchFleetM
 		Watches(&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(r.mapSecretToRepoSyncs),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
+		// Watch the ClusterInfo ConfigMap, which holds the cluster labels.
+		Watches(withNamespace(&corev1.ConfigMap{}, configsync.ControllerNamespace),
+			handler.EnqueueRequestsFromMapFunc(r.mapClusterInfoToRSyncs),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
 		Watches(withNamespace(&appsv1.Deployment{}, configsync.ControllerNamespace),
 			handler.EnqueueRequestsFromMapFunc(r.mapObjectToRepoSync),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{})).
@@ -526,7 +534,8 @@ func (r *RepoSyncReconciler) Register(mgr controllerruntime.Manager, watchFleetM
 		// Custom Watch for membership to trigger reconciliation.
 		controllerBuilder.Watches(&hubv1.Membership{},
 			handler.EnqueueRequestsFromMapFunc(r.mapMembershipToRepoSyncs),
-			builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+			// Labels are used for Cluster selection, and don't change the generation.
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{})))
 	}
 
 	if r.controllerName != "" {
@@ -542,8 +551,15 @@ func (r *RepoSyncReconciler) Register(mgr controllerruntime.Manager, watchFleetM
 func (r *RepoSyncReconciler) watchConfigMaps(ctx context.Context, rs *v1beta1.RepoSync) error {
 	// We add watches dynamically at runtime based on the RepoSync namespace
 	// in order to avoid watching ConfigMaps in the entire cluster.
-	if rs == nil || rs.Spec.SourceType != configsync.HelmSource || rs.Spec.Helm == nil ||
-		len(rs.Spec.Helm.ValuesFileRefs) == 0 {
+	if rs == nil {
+		return nil
+	}
+	usesHelmValues := rs.Spec.SourceType == configsync.HelmSource && rs.Spec.Helm != nil &&
+		len(rs.Spec.Helm.ValuesFileRefs) > 0
+	usesSubstitutionValues := substitutionValuesConfigMapName(rs.Spec.Substitution) != ""
+	usesHelmPostRender := rs.Spec.SourceType == configsync.HelmSource &&
+		helmPostRenderConfigMapName(reposync.GetHelmBase(rs.Spec.Helm)) != ""
+	if !usesHelmValues && !usesSubstitutionValues && !usesHelmPostRender {
 		// TODO: When it's available, we should remove unneeded watches from the controller
 		// when all RepoSyncs with ConfigMap references in a particular namespace are
 		// deleted (or are no longer referencing ConfigMaps).
@@ -669,7 +685,9 @@ func (r *RepoSyncReconciler) mapSecretToRepoSyncs(ctx context.Context, secret cl
 		switch sRef.Name {
 		case repoSyncGitSecretName(&rs), repoSyncGitCACertSecretName(&rs),
 			repoSyncOCICACertSecretName(&rs), repoSyncHelmCACertSecretName(&rs),
-			repoSyncOciSecretName(&rs), repoSyncHelmSecretName(&rs):
+			repoSyncOciSecretName(&rs), repoSyncHelmSecretName(&rs),
+			repoSyncHTTPCACertSecretName(&rs), repoSyncHTTPSecretName(&rs),
+			repoSyncBucketCACertSecretName(&rs), repoSyncBucketSecretName(&rs):
 			attachedRSNames = append(attachedRSNames, rs.GetName())
 			requests = append(requests, reconcile.Request{
 				NamespacedName: client.ObjectKeyFromObject(&rs),
@@ -768,6 +786,58 @@ func repoSyncHelmSecretName(rs *v1beta1.RepoSync) string {
 	return rs.Spec.Helm.SecretRef.Name
 }
 
+func repoSyncHTTPCACertSecretName(rs *v1beta1.RepoSync) string {
+	if rs == nil {
+		return ""
+	}
+	if rs.Spec.HTTP == nil {
+		return ""
+	}
+	if rs.Spec.HTTP.CACertSecretRef == nil {
+		return ""
+	}
+	return rs.Spec.HTTP.CACertSecretRef.Name
+}
+
+func repoSyncHTTPSecretName(rs *v1beta1.RepoSync) string {
+	if rs == nil {
+		return ""
+	}
+	if rs.Spec.HTTP == nil {
+		return ""
+	}
+	if rs.Spec.HTTP.SecretRef == nil {
+		return ""
+	}
+	return rs.Spec.HTTP.SecretRef.Name
+}
+
+func repoSyncBucketCACertSecretName(rs *v1beta1.RepoSync) string {
+	if rs == nil {
+		return ""
+	}
+	if rs.Spec.Bucket == nil {
+		return ""
+	}
+	if rs.Spec.Bucket.CACertSecretRef == nil {
+		return ""
+	}
+	return rs.Spec.Bucket.CACertSecretRef.Name
+}
+
+func repoSyncBucketSecretName(rs *v1beta1.RepoSync) string {
+	if rs == nil {
+		return ""
+	}
+	if rs.Spec.Bucket == nil {
+		return ""
+	}
+	if rs.Spec.Bucket.SecretRef == nil {
+		return ""
+	}
+	return rs.Spec.Bucket.SecretRef.Name
+}
+
 func (r *RepoSyncReconciler) mapConfigMapToRepoSyncs(ctx context.Context, obj client.Object) []reconcile.Request {
 	objRef := client.ObjectKeyFromObject(obj)
 
@@ -811,7 +881,9 @@ func (r *RepoSyncReconciler) mapConfigMapToRepoSyncs(ctx context.Context, obj cl
 	for _, rs := range repoSyncList.Items {
 		// Only enqueue a request for the RSync if it references the ConfigMap that triggered the event
 		//TODO: Use stdlib slices.Contains in Go 1.21+
-		if slices.Contains(repoSyncHelmValuesFileNames(&rs), objRef.Name) {
+		if slices.Contains(repoSyncHelmValuesFileNames(&rs), objRef.Name) ||
+			substitutionValuesConfigMapName(rs.Spec.Substitution) == objRef.Name ||
+			helmPostRenderConfigMapName(reposync.GetHelmBase(rs.Spec.Helm)) == objRef.Name {
 			requests = append(requests, reconcile.Request{
 				NamespacedName: client.ObjectKeyFromObject(&rs),
 			})
@@ -904,38 +976,60 @@ func (r *RepoSyncReconciler) mapObjectToRepoSync(ctx context.Context, obj client
 }
 
 func (r *RepoSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1beta1.RepoSync, reconcilerName string) (map[string][]corev1.EnvVar, error) {
+	clusterLabels, err := r.clusterLabels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	substitutionValues, err := r.substitutionValues(ctx, rs.Namespace, rs.Spec.Substitution)
+	if err != nil {
+		return nil, err
+	}
 	result := map[string][]corev1.EnvVar{
 		reconcilermanager.HydrationController: hydrationEnvs(hydrationOptions{
-			sourceType:     rs.Spec.SourceType,
-			gitConfig:      rs.Spec.Git,
-			ociConfig:      rs.Spec.Oci,
-			scope:          declared.Scope(rs.Namespace),
-			reconcilerName: reconcilerName,
-			pollPeriod:     r.hydrationPollingPeriod.String(),
+			sourceType:              rs.Spec.SourceType,
+			gitConfig:               rs.Spec.Git,
+			ociConfig:               rs.Spec.Oci,
+			httpConfig:              rs.Spec.HTTP,
+			bucketConfig:            rs.Spec.Bucket,
+			helmConfig:              reposync.GetHelmBase(rs.Spec.Helm),
+			scope:                   declared.Scope(rs.Namespace),
+			reconcilerName:          reconcilerName,
+			pollPeriod:              r.hydrationPollingPeriod.String(),
+			functionPipelineEnabled: r.isAnnotationValueTrue(ctx, rs, metadata.FunctionPipelineAnnotationKey),
 		}),
 		reconcilermanager.Reconciler: reconcilerEnvs(reconcilerOptions{
-			clusterName:       r.clusterName,
-			syncName:          rs.Name,
-			syncGeneration:    rs.Generation,
-			reconcilerName:    reconcilerName,
-			reconcilerScope:   declared.Scope(rs.Namespace),
-			sourceType:        rs.Spec.SourceType,
-			gitConfig:         rs.Spec.Git,
-			ociConfig:         rs.Spec.Oci,
-			helmConfig:        reposync.GetHelmBase(rs.Spec.Helm),
-			pollPeriod:        r.reconcilerPollingPeriod.String(),
-			statusMode:        metadata.StatusMode(rs.Spec.SafeOverride().StatusMode),
-			reconcileTimeout:  v1beta1.GetReconcileTimeout(rs.Spec.SafeOverride().ReconcileTimeout),
-			apiServerTimeout:  v1beta1.GetAPIServerTimeout(rs.Spec.SafeOverride().APIServerTimeout),
-			requiresRendering: r.isAnnotationValueTrue(ctx, rs, metadata.RequiresRenderingAnnotationKey),
+			clusterName:             r.clusterName,
+			clusterLabels:           clusterLabels,
+			functionPipelineEnabled: r.isAnnotationValueTrue(ctx, rs, metadata.FunctionPipelineAnnotationKey),
+			substitutionEnabled:     rs.Spec.Substitution != nil,
+			substitutionValues:      substitutionValues,
+			prunePolicy:             rs.Spec.PrunePolicy,
+			driftPolicy:             metadata.GetDriftPolicy(rs),
+			driftAuditLog:           r.isAnnotationValueTrue(ctx, rs, metadata.DriftAuditLogAnnotationKey),
+			inventorySelfHeal:       r.isAnnotationValueTrue(ctx, rs, metadata.InventorySelfHealAnnotationKey),
+			remediatorPriorities:    rs.GetAnnotations()[metadata.RemediatorPrioritiesAnnotationKey],
+			forceConflictsDisabled:  !metadata.GetForceConflicts(rs),
+			syncName:                rs.Name,
+			syncGeneration:          rs.Generation,
+			reconcilerName:          reconcilerName,
+			reconcilerScope:         declared.Scope(rs.Namespace),
+			sourceType:              rs.Spec.SourceType,
+			gitConfig:               rs.Spec.Git,
+			ociConfig:               rs.Spec.Oci,
+			httpConfig:              rs.Spec.HTTP,
+			bucketConfig:            rs.Spec.Bucket,
+			helmConfig:              reposync.GetHelmBase(rs.Spec.Helm),
+			pollPeriod:              r.reconcilerPollingPeriod.String(),
+			statusMode:              metadata.StatusMode(rs.Spec.SafeOverride().StatusMode),
+			reconcileTimeout:        v1beta1.GetReconcileTimeout(rs.Spec.SafeOverride().ReconcileTimeout),
+			apiServerTimeout:        v1beta1.GetAPIServerTimeout(rs.Spec.SafeOverride().APIServerTimeout),
+			requiresRendering:       r.isAnnotationValueTrue(ctx, rs, metadata.RequiresRenderingAnnotationKey),
 			// Namespace reconciler doesn't support NamespaceSelector at all.
 			dynamicNSSelectorEnabled: false,
 			webhookEnabled:           r.webhookEnabled,
 		}),
 	}
 
-	var err error
-
 	switch rs.Spec.SourceType {
 	case configsync.GitSource:
 		result[reconcilermanager.GitSync], err = gitSyncEnvs(ctx, options{
@@ -962,14 +1056,39 @@ func (r *RepoSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 			auth:            rs.Spec.Oci.Auth,
 			period:          v1beta1.GetPeriod(rs.Spec.Oci.Period, configsync.DefaultReconcilerPollingPeriod).Seconds(),
 			caCertSecretRef: v1beta1.GetSecretName(rs.Spec.Oci.CACertSecretRef),
+			layerSelector:   rs.Spec.Oci.LayerSelector,
+		})
+	case configsync.HTTPSource:
+		result[reconcilermanager.HTTPSync] = httpSyncEnvs(httpOptions{
+			url:             rs.Spec.HTTP.URL,
+			auth:            rs.Spec.HTTP.Auth,
+			period:          v1beta1.GetPeriod(rs.Spec.HTTP.Period, configsync.DefaultReconcilerPollingPeriod).Seconds(),
+			checksum:        rs.Spec.HTTP.Checksum,
+			caCertSecretRef: v1beta1.GetSecretName(rs.Spec.HTTP.CACertSecretRef),
+		})
+	case configsync.BucketSource:
+		result[reconcilermanager.BucketSync] = bucketSyncEnvs(bucketOptions{
+			endpoint:        rs.Spec.Bucket.Endpoint,
+			name:            rs.Spec.Bucket.Name,
+			prefix:          rs.Spec.Bucket.Prefix,
+			region:          rs.Spec.Bucket.Region,
+			insecure:        rs.Spec.Bucket.Insecure,
+			auth:            rs.Spec.Bucket.Auth,
+			period:          v1beta1.GetPeriod(rs.Spec.Bucket.Period, configsync.DefaultReconcilerPollingPeriod).Seconds(),
+			caCertSecretRef: v1beta1.GetSecretName(rs.Spec.Bucket.CACertSecretRef),
 		})
 	case configsync.HelmSource:
+		postRenderPatches, err := r.helmPostRenderPatches(ctx, rs.Namespace, &rs.Spec.Helm.HelmBase)
+		if err != nil {
+			return nil, err
+		}
 		result[reconcilermanager.HelmSync] = helmSyncEnvs(helmOptions{
 			helmBase:         &rs.Spec.Helm.HelmBase,
 			releaseNamespace: rs.Namespace,
 			// RepoSync API doesn't support specifying deployNamespace
-			deployNamespace: "",
-			caCertSecretRef: v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef),
+			deployNamespace:   "",
+			caCertSecretRef:   v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef),
+			postRenderPatches: postRenderPatches,
 		})
 	}
 
@@ -1009,6 +1128,10 @@ func (r *RepoSyncReconciler) validateDependencies(ctx context.Context, rs *v1bet
 		return r.validateOciDependencies(ctx, rs)
 	case configsync.HelmSource:
 		return r.validateHelmDependencies(ctx, rs)
+	case configsync.HTTPSource:
+		return r.validateHTTPDependencies(ctx, rs, reconcilerName)
+	case configsync.BucketSource:
+		return r.validateBucketDependencies(ctx, rs, reconcilerName)
 	default:
 		return validate.InvalidSourceType(r.syncGVK.Kind)
 	}
@@ -1025,6 +1148,20 @@ func (r *RepoSyncReconciler) validateOciDependencies(ctx context.Context, rs *v1
 	return r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Oci.CACertSecretRef))
 }
 
+func (r *RepoSyncReconciler) validateHTTPDependencies(ctx context.Context, rs *v1beta1.RepoSync, reconcilerName string) status.Error {
+	if err := r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.HTTP.CACertSecretRef)); err != nil {
+		return err
+	}
+	return r.validateNamespaceSecret(ctx, rs, reconcilerName)
+}
+
+func (r *RepoSyncReconciler) validateBucketDependencies(ctx context.Context, rs *v1beta1.RepoSync, reconcilerName string) status.Error {
+	if err := r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Bucket.CACertSecretRef)); err != nil {
+		return err
+	}
+	return r.validateNamespaceSecret(ctx, rs, reconcilerName)
+}
+
 func (r *RepoSyncReconciler) validateHelmDependencies(ctx context.Context, rs *v1beta1.RepoSync) status.Error {
 	if err := r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef)); err != nil {
 		return err
@@ -1046,6 +1183,12 @@ func (r *RepoSyncReconciler) validateNamespaceSecret(ctx context.Context, repoSy
 	case configsync.OciSource:
 		authType = repoSync.Spec.Oci.Auth
 		namespaceSecretName = v1beta1.GetSecretName(repoSync.Spec.Oci.SecretRef)
+	case configsync.HTTPSource:
+		authType = repoSync.Spec.HTTP.Auth
+		namespaceSecretName = v1beta1.GetSecretName(repoSync.Spec.HTTP.SecretRef)
+	case configsync.BucketSource:
+		authType = repoSync.Spec.Bucket.Auth
+		namespaceSecretName = v1beta1.GetSecretName(repoSync.Spec.Bucket.SecretRef)
 	}
 
 	if SkipForAuth(authType) {
@@ -1181,6 +1324,14 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 			gcpSAEmail = rs.Spec.Helm.GCPServiceAccountEmail
 			secretRefName = v1beta1.GetSecretName(rs.Spec.Helm.SecretRef)
 			caCertSecretRefName = v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef)
+		case configsync.HTTPSource:
+			auth = rs.Spec.HTTP.Auth
+			secretRefName = v1beta1.GetSecretName(rs.Spec.HTTP.SecretRef)
+			caCertSecretRefName = v1beta1.GetSecretName(rs.Spec.HTTP.CACertSecretRef)
+		case configsync.BucketSource:
+			auth = rs.Spec.Bucket.Auth
+			secretRefName = v1beta1.GetSecretName(rs.Spec.Bucket.SecretRef)
+			caCertSecretRefName = v1beta1.GetSecretName(rs.Spec.Bucket.CACertSecretRef)
 		}
 		injectFWICreds := useFWIAuth(auth, r.membership)
 		if injectFWICreds {
@@ -1259,6 +1410,28 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 					}
 					injectFWICredsToContainer(&container, injectFWICreds)
 				}
+			case reconcilermanager.HTTPSync:
+				// Don't add the http-sync container when sourceType is NOT http.
+				if rs.Spec.SourceType != configsync.HTTPSource {
+					addContainer = false
+				} else {
+					container.Env = append(container.Env, containerEnvs[container.Name]...)
+					container.VolumeMounts = volumeMounts(rs.Spec.HTTP.Auth, caCertSecretRefName, rs.Spec.SourceType, container.VolumeMounts)
+					if authTypeToken(rs.Spec.HTTP.Auth) {
+						container.Env = append(container.Env, httpSyncTokenAuthEnv(secretName)...)
+					}
+				}
+			case reconcilermanager.BucketSync:
+				// Don't add the bucket-sync container when sourceType is NOT bucket.
+				if rs.Spec.SourceType != configsync.BucketSource {
+					addContainer = false
+				} else {
+					container.Env = append(container.Env, containerEnvs[container.Name]...)
+					container.VolumeMounts = volumeMounts(rs.Spec.Bucket.Auth, caCertSecretRefName, rs.Spec.SourceType, container.VolumeMounts)
+					if authTypeToken(rs.Spec.Bucket.Auth) {
+						container.Env = append(container.Env, bucketSyncTokenAuthEnv(secretName)...)
+					}
+				}
 			case reconcilermanager.HelmSync:
 				// Don't add the helm-sync container when sourceType is NOT helm.
 				if rs.Spec.SourceType != configsync.HelmSource {
@@ -206,6 +206,10 @@ func (r *RootSyncReconciler) upsertManagedObjects(ctx context.Context, reconcile
 	case configsync.HelmSource:
 		auth = rs.Spec.Helm.Auth
 		gcpSAEmail = rs.Spec.Helm.GCPServiceAccountEmail
+	case configsync.HTTPSource:
+		auth = rs.Spec.HTTP.Auth
+	case configsync.BucketSource:
+		auth = rs.Spec.Bucket.Auth
 	default:
 		// Should have been caught by validation
 		return fmt.Errorf("invalid source type: %s", rs.Spec.SourceType)
@@ -464,7 +468,8 @@ func (r *RootSyncReconciler) Register(mgr controllerruntime.Manager, watchFleetM
 		// Custom Watch for membership to trigger reconciliation.
 		controllerBuilder.Watches(&hubv1.Membership{},
 			handler.EnqueueRequestsFromMapFunc(r.mapMembershipToRootSyncs),
-			builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+			// Labels are used for Cluster selection, and don't change the generation.
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{})))
 	}
 
 	if r.controllerName != "" {
@@ -516,6 +521,11 @@ func (r *RootSyncReconciler) mapConfigMapsToRootSyncs(ctx context.Context, obj c
 		return nil
 	}
 
+	// Changes to the cluster labels affect every RootSync.
+	if objRef.Name == configsync.ClusterInfoConfigMapName {
+		return r.mapClusterInfoToRSyncs(ctx, obj)
+	}
+
 	// Look up RootSyncs and see if any of them reference this ConfigMap.
 	rootSyncList := &v1beta1.RootSyncList{}
 	if err := r.client.List(ctx, rootSyncList, client.InNamespace(objRef.Namespace)); err != nil {
@@ -528,7 +538,9 @@ func (r *RootSyncReconciler) mapConfigMapsToRootSyncs(ctx context.Context, obj c
 	var attachedRSNames []string
 	for _, rs := range rootSyncList.Items {
 		// Only enqueue a request for the RSync if it references the ConfigMap that triggered the event
-		if slices.Contains(rootSyncHelmValuesFileNames(&rs), objRef.Name) {
+		if slices.Contains(rootSyncHelmValuesFileNames(&rs), objRef.Name) ||
+			substitutionValuesConfigMapName(rs.Spec.Substitution) == objRef.Name ||
+			helmPostRenderConfigMapName(rootsync.GetHelmBase(rs.Spec.Helm)) == objRef.Name {
 			requests = append(requests, reconcile.Request{
 				NamespacedName: client.ObjectKeyFromObject(&rs),
 			})
@@ -670,7 +682,9 @@ func (r *RootSyncReconciler) mapSecretToRootSyncs(ctx context.Context, secret cl
 		switch sRef.Name {
 		case rootSyncGitSecretName(&rs), rootSyncGitCACertSecretName(&rs),
 			rootSyncOCICACertSecretName(&rs), rootSyncHelmCACertSecretName(&rs),
-			rootSyncOCISecretName(&rs), rootSyncHelmSecretName(&rs):
+			rootSyncOCISecretName(&rs), rootSyncHelmSecretName(&rs),
+			rootSyncHTTPCACertSecretName(&rs), rootSyncHTTPSecretName(&rs),
+			rootSyncBucketCACertSecretName(&rs), rootSyncBucketSecretName(&rs):
 			attachedRSNames = append(attachedRSNames, rs.GetName())
 			requests = append(requests, reconcile.Request{
 				NamespacedName: client.ObjectKeyFromObject(&rs),
@@ -762,19 +776,93 @@ func rootSyncOCISecretName(rs *v1beta1.RootSync) string {
 	return rs.Spec.Oci.SecretRef.Name
 }
 
+func rootSyncHTTPCACertSecretName(rs *v1beta1.RootSync) string {
+	if rs == nil {
+		return ""
+	}
+	if rs.Spec.HTTP == nil {
+		return ""
+	}
+	if rs.Spec.HTTP.CACertSecretRef == nil {
+		return ""
+	}
+	return rs.Spec.HTTP.CACertSecretRef.Name
+}
+
+func rootSyncHTTPSecretName(rs *v1beta1.RootSync) string {
+	if rs == nil {
+		return ""
+	}
+	if rs.Spec.HTTP == nil {
+		return ""
+	}
+	if rs.Spec.HTTP.SecretRef == nil {
+		return ""
+	}
+	return rs.Spec.HTTP.SecretRef.Name
+}
+
+func rootSyncBucketCACertSecretName(rs *v1beta1.RootSync) string {
+	if rs == nil {
+		return ""
+	}
+	if rs.Spec.Bucket == nil {
+		return ""
+	}
+	if rs.Spec.Bucket.CACertSecretRef == nil {
+		return ""
+	}
+	return rs.Spec.Bucket.CACertSecretRef.Name
+}
+
+func rootSyncBucketSecretName(rs *v1beta1.RootSync) string {
+	if rs == nil {
+		return ""
+	}
+	if rs.Spec.Bucket == nil {
+		return ""
+	}
+	if rs.Spec.Bucket.SecretRef == nil {
+		return ""
+	}
+	return rs.Spec.Bucket.SecretRef.Name
+}
+
 func (r *RootSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1beta1.RootSync, reconcilerName string) (map[string][]corev1.EnvVar, error) {
+	clusterLabels, err := r.clusterLabels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	substitutionValues, err := r.substitutionValues(ctx, rs.Namespace, rs.Spec.Substitution)
+	if err != nil {
+		return nil, err
+	}
 	result := map[string][]corev1.EnvVar{
 		reconcilermanager.HydrationController: hydrationEnvs(hydrationOptions{
-			sourceType:     rs.Spec.SourceType,
-			gitConfig:      rs.Spec.Git,
-			ociConfig:      rs.Spec.Oci,
-			scope:          declared.RootScope,
-			reconcilerName: reconcilerName,
-			pollPeriod:     r.hydrationPollingPeriod.String(),
+			sourceType:              rs.Spec.SourceType,
+			gitConfig:               rs.Spec.Git,
+			ociConfig:               rs.Spec.Oci,
+			httpConfig:              rs.Spec.HTTP,
+			bucketConfig:            rs.Spec.Bucket,
+			helmConfig:              rootsync.GetHelmBase(rs.Spec.Helm),
+			scope:                   declared.RootScope,
+			reconcilerName:          reconcilerName,
+			pollPeriod:              r.hydrationPollingPeriod.String(),
+			functionPipelineEnabled: r.isAnnotationValueTrue(ctx, rs, metadata.FunctionPipelineAnnotationKey),
 		}),
 		reconcilermanager.Reconciler: append(
 			reconcilerEnvs(reconcilerOptions{
 				clusterName:              r.clusterName,
+				clusterLabels:            clusterLabels,
+				functionPipelineEnabled:  r.isAnnotationValueTrue(ctx, rs, metadata.FunctionPipelineAnnotationKey),
+				substitutionEnabled:      rs.Spec.Substitution != nil,
+				substitutionValues:       substitutionValues,
+				prunePolicy:              rs.Spec.PrunePolicy,
+				driftPolicy:              metadata.GetDriftPolicy(rs),
+				driftAuditLog:            r.isAnnotationValueTrue(ctx, rs, metadata.DriftAuditLogAnnotationKey),
+				inventorySelfHeal:        r.isAnnotationValueTrue(ctx, rs, metadata.InventorySelfHealAnnotationKey),
+				remediatorPriorities:     rs.GetAnnotations()[metadata.RemediatorPrioritiesAnnotationKey],
+				forceConflictsDisabled:   !metadata.GetForceConflicts(rs),
 				syncName:                 rs.Name,
 				syncGeneration:           rs.Generation,
 				reconcilerName:           reconcilerName,
@@ -782,6 +870,8 @@ func (r *RootSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 				sourceType:               rs.Spec.SourceType,
 				gitConfig:                rs.Spec.Git,
 				ociConfig:                rs.Spec.Oci,
+				httpConfig:               rs.Spec.HTTP,
+				bucketConfig:             rs.Spec.Bucket,
 				helmConfig:               rootsync.GetHelmBase(rs.Spec.Helm),
 				pollPeriod:               r.reconcilerPollingPeriod.String(),
 				statusMode:               metadata.StatusMode(rs.Spec.SafeOverride().StatusMode),
@@ -796,8 +886,6 @@ func (r *RootSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 		),
 	}
 
-	var err error
-
 	switch rs.Spec.SourceType {
 	case configsync.GitSource:
 		result[reconcilermanager.GitSync], err = gitSyncEnvs(ctx, options{
@@ -824,13 +912,38 @@ func (r *RootSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 			auth:            rs.Spec.Oci.Auth,
 			period:          v1beta1.GetPeriod(rs.Spec.Oci.Period, configsync.DefaultReconcilerPollingPeriod).Seconds(),
 			caCertSecretRef: v1beta1.GetSecretName(rs.Spec.Oci.CACertSecretRef),
+			layerSelector:   rs.Spec.Oci.LayerSelector,
+		})
+	case configsync.HTTPSource:
+		result[reconcilermanager.HTTPSync] = httpSyncEnvs(httpOptions{
+			url:             rs.Spec.HTTP.URL,
+			auth:            rs.Spec.HTTP.Auth,
+			period:          v1beta1.GetPeriod(rs.Spec.HTTP.Period, configsync.DefaultReconcilerPollingPeriod).Seconds(),
+			checksum:        rs.Spec.HTTP.Checksum,
+			caCertSecretRef: v1beta1.GetSecretName(rs.Spec.HTTP.CACertSecretRef),
+		})
+	case configsync.BucketSource:
+		result[reconcilermanager.BucketSync] = bucketSyncEnvs(bucketOptions{
+			endpoint:        rs.Spec.Bucket.Endpoint,
+			name:            rs.Spec.Bucket.Name,
+			prefix:          rs.Spec.Bucket.Prefix,
+			region:          rs.Spec.Bucket.Region,
+			insecure:        rs.Spec.Bucket.Insecure,
+			auth:            rs.Spec.Bucket.Auth,
+			period:          v1beta1.GetPeriod(rs.Spec.Bucket.Period, configsync.DefaultReconcilerPollingPeriod).Seconds(),
+			caCertSecretRef: v1beta1.GetSecretName(rs.Spec.Bucket.CACertSecretRef),
 		})
 	case configsync.HelmSource:
+		postRenderPatches, err := r.helmPostRenderPatches(ctx, rs.Namespace, &rs.Spec.Helm.HelmBase)
+		if err != nil {
+			return nil, err
+		}
 		result[reconcilermanager.HelmSync] = helmSyncEnvs(helmOptions{
-			helmBase:         &rs.Spec.Helm.HelmBase,
-			releaseNamespace: rs.Spec.Helm.Namespace,
-			deployNamespace:  rs.Spec.Helm.DeployNamespace,
-			caCertSecretRef:  v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef),
+			helmBase:          &rs.Spec.Helm.HelmBase,
+			releaseNamespace:  rs.Spec.Helm.Namespace,
+			deployNamespace:   rs.Spec.Helm.DeployNamespace,
+			caCertSecretRef:   v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef),
+			postRenderPatches: postRenderPatches,
 		})
 	}
 
@@ -870,6 +983,10 @@ func (r *RootSyncReconciler) validateDependencies(ctx context.Context, rs *v1bet
 		return r.validateOciDependencies(ctx, rs)
 	case configsync.HelmSource:
 		return r.validateHelmDependencies(ctx, rs)
+	case configsync.HTTPSource:
+		return r.validateHTTPDependencies(ctx, rs)
+	case configsync.BucketSource:
+		return r.validateBucketDependencies(ctx, rs)
 	default:
 		return validate.InvalidSourceType(r.syncGVK.Kind)
 	}
@@ -886,6 +1003,14 @@ func (r *RootSyncReconciler) validateOciDependencies(ctx context.Context, rs *v1
 	return r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Oci.CACertSecretRef))
 }
 
+func (r *RootSyncReconciler) validateHTTPDependencies(ctx context.Context, rs *v1beta1.RootSync) status.Error {
+	return r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.HTTP.CACertSecretRef))
+}
+
+func (r *RootSyncReconciler) validateBucketDependencies(ctx context.Context, rs *v1beta1.RootSync) status.Error {
+	return r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Bucket.CACertSecretRef))
+}
+
 func (r *RootSyncReconciler) validateHelmDependencies(ctx context.Context, rs *v1beta1.RootSync) status.Error {
 	if err := r.validateCACertSecret(ctx, rs.Namespace, v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef)); err != nil {
 		return err
@@ -1146,6 +1271,14 @@ func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootS
 			gcpSAEmail = rs.Spec.Helm.GCPServiceAccountEmail
 			secretRefName = v1beta1.GetSecretName(rs.Spec.Helm.SecretRef)
 			caCertSecretRefName = v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef)
+		case configsync.HTTPSource:
+			auth = rs.Spec.HTTP.Auth
+			secretRefName = v1beta1.GetSecretName(rs.Spec.HTTP.SecretRef)
+			caCertSecretRefName = v1beta1.GetSecretName(rs.Spec.HTTP.CACertSecretRef)
+		case configsync.BucketSource:
+			auth = rs.Spec.Bucket.Auth
+			secretRefName = v1beta1.GetSecretName(rs.Spec.Bucket.SecretRef)
+			caCertSecretRefName = v1beta1.GetSecretName(rs.Spec.Bucket.CACertSecretRef)
 		}
 		injectFWICreds := useFWIAuth(auth, r.membership)
 		if injectFWICreds {
@@ -1221,6 +1354,28 @@ func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootS
 					}
 					injectFWICredsToContainer(&container, injectFWICreds)
 				}
+			case reconcilermanager.HTTPSync:
+				// Don't add the http-sync container when sourceType is NOT http.
+				if rs.Spec.SourceType != configsync.HTTPSource {
+					addContainer = false
+				} else {
+					container.Env = append(container.Env, containerEnvs[container.Name]...)
+					container.VolumeMounts = volumeMounts(rs.Spec.HTTP.Auth, caCertSecretRefName, rs.Spec.SourceType, container.VolumeMounts)
+					if authTypeToken(rs.Spec.HTTP.Auth) {
+						container.Env = append(container.Env, httpSyncTokenAuthEnv(secretRefName)...)
+					}
+				}
+			case reconcilermanager.BucketSync:
+				// Don't add the bucket-sync container when sourceType is NOT bucket.
+				if rs.Spec.SourceType != configsync.BucketSource {
+					addContainer = false
+				} else {
+					container.Env = append(container.Env, containerEnvs[container.Name]...)
+					container.VolumeMounts = volumeMounts(rs.Spec.Bucket.Auth, caCertSecretRefName, rs.Spec.SourceType, container.VolumeMounts)
+					if authTypeToken(rs.Spec.Bucket.Auth) {
+						container.Env = append(container.Env, bucketSyncTokenAuthEnv(secretRefName)...)
+					}
+				}
 			case reconcilermanager.HelmSync:
 				// Don't add the helm-sync container when sourceType is NOT helm.
 				if rs.Spec.SourceType != configsync.HelmSource {
@@ -44,6 +44,12 @@ func isUpsertedSecret(rs *v1beta1.RepoSync, secretName string) bool {
 	if shouldUpsertOciSecret(rs) && secretName == ReconcilerResourceName(reconcilerName, v1beta1.GetSecretName(rs.Spec.Oci.SecretRef)) {
 		return true
 	}
+	if shouldUpsertHTTPSecret(rs) && secretName == ReconcilerResourceName(reconcilerName, v1beta1.GetSecretName(rs.Spec.HTTP.SecretRef)) {
+		return true
+	}
+	if shouldUpsertBucketSecret(rs) && secretName == ReconcilerResourceName(reconcilerName, v1beta1.GetSecretName(rs.Spec.Bucket.SecretRef)) {
+		return true
+	}
 
 	return false
 }
@@ -65,6 +71,16 @@ func getCACertName(rs *v1beta1.RepoSync) (string, bool) {
 			return "", false
 		}
 		return v1beta1.GetSecretName(rs.Spec.Helm.CACertSecretRef), true
+	case configsync.HTTPSource:
+		if rs.Spec.HTTP == nil || rs.Spec.HTTP.CACertSecretRef == nil {
+			return "", false
+		}
+		return v1beta1.GetSecretName(rs.Spec.HTTP.CACertSecretRef), true
+	case configsync.BucketSource:
+		if rs.Spec.Bucket == nil || rs.Spec.Bucket.CACertSecretRef == nil {
+			return "", false
+		}
+		return v1beta1.GetSecretName(rs.Spec.Bucket.CACertSecretRef), true
 	default:
 		return "", false
 	}
@@ -82,6 +98,14 @@ func shouldUpsertOciSecret(rs *v1beta1.RepoSync) bool {
 	return rs.Spec.SourceType == configsync.OciSource && rs.Spec.Oci != nil && rs.Spec.Oci.SecretRef != nil && !SkipForAuth(rs.Spec.Oci.Auth)
 }
 
+func shouldUpsertHTTPSecret(rs *v1beta1.RepoSync) bool {
+	return rs.Spec.SourceType == configsync.HTTPSource && rs.Spec.HTTP != nil && rs.Spec.HTTP.SecretRef != nil && !SkipForAuth(rs.Spec.HTTP.Auth)
+}
+
+func shouldUpsertBucketSecret(rs *v1beta1.RepoSync) bool {
+	return rs.Spec.SourceType == configsync.BucketSource && rs.Spec.Bucket != nil && rs.Spec.Bucket.SecretRef != nil && !SkipForAuth(rs.Spec.Bucket.Auth)
+}
+
 // upsertAuthSecret creates or updates the auth secret in the
 // config-management-system namespace using an existing secret in the RepoSync
 // namespace.
@@ -112,6 +136,22 @@ func (r *reconcilerBase) upsertAuthSecret(ctx context.Context, rs *v1beta1.RepoS
 		}
 		_, err = r.upsertSecret(ctx, cmsSecretRef, userSecret, labelMap)
 		return cmsSecretRef, err
+	case shouldUpsertHTTPSecret(rs):
+		nsSecretRef, cmsSecretRef := getSecretRefs(rsRef, reconcilerRef, v1beta1.GetSecretName(rs.Spec.HTTP.SecretRef))
+		userSecret, err := getUserSecret(ctx, r.client, nsSecretRef)
+		if err != nil {
+			return cmsSecretRef, fmt.Errorf("user secret required for http client authentication: %w", err)
+		}
+		_, err = r.upsertSecret(ctx, cmsSecretRef, userSecret, labelMap)
+		return cmsSecretRef, err
+	case shouldUpsertBucketSecret(rs):
+		nsSecretRef, cmsSecretRef := getSecretRefs(rsRef, reconcilerRef, v1beta1.GetSecretName(rs.Spec.Bucket.SecretRef))
+		userSecret, err := getUserSecret(ctx, r.client, nsSecretRef)
+		if err != nil {
+			return cmsSecretRef, fmt.Errorf("user secret required for bucket client authentication: %w", err)
+		}
+		_, err = r.upsertSecret(ctx, cmsSecretRef, userSecret, labelMap)
+		return cmsSecretRef, err
 	default:
 		// No secret required
 		return client.ObjectKey{}, nil
@@ -0,0 +1,49 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// substitutionValuesConfigMapName returns the name of the ConfigMap that holds
+// the substitution values, or an empty string if there is none.
+func substitutionValuesConfigMapName(substitution *v1beta1.Substitution) string {
+	if substitution == nil || substitution.ValuesFrom == nil {
+		return ""
+	}
+	return substitution.ValuesFrom.Name
+}
+
+// substitutionValues returns the data of the ConfigMap in the namespace that
+// holds the substitution values. A missing ConfigMap is reported as an error,
+// so that the reconciler doesn't sync the resources with undefined values.
+func (r *reconcilerBase) substitutionValues(ctx context.Context, namespace string, substitution *v1beta1.Substitution) (map[string]string, error) {
+	name := substitutionValuesConfigMapName(substitution)
+	if name == "" {
+		return nil, nil
+	}
+	cm := &corev1.ConfigMap{}
+	cmRef := client.ObjectKey{Namespace: namespace, Name: name}
+	if err := r.client.Get(ctx, cmRef, cm); err != nil {
+		return nil, fmt.Errorf("getting substitution values ConfigMap %s: %w", cmRef, err)
+	}
+	return cm.Data, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync/v1beta1"
+	"github.com/GoogleContainerTools/config-sync/pkg/reconcilermanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSubstitutionValues(t *testing.T) {
+	valuesCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "values",
+			Namespace: configsync.ControllerNamespace,
+		},
+		Data: map[string]string{"replicas": "3"},
+	}
+	testCases := []struct {
+		name         string
+		substitution *v1beta1.Substitution
+		objs         []client.Object
+		want         map[string]string
+		wantErr      string
+	}{
+		{
+			name: "no substitution",
+		},
+		{
+			name:         "no ConfigMap reference",
+			substitution: &v1beta1.Substitution{},
+		},
+		{
+			name: "ConfigMap values",
+			substitution: &v1beta1.Substitution{
+				ValuesFrom: &v1beta1.SubstitutionValuesFrom{Name: "values"},
+			},
+			objs: []client.Object{valuesCM},
+			want: map[string]string{"replicas": "3"},
+		},
+		{
+			name: "missing ConfigMap",
+			substitution: &v1beta1.Substitution{
+				ValuesFrom: &v1beta1.SubstitutionValuesFrom{Name: "values"},
+			},
+			wantErr: "getting substitution values ConfigMap config-management-system/values",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, testReconciler := setupRootReconciler(t, tc.objs...)
+
+			got, err := testReconciler.substitutionValues(context.Background(), configsync.ControllerNamespace, tc.substitution)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestReconcilerEnvsSubstitutionValues(t *testing.T) {
+	opts := reconcilerOptions{
+		sourceType:         configsync.GitSource,
+		gitConfig:          rootSyncWithGit(rootsyncName).Spec.Git,
+		substitutionValues: map[string]string{"replicas": "3", "tier": "gold"},
+	}
+	envs := reconcilerEnvs(opts)
+	assert.Contains(t, envs, corev1.EnvVar{Name: reconcilermanager.SubstitutionValuesKey, Value: `{"replicas":"3","tier":"gold"}`})
+
+	opts.substitutionValues = nil
+	for _, env := range reconcilerEnvs(opts) {
+		assert.NotEqual(t, reconcilermanager.SubstitutionValuesKey, env.Name)
+	}
+}
+
+func TestReconcilerEnvsSubstitutionEnabled(t *testing.T) {
+	opts := reconcilerOptions{
+		sourceType:          configsync.GitSource,
+		gitConfig:           rootSyncWithGit(rootsyncName).Spec.Git,
+		substitutionEnabled: true,
+	}
+	envs := reconcilerEnvs(opts)
+	assert.Contains(t, envs, corev1.EnvVar{Name: reconcilermanager.SubstitutionEnabledKey, Value: "true"})
+
+	opts.substitutionEnabled = false
+	for _, env := range reconcilerEnvs(opts) {
+		assert.NotEqual(t, reconcilermanager.SubstitutionEnabledKey, env.Name)
+	}
+}
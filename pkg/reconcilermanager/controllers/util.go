@@ -15,6 +15,7 @@
 package controllers
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
@@ -24,12 +25,15 @@ import (
 	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
 	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync/v1beta1"
 	hubv1 "github.com/GoogleContainerTools/config-sync/pkg/api/hub/v1"
+	"github.com/GoogleContainerTools/config-sync/pkg/bucket"
 	"github.com/GoogleContainerTools/config-sync/pkg/declared"
 	"github.com/GoogleContainerTools/config-sync/pkg/importer/filesystem"
 	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
 	"github.com/GoogleContainerTools/config-sync/pkg/reconcilermanager"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
 )
 
 // updateHydrationControllerImage sets the image of hydration-controller based
@@ -60,22 +64,42 @@ type hydrationOptions struct {
 	sourceType     configsync.SourceType
 	gitConfig      *v1beta1.Git
 	ociConfig      *v1beta1.Oci
+	httpConfig     *v1beta1.HTTP
+	bucketConfig   *v1beta1.Bucket
+	helmConfig     *v1beta1.HelmBase
 	scope          declared.Scope
 	reconcilerName string
 	pollPeriod     string
+	// functionPipelineEnabled is whether the function pipeline of the Kptfile
+	// is run during rendering.
+	functionPipelineEnabled bool
 }
 
 // hydrationEnvs returns environment variables for the hydration controller.
 func hydrationEnvs(opts hydrationOptions) []corev1.EnvVar {
 	var result []corev1.EnvVar
 	var syncDir string
+	var auth configsync.AuthType
+	var secretRef *v1beta1.SecretReference
+	var repo string
 	switch opts.sourceType {
 	case configsync.OciSource:
 		syncDir = opts.ociConfig.Dir
+		auth, secretRef, repo = opts.ociConfig.Auth, opts.ociConfig.SecretRef, opts.ociConfig.Image
+	case configsync.HTTPSource:
+		syncDir = opts.httpConfig.Dir
+		auth, secretRef, repo = opts.httpConfig.Auth, opts.httpConfig.SecretRef, opts.httpConfig.URL
+	case configsync.BucketSource:
+		syncDir = opts.bucketConfig.Dir
+		auth, secretRef, repo = opts.bucketConfig.Auth, opts.bucketConfig.SecretRef, opts.bucketConfig.Endpoint
 	case configsync.GitSource:
 		syncDir = opts.gitConfig.Dir
+		auth, secretRef, repo = opts.gitConfig.Auth, opts.gitConfig.SecretRef, opts.gitConfig.Repo
 	case configsync.HelmSource:
 		syncDir = "."
+		if opts.helmConfig != nil {
+			auth, secretRef, repo = opts.helmConfig.Auth, opts.helmConfig.SecretRef, opts.helmConfig.Repo
+		}
 	}
 
 	result = append(result,
@@ -104,11 +128,37 @@ func hydrationEnvs(opts hydrationOptions) []corev1.EnvVar {
 			Name:  reconcilermanager.HydrationPollingPeriod,
 			Value: opts.pollPeriod,
 		})
+	if secretName := v1beta1.GetSecretName(secretRef); secretName != "" && !SkipForAuth(auth) {
+		if opts.scope != declared.RootScope {
+			// The Secret of a RepoSync is copied to the reconciler namespace.
+			secretName = ReconcilerResourceName(opts.reconcilerName, secretName)
+		}
+		result = append(result, hydrationHelmAuthEnv(secretName, repo)...)
+	}
+	if opts.functionPipelineEnabled {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.FunctionPipelineEnabled,
+			Value: strconv.FormatBool(opts.functionPipelineEnabled),
+		})
+	}
 	return result
 }
 
 type reconcilerOptions struct {
-	clusterName              string
+	clusterName         string
+	clusterLabels       map[string]string
+	substitutionEnabled bool
+	// functionPipelineEnabled is whether the reconciler requires rendering
+	// for a Kptfile declaring a function pipeline.
+	functionPipelineEnabled bool
+	substitutionValues      map[string]string
+	prunePolicy             *v1beta1.PrunePolicy
+	driftPolicy             metadata.DriftPolicy
+	driftAuditLog           bool
+	inventorySelfHeal       bool
+	remediatorPriorities    string
+	// forceConflictsDisabled disables force-conflicts, which is the default.
+	forceConflictsDisabled   bool
 	syncName                 string
 	syncGeneration           int64
 	reconcilerName           string
@@ -116,6 +166,8 @@ type reconcilerOptions struct {
 	sourceType               configsync.SourceType
 	gitConfig                *v1beta1.Git
 	ociConfig                *v1beta1.Oci
+	httpConfig               *v1beta1.HTTP
+	bucketConfig             *v1beta1.Bucket
 	helmConfig               *v1beta1.HelmBase
 	pollPeriod               string
 	statusMode               metadata.StatusMode
@@ -141,6 +193,12 @@ func reconcilerEnvs(opts reconcilerOptions) []corev1.EnvVar {
 	case configsync.OciSource:
 		syncRepo = opts.ociConfig.Image
 		syncDir = opts.ociConfig.Dir
+	case configsync.HTTPSource:
+		syncRepo = opts.httpConfig.URL
+		syncDir = opts.httpConfig.Dir
+	case configsync.BucketSource:
+		syncRepo = bucket.URL(opts.bucketConfig.Endpoint, opts.bucketConfig.Name, opts.bucketConfig.Prefix, opts.bucketConfig.Insecure)
+		syncDir = opts.bucketConfig.Dir
 	case configsync.HelmSource:
 		syncRepo = opts.helmConfig.Repo
 		syncDir = opts.helmConfig.Chart
@@ -224,6 +282,100 @@ func reconcilerEnvs(opts reconcilerOptions) []corev1.EnvVar {
 		},
 	)
 
+	if len(opts.clusterLabels) > 0 {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.ClusterLabelsKey,
+				Value: labels.Set(opts.clusterLabels).String(),
+			},
+		)
+	}
+
+	if opts.functionPipelineEnabled {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.FunctionPipelineEnabled,
+				Value: strconv.FormatBool(opts.functionPipelineEnabled),
+			},
+		)
+	}
+
+	if opts.substitutionEnabled {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.SubstitutionEnabledKey,
+				Value: strconv.FormatBool(opts.substitutionEnabled),
+			},
+		)
+	}
+
+	if len(opts.substitutionValues) > 0 {
+		// Marshaling a map of strings can't fail.
+		values, _ := json.Marshal(opts.substitutionValues)
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.SubstitutionValuesKey,
+				Value: string(values),
+			},
+		)
+	}
+
+	if opts.prunePolicy != nil && (len(opts.prunePolicy.Rules) > 0 || opts.prunePolicy.Safeguard != nil) {
+		// Marshaling the API type can't fail.
+		policy, _ := json.Marshal(opts.prunePolicy)
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.PrunePolicyKey,
+				Value: string(policy),
+			},
+		)
+	}
+
+	if opts.driftPolicy != "" {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.DriftPolicyKey,
+				Value: opts.driftPolicy.String(),
+			},
+		)
+	}
+
+	if opts.driftAuditLog {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.DriftAuditLogKey,
+				Value: strconv.FormatBool(opts.driftAuditLog),
+			},
+		)
+	}
+
+	if opts.inventorySelfHeal {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.InventorySelfHealKey,
+				Value: strconv.FormatBool(opts.inventorySelfHeal),
+			},
+		)
+	}
+
+	if opts.forceConflictsDisabled {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.ForceConflictsKey,
+				Value: strconv.FormatBool(false),
+			},
+		)
+	}
+
+	if opts.remediatorPriorities != "" {
+		result = append(result,
+			corev1.EnvVar{
+				Name:  reconcilermanager.RemediatorPrioritiesKey,
+				Value: opts.remediatorPriorities,
+			},
+		)
+	}
+
 	if opts.webhookEnabled {
 		result = append(result,
 			corev1.EnvVar{
@@ -287,6 +439,7 @@ type ociOptions struct {
 	auth            configsync.AuthType
 	period          float64
 	caCertSecretRef string
+	layerSelector   *v1beta1.OciLayerSelector
 }
 
 // ociSyncEnvs returns the environment variables for the oci-sync container.
@@ -302,6 +455,20 @@ func ociSyncEnvs(opts ociOptions) []corev1.EnvVar {
 		Name:  reconcilermanager.OciSyncWait,
 		Value: fmt.Sprintf("%f", opts.period),
 	})
+	if opts.layerSelector != nil {
+		if opts.layerSelector.MediaType != "" {
+			result = append(result, corev1.EnvVar{
+				Name:  reconcilermanager.OciSyncLayerMediaType,
+				Value: opts.layerSelector.MediaType,
+			})
+		}
+		if opts.layerSelector.Annotation != "" {
+			result = append(result, corev1.EnvVar{
+				Name:  reconcilermanager.OciSyncLayerAnnotation,
+				Value: opts.layerSelector.Annotation,
+			})
+		}
+	}
 	if useCACert(opts.caCertSecretRef) {
 		result = append(result, corev1.EnvVar{
 			Name:  reconcilermanager.OciCACert,
@@ -341,6 +508,166 @@ func ociSyncTokenAuthEnv(secretRef string) []corev1.EnvVar {
 	}
 }
 
+const (
+	// http-sync container specific environment variables.
+	httpSyncName     = "HTTP_SYNC_USERNAME"
+	httpSyncPassword = "HTTP_SYNC_PASSWORD"
+	httpSyncToken    = "HTTP_SYNC_TOKEN"
+)
+
+type httpOptions struct {
+	url             string
+	auth            configsync.AuthType
+	period          float64
+	checksum        string
+	caCertSecretRef string
+}
+
+// httpSyncEnvs returns the environment variables for the http-sync container.
+func httpSyncEnvs(opts httpOptions) []corev1.EnvVar {
+	var result []corev1.EnvVar
+	result = append(result, corev1.EnvVar{
+		Name:  reconcilermanager.HTTPSyncURL,
+		Value: opts.url,
+	}, corev1.EnvVar{
+		Name:  reconcilermanager.HTTPSyncAuth,
+		Value: string(opts.auth),
+	}, corev1.EnvVar{
+		Name:  reconcilermanager.HTTPSyncWait,
+		Value: fmt.Sprintf("%f", opts.period),
+	})
+	if opts.checksum != "" {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.HTTPSyncChecksum,
+			Value: opts.checksum,
+		})
+	}
+	if useCACert(opts.caCertSecretRef) {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.HTTPCACert,
+			Value: fmt.Sprintf("%s/%s", CACertPath, CACertSecretKey),
+		})
+	}
+	return result
+}
+
+// httpSyncTokenAuthEnv returns the environment variables for the http-sync
+// container to read the credentials from the secret. The secret stores either
+// a username and password, or a token, so every key is optional.
+func httpSyncTokenAuthEnv(secretRef string) []corev1.EnvVar {
+	optionalKey := func(key string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secretRef,
+				},
+				Key:      key,
+				Optional: ptr.To(true),
+			},
+		}
+	}
+	return []corev1.EnvVar{
+		{
+			Name:      httpSyncName,
+			ValueFrom: optionalKey("username"),
+		},
+		{
+			Name:      httpSyncPassword,
+			ValueFrom: optionalKey("password"),
+		},
+		{
+			Name:      httpSyncToken,
+			ValueFrom: optionalKey("token"),
+		},
+	}
+}
+
+const (
+	// bucket-sync container specific environment variables.
+	bucketSyncAccessKeyID     = "BUCKET_SYNC_ACCESS_KEY_ID"
+	bucketSyncSecretAccessKey = "BUCKET_SYNC_SECRET_ACCESS_KEY"
+)
+
+type bucketOptions struct {
+	endpoint        string
+	name            string
+	prefix          string
+	region          string
+	insecure        bool
+	auth            configsync.AuthType
+	period          float64
+	caCertSecretRef string
+}
+
+// bucketSyncEnvs returns the environment variables for the bucket-sync container.
+func bucketSyncEnvs(opts bucketOptions) []corev1.EnvVar {
+	var result []corev1.EnvVar
+	result = append(result, corev1.EnvVar{
+		Name:  reconcilermanager.BucketSyncEndpoint,
+		Value: opts.endpoint,
+	}, corev1.EnvVar{
+		Name:  reconcilermanager.BucketSyncName,
+		Value: opts.name,
+	}, corev1.EnvVar{
+		Name:  reconcilermanager.BucketSyncPrefix,
+		Value: opts.prefix,
+	})
+	if opts.region != "" {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.BucketSyncRegion,
+			Value: opts.region,
+		})
+	}
+	result = append(result, corev1.EnvVar{
+		Name:  reconcilermanager.BucketSyncInsecure,
+		Value: strconv.FormatBool(opts.insecure),
+	}, corev1.EnvVar{
+		Name:  reconcilermanager.BucketSyncAuth,
+		Value: string(opts.auth),
+	}, corev1.EnvVar{
+		Name:  reconcilermanager.BucketSyncWait,
+		Value: fmt.Sprintf("%f", opts.period),
+	})
+	if useCACert(opts.caCertSecretRef) {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.BucketCACert,
+			Value: fmt.Sprintf("%s/%s", CACertPath, CACertSecretKey),
+		})
+	}
+	return result
+}
+
+// bucketSyncTokenAuthEnv returns the environment variables for the bucket-sync
+// container to read the access key from the secret.
+func bucketSyncTokenAuthEnv(secretRef string) []corev1.EnvVar {
+	accessKeyID := &corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: secretRef,
+			},
+			Key: "accessKeyID",
+		},
+	}
+	secretAccessKey := &corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: secretRef,
+			},
+			Key: "secretAccessKey",
+		},
+	}
+	return []corev1.EnvVar{
+		{
+			Name:      bucketSyncAccessKeyID,
+			ValueFrom: accessKeyID,
+		},
+		{
+			Name:      bucketSyncSecretAccessKey,
+			ValueFrom: secretAccessKey,
+		},
+	}
+}
+
 const (
 	// helm-sync container specific environment variables.
 	helmSyncName     = "HELM_SYNC_USERNAME"
@@ -352,6 +679,8 @@ type helmOptions struct {
 	releaseNamespace string
 	deployNamespace  string
 	caCertSecretRef  string
+	// postRenderPatches are the post-render patches, formatted as a yaml list.
+	postRenderPatches string
 }
 
 // helmSyncEnvs returns the environment variables for the helm-sync container.
@@ -398,6 +727,24 @@ func helmSyncEnvs(opts helmOptions) []corev1.EnvVar {
 			Value: fmt.Sprintf("%s/%s", CACertPath, CACertSecretKey),
 		})
 	}
+	if len(opts.helmBase.APIVersions) > 0 {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.HelmAPIVersions,
+			Value: strings.Join(opts.helmBase.APIVersions, ","),
+		})
+	}
+	if opts.helmBase.KubeVersion != "" {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.HelmKubeVersion,
+			Value: opts.helmBase.KubeVersion,
+		})
+	}
+	if opts.postRenderPatches != "" {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.HelmPostRenderPatches,
+			Value: opts.postRenderPatches,
+		})
+	}
 	return result
 }
 
@@ -433,6 +780,55 @@ func helmSyncTokenAuthEnv(secretRef string) []corev1.EnvVar {
 	}
 }
 
+const (
+	// hydrationHelmUsernameKey and hydrationHelmPasswordKey are the optional
+	// keys of the source Secret with the credentials used by the
+	// hydration-controller to pull the Helm charts of kustomizations.
+	hydrationHelmUsernameKey = "helmUsername"
+	hydrationHelmPasswordKey = "helmPassword"
+	// hydrationHelmHostsKey is the optional key of the source Secret with the
+	// comma-separated hosts of the chart repositories the credentials are
+	// used for.
+	hydrationHelmHostsKey = "helmRepoHosts"
+)
+
+// hydrationHelmAuthEnv returns environment variables for the
+// hydration-controller container to pull the Helm charts of kustomizations
+// with the credentials of the source Secret, if it has them. The credentials
+// are only used for the charts on the hosts listed in the Secret, or on the
+// host of the source repository if the Secret lists none.
+func hydrationHelmAuthEnv(secretRef, repo string) []corev1.EnvVar {
+	envFromKey := func(key string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secretRef,
+				},
+				Key:      key,
+				Optional: ptr.To(true),
+			},
+		}
+	}
+	return []corev1.EnvVar{
+		{
+			Name:      reconcilermanager.HydrationHelmUsername,
+			ValueFrom: envFromKey(hydrationHelmUsernameKey),
+		},
+		{
+			Name:      reconcilermanager.HydrationHelmPassword,
+			ValueFrom: envFromKey(hydrationHelmPasswordKey),
+		},
+		{
+			Name:      reconcilermanager.HydrationHelmHosts,
+			ValueFrom: envFromKey(hydrationHelmHostsKey),
+		},
+		{
+			Name:  reconcilermanager.HydrationHelmRepo,
+			Value: repo,
+		},
+	}
+}
+
 // PollingPeriod parses the polling duration from the environment variable.
 // If the variable is not present, it returns the default value.
 func PollingPeriod(envName string, defaultValue time.Duration) time.Duration {
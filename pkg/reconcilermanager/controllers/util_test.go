@@ -15,16 +15,20 @@
 package controllers
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
 	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync/v1beta1"
+	"github.com/GoogleContainerTools/config-sync/pkg/declared"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
 	"github.com/GoogleContainerTools/config-sync/pkg/reconcilermanager"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func TestHelmSyncEnvs(t *testing.T) {
@@ -213,6 +217,24 @@ func TestOCISyncEnvs(t *testing.T) {
 				{Name: "OCI_SYNC_WAIT", Value: "30.000000"},
 			},
 		},
+		"oci-sync with layer selector": {
+			options: ociOptions{
+				image:  "registry/some/image:v1",
+				period: 30,
+				auth:   configsync.AuthNone,
+				layerSelector: &v1beta1.OciLayerSelector{
+					MediaType:  "application/vnd.configsync.content.v1.tar+gzip",
+					Annotation: "org.opencontainers.image.title=prod",
+				},
+			},
+			expectedEnvs: []corev1.EnvVar{
+				{Name: "OCI_SYNC_IMAGE", Value: "registry/some/image:v1"},
+				{Name: "OCI_SYNC_AUTH", Value: "none"},
+				{Name: "OCI_SYNC_WAIT", Value: "30.000000"},
+				{Name: "OCI_SYNC_LAYER_MEDIA_TYPE", Value: "application/vnd.configsync.content.v1.tar+gzip"},
+				{Name: "OCI_SYNC_LAYER_ANNOTATION", Value: "org.opencontainers.image.title=prod"},
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -222,6 +244,154 @@ func TestOCISyncEnvs(t *testing.T) {
 	}
 }
 
+func TestHTTPSyncEnvs(t *testing.T) {
+	testCases := map[string]struct {
+		options      httpOptions
+		expectedEnvs []corev1.EnvVar
+	}{
+		"http-sync with checksum and CA cert": {
+			options: httpOptions{
+				url:             "https://artifacts.example.com/configs.tar.gz",
+				period:          30,
+				auth:            configsync.AuthToken,
+				checksum:        "sha256:0123",
+				caCertSecretRef: "cert-ref",
+			},
+			expectedEnvs: []corev1.EnvVar{
+				{Name: "HTTP_SYNC_URL", Value: "https://artifacts.example.com/configs.tar.gz"},
+				{Name: "HTTP_SYNC_AUTH", Value: "token"},
+				{Name: "HTTP_SYNC_WAIT", Value: "30.000000"},
+				{Name: "HTTP_SYNC_CHECKSUM", Value: "sha256:0123"},
+				{Name: "SSL_CERT_FILE", Value: "/etc/ca-cert/cert"},
+			},
+		},
+		"http-sync without checksum": {
+			options: httpOptions{
+				url:    "https://artifacts.example.com/configs.tar.gz",
+				period: 30,
+				auth:   configsync.AuthNone,
+			},
+			expectedEnvs: []corev1.EnvVar{
+				{Name: "HTTP_SYNC_URL", Value: "https://artifacts.example.com/configs.tar.gz"},
+				{Name: "HTTP_SYNC_AUTH", Value: "none"},
+				{Name: "HTTP_SYNC_WAIT", Value: "30.000000"},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			envs := httpSyncEnvs(tc.options)
+			assert.Equal(t, tc.expectedEnvs, envs)
+		})
+	}
+}
+
+func TestBucketSyncEnvs(t *testing.T) {
+	testCases := map[string]struct {
+		options      bucketOptions
+		expectedEnvs []corev1.EnvVar
+	}{
+		"bucket-sync with region and CA cert": {
+			options: bucketOptions{
+				endpoint:        "minio.minio:9000",
+				name:            "configs",
+				prefix:          "prod/",
+				region:          "eu-west-1",
+				insecure:        true,
+				auth:            configsync.AuthToken,
+				period:          30,
+				caCertSecretRef: "cert-ref",
+			},
+			expectedEnvs: []corev1.EnvVar{
+				{Name: "BUCKET_SYNC_ENDPOINT", Value: "minio.minio:9000"},
+				{Name: "BUCKET_SYNC_NAME", Value: "configs"},
+				{Name: "BUCKET_SYNC_PREFIX", Value: "prod/"},
+				{Name: "BUCKET_SYNC_REGION", Value: "eu-west-1"},
+				{Name: "BUCKET_SYNC_INSECURE", Value: "true"},
+				{Name: "BUCKET_SYNC_AUTH", Value: "token"},
+				{Name: "BUCKET_SYNC_WAIT", Value: "30.000000"},
+				{Name: "SSL_CERT_FILE", Value: "/etc/ca-cert/cert"},
+			},
+		},
+		"bucket-sync without region": {
+			options: bucketOptions{
+				endpoint: "storage.googleapis.com",
+				name:     "configs",
+				auth:     configsync.AuthNone,
+				period:   30,
+			},
+			expectedEnvs: []corev1.EnvVar{
+				{Name: "BUCKET_SYNC_ENDPOINT", Value: "storage.googleapis.com"},
+				{Name: "BUCKET_SYNC_NAME", Value: "configs"},
+				{Name: "BUCKET_SYNC_PREFIX", Value: ""},
+				{Name: "BUCKET_SYNC_INSECURE", Value: "false"},
+				{Name: "BUCKET_SYNC_AUTH", Value: "none"},
+				{Name: "BUCKET_SYNC_WAIT", Value: "30.000000"},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			envs := bucketSyncEnvs(tc.options)
+			assert.Equal(t, tc.expectedEnvs, envs)
+		})
+	}
+}
+
+func TestHydrationEnvsHelmAuth(t *testing.T) {
+	helmAuthEnvs := func(secretName, repo string) []corev1.EnvVar {
+		return []corev1.EnvVar{
+			{Name: reconcilermanager.HydrationHelmUsername, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName}, Key: "helmUsername", Optional: ptr.To(true)}}},
+			{Name: reconcilermanager.HydrationHelmPassword, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName}, Key: "helmPassword", Optional: ptr.To(true)}}},
+			{Name: reconcilermanager.HydrationHelmHosts, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName}, Key: "helmRepoHosts", Optional: ptr.To(true)}}},
+			{Name: reconcilermanager.HydrationHelmRepo, Value: repo},
+		}
+	}
+	testCases := map[string]struct {
+		options      hydrationOptions
+		expectedEnvs []corev1.EnvVar
+	}{
+		"RootSync with token auth": {
+			options: hydrationOptions{
+				sourceType: configsync.GitSource,
+				gitConfig:  &v1beta1.Git{Repo: "https://github.com/org/repo", Auth: configsync.AuthToken, SecretRef: &v1beta1.SecretReference{Name: "git-creds"}},
+				scope:      declared.RootScope,
+			},
+			expectedEnvs: helmAuthEnvs("git-creds", "https://github.com/org/repo"),
+		},
+		"RepoSync with token auth": {
+			options: hydrationOptions{
+				sourceType:     configsync.OciSource,
+				ociConfig:      &v1beta1.Oci{Image: "us-docker.pkg.dev/project/repo/image", Auth: configsync.AuthToken, SecretRef: &v1beta1.SecretReference{Name: "oci-creds"}},
+				scope:          "bookstore",
+				reconcilerName: "ns-reconciler-bookstore",
+			},
+			expectedEnvs: helmAuthEnvs("ns-reconciler-bookstore-oci-creds", "us-docker.pkg.dev/project/repo/image"),
+		},
+		"auth without Secret": {
+			options: hydrationOptions{
+				sourceType: configsync.GitSource,
+				gitConfig:  &v1beta1.Git{Auth: configsync.AuthGCENode},
+				scope:      declared.RootScope,
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var envs []corev1.EnvVar
+			for _, env := range hydrationEnvs(tc.options) {
+				if strings.HasPrefix(env.Name, "HYDRATION_HELM_") {
+					envs = append(envs, env)
+				}
+			}
+			assert.Equal(t, tc.expectedEnvs, envs)
+		})
+	}
+}
+
 func TestIsMonitoringEnabled(t *testing.T) {
 	trueVal := true
 	falseVal := false
@@ -258,3 +428,94 @@ func TestIsMonitoringEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcilerEnvsDriftPolicy(t *testing.T) {
+	opts := reconcilerOptions{
+		sourceType:  configsync.GitSource,
+		gitConfig:   rootSyncWithGit(rootsyncName).Spec.Git,
+		driftPolicy: metadata.DriftPolicyReport,
+	}
+	envs := reconcilerEnvs(opts)
+	assert.Contains(t, envs, corev1.EnvVar{Name: reconcilermanager.DriftPolicyKey, Value: "report"})
+
+	opts.driftPolicy = ""
+	for _, env := range reconcilerEnvs(opts) {
+		assert.NotEqual(t, reconcilermanager.DriftPolicyKey, env.Name)
+	}
+}
+
+func TestReconcilerEnvsRemediatorPriorities(t *testing.T) {
+	opts := reconcilerOptions{
+		sourceType:           configsync.GitSource,
+		gitConfig:            rootSyncWithGit(rootsyncName).Spec.Git,
+		remediatorPriorities: "ClusterRole.rbac.authorization.k8s.io=10",
+	}
+	envs := reconcilerEnvs(opts)
+	assert.Contains(t, envs, corev1.EnvVar{Name: reconcilermanager.RemediatorPrioritiesKey, Value: "ClusterRole.rbac.authorization.k8s.io=10"})
+
+	opts.remediatorPriorities = ""
+	for _, env := range reconcilerEnvs(opts) {
+		assert.NotEqual(t, reconcilermanager.RemediatorPrioritiesKey, env.Name)
+	}
+}
+
+func TestReconcilerEnvsForceConflicts(t *testing.T) {
+	opts := reconcilerOptions{
+		sourceType:             configsync.GitSource,
+		gitConfig:              rootSyncWithGit(rootsyncName).Spec.Git,
+		forceConflictsDisabled: true,
+	}
+	envs := reconcilerEnvs(opts)
+	assert.Contains(t, envs, corev1.EnvVar{Name: reconcilermanager.ForceConflictsKey, Value: "false"})
+
+	opts.forceConflictsDisabled = false
+	for _, env := range reconcilerEnvs(opts) {
+		assert.NotEqual(t, reconcilermanager.ForceConflictsKey, env.Name)
+	}
+}
+
+func TestReconcilerEnvsInventorySelfHeal(t *testing.T) {
+	opts := reconcilerOptions{
+		sourceType:        configsync.GitSource,
+		gitConfig:         rootSyncWithGit(rootsyncName).Spec.Git,
+		inventorySelfHeal: true,
+	}
+	envs := reconcilerEnvs(opts)
+	assert.Contains(t, envs, corev1.EnvVar{Name: reconcilermanager.InventorySelfHealKey, Value: "true"})
+
+	opts.inventorySelfHeal = false
+	for _, env := range reconcilerEnvs(opts) {
+		assert.NotEqual(t, reconcilermanager.InventorySelfHealKey, env.Name)
+	}
+}
+
+func TestReconcilerEnvsPrunePolicy(t *testing.T) {
+	opts := reconcilerOptions{
+		sourceType: configsync.GitSource,
+		gitConfig:  rootSyncWithGit(rootsyncName).Spec.Git,
+		prunePolicy: &v1beta1.PrunePolicy{
+			Rules: []v1beta1.PruneRule{
+				{Kind: "Namespace", Action: configsync.PruneActionBlock},
+			},
+		},
+	}
+	envs := reconcilerEnvs(opts)
+	assert.Contains(t, envs, corev1.EnvVar{
+		Name:  reconcilermanager.PrunePolicyKey,
+		Value: `{"rules":[{"kind":"Namespace","action":"block"}]}`,
+	})
+
+	opts.prunePolicy = &v1beta1.PrunePolicy{
+		Safeguard: &v1beta1.PruneSafeguard{MaxDeletionPercent: ptr.To[int32](20)},
+	}
+	envs = reconcilerEnvs(opts)
+	assert.Contains(t, envs, corev1.EnvVar{
+		Name:  reconcilermanager.PrunePolicyKey,
+		Value: `{"safeguard":{"maxDeletionPercent":20}}`,
+	})
+
+	opts.prunePolicy = &v1beta1.PrunePolicy{}
+	for _, env := range reconcilerEnvs(opts) {
+		assert.NotEqual(t, reconcilermanager.PrunePolicyKey, env.Name)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/kinds"
+)
+
+const (
+	// OperationDelete is the operation of an audit record for an undeclared
+	// object with Config Sync metadata that was deleted.
+	OperationDelete = "delete"
+
+	// CorrectedEventReason is the reason of the Events emitted for corrected
+	// drift.
+	CorrectedEventReason = "DriftCorrected"
+
+	// redactedValue replaces the values of the fields of Secrets in audit
+	// records.
+	redactedValue = "REDACTED"
+
+	// maxEventMessageLength is the maximum length of the message of an Event.
+	maxEventMessageLength = 1024
+)
+
+// AuditRecord is a structured record of drift corrected by the remediator.
+type AuditRecord struct {
+	// Time is when the drift was corrected.
+	Time time.Time `json:"time"`
+	// Group of the corrected object.
+	Group string `json:"group,omitempty"`
+	// Version of the corrected object.
+	Version string `json:"version"`
+	// Kind of the corrected object.
+	Kind string `json:"kind"`
+	// Namespace of the corrected object.
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the corrected object.
+	Name string `json:"name"`
+	// Operation is how the drift was corrected: `create`, `update`, or
+	// `delete`.
+	Operation string `json:"operation"`
+	// Actor is the field manager that most likely caused the drift.
+	Actor string `json:"actor,omitempty"`
+	// Commit is the commit of the source of truth the object was restored to.
+	Commit string `json:"commit,omitempty"`
+	// Changes are the drifted fields, for updates.
+	Changes []FieldChange `json:"changes,omitempty"`
+}
+
+// FieldChange is a drifted field, with its value before and after the
+// correction.
+type FieldChange struct {
+	// Field is the path of the field, like `spec.replicas`.
+	Field string `json:"field"`
+	// Old is the drifted value, or nil if the field was removed.
+	Old interface{} `json:"old,omitempty"`
+	// New is the declared value the field was restored to.
+	New interface{} `json:"new,omitempty"`
+}
+
+// redact replaces the values of the changes with a placeholder if the object
+// is a Secret, so that they are never written to Events or logs.
+func (r *AuditRecord) redact() {
+	if r.Group != kinds.Secret().Group || r.Kind != kinds.Secret().Kind {
+		return
+	}
+	for i := range r.Changes {
+		if r.Changes[i].Old != nil {
+			r.Changes[i].Old = redactedValue
+		}
+		if r.Changes[i].New != nil {
+			r.Changes[i].New = redactedValue
+		}
+	}
+}
+
+// message returns the message of the Event for the audit record.
+func (r *AuditRecord) message() string {
+	var sb strings.Builder
+	switch r.Operation {
+	case OperationCreate:
+		sb.WriteString("Recreated deleted managed object")
+	case OperationDelete:
+		sb.WriteString("Deleted undeclared object with Config Sync metadata")
+	default:
+		sb.WriteString("Reverted drift of managed object")
+	}
+	if r.Actor != "" {
+		sb.WriteString(" (by " + r.Actor + ")")
+	}
+	if r.Commit != "" {
+		sb.WriteString(" to commit " + r.Commit)
+	}
+	for i, change := range r.Changes {
+		if i == 0 {
+			sb.WriteString(": ")
+		} else {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "%s: %s -> %s", change.Field, formatValue(change.Old), formatValue(change.New))
+	}
+	message := sb.String()
+	if len(message) > maxEventMessageLength {
+		message = message[:maxEventMessageLength-3] + "..."
+	}
+	return message
+}
+
+// formatValue formats a field value for an Event message.
+func formatValue(value interface{}) string {
+	if value == nil {
+		return "<none>"
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(b)
+}
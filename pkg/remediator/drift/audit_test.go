@@ -0,0 +1,118 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestAuditCorrection(t *testing.T) {
+	correctedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	testCases := []struct {
+		name      string
+		record    AuditRecord
+		wantEvent string
+		wantLog   string
+	}{
+		{
+			name: "update",
+			record: AuditRecord{
+				Time:      correctedAt,
+				Group:     "apps",
+				Version:   "v1",
+				Kind:      "Deployment",
+				Namespace: "bookstore",
+				Name:      "web",
+				Operation: OperationUpdate,
+				Actor:     "kubectl-edit",
+				Commit:    "abc123",
+				Changes: []FieldChange{
+					{Field: "spec.replicas", Old: int64(5), New: int64(3)},
+					{Field: "metadata.labels.team", New: "books"},
+				},
+			},
+			wantEvent: "Normal DriftCorrected Reverted drift of managed object (by kubectl-edit) to commit abc123: " +
+				"spec.replicas: 5 -> 3, metadata.labels.team: <none> -> books",
+			wantLog: `{"time":"2026-01-02T03:04:05Z","group":"apps","version":"v1","kind":"Deployment","namespace":"bookstore","name":"web",` +
+				`"operation":"update","actor":"kubectl-edit","commit":"abc123",` +
+				`"changes":[{"field":"spec.replicas","old":5,"new":3},{"field":"metadata.labels.team","new":"books"}]}`,
+		},
+		{
+			name: "Secret values are redacted",
+			record: AuditRecord{
+				Time:      correctedAt,
+				Version:   "v1",
+				Kind:      "Secret",
+				Namespace: "bookstore",
+				Name:      "db",
+				Operation: OperationUpdate,
+				Changes: []FieldChange{
+					{Field: "data.password", Old: "c2VjcmV0", New: "aHVudGVyMg=="},
+				},
+			},
+			wantEvent: "Normal DriftCorrected Reverted drift of managed object: data.password: REDACTED -> REDACTED",
+			wantLog: `{"time":"2026-01-02T03:04:05Z","version":"v1","kind":"Secret","namespace":"bookstore","name":"db",` +
+				`"operation":"update","changes":[{"field":"data.password","old":"REDACTED","new":"REDACTED"}]}`,
+		},
+		{
+			name: "create",
+			record: AuditRecord{
+				Time:      correctedAt,
+				Version:   "v1",
+				Kind:      "ConfigMap",
+				Namespace: "bookstore",
+				Name:      "settings",
+				Operation: OperationCreate,
+				Commit:    "abc123",
+			},
+			wantEvent: "Normal DriftCorrected Recreated deleted managed object to commit abc123",
+			wantLog: `{"time":"2026-01-02T03:04:05Z","version":"v1","kind":"ConfigMap","namespace":"bookstore","name":"settings",` +
+				`"operation":"create","commit":"abc123"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(1)
+			var auditLog bytes.Buffer
+			h := NewHandler("", recorder, &auditLog)
+
+			h.AuditCorrection(k8sobjects.ConfigMapObject(core.Namespace("bookstore")), tc.record)
+
+			assert.Equal(t, tc.wantEvent, <-recorder.Events)
+			assert.Equal(t, tc.wantLog+"\n", auditLog.String())
+		})
+	}
+}
+
+func TestAuditRecordMessageTruncated(t *testing.T) {
+	rec := AuditRecord{
+		Operation: OperationUpdate,
+		Changes: []FieldChange{
+			{Field: "data.large", Old: strings.Repeat("a", maxEventMessageLength), New: "b"},
+		},
+	}
+	message := rec.message()
+	assert.Len(t, message, maxEventMessageLength)
+	assert.True(t, strings.HasSuffix(message, "..."))
+}
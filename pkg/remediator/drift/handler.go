@@ -0,0 +1,204 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/elliotchance/orderedmap/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// OperationCreate is the operation of a record for a declared object that
+	// was deleted from the cluster.
+	OperationCreate = "create"
+	// OperationUpdate is the operation of a record for a declared object that
+	// was modified in the cluster.
+	OperationUpdate = "update"
+
+	// EventReason is the reason of the Events emitted for detected drift.
+	EventReason = "DriftDetected"
+)
+
+// Record is the drift of a managed object from its declared state that was
+// reported instead of corrected.
+type Record struct {
+	// ID of the drifted object.
+	ID core.ID
+	// Version of the drifted object, as declared.
+	Version string
+	// SourcePath is the repo-relative slash path to where the object is declared.
+	SourcePath string
+	// Operation that would correct the drift.
+	Operation string
+	// Fields are the paths of the drifted fields, for updates.
+	Fields []string
+	// Actor is the field manager that most likely caused the drift.
+	Actor string
+	// DetectedAt is when the drift was first detected.
+	DetectedAt time.Time
+}
+
+// Handler is the generic interface of the drift handler.
+type Handler interface {
+	// Policy returns the default drift policy of the reconciler, used for
+	// objects without the drift-policy annotation.
+	Policy() metadata.DriftPolicy
+
+	AddDrift(client.Object, Record)
+	RemoveDrift(core.ID)
+
+	// DriftRecords returns the drift the remediator reported instead of
+	// correcting.
+	DriftRecords() []Record
+
+	// AuditCorrection records drift the remediator corrected.
+	AuditCorrection(client.Object, AuditRecord)
+}
+
+// handler implements Handler.
+type handler struct {
+	policy   metadata.DriftPolicy
+	recorder record.EventRecorder
+
+	// auditMux guards the auditLog
+	auditMux sync.Mutex
+	// auditLog is where the audit records are written as JSON lines, if set.
+	auditLog io.Writer
+
+	// mux guards the records
+	mux sync.Mutex
+	// records tracks the drift the remediator reported, and reports it to the
+	// RootSync|RepoSync status.
+	records *orderedmap.OrderedMap[core.ID, Record]
+}
+
+var _ Handler = &handler{}
+
+// NewHandler instantiates a drift handler with the default drift policy of
+// the reconciler. Events are emitted with the recorder, unless it is nil.
+// Audit records of corrected drift are also written to the auditLog as JSON
+// lines, unless it is nil.
+func NewHandler(policy metadata.DriftPolicy, recorder record.EventRecorder, auditLog io.Writer) Handler {
+	if policy == "" {
+		policy = metadata.DriftPolicyCorrect
+	}
+	return &handler{
+		policy:   policy,
+		recorder: recorder,
+		auditLog: auditLog,
+		records:  orderedmap.NewOrderedMap[core.ID, Record](),
+	}
+}
+
+func (h *handler) Policy() metadata.DriftPolicy {
+	return h.policy
+}
+
+func (h *handler) AddDrift(obj client.Object, rec Record) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	old, found := h.records.Get(rec.ID)
+	if found && old.Operation == rec.Operation && slices.Equal(old.Fields, rec.Fields) {
+		// Keep the original detection time and don't repeat the Event.
+		rec.DetectedAt = old.DetectedAt
+		if rec.Actor == "" {
+			rec.Actor = old.Actor
+		}
+		h.records.Set(rec.ID, rec)
+		return
+	}
+	if found {
+		rec.DetectedAt = old.DetectedAt
+	}
+	h.records.Set(rec.ID, rec)
+
+	klog.Warningf("Remediator detected drift of %s (operation: %s, fields: %v, actor: %q)",
+		rec.ID, rec.Operation, rec.Fields, rec.Actor)
+	if h.recorder != nil && obj != nil {
+		h.recorder.Eventf(obj, corev1.EventTypeWarning, EventReason, "%s", message(rec))
+	}
+}
+
+func (h *handler) RemoveDrift(id core.ID) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	if h.records.Delete(id) {
+		klog.Infof("Drift resolved for %s", id)
+	}
+}
+
+func (h *handler) DriftRecords() []Record {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	// Return a copy
+	var records []Record
+	for e := h.records.Front(); e != nil; e = e.Next() {
+		records = append(records, e.Value)
+	}
+	return records
+}
+
+func (h *handler) AuditCorrection(obj client.Object, rec AuditRecord) {
+	rec.redact()
+	klog.Infof("Remediator corrected drift of %s/%s %s/%s (operation: %s, actor: %q, commit: %s)",
+		rec.Group, rec.Kind, rec.Namespace, rec.Name, rec.Operation, rec.Actor, rec.Commit)
+	if h.recorder != nil && obj != nil {
+		h.recorder.Eventf(obj, corev1.EventTypeNormal, CorrectedEventReason, "%s", rec.message())
+	}
+	if h.auditLog == nil {
+		return
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		klog.Warningf("Failed to encode drift audit record: %v", err)
+		return
+	}
+	h.auditMux.Lock()
+	defer h.auditMux.Unlock()
+	if _, err := h.auditLog.Write(append(line, '\n')); err != nil {
+		klog.Warningf("Failed to write drift audit record: %v", err)
+	}
+}
+
+// message returns the message of the Event for the record.
+func message(rec Record) string {
+	var sb strings.Builder
+	if rec.Operation == OperationCreate {
+		sb.WriteString("Managed object was deleted from the cluster")
+	} else {
+		sb.WriteString("Managed object drifted from the source of truth: ")
+		sb.WriteString(strings.Join(rec.Fields, ", "))
+	}
+	if rec.Actor != "" {
+		sb.WriteString(" (by " + rec.Actor + ")")
+	}
+	sb.WriteString(". The drift was not corrected because the drift policy is " + metadata.DriftPolicyReport.String() + ".")
+	return sb.String()
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestHandler(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	h := NewHandler("", recorder, nil)
+	assert.Equal(t, metadata.DriftPolicyCorrect, h.Policy())
+
+	obj := k8sobjects.ConfigMapObject(core.Name("settings"), core.Namespace("bookstore"))
+	id := core.IDOf(obj)
+	firstDetected := time.Now().Add(-time.Minute)
+
+	h.AddDrift(obj, Record{
+		ID:         id,
+		Operation:  OperationUpdate,
+		Fields:     []string{"data.endpoint"},
+		Actor:      "kubectl-edit",
+		DetectedAt: firstDetected,
+	})
+	assert.Equal(t, "Warning DriftDetected Managed object drifted from the source of truth: data.endpoint (by kubectl-edit). "+
+		"The drift was not corrected because the drift policy is report.", <-recorder.Events)
+
+	// The same drift is not reported again, and keeps its detection time.
+	h.AddDrift(obj, Record{
+		ID:         id,
+		Operation:  OperationUpdate,
+		Fields:     []string{"data.endpoint"},
+		DetectedAt: time.Now(),
+	})
+	assert.Empty(t, recorder.Events)
+	assert.Equal(t, []Record{{
+		ID:         id,
+		Operation:  OperationUpdate,
+		Fields:     []string{"data.endpoint"},
+		Actor:      "kubectl-edit",
+		DetectedAt: firstDetected,
+	}}, h.DriftRecords())
+
+	// New drift of the same object is reported again.
+	h.AddDrift(obj, Record{
+		ID:         id,
+		Operation:  OperationCreate,
+		DetectedAt: time.Now(),
+	})
+	assert.Equal(t, "Warning DriftDetected Managed object was deleted from the cluster. "+
+		"The drift was not corrected because the drift policy is report.", <-recorder.Events)
+	assert.Equal(t, firstDetected, h.DriftRecords()[0].DetectedAt)
+
+	h.RemoveDrift(id)
+	assert.Empty(t, h.DriftRecords())
+}
@@ -0,0 +1,261 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/metrics"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Priorities are the priorities of the GroupKinds in the queue. Objects of
+// GroupKinds with a higher priority are processed first. GroupKinds that are
+// not listed have priority 0.
+type Priorities map[schema.GroupKind]int
+
+// ParsePriorities parses a comma-separated list of `Kind.group=priority`
+// pairs, like `ClusterRole.rbac.authorization.k8s.io=10,Namespace=20`.
+func ParsePriorities(s string) (Priorities, error) {
+	priorities := Priorities{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		kind, value, found := strings.Cut(pair, "=")
+		if !found || kind == "" {
+			return nil, fmt.Errorf("invalid priority %q: must be Kind.group=priority", pair)
+		}
+		priority, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid priority %q: %w", pair, err)
+		}
+		priorities[schema.ParseGroupKind(kind)] = priority
+	}
+	return priorities, nil
+}
+
+// fairQueue is a work queue of GVKNNs with the same deduplication semantics
+// as a workqueue.Interface: an item is queued at most once, and an item added
+// while it is being processed is only queued again once it is Done.
+//
+// Instead of FIFO, items are handed out by priority and fairly, so that a
+// storm of updates to one kind or namespace doesn't starve the others:
+//  1. Items of the GroupKinds with the highest priority first.
+//  2. Round-robin across the GroupKinds with the same priority.
+//  3. Round-robin across the namespaces of each GroupKind.
+//  4. FIFO within a namespace.
+type fairQueue struct {
+	// mux guards all the fields below.
+	mux sync.Mutex
+
+	priorities Priorities
+	// levels are the queued items, by priority.
+	levels map[int]*kindShards
+	// length is the number of queued items.
+	length int
+	// depths are the number of queued items, by GroupKind.
+	depths map[schema.GroupKind]int64
+
+	// dirty are the items that need processing, with when they were added.
+	dirty map[GVKNN]time.Time
+	// processing are the items that are being processed.
+	processing map[GVKNN]bool
+
+	shuttingDown bool
+}
+
+func newFairQueue(priorities Priorities) *fairQueue {
+	return &fairQueue{
+		priorities: priorities,
+		levels:     map[int]*kindShards{},
+		depths:     map[schema.GroupKind]int64{},
+		dirty:      map[GVKNN]time.Time{},
+		processing: map[GVKNN]bool{},
+	}
+}
+
+// Add marks the item as needing processing.
+func (q *fairQueue) Add(item GVKNN) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	if q.shuttingDown {
+		return
+	}
+	if _, found := q.dirty[item]; found {
+		return
+	}
+	q.dirty[item] = time.Now()
+	if q.processing[item] {
+		return
+	}
+	q.push(item)
+}
+
+// Get returns the next item to process. It doesn't block: shutdown is true if
+// the queue is empty.
+func (q *fairQueue) Get() (item GVKNN, shutdown bool) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	if q.length == 0 {
+		return GVKNN{}, true
+	}
+	item = q.pop()
+	metrics.RecordRemediatorQueueLatency(context.Background(), item.GroupKind.String(), q.dirty[item])
+	q.processing[item] = true
+	delete(q.dirty, item)
+	return item, false
+}
+
+// Done marks the item as done processing, and queues it again if it was added
+// while it was being processed.
+func (q *fairQueue) Done(item GVKNN) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	delete(q.processing, item)
+	if _, found := q.dirty[item]; found {
+		q.push(item)
+	}
+}
+
+// Len returns the number of queued items.
+func (q *fairQueue) Len() int {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	return q.length
+}
+
+// ShutDown stops the queue from accepting new items.
+func (q *fairQueue) ShutDown() {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	q.shuttingDown = true
+}
+
+// ShuttingDown returns true if the queue is shutting down.
+func (q *fairQueue) ShuttingDown() bool {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	return q.shuttingDown
+}
+
+func (q *fairQueue) push(item GVKNN) {
+	priority := q.priorities[item.GroupKind]
+	level, found := q.levels[priority]
+	if !found {
+		level = &kindShards{byKind: map[schema.GroupKind]*namespaceShards{}}
+		q.levels[priority] = level
+	}
+	level.push(item)
+	q.length++
+	q.recordDepth(item.GroupKind, 1)
+}
+
+func (q *fairQueue) pop() GVKNN {
+	var level *kindShards
+	priority := 0
+	for p, l := range q.levels {
+		if level == nil || p > priority {
+			level, priority = l, p
+		}
+	}
+	item := level.pop()
+	if level.len() == 0 {
+		delete(q.levels, priority)
+	}
+	q.length--
+	q.recordDepth(item.GroupKind, -1)
+	return item
+}
+
+func (q *fairQueue) recordDepth(gk schema.GroupKind, delta int64) {
+	q.depths[gk] += delta
+	metrics.RecordRemediatorQueueDepth(context.Background(), gk.String(), q.depths[gk])
+	if q.depths[gk] == 0 {
+		delete(q.depths, gk)
+	}
+}
+
+// kindShards are the queued items of the same priority, by GroupKind.
+type kindShards struct {
+	// order is the round-robin order of the GroupKinds with queued items.
+	order  []schema.GroupKind
+	byKind map[schema.GroupKind]*namespaceShards
+}
+
+func (s *kindShards) push(item GVKNN) {
+	shard, found := s.byKind[item.GroupKind]
+	if !found {
+		shard = &namespaceShards{byNamespace: map[string][]GVKNN{}}
+		s.byKind[item.GroupKind] = shard
+		s.order = append(s.order, item.GroupKind)
+	}
+	shard.push(item)
+}
+
+func (s *kindShards) pop() GVKNN {
+	gk := s.order[0]
+	s.order = s.order[1:]
+	shard := s.byKind[gk]
+	item := shard.pop()
+	if len(shard.order) == 0 {
+		delete(s.byKind, gk)
+	} else {
+		s.order = append(s.order, gk)
+	}
+	return item
+}
+
+func (s *kindShards) len() int {
+	return len(s.order)
+}
+
+// namespaceShards are the queued items of the same GroupKind, by namespace.
+type namespaceShards struct {
+	// order is the round-robin order of the namespaces with queued items.
+	order       []string
+	byNamespace map[string][]GVKNN
+}
+
+func (s *namespaceShards) push(item GVKNN) {
+	items, found := s.byNamespace[item.Namespace]
+	if !found {
+		s.order = append(s.order, item.Namespace)
+	}
+	s.byNamespace[item.Namespace] = append(items, item)
+}
+
+func (s *namespaceShards) pop() GVKNN {
+	namespace := s.order[0]
+	s.order = s.order[1:]
+	items := s.byNamespace[namespace]
+	item := items[0]
+	if len(items) == 1 {
+		delete(s.byNamespace, namespace)
+	} else {
+		s.byNamespace[namespace] = items[1:]
+		s.order = append(s.order, namespace)
+	}
+	return item
+}
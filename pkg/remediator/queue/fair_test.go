@@ -0,0 +1,193 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
+	"github.com/GoogleContainerTools/config-sync/pkg/kinds"
+	"github.com/GoogleContainerTools/config-sync/pkg/metrics"
+	"github.com/GoogleContainerTools/config-sync/pkg/testing/testmetrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestParsePriorities(t *testing.T) {
+	testCases := []struct {
+		name    string
+		value   string
+		want    Priorities
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			value: "",
+			want:  Priorities{},
+		},
+		{
+			name:  "kinds with and without group",
+			value: "ClusterRole.rbac.authorization.k8s.io=10, Namespace=20,Deployment.apps=-1",
+			want: Priorities{
+				kinds.ClusterRole().GroupKind(): 10,
+				kinds.Namespace().GroupKind():   20,
+				kinds.Deployment().GroupKind():  -1,
+			},
+		},
+		{
+			name:    "missing priority",
+			value:   "Namespace",
+			wantErr: true,
+		},
+		{
+			name:    "invalid priority",
+			value:   "Namespace=high",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParsePriorities(tc.value)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestObjectQueue_Fairness(t *testing.T) {
+	deployment := func(ns, name string) client.Object {
+		return k8sobjects.DeploymentObject(core.Namespace(ns), core.Name(name))
+	}
+	roleBinding := func(ns, name string) client.Object {
+		return k8sobjects.RoleBindingObject(core.Namespace(ns), core.Name(name))
+	}
+	clusterRole := func(name string) client.Object {
+		return k8sobjects.ClusterRoleObject(core.Name(name))
+	}
+
+	testCases := []struct {
+		name       string
+		priorities Priorities
+		added      []client.Object
+		want       []client.Object
+	}{
+		{
+			name: "round-robin across kinds",
+			added: []client.Object{
+				deployment("shop", "web-1"),
+				deployment("shop", "web-2"),
+				deployment("shop", "web-3"),
+				roleBinding("shop", "admin"),
+				clusterRole("viewer"),
+			},
+			want: []client.Object{
+				deployment("shop", "web-1"),
+				roleBinding("shop", "admin"),
+				clusterRole("viewer"),
+				deployment("shop", "web-2"),
+				deployment("shop", "web-3"),
+			},
+		},
+		{
+			name: "round-robin across namespaces",
+			added: []client.Object{
+				deployment("shop", "web-1"),
+				deployment("shop", "web-2"),
+				deployment("shop", "web-3"),
+				deployment("books", "web-1"),
+				deployment("music", "web-1"),
+			},
+			want: []client.Object{
+				deployment("shop", "web-1"),
+				deployment("books", "web-1"),
+				deployment("music", "web-1"),
+				deployment("shop", "web-2"),
+				deployment("shop", "web-3"),
+			},
+		},
+		{
+			name: "higher priority first",
+			priorities: Priorities{
+				kinds.ClusterRole().GroupKind(): 10,
+				kinds.RoleBinding().GroupKind(): 10,
+				kinds.Deployment().GroupKind():  -1,
+			},
+			added: []client.Object{
+				deployment("shop", "web-1"),
+				k8sobjects.ConfigMapObject(core.Namespace("shop"), core.Name("settings")),
+				roleBinding("shop", "admin"),
+				roleBinding("shop", "viewer"),
+				clusterRole("viewer"),
+			},
+			want: []client.Object{
+				roleBinding("shop", "admin"),
+				clusterRole("viewer"),
+				roleBinding("shop", "viewer"),
+				k8sobjects.ConfigMapObject(core.Namespace("shop"), core.Name("settings")),
+				deployment("shop", "web-1"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := NewWithPriorities("test", tc.priorities)
+			defer q.ShutDown()
+			for _, obj := range tc.added {
+				q.Add(obj)
+			}
+			var got []client.Object
+			for q.Len() > 0 {
+				obj, err := q.Get(context.Background())
+				require.NoError(t, err)
+				q.Done(obj)
+				got = append(got, obj)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestObjectQueue_DepthMetrics(t *testing.T) {
+	exporter, err := testmetrics.NewTestExporter()
+	require.NoError(t, err)
+	defer exporter.ClearMetrics()
+
+	q := New("test")
+	defer q.ShutDown()
+	q.Add(k8sobjects.DeploymentObject(core.Namespace("shop"), core.Name("web-1")))
+	q.Add(k8sobjects.DeploymentObject(core.Namespace("shop"), core.Name("web-2")))
+	q.Add(k8sobjects.ClusterRoleObject(core.Name("viewer")))
+	obj, err := q.Get(context.Background())
+	require.NoError(t, err)
+	q.Done(obj)
+
+	wantMetrics := []testmetrics.MetricData{
+		{Name: metrics.RemediatorQueueDepthName, Value: 1, Labels: map[string]string{"kind": schema.GroupKind{Group: "apps", Kind: "Deployment"}.String()}},
+		{Name: metrics.RemediatorQueueDepthName, Value: 1, Labels: map[string]string{"kind": kinds.ClusterRole().GroupKind().String()}},
+	}
+	if diff := exporter.ValidateMetrics(wantMetrics); diff != "" {
+		t.Error(diff)
+	}
+}
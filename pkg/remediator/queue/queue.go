@@ -62,12 +62,12 @@ type Interface interface {
 	ShutDown()
 }
 
-// ObjectQueue is a wrapper around a workqueue.Interface for use with declared
-// resources. It deduplicates work items by their GVKNN.
+// ObjectQueue is a work queue for use with declared resources. It deduplicates
+// work items by their GVKNN, and hands them out by priority and fairly across
+// GroupKinds and namespaces.
 // NOTE: This was originally designed to wrap a DelayingInterface, but we have
 // had to copy a lot of that logic here. At some point it may make sense to
-// remove the underlying workqueue.Interface and just consolidate copied logic
-// here.
+// consolidate the fairQueue and the copied logic here.
 type ObjectQueue struct {
 	// cond is a locking condition which allows us to lock all mutating calls but
 	// also allow any call to yield the lock safely (specifically for Get).
@@ -76,9 +76,9 @@ type ObjectQueue struct {
 	rateLimiter workqueue.TypedRateLimiter[GVKNN]
 	// delayer is a wrapper around the ObjectQueue which supports delayed Adds.
 	delayer workqueue.TypedDelayingInterface[client.Object]
-	// underlying is the workqueue that contains work item keys so that it can
+	// underlying is the work queue that contains work item keys so that it can
 	// maintain the order in which those items should be worked on.
-	underlying workqueue.TypedInterface[GVKNN]
+	underlying *fairQueue
 	// objects is a map of actual work items which need to be processed.
 	objects map[GVKNN]client.Object
 	// dirty is a map of object keys which will need to be reprocessed even if
@@ -89,14 +89,20 @@ type ObjectQueue struct {
 // New creates a new work queue for use in signalling objects that may need
 // remediation.
 func New(name string) *ObjectQueue {
+	return NewWithPriorities(name, nil)
+}
+
+// NewWithPriorities creates a new work queue for use in signalling objects
+// that may need remediation, which hands out the objects of the GroupKinds
+// with a higher priority first, and the objects of the same priority fairly
+// across GroupKinds and namespaces.
+func NewWithPriorities(name string, priorities Priorities) *ObjectQueue {
 	oq := &ObjectQueue{
 		cond:        sync.NewCond(&sync.Mutex{}),
 		rateLimiter: workqueue.DefaultTypedControllerRateLimiter[GVKNN](),
-		underlying: workqueue.NewTypedWithConfig[GVKNN](workqueue.TypedQueueConfig[GVKNN]{
-			Name: name,
-		}),
-		objects: map[GVKNN]client.Object{},
-		dirty:   map[GVKNN]bool{},
+		underlying:  newFairQueue(priorities),
+		objects:     map[GVKNN]client.Object{},
+		dirty:       map[GVKNN]bool{},
 	}
 	oq.delayer = delayingWrap(oq, name)
 	return oq
@@ -169,7 +175,7 @@ func (q *ObjectQueue) Get(ctx context.Context) (client.Object, error) {
 	defer q.cond.L.Unlock()
 
 	// This background thread converts a done channel into a condition signal.
-	// This is required because the underlying workqueue.Interface doesn't use
+	// This is required because the underlying fairQueue doesn't use
 	// channels to communicate.
 	innerCtx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -25,6 +25,7 @@ import (
 	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
 	"github.com/GoogleContainerTools/config-sync/pkg/metrics"
 	"github.com/GoogleContainerTools/config-sync/pkg/remediator/conflict"
+	"github.com/GoogleContainerTools/config-sync/pkg/remediator/drift"
 	"github.com/GoogleContainerTools/config-sync/pkg/status"
 	syncerclient "github.com/GoogleContainerTools/config-sync/pkg/syncer/client"
 	"github.com/GoogleContainerTools/config-sync/pkg/syncer/reconcile"
@@ -51,6 +52,7 @@ type reconciler struct {
 
 	conflictHandler conflict.Handler
 	fightHandler    fight.Handler
+	driftHandler    drift.Handler
 }
 
 // newReconciler instantiates a new reconciler.
@@ -61,6 +63,7 @@ func newReconciler(
 	declared *declared.Resources,
 	conflictHandler conflict.Handler,
 	fightHandler fight.Handler,
+	driftHandler drift.Handler,
 ) *reconciler {
 	return &reconciler{
 		scope:           scope,
@@ -69,6 +72,7 @@ func newReconciler(
 		declared:        declared,
 		conflictHandler: conflictHandler,
 		fightHandler:    fightHandler,
+		driftHandler:    driftHandler,
 	}
 }
 
@@ -90,7 +94,17 @@ func (r *reconciler) Remediate(ctx context.Context, id core.ID, obj client.Objec
 		Actual:   obj,
 	}
 
-	err := r.remediate(ctx, id, objDiff)
+	reported, err := r.reportDrift(id, objDiff)
+	if reported {
+		metrics.RecordRemediateDuration(ctx, metrics.StatusTagKey(err), start)
+		return err
+	}
+	r.driftHandler.RemoveDrift(id)
+
+	// Compute the audit record before the actual object is corrected.
+	auditObj, audit := r.auditRecord(id, objDiff, commit)
+
+	err = r.remediate(ctx, id, objDiff)
 
 	// Record duration, even if there's an error
 	metrics.RecordRemediateDuration(ctx, metrics.StatusTagKey(err), start)
@@ -117,9 +131,135 @@ func (r *reconciler) Remediate(ctx context.Context, id core.ID, obj client.Objec
 
 	r.conflictHandler.RemoveConflictError(id)
 	r.fightHandler.RemoveFightError(id)
+	if audit != nil {
+		audit.Time = time.Now()
+		r.driftHandler.AuditCorrection(auditObj, *audit)
+	}
 	return nil
 }
 
+// auditRecord returns the audit record of the drift the remediator is about to
+// correct, and the object to emit the Event on. It returns a nil record if
+// the remediation doesn't correct drift, like metadata updates or updates
+// that don't change any declared field.
+func (r *reconciler) auditRecord(id core.ID, objDiff diff.Diff, commit string) (client.Object, *drift.AuditRecord) {
+	var obj client.Object
+	rec := &drift.AuditRecord{
+		Group:     id.Group,
+		Kind:      id.Kind,
+		Namespace: id.Namespace,
+		Name:      id.Name,
+		Commit:    commit,
+	}
+	switch objDiff.Operation(r.scope, r.syncName) {
+	case diff.Create:
+		obj = objDiff.Declared
+		rec.Operation = drift.OperationCreate
+	case diff.Update:
+		declared, err := objDiff.UnstructuredDeclared()
+		if err != nil {
+			return nil, nil
+		}
+		actual, err := objDiff.UnstructuredActual()
+		if err != nil {
+			return nil, nil
+		}
+		paths := diff.DriftedFields(declared, actual)
+		if len(paths) == 0 {
+			return nil, nil
+		}
+		obj = objDiff.Actual
+		rec.Operation = drift.OperationUpdate
+		rec.Actor = diff.DriftActor(actual, paths)
+		for _, path := range paths {
+			oldValue, _ := diff.FieldValue(actual.Object, path)
+			newValue, _ := diff.FieldValue(declared.Object, path)
+			rec.Changes = append(rec.Changes, drift.FieldChange{
+				Field: path.String(),
+				Old:   oldValue,
+				New:   newValue,
+			})
+		}
+	case diff.Delete:
+		actual, err := objDiff.UnstructuredActual()
+		if err != nil {
+			return nil, nil
+		}
+		obj = objDiff.Actual
+		rec.Operation = drift.OperationDelete
+		rec.Actor = diff.DriftActor(actual, nil)
+	default:
+		return nil, nil
+	}
+	rec.Version = obj.GetObjectKind().GroupVersionKind().Version
+	return obj, rec
+}
+
+// reportDrift records the drift of the object, instead of correcting it, if the
+// drift policy of the object is report. The drift policy is read from the
+// declared object, and defaults to the drift policy of the reconciler. It
+// returns true if the object should not be remediated.
+func (r *reconciler) reportDrift(id core.ID, objDiff diff.Diff) (bool, status.Error) {
+	if objDiff.Declared == nil {
+		return false, nil
+	}
+	policy := metadata.GetDriftPolicy(objDiff.Declared)
+	if policy == "" {
+		policy = r.driftHandler.Policy()
+	}
+	if policy != metadata.DriftPolicyReport {
+		return false, nil
+	}
+
+	switch objDiff.Operation(r.scope, r.syncName) {
+	case diff.Create:
+		declared, err := objDiff.UnstructuredDeclared()
+		if err != nil {
+			return true, err
+		}
+		r.driftHandler.AddDrift(objDiff.Declared, drift.Record{
+			ID:         id,
+			Version:    declared.GroupVersionKind().Version,
+			SourcePath: status.GetSourceAnnotation(objDiff.Declared),
+			Operation:  drift.OperationCreate,
+			DetectedAt: time.Now(),
+		})
+		return true, nil
+	case diff.Update:
+		declared, err := objDiff.UnstructuredDeclared()
+		if err != nil {
+			return true, err
+		}
+		actual, err := objDiff.UnstructuredActual()
+		if err != nil {
+			return true, err
+		}
+		paths := diff.DriftedFields(declared, actual)
+		if len(paths) == 0 {
+			r.driftHandler.RemoveDrift(id)
+			return true, nil
+		}
+		fields := make([]string, len(paths))
+		for i, path := range paths {
+			fields[i] = path.String()
+		}
+		r.driftHandler.AddDrift(objDiff.Actual, drift.Record{
+			ID:         id,
+			Version:    declared.GroupVersionKind().Version,
+			SourcePath: status.GetSourceAnnotation(objDiff.Declared),
+			Operation:  drift.OperationUpdate,
+			Fields:     fields,
+			Actor:      diff.DriftActor(actual, paths),
+			DetectedAt: time.Now(),
+		})
+		return true, nil
+	default:
+		// Management conflicts, abandoning, and metadata updates are not
+		// drift, and are handled the same for every drift policy.
+		return false, nil
+	}
+}
+
 // Remediate takes diff (declared & actual) and ensures the server matches the
 // declared state.
 func (r *reconciler) remediate(ctx context.Context, id core.ID, objDiff diff.Diff) status.Error {
@@ -15,8 +15,11 @@
 package reconcile
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
 	"github.com/GoogleContainerTools/config-sync/pkg/core"
@@ -27,6 +30,7 @@ import (
 	"github.com/GoogleContainerTools/config-sync/pkg/metrics"
 	"github.com/GoogleContainerTools/config-sync/pkg/policycontroller"
 	"github.com/GoogleContainerTools/config-sync/pkg/remediator/conflict"
+	"github.com/GoogleContainerTools/config-sync/pkg/remediator/drift"
 	"github.com/GoogleContainerTools/config-sync/pkg/status"
 	syncerclient "github.com/GoogleContainerTools/config-sync/pkg/syncer/client"
 	"github.com/GoogleContainerTools/config-sync/pkg/syncer/syncertest"
@@ -411,7 +415,7 @@ func TestRemediator_Reconcile(t *testing.T) {
 			}
 
 			r := newReconciler(declared.RootScope, configsync.RootSyncName, c.Applier(configsync.FieldManager), d,
-				tc.conflictHandler, testingfake.NewFightHandler())
+				tc.conflictHandler, testingfake.NewFightHandler(), testingfake.NewDriftHandler())
 
 			// Get the triggering object for the reconcile event.
 			var obj client.Object
@@ -572,7 +576,7 @@ func TestRemediator_Reconcile_Metrics(t *testing.T) {
 			fakeApplier.DeleteError = tc.deleteError
 
 			reconciler := newReconciler(declared.RootScope, configsync.RootSyncName, fakeApplier, d,
-				testingfake.NewConflictHandler(), testingfake.NewFightHandler())
+				testingfake.NewConflictHandler(), testingfake.NewFightHandler(), testingfake.NewDriftHandler())
 
 			// Get the triggering object for the reconcile event.
 			var obj client.Object
@@ -601,6 +605,193 @@ func TestRemediator_Reconcile_Metrics(t *testing.T) {
 	}
 }
 
+func TestRemediator_Reconcile_DriftPolicyReport(t *testing.T) {
+	managed := core.Annotation(metadata.ResourceManagerKey, declared.ResourceManager(declared.RootScope, configsync.RootSyncName))
+	testCases := []struct {
+		name string
+		// policy is the default drift policy of the reconciler.
+		policy metadata.DriftPolicy
+		// declared is the state of the object as returned by the Parser.
+		declared client.Object
+		// actual is the current state of the object on the cluster.
+		actual client.Object
+		// want is the expected final state of the object on the cluster after
+		// reconciliation.
+		want client.Object
+		// wantRecords is the expected drift reported by the drift handler.
+		wantRecords []drift.Record
+	}{
+		{
+			name:   "report drifted fields",
+			policy: metadata.DriftPolicyReport,
+			declared: k8sobjects.ClusterRoleBindingObject(syncertest.ManagementEnabled, managed,
+				core.Label("team", "one")),
+			actual: k8sobjects.ClusterRoleBindingObject(syncertest.ManagementEnabled, managed,
+				core.Label("team", "two")),
+			want: k8sobjects.ClusterRoleBindingObject(syncertest.ManagementEnabled, managed,
+				core.Label("team", "two"),
+				core.UID("1"), core.ResourceVersion("1"), core.Generation(1)),
+			wantRecords: []drift.Record{{
+				Operation: drift.OperationUpdate,
+				Fields:    []string{"metadata.labels.team"},
+			}},
+		},
+		{
+			name: "report deleted object with the drift-policy annotation",
+			declared: k8sobjects.ClusterRoleBindingObject(syncertest.ManagementEnabled, managed,
+				metadata.WithDriftPolicy(metadata.DriftPolicyReport)),
+			wantRecords: []drift.Record{{
+				Operation: drift.OperationCreate,
+			}},
+		},
+		{
+			name:   "no drift to report",
+			policy: metadata.DriftPolicyReport,
+			declared: k8sobjects.ClusterRoleBindingObject(syncertest.ManagementEnabled, managed,
+				core.Label("team", "one")),
+			actual: k8sobjects.ClusterRoleBindingObject(syncertest.ManagementEnabled, managed,
+				core.Label("team", "one")),
+			want: k8sobjects.ClusterRoleBindingObject(syncertest.ManagementEnabled, managed,
+				core.Label("team", "one"),
+				core.UID("1"), core.ResourceVersion("1"), core.Generation(1)),
+		},
+		{
+			name:   "drift-policy annotation overrides the reconciler policy",
+			policy: metadata.DriftPolicyReport,
+			declared: k8sobjects.ClusterRoleBindingObject(syncertest.ManagementEnabled, managed,
+				metadata.WithDriftPolicy(metadata.DriftPolicyCorrect),
+				core.Label("team", "one")),
+			actual: k8sobjects.ClusterRoleBindingObject(syncertest.ManagementEnabled, managed,
+				core.Label("team", "two")),
+			want: k8sobjects.ClusterRoleBindingObject(syncertest.ManagementEnabled, managed,
+				metadata.WithDriftPolicy(metadata.DriftPolicyCorrect),
+				core.Label("team", "one"),
+				core.UID("1"), core.ResourceVersion("2"), core.Generation(1)),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			exporter, err := testmetrics.NewTestExporter()
+			require.NoError(t, err)
+			defer exporter.ClearMetrics()
+			var existingObjs []client.Object
+			if tc.actual != nil {
+				existingObjs = append(existingObjs, tc.actual)
+			}
+			c := testingfake.NewClient(t, core.Scheme, existingObjs...)
+			d := makeDeclared(t, "unused", tc.declared)
+			driftHandler := drift.NewHandler(tc.policy, nil, nil)
+
+			r := newReconciler(declared.RootScope, configsync.RootSyncName, c.Applier(configsync.FieldManager), d,
+				conflict.NewHandler(), testingfake.NewFightHandler(), driftHandler)
+
+			err = r.Remediate(context.Background(), core.IDOf(tc.declared), tc.actual)
+			require.NoError(t, err)
+
+			var gotRecords []drift.Record
+			for _, rec := range driftHandler.DriftRecords() {
+				assert.Equal(t, core.IDOf(tc.declared), rec.ID)
+				gotRecords = append(gotRecords, drift.Record{
+					Operation: rec.Operation,
+					Fields:    rec.Fields,
+				})
+			}
+			assert.Equal(t, tc.wantRecords, gotRecords)
+
+			if tc.want == nil {
+				c.Check(t)
+			} else {
+				c.Check(t, tc.want)
+			}
+		})
+	}
+}
+
+func TestRemediator_Reconcile_AuditCorrection(t *testing.T) {
+	managed := core.Annotation(metadata.ResourceManagerKey, declared.ResourceManager(declared.RootScope, configsync.RootSyncName))
+	testCases := []struct {
+		name string
+		// declared is the state of the object as returned by the Parser.
+		declared client.Object
+		// actual is the current state of the object on the cluster.
+		actual client.Object
+		// wantRecords is the expected audit records of the correction.
+		wantRecords []drift.AuditRecord
+	}{
+		{
+			name: "audit reverted fields",
+			declared: k8sobjects.ClusterRoleBindingObject(syncertest.ManagementEnabled, managed,
+				core.Label("team", "one")),
+			actual: k8sobjects.ClusterRoleBindingObject(syncertest.ManagementEnabled, managed,
+				core.Label("team", "two")),
+			wantRecords: []drift.AuditRecord{{
+				Group:     "rbac.authorization.k8s.io",
+				Version:   "v1",
+				Kind:      "ClusterRoleBinding",
+				Name:      "default-name",
+				Operation: drift.OperationUpdate,
+				Commit:    "abc123",
+				Changes: []drift.FieldChange{
+					{Field: "metadata.labels.team", Old: "two", New: "one"},
+				},
+			}},
+		},
+		{
+			name: "audit recreated object",
+			declared: k8sobjects.ClusterRoleBindingObject(syncertest.ManagementEnabled, managed,
+				core.Label("team", "one")),
+			wantRecords: []drift.AuditRecord{{
+				Group:     "rbac.authorization.k8s.io",
+				Version:   "v1",
+				Kind:      "ClusterRoleBinding",
+				Name:      "default-name",
+				Operation: drift.OperationCreate,
+				Commit:    "abc123",
+			}},
+		},
+		{
+			name: "no drift to audit",
+			declared: k8sobjects.ClusterRoleBindingObject(syncertest.ManagementEnabled, managed,
+				core.Label("team", "one")),
+			actual: k8sobjects.ClusterRoleBindingObject(syncertest.ManagementEnabled, managed,
+				core.Label("team", "one")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			exporter, err := testmetrics.NewTestExporter()
+			require.NoError(t, err)
+			defer exporter.ClearMetrics()
+			var existingObjs []client.Object
+			if tc.actual != nil {
+				existingObjs = append(existingObjs, tc.actual)
+			}
+			c := testingfake.NewClient(t, core.Scheme, existingObjs...)
+			d := makeDeclared(t, "abc123", tc.declared)
+			var auditLog bytes.Buffer
+
+			r := newReconciler(declared.RootScope, configsync.RootSyncName, c.Applier(configsync.FieldManager), d,
+				conflict.NewHandler(), testingfake.NewFightHandler(), drift.NewHandler("", nil, &auditLog))
+
+			err = r.Remediate(context.Background(), core.IDOf(tc.declared), tc.actual)
+			require.NoError(t, err)
+
+			var gotRecords []drift.AuditRecord
+			decoder := json.NewDecoder(&auditLog)
+			for decoder.More() {
+				var rec drift.AuditRecord
+				require.NoError(t, decoder.Decode(&rec))
+				assert.False(t, rec.Time.IsZero())
+				rec.Time = time.Time{}
+				gotRecords = append(gotRecords, rec)
+			}
+			assert.Equal(t, tc.wantRecords, gotRecords)
+		})
+	}
+}
+
 func makeDeclared(t *testing.T, commit string, objs ...client.Object) *declared.Resources {
 	t.Helper()
 	d := &declared.Resources{}
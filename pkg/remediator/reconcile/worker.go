@@ -22,6 +22,7 @@ import (
 	"github.com/GoogleContainerTools/config-sync/pkg/core"
 	"github.com/GoogleContainerTools/config-sync/pkg/declared"
 	"github.com/GoogleContainerTools/config-sync/pkg/remediator/conflict"
+	"github.com/GoogleContainerTools/config-sync/pkg/remediator/drift"
 	"github.com/GoogleContainerTools/config-sync/pkg/remediator/queue"
 	"github.com/GoogleContainerTools/config-sync/pkg/status"
 	syncerclient "github.com/GoogleContainerTools/config-sync/pkg/syncer/client"
@@ -43,10 +44,10 @@ type Worker struct {
 
 // NewWorker returns a new Worker for the given queue and declared resources.
 func NewWorker(scope declared.Scope, syncName string, a syncerreconcile.Applier,
-	q *queue.ObjectQueue, d *declared.Resources, ch conflict.Handler, fh fight.Handler) *Worker {
+	q *queue.ObjectQueue, d *declared.Resources, ch conflict.Handler, fh fight.Handler, dh drift.Handler) *Worker {
 	return &Worker{
 		objectQueue: q,
-		reconciler:  newReconciler(scope, syncName, a, d, ch, fh),
+		reconciler:  newReconciler(scope, syncName, a, d, ch, fh, dh),
 	}
 }
 
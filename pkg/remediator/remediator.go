@@ -22,6 +22,7 @@ import (
 	"github.com/GoogleContainerTools/config-sync/pkg/declared"
 	"github.com/GoogleContainerTools/config-sync/pkg/reconcilermanager/controllers"
 	"github.com/GoogleContainerTools/config-sync/pkg/remediator/conflict"
+	"github.com/GoogleContainerTools/config-sync/pkg/remediator/drift"
 	"github.com/GoogleContainerTools/config-sync/pkg/remediator/queue"
 	"github.com/GoogleContainerTools/config-sync/pkg/remediator/reconcile"
 	"github.com/GoogleContainerTools/config-sync/pkg/remediator/watch"
@@ -103,14 +104,16 @@ func New(
 	applier syncerreconcile.Applier,
 	conflictHandler conflict.Handler,
 	fightHandler fight.Handler,
+	driftHandler drift.Handler,
 	crdController *controllers.CRDController,
 	decls *declared.Resources,
 	numWorkers int,
+	priorities queue.Priorities,
 ) (*Remediator, error) {
-	q := queue.New(scope.String())
+	q := queue.NewWithPriorities(scope.String(), priorities)
 	workers := make([]*reconcile.Worker, numWorkers)
 	for i := 0; i < numWorkers; i++ {
-		workers[i] = reconcile.NewWorker(scope, syncName, applier, q, decls, conflictHandler, fightHandler)
+		workers[i] = reconcile.NewWorker(scope, syncName, applier, q, decls, conflictHandler, fightHandler, driftHandler)
 	}
 
 	remediator := &Remediator{
@@ -27,6 +27,7 @@ import (
 	"github.com/GoogleContainerTools/config-sync/pkg/core"
 	"github.com/GoogleContainerTools/config-sync/pkg/declared"
 	"github.com/GoogleContainerTools/config-sync/pkg/diff"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
 	"github.com/GoogleContainerTools/config-sync/pkg/metrics"
 	"github.com/GoogleContainerTools/config-sync/pkg/remediator/conflict"
 	"github.com/GoogleContainerTools/config-sync/pkg/remediator/queue"
@@ -477,6 +478,18 @@ func (w *filteredWatcher) shouldProcess(object client.Object) bool {
 		return false
 	}
 
+	if metadata.HasTransferTo(decl) {
+		// The resource is being handed over to another reconciler, so it is
+		// expected to be managed by the other reconciler.
+		return false
+	}
+
+	if metadata.IsSharedFieldOwnership(decl) {
+		// The resource is managed by another reconciler, and this reconciler
+		// only co-manages some of its fields.
+		return false
+	}
+
 	if diff.CanManage(w.scope, w.syncName, object, diff.OperationManage) {
 		return true
 	}
@@ -14,7 +14,12 @@
 
 package status
 
-import "sigs.k8s.io/controller-runtime/pkg/client"
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
 
 // FightErrorCode is the error code for Config Sync fighting with other controllers.
 const FightErrorCode = "2005"
@@ -24,7 +29,21 @@ var fightErrorBuilder = NewErrorBuilder(FightErrorCode)
 // FightError represents when the remediator is fighting over a resource object
 // with some other process on a Kubernetes cluster.
 func FightError(frequency float64, resource client.Object) ResourceError {
-	return fightErrorBuilder.Sprintf("detected excessive object updates, approximately %d times per minute. "+
-		"This may indicate Config Sync is fighting with another controller over the object.", int(frequency)).
-		BuildWithResources(resource)
+	return FightErrorWithContenders(frequency, resource, nil, nil)
+}
+
+// FightErrorWithContenders represents when the remediator is fighting over a
+// resource object with other field managers, which modified the declared
+// fields between updates by Config Sync.
+func FightErrorWithContenders(frequency float64, resource client.Object, managers, fields []string) ResourceError {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "detected excessive object updates, approximately %d times per minute. "+
+		"This may indicate Config Sync is fighting with another controller over the object.", int(frequency))
+	if len(managers) > 0 {
+		fmt.Fprintf(&sb, " Competing field managers: %s.", strings.Join(managers, ", "))
+	}
+	if len(fields) > 0 {
+		fmt.Fprintf(&sb, " Contested fields: %s.", strings.Join(fields, ", "))
+	}
+	return fightErrorBuilder.Sprint(sb.String()).BuildWithResources(resource)
 }
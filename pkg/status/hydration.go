@@ -14,6 +14,8 @@
 
 package status
 
+import "github.com/GoogleContainerTools/config-sync/pkg/importer/id"
+
 // InternalHydrationErrorCode is the error code for an internal Error related to the hydration process.
 const InternalHydrationErrorCode = "2015"
 
@@ -42,3 +44,20 @@ func HydrationError(code string, err error) Error {
 		return internalHydrationErrorBuilder.Wrap(err).Build()
 	}
 }
+
+// KustomizeBuildError returns a user actionable hydration error for a
+// kustomization which failed to render, with the slash paths of the
+// kustomization file and of the resource which failed, if any.
+func KustomizeBuildError(err error, slashPaths ...string) Error {
+	var paths []id.Path
+	for _, p := range slashPaths {
+		if p != "" {
+			paths = append(paths, path{slashPath: p})
+		}
+	}
+	eb := actionableHydrationErrorBuilder.Sprint("failed to render the kustomization").Wrap(err)
+	if len(paths) == 0 {
+		return eb.Build()
+	}
+	return eb.BuildWithPaths(paths...)
+}
@@ -70,16 +70,20 @@ type clientApplier struct {
 	client           *syncerclient.Client
 	fights           fight.Detector
 	applySetID       string
+	// forceConflicts is whether server-side apply takes the ownership of the
+	// declared fields managed by other field managers. If false, applying
+	// fields with a different value than another field manager fails.
+	forceConflicts bool
 }
 
 var _ Applier = &clientApplier{}
 
 // NewApplierForMultiRepo returns a new clientApplier for callers with multi repo feature enabled.
-func NewApplierForMultiRepo(cfg *rest.Config, client *syncerclient.Client, applySetID string, fightThreshold float64) (Applier, error) {
-	return newApplier(cfg, client, applySetID, fightThreshold)
+func NewApplierForMultiRepo(cfg *rest.Config, client *syncerclient.Client, applySetID string, fightThreshold float64, forceConflicts bool) (Applier, error) {
+	return newApplier(cfg, client, applySetID, fightThreshold, forceConflicts)
 }
 
-func newApplier(cfg *rest.Config, client *syncerclient.Client, applySetID string, fightThreshold float64) (Applier, error) {
+func newApplier(cfg *rest.Config, client *syncerclient.Client, applySetID string, fightThreshold float64, forceConflicts bool) (Applier, error) {
 	c, err := dynamic.NewForConfig(cfg)
 	if err != nil {
 		return nil, err
@@ -102,6 +106,7 @@ func newApplier(cfg *rest.Config, client *syncerclient.Client, applySetID string
 		client:           client,
 		fights:           fight.NewDetector(fightThreshold),
 		applySetID:       applySetID,
+		forceConflicts:   forceConflicts,
 	}, nil
 }
 
@@ -115,7 +120,7 @@ func (c *clientApplier) Create(ctx context.Context, intendedState *unstructured.
 	} else {
 		//nolint:staticcheck // allow deprecated field for backwards compatibility
 		//TODO: Refactor to remove the usage of the deprecated field
-		if err1 := c.client.Patch(ctx, intendedState, client.Apply, client.FieldOwner(configsync.FieldManager)); err1 != nil {
+		if err1 := c.client.Patch(ctx, intendedState, client.Apply, c.applyOptions()...); err1 != nil {
 			switch {
 			case meta.IsNoMatchError(err1), apierrors.IsNotFound(err1):
 				err = syncerclient.ConflictCreateResourceDoesNotExist(err1, intendedState)
@@ -131,7 +136,7 @@ func (c *clientApplier) Create(ctx context.Context, intendedState *unstructured.
 		klog.V(3).Infof("Failed to create object %v: %v", core.GKNN(intendedState), err)
 		return err
 	}
-	logErr, err := c.fights.DetectFight(time.Now(), intendedState)
+	logErr, err := c.fights.DetectFight(time.Now(), intendedState, nil)
 	if logErr {
 		klog.Errorf("Fight detected on create of %s.", description(intendedState))
 	}
@@ -148,6 +153,8 @@ func (c *clientApplier) Update(ctx context.Context, intendedState, currentState
 	m.RecordApplyOperation(ctx, m.RemediatorController, "update", m.StatusTagKey(err))
 
 	switch {
+	case isFieldManagerConflict(err):
+		return fieldManagerConflictError(err, intendedState)
 	case apierrors.IsConflict(err):
 		return syncerclient.ConflictUpdateOldVersion(err, intendedState)
 	case apierrors.IsNotFound(err):
@@ -160,7 +167,7 @@ func (c *clientApplier) Update(ctx context.Context, intendedState, currentState
 
 	updated := !isNoOpPatch(patch)
 	if updated {
-		logFight, err := c.fights.DetectFight(time.Now(), intendedState)
+		logFight, err := c.fights.DetectFight(time.Now(), intendedState, currentState)
 		if logFight {
 			diff := cmp.Diff(currentState, intendedState)
 			klog.Errorf("Fight detected on update of %s with difference %s", description(intendedState), diff)
@@ -208,7 +215,7 @@ func (c *clientApplier) Delete(ctx context.Context, obj *unstructured.Unstructur
 		klog.V(3).Infof("Failed to delete object %v: %v", core.GKNN(obj), err)
 		return err
 	}
-	logFight, err := c.fights.DetectFight(time.Now(), obj)
+	logFight, err := c.fights.DetectFight(time.Now(), nil, obj)
 	if logFight {
 		klog.Errorf("Fight detected on delete of %s.", description(obj))
 	}
@@ -253,7 +260,7 @@ func (c *clientApplier) update(ctx context.Context, intendedState, currentState
 	// If the returned object doesn't change, skip running server-side apply.
 	//nolint:staticcheck // allow deprecated field for backwards compatibility
 	//TODO: Refactor to remove the usage of the deprecated field
-	err := c.client.Patch(ctx, objCopy, client.Apply, client.FieldOwner(configsync.FieldManager), client.ForceOwnership, client.DryRunAll)
+	err := c.client.Patch(ctx, objCopy, client.Apply, append(c.applyOptions(), client.DryRunAll)...)
 	if err != nil {
 		return nil, err
 	}
@@ -264,13 +271,41 @@ func (c *clientApplier) update(ctx context.Context, intendedState, currentState
 	start := time.Now()
 	//nolint:staticcheck // allow deprecated field for backwards compatibility
 	//TODO: Refactor to remove the usage of the deprecated field
-	err = c.client.Patch(ctx, intendedState, client.Apply, client.FieldOwner(configsync.FieldManager), client.ForceOwnership)
+	err = c.client.Patch(ctx, intendedState, client.Apply, c.applyOptions()...)
 	duration := time.Since(start).Seconds()
 	metrics.APICallDuration.WithLabelValues("update", metrics.StatusLabel(err)).Observe(duration)
 	m.RecordAPICallDuration(ctx, "update", m.StatusTagKey(err), start)
 	return []byte(cmp.Diff(currentState, intendedState)), err
 }
 
+// applyOptions returns the options of server-side apply requests. The field
+// manager is the same as the applier's, so that they share the ownership of
+// the declared fields.
+func (c *clientApplier) applyOptions() []client.PatchOption {
+	opts := []client.PatchOption{client.FieldOwner(configsync.FieldManager)}
+	if c.forceConflicts {
+		opts = append(opts, client.ForceOwnership)
+	}
+	return opts
+}
+
+// isFieldManagerConflict returns true if the error is a server-side apply
+// conflict with the fields managed by other field managers.
+func isFieldManagerConflict(err error) bool {
+	if !apierrors.IsConflict(err) {
+		return false
+	}
+	return apierrors.HasStatusCause(err, metav1.CauseTypeFieldManagerConflict)
+}
+
+// fieldManagerConflictError means the remediator didn't take the ownership of
+// declared fields managed by other field managers, because force-conflicts is
+// disabled.
+func fieldManagerConflictError(err error, resource client.Object) status.Error {
+	return status.ResourceWrap(err, fmt.Sprintf("declared fields are managed by other field managers, "+
+		"and %s is set to false", metadata.ForceConflictsAnnotationKey), resource)
+}
+
 // updateAPIService updates APIService type resources.
 // APIService is handled specially by client-side apply due to
 // https://github.com/kubernetes/kubernetes/issues/89264
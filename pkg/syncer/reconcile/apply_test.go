@@ -15,13 +15,16 @@
 package reconcile
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/GoogleContainerTools/config-sync/pkg/core"
 	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
 	"github.com/GoogleContainerTools/config-sync/pkg/kinds"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -73,3 +76,57 @@ func TestEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestIsFieldManagerConflict(t *testing.T) {
+	gr := schema.GroupResource{Group: "apps", Resource: "deployments"}
+	fieldManagerConflict := &apierrors.StatusError{ErrStatus: metav1.Status{
+		Status: metav1.StatusFailure,
+		Code:   409,
+		Reason: metav1.StatusReasonConflict,
+		Details: &metav1.StatusDetails{
+			Group: gr.Group,
+			Kind:  gr.Resource,
+			Name:  "web",
+			Causes: []metav1.StatusCause{{
+				Type:    metav1.CauseTypeFieldManagerConflict,
+				Message: `conflict with "kubectl-edit"`,
+				Field:   ".spec.replicas",
+			}},
+		},
+	}}
+
+	testcases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "no error",
+			err:  nil,
+			want: false,
+		},
+		{
+			name: "field manager conflict",
+			err:  fieldManagerConflict,
+			want: true,
+		},
+		{
+			name: "resource version conflict",
+			err:  apierrors.NewConflict(gr, "web", errors.New("the object has been modified")),
+			want: false,
+		},
+		{
+			name: "other error",
+			err:  apierrors.NewNotFound(gr, "web"),
+			want: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isFieldManagerConflict(tc.err); got != tc.want {
+				t.Errorf("isFieldManagerConflict() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
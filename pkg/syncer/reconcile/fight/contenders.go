@@ -0,0 +1,188 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fight
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v6/value"
+)
+
+// contenders is the set of field managers that modified an object between
+// updates by Config Sync, and the declared fields they modified.
+type contenders struct {
+	managers map[string]bool
+	fields   map[string]bool
+}
+
+// add records the field managers, other than Config Sync, that modified the
+// declared fields of the current object at or after since, according to its
+// managed fields, and the fields they own that are declared in the intended
+// object. Either object may be nil.
+func (c *contenders) add(intended, current client.Object, since time.Time) {
+	if current == nil {
+		return
+	}
+	var declared map[string]interface{}
+	if intended != nil {
+		var err error
+		declared, err = runtime.DefaultUnstructuredConverter.ToUnstructured(intended)
+		if err != nil {
+			klog.Warningf("Failed to convert %v to unstructured: %v", core.GKNN(intended), err)
+		}
+	}
+	// Managed fields timestamps have a precision of one second.
+	since = since.Truncate(time.Second)
+	for _, entry := range current.GetManagedFields() {
+		if entry.Manager == configsync.FieldManager {
+			continue
+		}
+		if entry.Time == nil || entry.Time.Time.Before(since) {
+			continue
+		}
+		if declared == nil {
+			// Without declared fields, like for deleted objects, every recent
+			// field manager is a contender.
+			c.addManager(entry.Manager)
+			continue
+		}
+		if entry.FieldsV1 == nil {
+			continue
+		}
+		set := &fieldpath.Set{}
+		if err := set.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			klog.Warningf("Failed to decode the managed fields of %q: %v", entry.Manager, err)
+			continue
+		}
+		set.Leaves().Iterate(func(path fieldpath.Path) {
+			if !isDeclared(declared, path) {
+				return
+			}
+			c.addManager(entry.Manager)
+			if c.fields == nil {
+				c.fields = make(map[string]bool)
+			}
+			c.fields[strings.TrimPrefix(path.String(), ".")] = true
+		})
+	}
+}
+
+func (c *contenders) addManager(manager string) {
+	if c.managers == nil {
+		c.managers = make(map[string]bool)
+	}
+	c.managers[manager] = true
+}
+
+// reset forgets the recorded field managers and fields.
+func (c *contenders) reset() {
+	c.managers = nil
+	c.fields = nil
+}
+
+// sortedManagers returns the recorded field managers in alphabetical order.
+func (c *contenders) sortedManagers() []string {
+	return sortedKeys(c.managers)
+}
+
+// sortedFields returns the recorded fields in alphabetical order.
+func (c *contenders) sortedFields() []string {
+	return sortedKeys(c.fields)
+}
+
+func sortedKeys(m map[string]bool) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isDeclared returns true if the field at the path is set in obj. List items
+// are matched by their associative keys, their value, or their index.
+func isDeclared(obj interface{}, path fieldpath.Path) bool {
+	for _, element := range path {
+		switch {
+		case element.FieldName != nil:
+			m, ok := obj.(map[string]interface{})
+			if !ok {
+				return false
+			}
+			obj, ok = m[*element.FieldName]
+			if !ok {
+				return false
+			}
+		case element.Key != nil:
+			item, ok := findListItem(obj, func(item interface{}) bool {
+				m, ok := item.(map[string]interface{})
+				if !ok {
+					return false
+				}
+				for _, field := range *element.Key {
+					v, found := m[field.Name]
+					if !found || !value.Equals(value.NewValueInterface(v), field.Value) {
+						return false
+					}
+				}
+				return true
+			})
+			if !ok {
+				return false
+			}
+			obj = item
+		case element.Value != nil:
+			item, ok := findListItem(obj, func(item interface{}) bool {
+				return value.Equals(value.NewValueInterface(item), *element.Value)
+			})
+			if !ok {
+				return false
+			}
+			obj = item
+		case element.Index != nil:
+			list, ok := obj.([]interface{})
+			if !ok || *element.Index >= len(list) {
+				return false
+			}
+			obj = list[*element.Index]
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// findListItem returns the first item of the list that matches.
+func findListItem(list interface{}, matches func(interface{}) bool) (interface{}, bool) {
+	items, ok := list.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	for _, item := range items {
+		if matches(item) {
+			return item, true
+		}
+	}
+	return nil, false
+}
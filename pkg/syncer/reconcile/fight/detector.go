@@ -62,16 +62,35 @@ func NewDetector(threshold float64) Detector {
 
 // DetectFight detects whether the resource is needing updates too frequently.
 // If so, it increments the resource_fights metric and logs to klog.Error.
-func (d *Detector) DetectFight(now time.Time, obj client.Object) (bool, status.ResourceError) {
+//
+// intended is the state of the object Config Sync applied, or nil if Config
+// Sync deleted it. current is the state of the object on the cluster before
+// Config Sync modified it, or nil if it didn't exist. The field managers that
+// modified the current object since the previous update by Config Sync, and
+// the declared fields they own, are included in the fight error.
+func (d *Detector) DetectFight(now time.Time, intended, current client.Object) (bool, status.ResourceError) {
 	d.mux.Lock()
 	defer d.mux.Unlock()
+	obj := intended
+	if obj == nil {
+		obj = current
+	}
 	id := core.IDOf(obj)
 
 	if d.fights[id] == nil {
 		d.fights[id] = &fight{}
 	}
-	if frequency := d.fights[id].refreshUpdateFrequency(now); frequency >= d.fightThreshold {
-		fightErr := status.FightError(frequency, obj)
+	f := d.fights[id]
+	// On the first detection, there is no previous update by Config Sync, so
+	// only the modifications at the time of the detection are considered.
+	since := f.last
+	if since.IsZero() {
+		since = now
+	}
+	frequency := f.refreshUpdateFrequency(now)
+	f.contenders.add(intended, current, since)
+	if frequency >= d.fightThreshold {
+		fightErr := status.FightErrorWithContenders(frequency, obj, f.contenders.sortedManagers(), f.contenders.sortedFields())
 		return d.fLogger.logFight(now, fightErr), fightErr
 	}
 	return false, nil
@@ -84,6 +103,9 @@ type fight struct {
 	heat float64
 	// last is the last time the resource was updated.
 	last time.Time
+	// contenders are the field managers that modified the resource between
+	// updates, since the fight started.
+	contenders contenders
 }
 
 // refreshUpdateFrequency advanced the time on fight to now and increases heat by 1.0.
@@ -114,6 +136,10 @@ func (f *fight) refreshUpdateFrequency(now time.Time) float64 {
 	// heat exactly our estimate of number of updates per minute before this
 	// update.
 	f.heat *= math.Exp(-d)
+	// Less than one update in the last minute means any previous fight ended.
+	if f.heat < 1 {
+		f.contenders.reset()
+	}
 
 	// Increment heat by one update. This is our new estimate of updates per minute.
 	f.heat++
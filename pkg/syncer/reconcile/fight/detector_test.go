@@ -20,7 +20,10 @@ import (
 
 	"github.com/GoogleContainerTools/config-sync/pkg/core"
 	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // durations creates a sequence of evenly-spaced time.Durations.
@@ -195,7 +198,7 @@ func TestFightDetector(t *testing.T) {
 				aboveThreshold := false
 				logged := false
 				for i, update := range updates {
-					logErr, fightErr := fd.DetectFight(now.Add(update), u)
+					logErr, fightErr := fd.DetectFight(now.Add(update), u, nil)
 					if i+1 >= int(fd.fightThreshold) {
 						require.Error(t, fightErr)
 						aboveThreshold = true
@@ -218,3 +221,65 @@ func TestFightDetector(t *testing.T) {
 		})
 	}
 }
+
+func TestFightDetector_Contenders(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC)
+	intended := k8sobjects.UnstructuredObject(k8sobjects.DeploymentObject().GroupVersionKind(),
+		core.Namespace("bookstore"), core.Name("web"))
+	require.NoError(t, unstructured.SetNestedField(intended.Object, int64(3), "spec", "replicas"))
+	require.NoError(t, unstructured.SetNestedSlice(intended.Object, []interface{}{
+		map[string]interface{}{"name": "nginx", "image": "nginx:1.25"},
+	}, "spec", "template", "spec", "containers"))
+
+	current := intended.DeepCopy()
+	current.SetManagedFields([]metav1.ManagedFieldsEntry{
+		managedFieldsEntry("configsync.gke.io", start, `{"f:spec":{"f:replicas":{}}}`),
+		managedFieldsEntry("horizontal-pod-autoscaler", start.Add(10*time.Second), `{"f:spec":{"f:replicas":{}}}`),
+		managedFieldsEntry("kubectl-edit", start.Add(20*time.Second),
+			`{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"nginx\"}":{"f:image":{}}}}}}}`),
+		managedFieldsEntry("kube-controller-manager", start.Add(30*time.Second), `{"f:status":{"f:replicas":{}}}`),
+		managedFieldsEntry("old-controller", start.Add(-time.Hour), `{"f:spec":{"f:paused":{}}}`),
+	})
+
+	fd := NewDetector(5.0)
+	var fightErr error
+	for i := 0; i < 6; i++ {
+		_, fightErr = fd.DetectFight(start.Add(time.Duration(i)*time.Second), intended, current)
+	}
+	require.Error(t, fightErr)
+	assert.Contains(t, fightErr.Error(),
+		"Competing field managers: horizontal-pod-autoscaler, kubectl-edit.")
+	assert.Contains(t, fightErr.Error(),
+		`Contested fields: spec.replicas, spec.template.spec.containers[name="nginx"].image.`)
+}
+
+func TestFightDetector_FirstDetection(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC)
+	intended := k8sobjects.UnstructuredObject(k8sobjects.DeploymentObject().GroupVersionKind(),
+		core.Namespace("bookstore"), core.Name("web"))
+	require.NoError(t, unstructured.SetNestedField(intended.Object, int64(3), "spec", "replicas"))
+
+	current := intended.DeepCopy()
+	current.SetManagedFields([]metav1.ManagedFieldsEntry{
+		managedFieldsEntry("installer", now.Add(-time.Hour), `{"f:spec":{"f:replicas":{}}}`),
+		managedFieldsEntry("horizontal-pod-autoscaler", now, `{"f:spec":{"f:replicas":{}}}`),
+	})
+
+	// Without a previous update by Config Sync, only the modifications at the
+	// time of the detection are considered.
+	fd := NewDetector(1.0)
+	_, fightErr := fd.DetectFight(now, intended, current)
+	require.Error(t, fightErr)
+	assert.Contains(t, fightErr.Error(), "Competing field managers: horizontal-pod-autoscaler.")
+}
+
+func managedFieldsEntry(manager string, updated time.Time, fields string) metav1.ManagedFieldsEntry {
+	return metav1.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  metav1.ManagedFieldsOperationUpdate,
+		APIVersion: "apps/v1",
+		Time:       &metav1.Time{Time: updated},
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: []byte(fields)},
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/remediator/drift"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DriftHandler is a fake implementation of drift.Handler.
+type DriftHandler struct{}
+
+// Policy is a fake implementation of the Policy of drift.Handler.
+func (h *DriftHandler) Policy() metadata.DriftPolicy {
+	return metadata.DriftPolicyCorrect
+}
+
+// AddDrift is a fake implementation of the AddDrift of drift.Handler.
+func (h *DriftHandler) AddDrift(client.Object, drift.Record) {}
+
+// RemoveDrift is a fake implementation of the RemoveDrift of drift.Handler.
+func (h *DriftHandler) RemoveDrift(core.ID) {}
+
+// DriftRecords is a fake implementation of the DriftRecords of drift.Handler.
+func (h *DriftHandler) DriftRecords() []drift.Record {
+	return nil
+}
+
+// AuditCorrection is a fake implementation of the AuditCorrection of drift.Handler.
+func (h *DriftHandler) AuditCorrection(client.Object, drift.AuditRecord) {}
+
+var _ drift.Handler = &DriftHandler{}
+
+// NewDriftHandler initiates a fake implementation of drift.Handler.
+func NewDriftHandler() drift.Handler {
+	return &DriftHandler{}
+}
@@ -0,0 +1,295 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package archive extracts tarballs and zip files into a directory.
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zipMagic  = []byte("PK\x03\x04")
+)
+
+// ExtractFile extracts the archive file to the target directory.
+// The archive format is detected from the file content: it must be a zip file,
+// a gzipped tarball, or an uncompressed tarball.
+func ExtractFile(archivePath, dir string) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			klog.Warningf("failed to close file %q: %v", file.Name(), err)
+		}
+	}()
+
+	reader := bufio.NewReader(file)
+	magic, err := reader.Peek(len(zipMagic))
+	if err != nil && err != io.EOF {
+		return err
+	}
+	switch {
+	case bytes.HasPrefix(magic, zipMagic):
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		return ExtractZip(file, info.Size(), dir)
+	case bytes.HasPrefix(magic, gzipMagic):
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := gzipReader.Close(); err != nil {
+				klog.Warningf("failed to close gzip reader: %v", err)
+			}
+		}()
+		return ExtractTar(gzipReader, dir)
+	default:
+		return ExtractTar(reader, dir)
+	}
+}
+
+// ExtractTar extracts (untar) the tar stream to the target directory.
+// Symlinks must be relative and resolve inside the directory, and hard links
+// must link to a regular file extracted before. Files are never written
+// through a symlink.
+func ExtractTar(reader io.Reader, dir string) error {
+	dir = filepath.Clean(dir)
+	var symlinks []string
+	tarReader := tar.NewReader(reader)
+	for {
+		hdr, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		path, err := targetPath(dir, hdr.Name)
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, hdr.FileInfo().Mode()); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := extractSymlink(dir, path, hdr.Linkname); err != nil {
+				return err
+			}
+			symlinks = append(symlinks, path)
+		case tar.TypeLink:
+			if err := extractHardLink(dir, path, hdr.Linkname); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeFile(path, os.FileMode(hdr.Mode), tarReader); err != nil {
+				return err
+			}
+		default:
+			klog.Warningf("skipping the unsupported tar entry %q of type %q", hdr.Name, hdr.Typeflag)
+		}
+	}
+	// The symlinks extracted later may change how the symlinks resolve, so
+	// they are checked again once all of them exist.
+	for _, path := range symlinks {
+		if _, err := resolveInDir(dir, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractSymlink creates the symlink at the path, if its target is relative
+// and resolves inside the directory.
+func extractSymlink(dir, path, target string) error {
+	if filepath.IsAbs(target) {
+		return fmt.Errorf("invalid symlink %q to %q: must be relative", path, target)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0755)); err != nil {
+		return err
+	}
+	if err := os.Symlink(target, path); err != nil {
+		return err
+	}
+	_, err := resolveInDir(dir, path)
+	return err
+}
+
+// extractHardLink links the path to the regular file at the target, a path
+// relative to the archive root, inside the directory.
+func extractHardLink(dir, path, target string) error {
+	source, err := targetPath(dir, target)
+	if err != nil {
+		return fmt.Errorf("invalid hard link %q to %q: %w", path, target, err)
+	}
+	info, err := os.Lstat(source)
+	if err != nil {
+		return fmt.Errorf("invalid hard link %q to %q: %w", path, target, err)
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("invalid hard link %q to %q: must link to a regular file", path, target)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0755)); err != nil {
+		return err
+	}
+	return os.Link(source, path)
+}
+
+// maxSymlinks is the maximum number of symlinks followed to resolve a path.
+const maxSymlinks = 255
+
+// resolveInDir returns the path which the path inside the directory resolves
+// to, following the symlinks. The components which don't exist yet are
+// resolved lexically. It returns an error if the path resolves outside of the
+// directory, or through an absolute symlink.
+func resolveInDir(dir, path string) (string, error) {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return "", err
+	}
+	var resolved []string
+	pending := strings.Split(rel, string(os.PathSeparator))
+	for followed := 0; len(pending) > 0; {
+		name := pending[0]
+		pending = pending[1:]
+		switch name {
+		case "", ".":
+			continue
+		case "..":
+			if len(resolved) == 0 {
+				return "", fmt.Errorf("invalid path %q: resolves outside of the archive", path)
+			}
+			resolved = resolved[:len(resolved)-1]
+			continue
+		}
+		current := filepath.Join(append([]string{dir}, append(resolved, name)...)...)
+		info, err := os.Lstat(current)
+		if err != nil || info.Mode()&os.ModeSymlink == 0 {
+			resolved = append(resolved, name)
+			continue
+		}
+		if followed++; followed > maxSymlinks {
+			return "", fmt.Errorf("invalid path %q: too many levels of symlinks", path)
+		}
+		target, err := os.Readlink(current)
+		if err != nil {
+			return "", err
+		}
+		if filepath.IsAbs(target) {
+			return "", fmt.Errorf("invalid path %q: resolves through the absolute symlink %q", path, current)
+		}
+		// The target is relative to the directory of the symlink.
+		pending = append(strings.Split(target, string(os.PathSeparator)), pending...)
+	}
+	return filepath.Join(append([]string{dir}, resolved...)...), nil
+}
+
+// ExtractZip extracts the zip file with the specified size to the target
+// directory.
+func ExtractZip(reader io.ReaderAt, size int64, dir string) error {
+	zipReader, err := zip.NewReader(reader, size)
+	if err != nil {
+		return err
+	}
+	for _, zipFile := range zipReader.File {
+		path, err := targetPath(dir, zipFile.Name)
+		if err != nil {
+			return err
+		}
+		if zipFile.FileInfo().IsDir() {
+			if err := os.MkdirAll(path, os.FileMode(0755)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := extractZipFile(zipFile, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractZipFile(zipFile *zip.File, path string) error {
+	fileReader, err := zipFile.Open()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := fileReader.Close(); err != nil {
+			klog.Warningf("failed to close zip entry %q: %v", zipFile.Name, err)
+		}
+	}()
+	mode := zipFile.Mode().Perm()
+	if mode == 0 {
+		// Zip files created on Windows may not record permissions.
+		mode = os.FileMode(0644)
+	}
+	return writeFile(path, mode, fileReader)
+}
+
+// targetPath returns the path of the archive entry in the target directory.
+// It returns an error if the entry would be written outside of the directory,
+// or through a symlink.
+func targetPath(dir, name string) (string, error) {
+	dir = filepath.Clean(dir)
+	path := filepath.Join(dir, name)
+	if path != dir && !strings.HasPrefix(path, dir+string(os.PathSeparator)) {
+		return "", fmt.Errorf("invalid file path %q: must be inside the archive", name)
+	}
+	for current := path; current != dir; current = filepath.Dir(current) {
+		info, err := os.Lstat(current)
+		if err == nil && info.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("invalid file path %q: must not be written through the symlink %q", name, current)
+		}
+	}
+	return path, nil
+}
+
+// writeFile writes the content to the file, creating parent directories as
+// needed, because archives may omit directory entries.
+func writeFile(path string, mode os.FileMode, content io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0755)); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			klog.Warningf("failed to close file %q: %v", file.Name(), err)
+		}
+	}()
+	_, err = io.Copy(file, content)
+	return err
+}
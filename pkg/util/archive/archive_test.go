@@ -0,0 +1,232 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tarball(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tarWriter := tar.NewWriter(&buf)
+	for name, content := range files {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tarWriter.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.Close())
+	return buf.Bytes()
+}
+
+func gzipped(t *testing.T, content []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	_, err := gzipWriter.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+	return buf.Bytes()
+}
+
+func zipped(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zipWriter.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zipWriter.Close())
+	return buf.Bytes()
+}
+
+func TestExtractFile(t *testing.T) {
+	files := map[string]string{
+		"namespaces/bookstore/ns.yaml": "kind: Namespace\n",
+		"cluster/clusterrole.yaml":     "kind: ClusterRole\n",
+	}
+	testCases := map[string]struct {
+		content   []byte
+		wantFiles map[string]string
+		wantError bool
+	}{
+		"tarball": {
+			content:   tarball(t, files),
+			wantFiles: files,
+		},
+		"gzipped tarball": {
+			content:   gzipped(t, tarball(t, files)),
+			wantFiles: files,
+		},
+		"zip": {
+			content:   zipped(t, files),
+			wantFiles: files,
+		},
+		"tarball with path outside of the directory": {
+			content:   tarball(t, map[string]string{"../escape.yaml": "kind: Namespace\n"}),
+			wantError: true,
+		},
+		"zip with path outside of the directory": {
+			content:   zipped(t, map[string]string{"../escape.yaml": "kind: Namespace\n"}),
+			wantError: true,
+		},
+		"not an archive": {
+			content:   []byte("kind: Namespace\n"),
+			wantError: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			archivePath := filepath.Join(t.TempDir(), "archive")
+			require.NoError(t, os.WriteFile(archivePath, tc.content, 0644))
+			dir := t.TempDir()
+			err := ExtractFile(archivePath, dir)
+			if tc.wantError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for path, want := range tc.wantFiles {
+				got, err := os.ReadFile(filepath.Join(dir, path))
+				require.NoError(t, err)
+				assert.Equal(t, want, string(got))
+			}
+		})
+	}
+}
+
+type tarEntry struct {
+	header  *tar.Header
+	content string
+}
+
+func TestExtractTarLinks(t *testing.T) {
+	file := func(name, content string) tarEntry {
+		return tarEntry{header: &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}, content: content}
+	}
+	dir := func(name string) tarEntry {
+		return tarEntry{header: &tar.Header{Name: name, Mode: 0755, Typeflag: tar.TypeDir}}
+	}
+	symlink := func(name, target string) tarEntry {
+		return tarEntry{header: &tar.Header{Name: name, Linkname: target, Typeflag: tar.TypeSymlink}}
+	}
+	hardLink := func(name, target string) tarEntry {
+		return tarEntry{header: &tar.Header{Name: name, Linkname: target, Typeflag: tar.TypeLink}}
+	}
+	testCases := map[string]struct {
+		entries   []tarEntry
+		wantFiles map[string]string
+		wantLinks map[string]string
+		wantError bool
+	}{
+		"relative symlink inside the directory": {
+			entries:   []tarEntry{dir("base/"), file("base/ns.yaml", "kind: Namespace\n"), symlink("overlay/ns.yaml", "../base/ns.yaml")},
+			wantFiles: map[string]string{"overlay/ns.yaml": "kind: Namespace\n"},
+			wantLinks: map[string]string{"overlay/ns.yaml": "../base/ns.yaml"},
+		},
+		"absolute symlink": {
+			entries:   []tarEntry{symlink("passwd", "/etc/passwd")},
+			wantError: true,
+		},
+		"symlink outside of the directory": {
+			entries:   []tarEntry{symlink("sub/escape", "../../escape")},
+			wantError: true,
+		},
+		"symlink outside of the directory through a later symlink": {
+			entries:   []tarEntry{symlink("escape", "a/b/../.."), dir("a/"), symlink("a/b", "..")},
+			wantError: true,
+		},
+		"file written through a symlink": {
+			entries:   []tarEntry{dir("sub/"), symlink("link", "sub"), file("link/ns.yaml", "kind: Namespace\n")},
+			wantError: true,
+		},
+		"file written over a symlink": {
+			entries:   []tarEntry{file("ns.yaml", "kind: Namespace\n"), symlink("link.yaml", "ns.yaml"), file("link.yaml", "kind: Role\n")},
+			wantError: true,
+		},
+		"hard link inside the directory": {
+			entries:   []tarEntry{file("base/ns.yaml", "kind: Namespace\n"), hardLink("overlay/ns.yaml", "base/ns.yaml")},
+			wantFiles: map[string]string{"overlay/ns.yaml": "kind: Namespace\n"},
+		},
+		"hard link outside of the directory": {
+			entries:   []tarEntry{hardLink("passwd", "../../etc/passwd")},
+			wantError: true,
+		},
+		"absolute hard link": {
+			entries:   []tarEntry{hardLink("passwd", "/etc/passwd")},
+			wantError: true,
+		},
+		"hard link to a symlink": {
+			entries:   []tarEntry{file("ns.yaml", "kind: Namespace\n"), symlink("link.yaml", "ns.yaml"), hardLink("hard.yaml", "link.yaml")},
+			wantError: true,
+		},
+		"hard link to a missing file": {
+			entries:   []tarEntry{hardLink("ns.yaml", "missing.yaml")},
+			wantError: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tarWriter := tar.NewWriter(&buf)
+			for _, entry := range tc.entries {
+				require.NoError(t, tarWriter.WriteHeader(entry.header))
+				_, err := tarWriter.Write([]byte(entry.content))
+				require.NoError(t, err)
+			}
+			require.NoError(t, tarWriter.Close())
+			root := t.TempDir()
+			dir := filepath.Join(root, "dir")
+			err := ExtractTar(&buf, dir)
+			if tc.wantError {
+				assert.Error(t, err)
+				assert.NoFileExists(t, filepath.Join(root, "escape"))
+				return
+			}
+			require.NoError(t, err)
+			for path, want := range tc.wantFiles {
+				got, err := os.ReadFile(filepath.Join(dir, path))
+				require.NoError(t, err)
+				assert.Equal(t, want, string(got))
+				info, err := os.Lstat(filepath.Join(dir, path))
+				require.NoError(t, err)
+				_, isLink := tc.wantLinks[path]
+				assert.Equal(t, isLink, info.Mode()&os.ModeSymlink != 0)
+			}
+			for path, want := range tc.wantLinks {
+				got, err := os.Readlink(filepath.Join(dir, path))
+				require.NoError(t, err)
+				assert.Equal(t, want, got)
+			}
+		})
+	}
+}
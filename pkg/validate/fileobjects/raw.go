@@ -48,6 +48,15 @@ type Raw struct {
 	BuildScoper  utildiscovery.BuildScoperFunc
 	Converter    *declared.ValueConverter
 	Scheme       *runtime.Scheme
+	// ClusterLabels are the labels of the cluster sourced from outside the
+	// repo, like the fleet Membership and the ClusterInfo ConfigMap.
+	ClusterLabels map[string]string
+	// SubstitutionEnabled is whether the `${cluster.*}` and `${values.*}`
+	// variables are substituted in the objects.
+	SubstitutionEnabled bool
+	// SubstitutionValues are the values of the `${values.KEY}` variables
+	// substituted in the objects.
+	SubstitutionValues map[string]string
 	// AllowAPICall indicates whether the hydration process can send k8s API
 	// calls. Currently, only dynamic NamespaceSelector requires talking to
 	// k8s-api-server.
@@ -15,6 +15,7 @@
 package fileobjects
 
 import (
+	v1 "github.com/GoogleContainerTools/config-sync/pkg/api/configmanagement/v1"
 	"github.com/GoogleContainerTools/config-sync/pkg/api/configmanagement/v1/repo"
 	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast"
 	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast/node"
@@ -91,6 +92,38 @@ func (t *Tree) Objects() []ast.FileObject {
 	return append(t.Cluster, t.Tree.Flatten()...)
 }
 
+// InheritsNamespaces returns true if a HierarchyConfig enables the inheritance
+// of Namespaces. If so, a Namespace declared in a directory with
+// subdirectories declares the label and annotation defaults of an abstract
+// namespace, instead of a namespace.
+func (t *Tree) InheritsNamespaces() bool {
+	return InheritsNamespaces(t.HierarchyConfigs)
+}
+
+// InheritsNamespaces returns true if one of the HierarchyConfigs enables the
+// inheritance of Namespaces.
+func InheritsNamespaces(hierarchyConfigs []ast.FileObject) bool {
+	inherits := false
+	for _, obj := range hierarchyConfigs {
+		s, err := obj.Structured()
+		if err != nil {
+			// The HierarchyConfig validator reports the error.
+			continue
+		}
+		for _, r := range s.(*v1.HierarchyConfig).Spec.Resources {
+			if r.Group != kinds.Namespace().Group {
+				continue
+			}
+			for _, k := range r.Kinds {
+				if k == kinds.Namespace().Kind {
+					inherits = r.HierarchyMode != v1.HierarchyModeNone
+				}
+			}
+		}
+	}
+	return inherits
+}
+
 // BuildTree builds a Tree collection of objects from the given Scoped objects.
 func BuildTree(scoped *Scoped) (*Tree, status.MultiError) {
 	var errs status.MultiError
@@ -37,7 +37,7 @@ func ClusterSelectors(objs *fileobjects.Raw) status.MultiError {
 	if errs != nil {
 		return errs
 	}
-	activeSelectors, errs := set.activeSelectors()
+	activeSelectors, errs := set.activeSelectors(objs.ClusterLabels)
 	if errs != nil {
 		return errs
 	}
@@ -184,11 +184,14 @@ func (h *hydratorSet) clusterSelectorObject(object ast.FileObject) status.Error
 	return nil
 }
 
-func (h *hydratorSet) activeSelectors() (map[string]bool, status.MultiError) {
+// activeSelectors evaluates every ClusterSelector against the labels of the
+// cluster. The labels sourced from outside the repo are merged with the labels
+// of the Cluster object declared in the repo, which take precedence.
+func (h *hydratorSet) activeSelectors(externalLabels map[string]string) (map[string]bool, status.MultiError) {
 	activeSels := make(map[string]bool)
-	clusterLabels := labels.Set{}
+	clusterLabels := labels.Merge(nil, externalLabels)
 	if h.cluster != nil {
-		clusterLabels = h.cluster.Labels
+		clusterLabels = labels.Merge(clusterLabels, h.cluster.Labels)
 	}
 
 	var errs status.MultiError
@@ -331,6 +331,47 @@ func TestClusterSelectors(t *testing.T) {
 			},
 			wantErrs: selectors.InvalidSelectorError(k8sobjects.ClusterSelector(), errors.New("")),
 		},
+		{
+			name: "Keep object selected by external cluster labels without Cluster object",
+			objs: &fileobjects.Raw{
+				ClusterName:   prodClusterName,
+				ClusterLabels: map[string]string{"environment": "prod", "region": "eu"},
+				Objects: []ast.FileObject{
+					prodSelector,
+					devSelector,
+					k8sobjects.ClusterRole(core.Name("prod-role"), withProdLegacyClusterSelector),
+					k8sobjects.ClusterRole(core.Name("dev-role"), withDevLegacyClusterSelector),
+				},
+			},
+			want: &fileobjects.Raw{
+				ClusterName:   prodClusterName,
+				ClusterLabels: map[string]string{"environment": "prod", "region": "eu"},
+				Objects: []ast.FileObject{
+					k8sobjects.ClusterRole(core.Name("prod-role"), withProdLegacyClusterSelector),
+				},
+			},
+		},
+		{
+			name: "Cluster object labels take precedence over external cluster labels",
+			objs: &fileobjects.Raw{
+				ClusterName:   devClusterName,
+				ClusterLabels: map[string]string{"environment": "prod"},
+				Objects: []ast.FileObject{
+					devCluster,
+					prodSelector,
+					devSelector,
+					k8sobjects.ClusterRole(core.Name("prod-role"), withProdLegacyClusterSelector),
+					k8sobjects.ClusterRole(core.Name("dev-role"), withDevLegacyClusterSelector),
+				},
+			},
+			want: &fileobjects.Raw{
+				ClusterName:   devClusterName,
+				ClusterLabels: map[string]string{"environment": "prod"},
+				Objects: []ast.FileObject{
+					k8sobjects.ClusterRole(core.Name("dev-role"), withDevLegacyClusterSelector),
+				},
+			},
+		},
 		{
 			name: "Error if ClusterSelector is empty",
 			objs: &fileobjects.Raw{
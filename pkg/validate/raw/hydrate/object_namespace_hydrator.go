@@ -25,11 +25,24 @@ import (
 
 // ObjectNamespaces hydrates the given raw Objects by setting the metadata
 // namespace field for objects that are located in a namespace directory but do
-// not have their namespace specified yet.
+// not have their namespace specified yet. If Namespaces are inherited, the
+// Namespaces in directories with subdirectories declare abstract namespaces,
+// so their directories are not namespace directories.
 func ObjectNamespaces(objs *fileobjects.Raw) status.MultiError {
+	var hierarchyConfigs []ast.FileObject
+	for _, obj := range objs.Objects {
+		if obj.GetObjectKind().GroupVersionKind() == kinds.HierarchyConfig() {
+			hierarchyConfigs = append(hierarchyConfigs, obj)
+		}
+	}
+	var abstractDirs map[string]bool
+	if fileobjects.InheritsNamespaces(hierarchyConfigs) {
+		abstractDirs = parentDirs(objs.Objects)
+	}
+
 	namespaces := make(map[string]bool)
 	for _, obj := range objs.Objects {
-		if isValidHierarchicalNamespace(obj) {
+		if isValidHierarchicalNamespace(obj) && !abstractDirs[obj.Dir().SlashPath()] {
 			namespaces[obj.GetName()] = true
 		}
 	}
@@ -54,6 +67,20 @@ func ObjectNamespaces(objs *fileobjects.Raw) status.MultiError {
 	return nil
 }
 
+// parentDirs returns the directories which have a subdirectory with objects.
+func parentDirs(objs []ast.FileObject) map[string]bool {
+	result := make(map[string]bool)
+	for _, obj := range objs {
+		dir := obj.Dir().SlashPath()
+		for i, c := range dir {
+			if c == '/' {
+				result[dir[:i]] = true
+			}
+		}
+	}
+	return result
+}
+
 func isValidHierarchicalNamespace(obj ast.FileObject) bool {
 	if obj.GetObjectKind().GroupVersionKind().GroupKind() != kinds.Namespace().GroupKind() {
 		return false
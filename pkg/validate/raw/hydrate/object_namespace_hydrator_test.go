@@ -17,9 +17,11 @@ package hydrate
 import (
 	"testing"
 
+	v1 "github.com/GoogleContainerTools/config-sync/pkg/api/configmanagement/v1"
 	"github.com/GoogleContainerTools/config-sync/pkg/core"
 	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
 	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast"
+	"github.com/GoogleContainerTools/config-sync/pkg/kinds"
 	"github.com/GoogleContainerTools/config-sync/pkg/validate/fileobjects"
 	"github.com/google/go-cmp/cmp"
 )
@@ -56,6 +58,36 @@ func TestObjectNamespaces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Do not set namespace on object in abstract namespace directory",
+			objs: &fileobjects.Raw{
+				Objects: []ast.FileObject{
+					k8sobjects.Repo(),
+					k8sobjects.HierarchyConfig(
+						k8sobjects.HierarchyConfigKind(v1.HierarchyModeInherit, kinds.Namespace())),
+					k8sobjects.Namespace("namespaces/foo"),
+					k8sobjects.RoleAtPath("namespaces/foo/role.yaml",
+						core.Name("reader")),
+					k8sobjects.Namespace("namespaces/foo/bar"),
+					k8sobjects.RoleBindingAtPath("namespaces/foo/bar/rb.yaml",
+						core.Name("reader-binding")),
+				},
+			},
+			want: &fileobjects.Raw{
+				Objects: []ast.FileObject{
+					k8sobjects.Repo(),
+					k8sobjects.HierarchyConfig(
+						k8sobjects.HierarchyConfigKind(v1.HierarchyModeInherit, kinds.Namespace())),
+					k8sobjects.Namespace("namespaces/foo"),
+					k8sobjects.RoleAtPath("namespaces/foo/role.yaml",
+						core.Name("reader")),
+					k8sobjects.Namespace("namespaces/foo/bar"),
+					k8sobjects.RoleBindingAtPath("namespaces/foo/bar/rb.yaml",
+						core.Name("reader-binding"),
+						core.Namespace("bar")),
+				},
+			},
+		},
 		{
 			// In this case, we have a validator that will catch this error and report
 			// it later. So the main thing here is to make sure that we don't
@@ -0,0 +1,154 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/kinds"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	"github.com/GoogleContainerTools/config-sync/pkg/validate/fileobjects"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	clusterNameVariable = "cluster.name"
+	clusterLabelsPrefix = "cluster.labels."
+	valuesPrefix        = "values."
+)
+
+// variablePattern matches a variable reference like `${cluster.name}`, or an
+// escaped reference like `$${cluster.name}` which is replaced by the literal
+// `${cluster.name}`. Escaped references outside the `cluster` and `values`
+// namespaces are kept as-is.
+var variablePattern = regexp.MustCompile(`\$?\$\{([^{}]*)\}`)
+
+// Variables hydrates the given Raw objects by substituting the variables in
+// string values with the name and labels of the current cluster and the
+// substitution values. References to variables outside the `cluster` and
+// `values` namespaces are left as-is, so that they can be consumed by other
+// tools.
+func Variables(objs *fileobjects.Raw) status.MultiError {
+	vars := &variables{
+		clusterName:   objs.ClusterName,
+		clusterLabels: labels.Merge(nil, objs.ClusterLabels),
+		values:        objs.SubstitutionValues,
+	}
+	// Labels of the Cluster object declared in the repo take precedence, as
+	// they do for cluster selection.
+	for _, obj := range objs.Objects {
+		if obj.GetObjectKind().GroupVersionKind() == kinds.Cluster() && obj.GetName() == objs.ClusterName {
+			vars.clusterLabels = labels.Merge(vars.clusterLabels, obj.GetLabels())
+		}
+	}
+
+	var errs status.MultiError
+	for _, obj := range objs.Objects {
+		undefined := map[string]bool{}
+		obj.Object = vars.substitute(obj.Object, undefined).(map[string]interface{})
+		for _, name := range sortedKeys(undefined) {
+			errs = status.Append(errs, UndefinedVariableError(obj, name))
+		}
+	}
+	return errs
+}
+
+type variables struct {
+	clusterName   string
+	clusterLabels map[string]string
+	values        map[string]string
+}
+
+// substitute returns a copy of the value with the variables substituted in
+// every string it contains. The names of undefined variables are added to
+// undefined.
+func (v *variables) substitute(value interface{}, undefined map[string]bool) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, field := range typed {
+			typed[key] = v.substitute(field, undefined)
+		}
+		return typed
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = v.substitute(item, undefined)
+		}
+		return typed
+	case string:
+		return variablePattern.ReplaceAllStringFunc(typed, func(match string) string {
+			name := variablePattern.FindStringSubmatch(match)[1]
+			result, known, defined := v.lookup(name)
+			if !known {
+				// Leave references to other namespaces, escaped or not, to
+				// the tools that consume them.
+				return match
+			}
+			if strings.HasPrefix(match, "$$") {
+				return match[1:]
+			}
+			if !defined {
+				undefined[name] = true
+				return match
+			}
+			return result
+		})
+	default:
+		return value
+	}
+}
+
+// lookup returns the value of the variable, whether the variable is in a
+// namespace handled by Config Sync, and whether it is defined.
+func (v *variables) lookup(name string) (value string, known, defined bool) {
+	switch {
+	case name == clusterNameVariable:
+		return v.clusterName, true, v.clusterName != ""
+	case strings.HasPrefix(name, clusterLabelsPrefix):
+		value, defined = v.clusterLabels[strings.TrimPrefix(name, clusterLabelsPrefix)]
+		return value, true, defined
+	case strings.HasPrefix(name, valuesPrefix):
+		value, defined = v.values[strings.TrimPrefix(name, valuesPrefix)]
+		return value, true, defined
+	default:
+		return "", false, false
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// UndefinedVariableErrorCode is the code for a reference to a variable that is
+// not defined for the cluster.
+var UndefinedVariableErrorCode = "1071"
+
+var undefinedVariableErrorBuilder = status.NewErrorBuilder(UndefinedVariableErrorCode)
+
+// UndefinedVariableError reports that an object references a variable that is
+// not defined for the cluster. To use the literal text, escape the reference
+// as `$${...}`.
+func UndefinedVariableError(o client.Object, name string) status.Error {
+	return undefinedVariableErrorBuilder.
+		Sprintf("variable %q is not defined for this cluster. Define it, or escape the reference as \"$${%s}\" to keep it as-is", name, name).
+		BuildWithResources(o)
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	"github.com/GoogleContainerTools/config-sync/pkg/validate/fileobjects"
+	"github.com/google/go-cmp/cmp"
+)
+
+// configMapWithData creates a FileObject containing a ConfigMap with the data.
+func configMapWithData(data map[string]string, opts ...core.MetaMutator) ast.FileObject {
+	cm := k8sobjects.ConfigMapObject(opts...)
+	cm.Data = data
+	return k8sobjects.FileObject(cm, "namespaces/foo/configmap.yaml")
+}
+
+func TestVariables(t *testing.T) {
+	testCases := []struct {
+		name     string
+		objs     *fileobjects.Raw
+		want     *fileobjects.Raw
+		wantErrs status.MultiError
+	}{
+		{
+			name: "Substitute cluster name, cluster labels, and values",
+			objs: &fileobjects.Raw{
+				ClusterName:        prodClusterName,
+				ClusterLabels:      map[string]string{"region": "us-east1"},
+				SubstitutionValues: map[string]string{"replicas": "3"},
+				Objects: []ast.FileObject{
+					configMapWithData(map[string]string{
+						"cluster":  "${cluster.name}",
+						"endpoint": "https://${cluster.labels.region}.example.com",
+						"replicas": "${values.replicas}",
+					}, core.Name("${cluster.name}-config")),
+				},
+			},
+			want: &fileobjects.Raw{
+				ClusterName:        prodClusterName,
+				ClusterLabels:      map[string]string{"region": "us-east1"},
+				SubstitutionValues: map[string]string{"replicas": "3"},
+				Objects: []ast.FileObject{
+					configMapWithData(map[string]string{
+						"cluster":  prodClusterName,
+						"endpoint": "https://us-east1.example.com",
+						"replicas": "3",
+					}, core.Name(prodClusterName+"-config")),
+				},
+			},
+		},
+		{
+			name: "Cluster object labels take precedence over external labels",
+			objs: &fileobjects.Raw{
+				ClusterName:   prodClusterName,
+				ClusterLabels: map[string]string{"environment": "staging"},
+				Objects: []ast.FileObject{
+					prodCluster,
+					configMapWithData(map[string]string{"environment": "${cluster.labels.environment}"}),
+				},
+			},
+			want: &fileobjects.Raw{
+				ClusterName:   prodClusterName,
+				ClusterLabels: map[string]string{"environment": "staging"},
+				Objects: []ast.FileObject{
+					prodCluster,
+					configMapWithData(map[string]string{"environment": "prod"}),
+				},
+			},
+		},
+		{
+			name: "Keep escaped and unknown variables",
+			objs: &fileobjects.Raw{
+				ClusterName: prodClusterName,
+				Objects: []ast.FileObject{
+					configMapWithData(map[string]string{
+						"escaped":         "$${cluster.name}",
+						"unknown":         "${HOME}",
+						"escaped-unknown": "$${var.region}",
+					}),
+				},
+			},
+			want: &fileobjects.Raw{
+				ClusterName: prodClusterName,
+				Objects: []ast.FileObject{
+					configMapWithData(map[string]string{
+						"escaped":         "${cluster.name}",
+						"unknown":         "${HOME}",
+						"escaped-unknown": "$${var.region}",
+					}),
+				},
+			},
+		},
+		{
+			name: "Undefined variable",
+			objs: &fileobjects.Raw{
+				ClusterName: prodClusterName,
+				Objects: []ast.FileObject{
+					configMapWithData(map[string]string{"region": "${cluster.labels.region}"}),
+				},
+			},
+			want: &fileobjects.Raw{
+				ClusterName: prodClusterName,
+				Objects: []ast.FileObject{
+					configMapWithData(map[string]string{"region": "${cluster.labels.region}"}),
+				},
+			},
+			wantErrs: UndefinedVariableError(k8sobjects.ConfigMap(), "cluster.labels.region"),
+		},
+		{
+			name: "Undefined cluster name",
+			objs: &fileobjects.Raw{
+				Objects: []ast.FileObject{
+					configMapWithData(map[string]string{"cluster": "${cluster.name}"}),
+				},
+			},
+			want: &fileobjects.Raw{
+				Objects: []ast.FileObject{
+					configMapWithData(map[string]string{"cluster": "${cluster.name}"}),
+				},
+			},
+			wantErrs: UndefinedVariableError(k8sobjects.ConfigMap(), "cluster.name"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := Variables(tc.objs)
+			if !errors.Is(errs, tc.wantErrs) {
+				t.Errorf("Got Variables() error %v, want %v", errs, tc.wantErrs)
+			}
+			if diff := cmp.Diff(tc.want, tc.objs, ast.CompareFileObject); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -26,7 +26,17 @@ import (
 // hierarchical repo against the given Raw objects. Note that this will modify
 // the Raw objects in-place.
 func Hierarchical(objs *fileobjects.Raw) status.MultiError {
+	// Variables are substituted before anything else, so that the objects are
+	// validated as they will be applied. Substitution is opt-in, so that
+	// repos using `${...}` for other tools are synced as-is.
 	var errs status.MultiError
+	if objs.SubstitutionEnabled {
+		errs = hydrate.Variables(objs)
+		if errs != nil {
+			return errs
+		}
+	}
+
 	// Note that the ordering here and in all other collections of validators is
 	// somewhat arbitrary. We always run all validators in a collection before
 	// exiting with any errors. We do put more "fundamental" validation checks
@@ -45,6 +55,9 @@ func Hierarchical(objs *fileobjects.Raw) status.MultiError {
 		fileobjects.VisitAllRaw(validate.Directory),
 		fileobjects.VisitAllRaw(validate.HNCLabels),
 		fileobjects.VisitAllRaw(validate.ManagementAnnotation),
+		fileobjects.VisitAllRaw(validate.DriftPolicyAnnotation),
+		fileobjects.VisitAllRaw(validate.FieldOwnershipAnnotation),
+		fileobjects.VisitAllRaw(validate.TransferToAnnotation(objs.Scope, objs.SyncName)),
 		fileobjects.VisitAllRaw(validate.IllegalCRD),
 		fileobjects.VisitAllRaw(validate.CRDName),
 		fileobjects.VisitAllRaw(validate.SelfReconcile(declared.ReconcilerNameFromScope(objs.Scope, objs.SyncName))),
@@ -89,8 +102,15 @@ func Hierarchical(objs *fileobjects.Raw) status.MultiError {
 // repo against the given Raw objects. Note that this will modify the Raw
 // objects in-place.
 func Unstructured(objs *fileobjects.Raw) status.MultiError {
+	// See the notes about variables and ordering above in Hierarchical().
 	var errs status.MultiError
-	// See the note about ordering above in Hierarchical().
+	if objs.SubstitutionEnabled {
+		errs = hydrate.Variables(objs)
+		if errs != nil {
+			return errs
+		}
+	}
+
 	validators := []fileobjects.RawVisitor{
 		fileobjects.VisitAllRaw(validate.Annotations),
 		fileobjects.VisitAllRaw(validate.Labels),
@@ -99,6 +119,9 @@ func Unstructured(objs *fileobjects.Raw) status.MultiError {
 		fileobjects.VisitAllRaw(validate.Name),
 		fileobjects.VisitAllRaw(validate.Namespace),
 		fileobjects.VisitAllRaw(validate.ManagementAnnotation),
+		fileobjects.VisitAllRaw(validate.DriftPolicyAnnotation),
+		fileobjects.VisitAllRaw(validate.FieldOwnershipAnnotation),
+		fileobjects.VisitAllRaw(validate.TransferToAnnotation(objs.Scope, objs.SyncName)),
 		fileobjects.VisitAllRaw(validate.IllegalCRD),
 		fileobjects.VisitAllRaw(validate.CRDName),
 		fileobjects.VisitAllRaw(validate.SelfReconcile(declared.ReconcilerNameFromScope(objs.Scope, objs.SyncName))),
@@ -0,0 +1,52 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DriftPolicyAnnotation returns an Error if the user-specified drift-policy
+// annotation is invalid.
+func DriftPolicyAnnotation(obj ast.FileObject) status.Error {
+	value, found := obj.GetAnnotations()[metadata.DriftPolicyAnnotationKey]
+	if !found {
+		return nil
+	}
+	switch metadata.DriftPolicy(value) {
+	case metadata.DriftPolicyCorrect, metadata.DriftPolicyReport:
+		return nil
+	default:
+		return IllegalDriftPolicyAnnotationError(obj, value)
+	}
+}
+
+// IllegalDriftPolicyAnnotationErrorCode is the error code for
+// IllegalDriftPolicyAnnotationError.
+var IllegalDriftPolicyAnnotationErrorCode = "1072"
+
+var illegalDriftPolicyAnnotationErrorBuilder = status.NewErrorBuilder(IllegalDriftPolicyAnnotationErrorCode)
+
+// IllegalDriftPolicyAnnotationError reports an illegal drift-policy annotation
+// value.
+func IllegalDriftPolicyAnnotationError(o client.Object, value string) status.Error {
+	return illegalDriftPolicyAnnotationErrorBuilder.
+		Sprintf("Config has invalid drift-policy annotation %s=%s. If set, the value must be %q or %q.",
+			metadata.DriftPolicyAnnotationKey, value, metadata.DriftPolicyCorrect, metadata.DriftPolicyReport).
+		BuildWithResources(o)
+}
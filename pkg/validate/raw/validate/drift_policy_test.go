@@ -0,0 +1,58 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	"github.com/GoogleContainerTools/config-sync/pkg/testing/testerrors"
+)
+
+func TestDriftPolicyAnnotation(t *testing.T) {
+	testCases := []struct {
+		name string
+		obj  ast.FileObject
+		want status.Error
+	}{
+		{
+			name: "no drift-policy annotation passes",
+			obj:  k8sobjects.Role(),
+		},
+		{
+			name: "correct drift-policy passes",
+			obj:  k8sobjects.Role(metadata.WithDriftPolicy(metadata.DriftPolicyCorrect)),
+		},
+		{
+			name: "report drift-policy passes",
+			obj:  k8sobjects.Role(metadata.WithDriftPolicy(metadata.DriftPolicyReport)),
+		},
+		{
+			name: "invalid drift-policy fails",
+			obj:  k8sobjects.Role(metadata.WithDriftPolicy("ignore")),
+			want: IllegalDriftPolicyAnnotationError(k8sobjects.Role(), "ignore"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := DriftPolicyAnnotation(tc.obj)
+			testerrors.AssertEqual(t, tc.want, err)
+		})
+	}
+}
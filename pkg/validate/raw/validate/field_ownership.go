@@ -0,0 +1,53 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// FieldOwnershipAnnotation returns an Error if the user-specified
+// field-ownership annotation is invalid.
+func FieldOwnershipAnnotation(obj ast.FileObject) status.Error {
+	value, found := obj.GetAnnotations()[metadata.FieldOwnershipAnnotationKey]
+	if !found {
+		return nil
+	}
+	if metadata.FieldOwnership(value) != metadata.FieldOwnershipShared {
+		return IllegalFieldOwnershipAnnotationError(obj, value)
+	}
+	if metadata.HasTransferTo(obj) {
+		return IllegalFieldOwnershipAnnotationError(obj, value)
+	}
+	return nil
+}
+
+// IllegalFieldOwnershipAnnotationErrorCode is the error code for
+// IllegalFieldOwnershipAnnotationError.
+var IllegalFieldOwnershipAnnotationErrorCode = "1074"
+
+var illegalFieldOwnershipAnnotationErrorBuilder = status.NewErrorBuilder(IllegalFieldOwnershipAnnotationErrorCode)
+
+// IllegalFieldOwnershipAnnotationError reports an illegal field-ownership
+// annotation value.
+func IllegalFieldOwnershipAnnotationError(o client.Object, value string) status.Error {
+	return illegalFieldOwnershipAnnotationErrorBuilder.
+		Sprintf("Config has invalid field-ownership annotation %s=%s. If set, the value must be %q, and the %s annotation must not be set.",
+			metadata.FieldOwnershipAnnotationKey, value, metadata.FieldOwnershipShared, metadata.TransferToAnnotationKey).
+		BuildWithResources(o)
+}
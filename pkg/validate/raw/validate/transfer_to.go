@@ -0,0 +1,66 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"fmt"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/declared"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	"github.com/GoogleContainerTools/config-sync/pkg/validate/fileobjects"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TransferToAnnotation returns a visitor that returns an Error if the
+// transfer-to annotation of an object doesn't reference another RootSync or
+// RepoSync able to manage the object.
+func TransferToAnnotation(scope declared.Scope, syncName string) fileobjects.ObjectVisitor {
+	return func(obj ast.FileObject) status.Error {
+		value := metadata.GetTransferTo(obj)
+		if value == "" {
+			return nil
+		}
+		toScope, toName, err := declared.ParseSyncRef(value)
+		if err != nil {
+			return IllegalTransferToAnnotationError(obj, value, err.Error())
+		}
+		if toScope == scope && toName == syncName {
+			return IllegalTransferToAnnotationError(obj, value,
+				fmt.Sprintf("the object is already managed by this %s", scope.SyncKind()))
+		}
+		if toScope != declared.RootScope && obj.GetNamespace() != "" && obj.GetNamespace() != toScope.String() {
+			return IllegalTransferToAnnotationError(obj, value,
+				fmt.Sprintf("a RepoSync can only manage objects in its namespace %q", toScope))
+		}
+		return nil
+	}
+}
+
+// IllegalTransferToAnnotationErrorCode is the error code for
+// IllegalTransferToAnnotationError.
+var IllegalTransferToAnnotationErrorCode = "1073"
+
+var illegalTransferToAnnotationErrorBuilder = status.NewErrorBuilder(IllegalTransferToAnnotationErrorCode)
+
+// IllegalTransferToAnnotationError reports an illegal transfer-to annotation
+// value.
+func IllegalTransferToAnnotationError(o client.Object, value, reason string) status.Error {
+	return illegalTransferToAnnotationErrorBuilder.
+		Sprintf("Config has invalid transfer-to annotation %s=%s: %s. If set, the value must be NAME for a RootSync or NAMESPACE/NAME for a RepoSync.",
+			metadata.TransferToAnnotationKey, value, reason).
+		BuildWithResources(o)
+}
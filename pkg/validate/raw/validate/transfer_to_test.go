@@ -0,0 +1,73 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
+	"github.com/GoogleContainerTools/config-sync/pkg/declared"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	"github.com/GoogleContainerTools/config-sync/pkg/testing/testerrors"
+)
+
+func TestTransferToAnnotation(t *testing.T) {
+	testCases := []struct {
+		name string
+		obj  ast.FileObject
+		want status.Error
+	}{
+		{
+			name: "no transfer-to annotation passes",
+			obj:  k8sobjects.Role(),
+		},
+		{
+			name: "transfer to another RootSync passes",
+			obj:  k8sobjects.ClusterRole(metadata.WithTransferTo("platform-sync")),
+		},
+		{
+			name: "transfer to a RepoSync in the namespace of the object passes",
+			obj:  k8sobjects.Role(core.Namespace("bookstore"), metadata.WithTransferTo("bookstore/repo-sync")),
+		},
+		{
+			name: "invalid sync reference fails",
+			obj:  k8sobjects.Role(metadata.WithTransferTo("a/b/c")),
+			want: IllegalTransferToAnnotationError(k8sobjects.Role(), "a/b/c",
+				`invalid sync "a/b/c": must be NAME for a RootSync or NAMESPACE/NAME for a RepoSync`),
+		},
+		{
+			name: "transfer to itself fails",
+			obj:  k8sobjects.Role(metadata.WithTransferTo("root-sync")),
+			want: IllegalTransferToAnnotationError(k8sobjects.Role(), "root-sync",
+				"the object is already managed by this RootSync"),
+		},
+		{
+			name: "transfer to a RepoSync in another namespace fails",
+			obj:  k8sobjects.Role(core.Namespace("bookstore"), metadata.WithTransferTo("shipping/repo-sync")),
+			want: IllegalTransferToAnnotationError(k8sobjects.Role(core.Namespace("bookstore")), "shipping/repo-sync",
+				`a RepoSync can only manage objects in its namespace "shipping"`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := TransferToAnnotation(declared.RootScope, "root-sync")(tc.obj)
+			testerrors.AssertEqual(t, tc.want, err)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DriftPolicyAnnotation returns an Error if the user-specified drift-policy
+// annotation is invalid.
+func DriftPolicyAnnotation(syncObj client.Object, syncKind string) status.Error {
+	value, found := syncObj.GetAnnotations()[metadata.DriftPolicyAnnotationKey]
+	if !found {
+		return nil
+	}
+	switch metadata.DriftPolicy(value) {
+	case metadata.DriftPolicyCorrect, metadata.DriftPolicyReport:
+		return nil
+	default:
+		return NewDriftPolicyAnnotationError(syncKind)
+	}
+}
+
+// NewDriftPolicyAnnotationError returns an error for an invalid drift-policy
+// annotation.
+func NewDriftPolicyAnnotationError(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss which specify the annotation %q must use one of the values %q or %q",
+			syncKind, metadata.DriftPolicyAnnotationKey,
+			metadata.DriftPolicyCorrect,
+			metadata.DriftPolicyReport).
+		Build()
+}
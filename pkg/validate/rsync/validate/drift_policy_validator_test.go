@@ -0,0 +1,67 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	"github.com/GoogleContainerTools/config-sync/pkg/testing/testerrors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestDriftPolicyAnnotation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		obj      client.Object
+		syncKind string
+		wantErr  status.Error
+	}{
+		{
+			name:     "RootSync no annotation passes",
+			obj:      k8sobjects.RootSyncObjectV1Beta1("test-name"),
+			syncKind: configsync.RootSyncKind,
+		},
+		{
+			name: "RootSync report passes",
+			obj: k8sobjects.RootSyncObjectV1Beta1("test-name",
+				metadata.WithDriftPolicy(metadata.DriftPolicyReport)),
+			syncKind: configsync.RootSyncKind,
+		},
+		{
+			name: "RepoSync correct passes",
+			obj: k8sobjects.RepoSyncObjectV1Beta1("test-ns", "test-name",
+				metadata.WithDriftPolicy(metadata.DriftPolicyCorrect)),
+			syncKind: configsync.RepoSyncKind,
+		},
+		{
+			name: "RepoSync invalid annotation fails",
+			obj: k8sobjects.RepoSyncObjectV1Beta1("test-ns", "test-name",
+				metadata.WithDriftPolicy("ignore")),
+			syncKind: configsync.RepoSyncKind,
+			wantErr:  NewDriftPolicyAnnotationError(configsync.RepoSyncKind),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := DriftPolicyAnnotation(tc.obj, tc.syncKind)
+			testerrors.AssertEqual(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"strconv"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ForceConflictsAnnotation validates the value of the force-conflicts
+// annotation, if specified.
+func ForceConflictsAnnotation(syncObj client.Object, syncKind string) status.Error {
+	value, found := syncObj.GetAnnotations()[metadata.ForceConflictsAnnotationKey]
+	if !found {
+		return nil
+	}
+	if _, err := strconv.ParseBool(value); err != nil {
+		return NewForceConflictsAnnotationError(syncKind)
+	}
+	return nil
+}
+
+// NewForceConflictsAnnotationError returns an error when the force-conflicts
+// annotation is not a boolean.
+func NewForceConflictsAnnotationError(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss which specify the annotation %q must use one of the values %q or %q",
+			syncKind, metadata.ForceConflictsAnnotationKey, "true", "false").
+		Build()
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync/v1beta1"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PrunePolicySpec validates the rules and the safeguard of the prune policy,
+// if specified.
+func PrunePolicySpec(policy *v1beta1.PrunePolicy, syncKind string) status.Error {
+	if policy == nil {
+		return nil
+	}
+	if safeguard := policy.Safeguard; safeguard != nil {
+		if safeguard.MaxDeletions != nil && *safeguard.MaxDeletions < 0 {
+			return InvalidPruneSafeguardMaxDeletions(syncKind)
+		}
+		if safeguard.MaxDeletionPercent != nil && (*safeguard.MaxDeletionPercent < 0 || *safeguard.MaxDeletionPercent > 100) {
+			return InvalidPruneSafeguardMaxDeletionPercent(syncKind)
+		}
+	}
+	for i, rule := range policy.Rules {
+		switch rule.Action {
+		case configsync.PruneActionPrune, configsync.PruneActionOrphan, configsync.PruneActionBlock:
+		default:
+			return InvalidPruneRuleAction(i, syncKind)
+		}
+		if rule.LabelSelector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(rule.LabelSelector); err != nil {
+				return InvalidPruneRuleLabelSelector(i, err, syncKind)
+			}
+		}
+	}
+	return nil
+}
+
+// InvalidPruneRuleAction reports that a prune rule has an invalid action.
+func InvalidPruneRuleAction(index int, syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.prunePolicy.rules[%d].action as one of %q, %q, or %q",
+			syncKind, index, configsync.PruneActionPrune, configsync.PruneActionOrphan, configsync.PruneActionBlock).
+		Build()
+}
+
+// InvalidPruneRuleLabelSelector reports that a prune rule has an invalid
+// label selector.
+func InvalidPruneRuleLabelSelector(index int, err error, syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify a valid spec.prunePolicy.rules[%d].labelSelector", syncKind, index).
+		Wrap(err).
+		Build()
+}
+
+// InvalidPruneSafeguardMaxDeletions reports that the prune safeguard has a
+// negative maxDeletions.
+func InvalidPruneSafeguardMaxDeletions(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must not specify a negative spec.prunePolicy.safeguard.maxDeletions", syncKind).
+		Build()
+}
+
+// InvalidPruneSafeguardMaxDeletionPercent reports that the prune safeguard has
+// a maxDeletionPercent out of range.
+func InvalidPruneSafeguardMaxDeletionPercent(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.prunePolicy.safeguard.maxDeletionPercent between 0 and 100", syncKind).
+		Build()
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync/v1beta1"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	"github.com/GoogleContainerTools/config-sync/pkg/testing/testerrors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestPrunePolicySpec(t *testing.T) {
+	testCases := []struct {
+		name    string
+		policy  *v1beta1.PrunePolicy
+		wantErr status.Error
+	}{
+		{
+			name: "no policy passes",
+		},
+		{
+			name: "valid rules pass",
+			policy: &v1beta1.PrunePolicy{
+				Rules: []v1beta1.PruneRule{
+					{Kind: "Namespace", Action: configsync.PruneActionBlock},
+					{
+						Kind:   "PersistentVolumeClaim",
+						Action: configsync.PruneActionOrphan,
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"tier": "data"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid action fails",
+			policy: &v1beta1.PrunePolicy{
+				Rules: []v1beta1.PruneRule{
+					{Kind: "Namespace", Action: configsync.PruneActionBlock},
+					{Kind: "PersistentVolumeClaim", Action: "keep"},
+				},
+			},
+			wantErr: InvalidPruneRuleAction(1, configsync.RootSyncKind),
+		},
+		{
+			name: "invalid label selector fails",
+			policy: &v1beta1.PrunePolicy{
+				Rules: []v1beta1.PruneRule{{
+					Action: configsync.PruneActionBlock,
+					LabelSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Near"}},
+					},
+				}},
+			},
+			wantErr: InvalidPruneRuleLabelSelector(0, errors.New(`"Near" is not a valid label selector operator`), configsync.RootSyncKind),
+		},
+		{
+			name: "valid safeguard passes",
+			policy: &v1beta1.PrunePolicy{
+				Safeguard: &v1beta1.PruneSafeguard{
+					MaxDeletions:       ptr.To[int32](0),
+					MaxDeletionPercent: ptr.To[int32](100),
+				},
+			},
+		},
+		{
+			name: "negative max deletions fails",
+			policy: &v1beta1.PrunePolicy{
+				Safeguard: &v1beta1.PruneSafeguard{MaxDeletions: ptr.To[int32](-1)},
+			},
+			wantErr: InvalidPruneSafeguardMaxDeletions(configsync.RootSyncKind),
+		},
+		{
+			name: "max deletion percent above 100 fails",
+			policy: &v1beta1.PrunePolicy{
+				Safeguard: &v1beta1.PruneSafeguard{MaxDeletionPercent: ptr.To[int32](101)},
+			},
+			wantErr: InvalidPruneSafeguardMaxDeletionPercent(configsync.RootSyncKind),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := PrunePolicySpec(tc.policy, configsync.RootSyncKind)
+			testerrors.AssertEqual(t, tc.wantErr, err)
+		})
+	}
+}
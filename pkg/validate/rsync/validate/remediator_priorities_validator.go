@@ -0,0 +1,45 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/GoogleContainerTools/config-sync/pkg/remediator/queue"
+	"github.com/GoogleContainerTools/config-sync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RemediatorPrioritiesAnnotation validates the value of the
+// remediator-priorities annotation, if specified.
+func RemediatorPrioritiesAnnotation(syncObj client.Object, syncKind string) status.Error {
+	value, found := syncObj.GetAnnotations()[metadata.RemediatorPrioritiesAnnotationKey]
+	if !found {
+		return nil
+	}
+	if _, err := queue.ParsePriorities(value); err != nil {
+		return NewRemediatorPrioritiesAnnotationError(syncKind, err)
+	}
+	return nil
+}
+
+// NewRemediatorPrioritiesAnnotationError returns an error when the
+// remediator-priorities annotation is invalid.
+func NewRemediatorPrioritiesAnnotationError(syncKind string, err error) status.Error {
+	return invalidSyncBuilder.
+		Wrap(err).
+		Sprintf("%ss which specify the annotation %q must use comma-separated Kind.group=priority pairs",
+			syncKind, metadata.RemediatorPrioritiesAnnotationKey).
+		Build()
+}
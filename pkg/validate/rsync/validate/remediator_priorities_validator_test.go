@@ -0,0 +1,65 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
+	"github.com/GoogleContainerTools/config-sync/pkg/core"
+	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRemediatorPrioritiesAnnotation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		obj      client.Object
+		syncKind string
+		wantErr  bool
+	}{
+		{
+			name:     "RootSync no annotation passes",
+			obj:      k8sobjects.RootSyncObjectV1Beta1("test-name"),
+			syncKind: configsync.RootSyncKind,
+		},
+		{
+			name: "RootSync valid priorities pass",
+			obj: k8sobjects.RootSyncObjectV1Beta1("test-name",
+				core.Annotation(metadata.RemediatorPrioritiesAnnotationKey, "ClusterRole.rbac.authorization.k8s.io=10,Namespace=20")),
+			syncKind: configsync.RootSyncKind,
+		},
+		{
+			name: "RepoSync invalid priorities fail",
+			obj: k8sobjects.RepoSyncObjectV1Beta1("test-ns", "test-name",
+				core.Annotation(metadata.RemediatorPrioritiesAnnotationKey, "RoleBinding.rbac.authorization.k8s.io=high")),
+			syncKind: configsync.RepoSyncKind,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := RemediatorPrioritiesAnnotation(tc.obj, tc.syncKind)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ package validate
 
 import (
 	"context"
+	"net/url"
 	"strings"
 
 	"github.com/GoogleContainerTools/config-sync/pkg/api/configsync"
@@ -47,6 +48,19 @@ func HelmValuesFileDataKeyOrDefault(key string) string {
 	return key
 }
 
+// HelmPostRenderDefaultDataKey is the default data key to use when
+// spec.helm.postRender.patchesFrom.dataKey is not specified.
+const HelmPostRenderDefaultDataKey = "patches.yaml"
+
+// HelmPostRenderDataKeyOrDefault returns the key or the default if the key is
+// empty.
+func HelmPostRenderDataKeyOrDefault(key string) string {
+	if len(key) == 0 {
+		return HelmPostRenderDefaultDataKey
+	}
+	return key
+}
+
 // RepoSyncSpec validates the RepoSync source specification.
 func RepoSyncSpec(spec v1beta1.RepoSyncSpec) status.Error {
 	syncKind := configsync.RepoSyncKind
@@ -59,6 +73,14 @@ func RepoSyncSpec(spec v1beta1.RepoSyncSpec) status.Error {
 		if err := OciSpec(spec.Oci, syncKind); err != nil {
 			return err
 		}
+	case configsync.HTTPSource:
+		if err := HTTPSpec(spec.HTTP, syncKind); err != nil {
+			return err
+		}
+	case configsync.BucketSource:
+		if err := BucketSpec(spec.Bucket, syncKind); err != nil {
+			return err
+		}
 	case configsync.HelmSource:
 		if err := RepoSyncHelmSpec(spec.Helm); err != nil {
 			return err
@@ -66,7 +88,10 @@ func RepoSyncSpec(spec v1beta1.RepoSyncSpec) status.Error {
 	default:
 		return InvalidSourceType(syncKind)
 	}
-	return RepoSyncOverrideSpec(spec.Override)
+	if err := RepoSyncOverrideSpec(spec.Override); err != nil {
+		return err
+	}
+	return PrunePolicySpec(spec.PrunePolicy, syncKind)
 }
 
 // RootSyncSpec validates the RootSync source specification.
@@ -81,6 +106,14 @@ func RootSyncSpec(spec v1beta1.RootSyncSpec) status.Error {
 		if err := OciSpec(spec.Oci, syncKind); err != nil {
 			return err
 		}
+	case configsync.HTTPSource:
+		if err := HTTPSpec(spec.HTTP, syncKind); err != nil {
+			return err
+		}
+	case configsync.BucketSource:
+		if err := BucketSpec(spec.Bucket, syncKind); err != nil {
+			return err
+		}
 	case configsync.HelmSource:
 		if err := RootSyncHelmSpec(spec.Helm); err != nil {
 			return err
@@ -88,7 +121,10 @@ func RootSyncSpec(spec v1beta1.RootSyncSpec) status.Error {
 	default:
 		return InvalidSourceType(syncKind)
 	}
-	return RootSyncOverrideSpec(spec.Override)
+	if err := RootSyncOverrideSpec(spec.Override); err != nil {
+		return err
+	}
+	return PrunePolicySpec(spec.PrunePolicy, syncKind)
 }
 
 // GitSpec validates the git specification.
@@ -177,6 +213,70 @@ func OciSpec(oci *v1beta1.Oci, syncKind string) status.Error {
 	return nil
 }
 
+// HTTPSpec validates the HTTP specification.
+func HTTPSpec(httpSpec *v1beta1.HTTP, syncKind string) status.Error {
+	if httpSpec == nil {
+		return MissingHTTPSpec(syncKind)
+	}
+
+	// We can't download the archive if we don't have the URL.
+	if httpSpec.URL == "" {
+		return MissingHTTPURL(syncKind)
+	}
+	if u, err := url.Parse(httpSpec.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return InvalidHTTPURL(syncKind)
+	}
+
+	// Ensure auth is a valid value.
+	// Note that Auth is a case-sensitive field, so ones with arbitrary capitalization
+	// will fail to apply.
+	switch httpSpec.Auth {
+	case configsync.AuthNone:
+		if httpSpec.SecretRef != nil && httpSpec.SecretRef.Name != "" {
+			return IllegalSecretRef(configsync.HTTPSource, syncKind)
+		}
+	case configsync.AuthToken:
+		if httpSpec.SecretRef == nil || httpSpec.SecretRef.Name == "" {
+			return MissingSecretRef(configsync.HTTPSource, syncKind)
+		}
+	default:
+		return InvalidHTTPAuthType(syncKind)
+	}
+	return nil
+}
+
+// BucketSpec validates the Bucket specification.
+func BucketSpec(bucket *v1beta1.Bucket, syncKind string) status.Error {
+	if bucket == nil {
+		return MissingBucketSpec(syncKind)
+	}
+
+	// We can't list the objects if we don't have the endpoint and the bucket.
+	if bucket.Endpoint == "" {
+		return MissingBucketEndpoint(syncKind)
+	}
+	if bucket.Name == "" {
+		return MissingBucketName(syncKind)
+	}
+
+	// Ensure auth is a valid value.
+	// Note that Auth is a case-sensitive field, so ones with arbitrary capitalization
+	// will fail to apply.
+	switch bucket.Auth {
+	case configsync.AuthNone:
+		if bucket.SecretRef != nil && bucket.SecretRef.Name != "" {
+			return IllegalSecretRef(configsync.BucketSource, syncKind)
+		}
+	case configsync.AuthToken:
+		if bucket.SecretRef == nil || bucket.SecretRef.Name == "" {
+			return MissingSecretRef(configsync.BucketSource, syncKind)
+		}
+	default:
+		return InvalidBucketAuthType(syncKind)
+	}
+	return nil
+}
+
 // RootSyncHelmSpec validates the RootSync Helm specification.
 func RootSyncHelmSpec(helm *v1beta1.HelmRootSync) status.Error {
 	syncKind := configsync.RootSyncKind
@@ -249,6 +349,17 @@ func HelmSpec(helm *v1beta1.HelmBase, syncKind string) status.Error {
 		}
 	}
 
+	if helm.PostRender != nil {
+		for _, patch := range helm.PostRender.Patches {
+			if patch.Patch == "" {
+				return MissingHelmPostRenderPatch(syncKind)
+			}
+		}
+		if helm.PostRender.PatchesFrom != nil && helm.PostRender.PatchesFrom.Name == "" {
+			return MissingHelmPostRenderPatchesFromName(syncKind)
+		}
+	}
+
 	return nil
 }
 
@@ -345,7 +456,16 @@ func RootSyncMetadata(rs *v1beta1.RootSync) status.Error {
 	if err := RootSyncName(rs); err != nil {
 		return err
 	}
-	return DeletionPropagationAnnotation(rs, "RootSync")
+	if err := DeletionPropagationAnnotation(rs, "RootSync"); err != nil {
+		return err
+	}
+	if err := DriftPolicyAnnotation(rs, "RootSync"); err != nil {
+		return err
+	}
+	if err := RemediatorPrioritiesAnnotation(rs, "RootSync"); err != nil {
+		return err
+	}
+	return ForceConflictsAnnotation(rs, "RootSync")
 }
 
 // RepoSyncName validates RepoSync NN length.
@@ -367,7 +487,16 @@ func RepoSyncMetadata(rs *v1beta1.RepoSync) status.Error {
 		return err
 	}
 
-	return DeletionPropagationAnnotation(rs, "RepoSync")
+	if err := DeletionPropagationAnnotation(rs, "RepoSync"); err != nil {
+		return err
+	}
+	if err := DriftPolicyAnnotation(rs, "RepoSync"); err != nil {
+		return err
+	}
+	if err := RemediatorPrioritiesAnnotation(rs, "RepoSync"); err != nil {
+		return err
+	}
+	return ForceConflictsAnnotation(rs, "RepoSync")
 }
 
 // InvalidSyncCode is the code for an invalid declared RootSync/RepoSync.
@@ -528,7 +657,7 @@ func validGCPServiceAccountEmail(email string) bool {
 // supported source types.
 func InvalidSourceType(syncKind string) status.Error {
 	return invalidSyncBuilder.
-		Sprintf("%ss must specify spec.sourceType to be one of %q, %q, %q", syncKind, configsync.GitSource, configsync.OciSource, configsync.HelmSource).
+		Sprintf("%ss must specify spec.sourceType to be one of %q, %q, %q, %q, %q", syncKind, configsync.GitSource, configsync.OciSource, configsync.HelmSource, configsync.HTTPSource, configsync.BucketSource).
 		Build()
 }
 
@@ -593,6 +722,74 @@ func InvalidOciAuthType(syncKind string) status.Error {
 		Build()
 }
 
+// MissingHTTPSpec reports that a RootSync/RepoSync doesn't declare the HTTP spec
+// when spec.sourceType is set to `http`.
+func MissingHTTPSpec(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.http when spec.sourceType is %q", syncKind, configsync.HTTPSource).
+		Build()
+}
+
+// MissingHTTPURL reports that a RootSync/RepoSync doesn't declare the URL of
+// the archive it is supposed to download.
+func MissingHTTPURL(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.http.url when spec.sourceType is %q", syncKind, configsync.HTTPSource).
+		Build()
+}
+
+// InvalidHTTPURL reports that a RootSync/RepoSync declares an archive URL that
+// is not an absolute HTTP(S) URL.
+func InvalidHTTPURL(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.http.url as an absolute URL with the http or https scheme", syncKind).
+		Build()
+}
+
+// InvalidHTTPAuthType reports that a RootSync/RepoSync doesn't use one of the
+// known auth methods for HTTP archives.
+func InvalidHTTPAuthType(syncKind string) status.Error {
+	types := []string{string(configsync.AuthToken), string(configsync.AuthNone)}
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.http.auth to be one of %s", syncKind,
+			strings.Join(types, ",")).
+		Build()
+}
+
+// MissingBucketSpec reports that a RootSync/RepoSync doesn't declare the Bucket
+// spec when spec.sourceType is set to `bucket`.
+func MissingBucketSpec(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.bucket when spec.sourceType is %q", syncKind, configsync.BucketSource).
+		Build()
+}
+
+// MissingBucketEndpoint reports that a RootSync/RepoSync doesn't declare the
+// endpoint of the bucket it is supposed to sync from.
+func MissingBucketEndpoint(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.bucket.endpoint when spec.sourceType is %q", syncKind, configsync.BucketSource).
+		Build()
+}
+
+// MissingBucketName reports that a RootSync/RepoSync doesn't declare the name
+// of the bucket it is supposed to sync from.
+func MissingBucketName(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.bucket.name when spec.sourceType is %q", syncKind, configsync.BucketSource).
+		Build()
+}
+
+// InvalidBucketAuthType reports that a RootSync/RepoSync doesn't use one of the
+// known auth methods for buckets.
+func InvalidBucketAuthType(syncKind string) status.Error {
+	types := []string{string(configsync.AuthToken), string(configsync.AuthNone)}
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.bucket.auth to be one of %s", syncKind,
+			strings.Join(types, ",")).
+		Build()
+}
+
 // MissingHelmSpec reports that a RootSync/RepoSync doesn't declare the Helm spec
 // when spec.sourceType is set to `helm`.
 func MissingHelmSpec(syncKind string) status.Error {
@@ -642,6 +839,22 @@ func MissingHelmValuesFileRefsName(syncKind string) status.Error {
 		Build()
 }
 
+// MissingHelmPostRenderPatch reports that an RSync has an empty
+// spec.helm.postRender.patches.patch
+func MissingHelmPostRenderPatch(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.helm.postRender.patches.patch", syncKind).
+		Build()
+}
+
+// MissingHelmPostRenderPatchesFromName reports that an RSync is missing
+// spec.helm.postRender.patchesFrom.name
+func MissingHelmPostRenderPatchesFromName(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.helm.postRender.patchesFrom.name", syncKind).
+		Build()
+}
+
 // HelmValuesMissingConfigMap reports that an RSync is referencing a ConfigMap that doesn't exist.
 func HelmValuesMissingConfigMap(syncKind string, err error) status.Error {
 	return invalidSyncBuilder.
@@ -128,6 +128,33 @@ func repoSyncWithOci(opts ...func(*v1beta1.RepoSync)) *v1beta1.RepoSync {
 	return rs
 }
 
+func repoSyncWithHTTP(opts ...func(*v1beta1.RepoSync)) *v1beta1.RepoSync {
+	rs := k8sobjects.RepoSyncObjectV1Beta1("test-ns", configsync.RepoSyncName)
+	rs.Spec.SourceType = configsync.HTTPSource
+	rs.Spec.HTTP = &v1beta1.HTTP{
+		URL:  "https://artifacts.example.com/configs.tar.gz",
+		Auth: configsync.AuthNone,
+	}
+	for _, opt := range opts {
+		opt(rs)
+	}
+	return rs
+}
+
+func repoSyncWithBucket(opts ...func(*v1beta1.RepoSync)) *v1beta1.RepoSync {
+	rs := k8sobjects.RepoSyncObjectV1Beta1("test-ns", configsync.RepoSyncName)
+	rs.Spec.SourceType = configsync.BucketSource
+	rs.Spec.Bucket = &v1beta1.Bucket{
+		Endpoint: "storage.googleapis.com",
+		Name:     "configs",
+		Auth:     configsync.AuthNone,
+	}
+	for _, opt := range opts {
+		opt(rs)
+	}
+	return rs
+}
+
 func repoSyncWithHelm(opts ...func(*v1beta1.RepoSync)) *v1beta1.RepoSync {
 	rs := k8sobjects.RepoSyncObjectV1Beta1("test-ns", configsync.RepoSyncName)
 	rs.Spec.SourceType = configsync.HelmSource
@@ -504,6 +531,91 @@ func TestValidateRepoSyncSpec(t *testing.T) {
 			}),
 			wantErr: MissingSecretRef(configsync.OciSource, configsync.RepoSyncKind),
 		},
+		// Validate HTTP spec
+		{
+			name: "valid http",
+			obj:  repoSyncWithHTTP(),
+		},
+		{
+			name:    "missing http spec",
+			obj:     repoSyncWithHTTP(func(rs *v1beta1.RepoSync) { rs.Spec.HTTP = nil }),
+			wantErr: MissingHTTPSpec(configsync.RepoSyncKind),
+		},
+		{
+			name:    "missing http url",
+			obj:     repoSyncWithHTTP(func(rs *v1beta1.RepoSync) { rs.Spec.HTTP.URL = "" }),
+			wantErr: MissingHTTPURL(configsync.RepoSyncKind),
+		},
+		{
+			name:    "http url without http scheme",
+			obj:     repoSyncWithHTTP(func(rs *v1beta1.RepoSync) { rs.Spec.HTTP.URL = "ftp://example.com/configs.tar.gz" }),
+			wantErr: InvalidHTTPURL(configsync.RepoSyncKind),
+		},
+		{
+			name:    "invalid http auth type",
+			obj:     repoSyncWithHTTP(func(rs *v1beta1.RepoSync) { rs.Spec.HTTP.Auth = configsync.AuthGCENode }),
+			wantErr: InvalidHTTPAuthType(configsync.RepoSyncKind),
+		},
+		{
+			name: "spec.http.auth=token and valid spec.http.secretRef",
+			obj: repoSyncWithHTTP(func(rs *v1beta1.RepoSync) {
+				rs.Spec.HTTP.Auth = configsync.AuthToken
+				rs.Spec.HTTP.SecretRef = &v1beta1.SecretReference{Name: "http-creds"}
+			}),
+		},
+		{
+			name: "spec.http.auth=token and nil spec.http.secretRef",
+			obj: repoSyncWithHTTP(func(rs *v1beta1.RepoSync) {
+				rs.Spec.HTTP.Auth = configsync.AuthToken
+			}),
+			wantErr: MissingSecretRef(configsync.HTTPSource, configsync.RepoSyncKind),
+		},
+		{
+			name: "spec.http.auth=none and illegal spec.http.secretRef",
+			obj: repoSyncWithHTTP(func(rs *v1beta1.RepoSync) {
+				rs.Spec.HTTP.SecretRef = &v1beta1.SecretReference{Name: "http-creds"}
+			}),
+			wantErr: IllegalSecretRef(configsync.HTTPSource, configsync.RepoSyncKind),
+		},
+		// Validate Bucket spec
+		{
+			name: "valid bucket",
+			obj:  repoSyncWithBucket(),
+		},
+		{
+			name:    "missing bucket spec",
+			obj:     repoSyncWithBucket(func(rs *v1beta1.RepoSync) { rs.Spec.Bucket = nil }),
+			wantErr: MissingBucketSpec(configsync.RepoSyncKind),
+		},
+		{
+			name:    "missing bucket endpoint",
+			obj:     repoSyncWithBucket(func(rs *v1beta1.RepoSync) { rs.Spec.Bucket.Endpoint = "" }),
+			wantErr: MissingBucketEndpoint(configsync.RepoSyncKind),
+		},
+		{
+			name:    "missing bucket name",
+			obj:     repoSyncWithBucket(func(rs *v1beta1.RepoSync) { rs.Spec.Bucket.Name = "" }),
+			wantErr: MissingBucketName(configsync.RepoSyncKind),
+		},
+		{
+			name:    "invalid bucket auth type",
+			obj:     repoSyncWithBucket(func(rs *v1beta1.RepoSync) { rs.Spec.Bucket.Auth = configsync.AuthGCPServiceAccount }),
+			wantErr: InvalidBucketAuthType(configsync.RepoSyncKind),
+		},
+		{
+			name: "spec.bucket.auth=token and nil spec.bucket.secretRef",
+			obj: repoSyncWithBucket(func(rs *v1beta1.RepoSync) {
+				rs.Spec.Bucket.Auth = configsync.AuthToken
+			}),
+			wantErr: MissingSecretRef(configsync.BucketSource, configsync.RepoSyncKind),
+		},
+		{
+			name: "spec.bucket.auth=none and illegal spec.bucket.secretRef",
+			obj: repoSyncWithBucket(func(rs *v1beta1.RepoSync) {
+				rs.Spec.Bucket.SecretRef = &v1beta1.SecretReference{Name: "hmac-key"}
+			}),
+			wantErr: IllegalSecretRef(configsync.BucketSource, configsync.RepoSyncKind),
+		},
 		// Validate Helm spec
 		{
 			name: "valid helm",
@@ -647,6 +759,33 @@ func TestValidateRootSyncSpec(t *testing.T) {
 			obj:     rootSyncWithHelm(func(rs *v1beta1.RootSync) { rs.Spec.Helm.Chart = "foo/bar" }),
 			wantErr: IllegalHelmChartName(configsync.RootSyncKind),
 		},
+		{
+			name: "missing helm post-render patch",
+			obj: rootSyncWithHelm(func(rs *v1beta1.RootSync) {
+				rs.Spec.Helm.PostRender = &v1beta1.HelmPostRender{
+					Patches: []v1beta1.HelmPatch{{Target: &v1beta1.HelmPatchTarget{Kind: "Deployment"}}},
+				}
+			}),
+			wantErr: MissingHelmPostRenderPatch(configsync.RootSyncKind),
+		},
+		{
+			name: "missing helm post-render patchesFrom name",
+			obj: rootSyncWithHelm(func(rs *v1beta1.RootSync) {
+				rs.Spec.Helm.PostRender = &v1beta1.HelmPostRender{
+					PatchesFrom: &v1beta1.HelmPatchesFrom{DataKey: "patches.yaml"},
+				}
+			}),
+			wantErr: MissingHelmPostRenderPatchesFromName(configsync.RootSyncKind),
+		},
+		{
+			name: "valid helm post-render",
+			obj: rootSyncWithHelm(func(rs *v1beta1.RootSync) {
+				rs.Spec.Helm.PostRender = &v1beta1.HelmPostRender{
+					Patches:     []v1beta1.HelmPatch{{Patch: "- op: remove\n  path: /spec/replicas"}},
+					PatchesFrom: &v1beta1.HelmPatchesFrom{Name: "patches"},
+				}
+			}),
+		},
 		{
 			name: "valid spec.override.roleRefs Role",
 			obj: rootSyncWithGit(func(sync *v1beta1.RootSync) {
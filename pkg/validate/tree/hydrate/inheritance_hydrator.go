@@ -17,10 +17,12 @@ package hydrate
 import (
 	v1 "github.com/GoogleContainerTools/config-sync/pkg/api/configmanagement/v1"
 	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast"
+	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast/node"
 	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/transform"
 	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/validation"
 	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/validation/syntax"
 	"github.com/GoogleContainerTools/config-sync/pkg/kinds"
+	"github.com/GoogleContainerTools/config-sync/pkg/metadata"
 	"github.com/GoogleContainerTools/config-sync/pkg/status"
 	"github.com/GoogleContainerTools/config-sync/pkg/validate/fileobjects"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -29,7 +31,9 @@ import (
 type inheritanceSpecs map[schema.GroupKind]transform.InheritanceSpec
 
 // Inheritance hydrates the given Tree objects by copying inherited objects from
-// abstract namespaces down into child namespaces.
+// abstract namespaces down into child namespaces. If Namespaces are inherited,
+// the labels and annotations of the Namespaces declared in abstract namespaces
+// are also copied to the child Namespaces.
 func Inheritance(objs *fileobjects.Tree) status.MultiError {
 	if objs.Tree == nil {
 		return nil
@@ -38,7 +42,7 @@ func Inheritance(objs *fileobjects.Tree) status.MultiError {
 	if err != nil {
 		return err
 	}
-	return specs.visitTreeNode(objs.Tree, nil)
+	return specs.visitTreeNode(objs.Tree, nil, nil)
 }
 
 // buildInheritanceSpecs populates the InheritanceHydrator with InheritanceSpecs
@@ -66,32 +70,120 @@ func buildInheritanceSpecs(objs []ast.FileObject) (inheritanceSpecs, status.Erro
 	return specs, nil
 }
 
+// inheritsNamespaces returns true if the Namespaces declared in abstract
+// namespaces are inherited.
+func (i inheritanceSpecs) inheritsNamespaces() bool {
+	spec, found := i[kinds.Namespace().GroupKind()]
+	return found && spec.Mode == v1.HierarchyModeInherit
+}
+
 // visitTreeNode recursively hydrates Namespaces by copying inherited resource
-// objects down into child Namespaces.
-func (i inheritanceSpecs) visitTreeNode(node *ast.TreeNode, inherited []ast.FileObject) status.MultiError {
+// objects, and the inherited Namespace metadata, down into child Namespaces.
+func (i inheritanceSpecs) visitTreeNode(treeNode *ast.TreeNode, inherited []ast.FileObject, defaults *namespaceDefaults) status.MultiError {
 	var nodeObjs []ast.FileObject
-	isNamespace := false
-	for _, o := range node.Objects {
+	var namespace *ast.FileObject
+	for j, o := range treeNode.Objects {
 		if o.GetObjectKind().GroupVersionKind() == kinds.Namespace() {
-			isNamespace = true
+			namespace = &treeNode.Objects[j]
 		} else if o.GetObjectKind().GroupVersionKind() != kinds.NamespaceSelector() {
 			// Don't copy down NamespaceSelectors.
 			nodeObjs = append(nodeObjs, o)
 		}
 	}
 
-	if isNamespace {
-		return hydrateNamespace(node, inherited)
+	if namespace != nil {
+		if len(treeNode.Children) == 0 || !i.inheritsNamespaces() {
+			defaults.apply(*namespace)
+			return hydrateNamespace(treeNode, inherited)
+		}
+		// The Namespace declares the defaults of the abstract namespace, and is
+		// not synced.
+		defaults = defaults.merge(*namespace)
+		treeNode.Type = node.AbstractNamespace
+		treeNode.Objects = removeNamespace(treeNode.Objects)
 	}
 
 	err := i.validateAbstractObjects(nodeObjs)
 	inherited = append(inherited, nodeObjs...)
-	for _, c := range node.Children {
-		err = status.Append(err, i.visitTreeNode(c, inherited))
+	for _, c := range treeNode.Children {
+		err = status.Append(err, i.visitTreeNode(c, inherited, defaults))
 	}
 	return err
 }
 
+// namespaceDefaults are the labels and annotations that the Namespaces of an
+// abstract namespace inherit.
+type namespaceDefaults struct {
+	labels      map[string]string
+	annotations map[string]string
+}
+
+// merge returns the defaults of an abstract namespace declaring the Namespace,
+// in a parent abstract namespace with the defaults d. The metadata of the
+// Namespace takes precedence over the defaults of the parent.
+func (d *namespaceDefaults) merge(ns ast.FileObject) *namespaceDefaults {
+	result := &namespaceDefaults{
+		labels:      make(map[string]string),
+		annotations: make(map[string]string),
+	}
+	if d != nil {
+		for k, v := range d.labels {
+			result.labels[k] = v
+		}
+		for k, v := range d.annotations {
+			result.annotations[k] = v
+		}
+	}
+	for k, v := range ns.GetLabels() {
+		if !metadata.IsConfigSyncLabelKey(k) {
+			result.labels[k] = v
+		}
+	}
+	for k, v := range ns.GetAnnotations() {
+		if !metadata.IsConfigSyncAnnotationKey(k) {
+			result.annotations[k] = v
+		}
+	}
+	return result
+}
+
+// apply sets the default labels and annotations the Namespace doesn't declare.
+func (d *namespaceDefaults) apply(ns ast.FileObject) {
+	if d == nil {
+		return
+	}
+	labels := ns.GetLabels()
+	for k, v := range d.labels {
+		if _, found := labels[k]; !found {
+			if labels == nil {
+				labels = make(map[string]string)
+			}
+			labels[k] = v
+		}
+	}
+	ns.SetLabels(labels)
+	annotations := ns.GetAnnotations()
+	for k, v := range d.annotations {
+		if _, found := annotations[k]; !found {
+			if annotations == nil {
+				annotations = make(map[string]string)
+			}
+			annotations[k] = v
+		}
+	}
+	ns.SetAnnotations(annotations)
+}
+
+func removeNamespace(objs []ast.FileObject) []ast.FileObject {
+	var result []ast.FileObject
+	for _, o := range objs {
+		if o.GetObjectKind().GroupVersionKind() != kinds.Namespace() {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
 // validateAbstractObjects returns an error if any invalid objects are declared
 // in an abstract namespace.
 func (i inheritanceSpecs) validateAbstractObjects(objs []ast.FileObject) status.MultiError {
@@ -106,13 +198,13 @@ func (i inheritanceSpecs) validateAbstractObjects(objs []ast.FileObject) status.
 	return err
 }
 
-func hydrateNamespace(node *ast.TreeNode, inherited []ast.FileObject) status.MultiError {
+func hydrateNamespace(treeNode *ast.TreeNode, inherited []ast.FileObject) status.MultiError {
 	var err status.MultiError
-	for _, child := range node.Children {
-		err = status.Append(err, validation.IllegalNamespaceSubdirectoryError(child, node))
+	for _, child := range treeNode.Children {
+		err = status.Append(err, validation.IllegalNamespaceSubdirectoryError(child, treeNode))
 	}
 	for _, obj := range inherited {
-		node.Objects = append(node.Objects, obj.DeepCopy())
+		treeNode.Objects = append(treeNode.Objects, obj.DeepCopy())
 	}
 	return err
 }
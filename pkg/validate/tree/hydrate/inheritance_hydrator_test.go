@@ -161,6 +161,95 @@ func TestInheritance(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Propagate abstract namespace Namespace metadata",
+			objs: &fileobjects.Tree{
+				HierarchyConfigs: []ast.FileObject{
+					k8sobjects.HierarchyConfig(
+						k8sobjects.HierarchyConfigResource(v1.HierarchyModeInherit, kinds.Namespace().GroupVersion(), kinds.Namespace().Kind),
+					),
+				},
+				Tree: &ast.TreeNode{
+					Relative: cmpath.RelativeSlash("namespaces"),
+					Type:     node.AbstractNamespace,
+					Children: []*ast.TreeNode{
+						{
+							Relative: cmpath.RelativeSlash("namespaces/eng"),
+							Type:     node.Namespace,
+							Objects: []ast.FileObject{
+								k8sobjects.Namespace("namespaces/eng",
+									core.Label("org", "eng"),
+									core.Label("env", "prod"),
+									core.Annotation("owner", "eng")),
+								k8sobjects.RoleAtPath("namespaces/eng/role.yaml", core.Name("reader")),
+							},
+							Children: []*ast.TreeNode{
+								{
+									Relative: cmpath.RelativeSlash("namespaces/eng/backend"),
+									Type:     node.Namespace,
+									Objects: []ast.FileObject{
+										k8sobjects.Namespace("namespaces/eng/backend",
+											core.Annotation("owner", "backend")),
+									},
+								},
+								{
+									Relative: cmpath.RelativeSlash("namespaces/eng/frontend"),
+									Type:     node.Namespace,
+									Objects: []ast.FileObject{
+										k8sobjects.Namespace("namespaces/eng/frontend",
+											core.Label("env", "dev")),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: &fileobjects.Tree{
+				HierarchyConfigs: []ast.FileObject{
+					k8sobjects.HierarchyConfig(
+						k8sobjects.HierarchyConfigResource(v1.HierarchyModeInherit, kinds.Namespace().GroupVersion(), kinds.Namespace().Kind),
+					),
+				},
+				Tree: &ast.TreeNode{
+					Relative: cmpath.RelativeSlash("namespaces"),
+					Type:     node.AbstractNamespace,
+					Children: []*ast.TreeNode{
+						{
+							Relative: cmpath.RelativeSlash("namespaces/eng"),
+							Type:     node.AbstractNamespace,
+							Objects: []ast.FileObject{
+								k8sobjects.RoleAtPath("namespaces/eng/role.yaml", core.Name("reader")),
+							},
+							Children: []*ast.TreeNode{
+								{
+									Relative: cmpath.RelativeSlash("namespaces/eng/backend"),
+									Type:     node.Namespace,
+									Objects: []ast.FileObject{
+										k8sobjects.Namespace("namespaces/eng/backend",
+											core.Label("org", "eng"),
+											core.Label("env", "prod"),
+											core.Annotation("owner", "backend")),
+										k8sobjects.RoleAtPath("namespaces/eng/role.yaml", core.Name("reader")),
+									},
+								},
+								{
+									Relative: cmpath.RelativeSlash("namespaces/eng/frontend"),
+									Type:     node.Namespace,
+									Objects: []ast.FileObject{
+										k8sobjects.Namespace("namespaces/eng/frontend",
+											core.Label("org", "eng"),
+											core.Label("env", "dev"),
+											core.Annotation("owner", "eng")),
+										k8sobjects.RoleAtPath("namespaces/eng/role.yaml", core.Name("reader")),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Validate Namespace can not have child Namespaces",
 			objs: &fileobjects.Tree{
@@ -19,14 +19,13 @@ import (
 	v1 "github.com/GoogleContainerTools/config-sync/pkg/api/configmanagement/v1"
 	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast"
 	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/validation/hierarchyconfig"
-	"github.com/GoogleContainerTools/config-sync/pkg/kinds"
 	"github.com/GoogleContainerTools/config-sync/pkg/status"
 	"github.com/GoogleContainerTools/config-sync/pkg/validate/fileobjects"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // HierarchyConfig verifies that all HierarchyConfig objects specify valid
-// namespace-scoped resource kinds and valid inheritance modes.
+// namespace-scoped resource kinds, or Namespaces, and valid inheritance modes.
 func HierarchyConfig(tree *fileobjects.Tree) status.MultiError {
 	clusterGKs := make(map[schema.GroupKind]bool)
 	for _, obj := range tree.Cluster {
@@ -80,5 +79,5 @@ func groupKinds(res v1.HierarchyConfigResource) []schema.GroupKind {
 }
 
 func unsupportedGK(gk schema.GroupKind) bool {
-	return gk == kinds.Namespace().GroupKind() || gk.Group == configmanagement.GroupName || gk.Kind == ""
+	return gk.Group == configmanagement.GroupName || gk.Kind == ""
 }
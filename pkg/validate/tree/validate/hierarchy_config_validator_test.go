@@ -77,6 +77,15 @@ func TestHierarchyConfig(t *testing.T) {
 			},
 			wantErrs: status.FakeMultiError(hierarchyconfig.UnsupportedResourceInHierarchyConfigErrorCode),
 		},
+		{
+			name: "Namespaces allowed",
+			objs: &fileobjects.Tree{
+				HierarchyConfigs: []ast.FileObject{
+					k8sobjects.HierarchyConfig(
+						k8sobjects.HierarchyConfigKind(v1.HierarchyModeInherit, kinds.Namespace())),
+				},
+			},
+		},
 		{
 			name: "Cluster-scoped objects not allowed",
 			objs: &fileobjects.Tree{
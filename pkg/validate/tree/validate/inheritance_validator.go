@@ -27,13 +27,14 @@ import (
 // Inheritance verifies that all syncable resources in an abstract namespace
 // have a concrete Namespace as a descendant.
 func Inheritance(tree *fileobjects.Tree) status.MultiError {
-	_, err := validateTreeNode(tree.Tree)
+	_, err := validateTreeNode(tree.Tree, tree.InheritsNamespaces())
 	return err
 }
 
 // validateTreeNode returns True if the give node is a Namespace or if any of
-// its descendants are.
-func validateTreeNode(node *ast.TreeNode) (bool, status.MultiError) {
+// its descendants are. If inheritsNamespaces is true, a node with children
+// which declares a Namespace is an abstract namespace.
+func validateTreeNode(node *ast.TreeNode, inheritsNamespaces bool) (bool, status.MultiError) {
 	hasSyncableObjects := false
 	var namespaces []client.Object
 	for _, obj := range node.Objects {
@@ -47,14 +48,14 @@ func validateTreeNode(node *ast.TreeNode) (bool, status.MultiError) {
 	if len(namespaces) > 1 {
 		return true, status.MultipleSingletonsError(namespaces...)
 	}
-	if len(namespaces) == 1 {
+	if len(namespaces) == 1 && !(inheritsNamespaces && len(node.Children) > 0) {
 		return true, nil
 	}
 
 	var errs status.MultiError
 	foundDescendant := false
 	for _, child := range node.Children {
-		hasNamespaceDescendant, err := validateTreeNode(child)
+		hasNamespaceDescendant, err := validateTreeNode(child, inheritsNamespaces)
 		foundDescendant = foundDescendant || hasNamespaceDescendant
 		errs = status.Append(errs, err)
 	}
@@ -18,11 +18,13 @@ import (
 	"errors"
 	"testing"
 
+	v1 "github.com/GoogleContainerTools/config-sync/pkg/api/configmanagement/v1"
 	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
 	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast"
 	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/ast/node"
 	"github.com/GoogleContainerTools/config-sync/pkg/importer/analyzer/validation/semantic"
 	"github.com/GoogleContainerTools/config-sync/pkg/importer/filesystem/cmpath"
+	"github.com/GoogleContainerTools/config-sync/pkg/kinds"
 	"github.com/GoogleContainerTools/config-sync/pkg/status"
 	"github.com/GoogleContainerTools/config-sync/pkg/validate/fileobjects"
 )
@@ -236,6 +238,68 @@ func TestInheritance(t *testing.T) {
 			},
 			wantErrs: status.FakeMultiError(status.MultipleSingletonsErrorCode),
 		},
+		{
+			name: "abstract namespace Namespace with resource and descendant namespace",
+			objs: &fileobjects.Tree{
+				HierarchyConfigs: []ast.FileObject{
+					k8sobjects.HierarchyConfig(
+						k8sobjects.HierarchyConfigKind(v1.HierarchyModeInherit, kinds.Namespace())),
+				},
+				Tree: &ast.TreeNode{
+					Relative: cmpath.RelativeSlash("namespaces"),
+					Type:     node.AbstractNamespace,
+					Children: []*ast.TreeNode{
+						{
+							Relative: cmpath.RelativeSlash("namespaces/hello"),
+							Type:     node.Namespace,
+							Objects: []ast.FileObject{
+								k8sobjects.Namespace("namespaces/hello"),
+								k8sobjects.RoleAtPath("namespaces/hello"),
+							},
+							Children: []*ast.TreeNode{
+								{
+									Relative: cmpath.RelativeSlash("namespaces/hello/world"),
+									Type:     node.Namespace,
+									Objects: []ast.FileObject{
+										k8sobjects.Namespace("namespaces/hello/world"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "abstract namespace Namespace with resource without descendant namespace",
+			objs: &fileobjects.Tree{
+				HierarchyConfigs: []ast.FileObject{
+					k8sobjects.HierarchyConfig(
+						k8sobjects.HierarchyConfigKind(v1.HierarchyModeInherit, kinds.Namespace())),
+				},
+				Tree: &ast.TreeNode{
+					Relative: cmpath.RelativeSlash("namespaces"),
+					Type:     node.AbstractNamespace,
+					Children: []*ast.TreeNode{
+						{
+							Relative: cmpath.RelativeSlash("namespaces/hello"),
+							Type:     node.Namespace,
+							Objects: []ast.FileObject{
+								k8sobjects.Namespace("namespaces/hello"),
+								k8sobjects.RoleAtPath("namespaces/hello"),
+							},
+							Children: []*ast.TreeNode{
+								{
+									Relative: cmpath.RelativeSlash("namespaces/hello/world"),
+									Type:     node.AbstractNamespace,
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErrs: status.FakeMultiError(semantic.UnsyncableResourcesErrorCode),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -32,27 +32,33 @@ import (
 // only declared in abstract namespace and that objects only reference legacy
 // NamespaceSelectors that are in an ancestor abstract namespace.
 func NamespaceSelector(tree *fileobjects.Tree) status.MultiError {
-	return validateSelectorsInNode(tree.Tree, tree.NamespaceSelectors)
+	return validateSelectorsInNode(tree.Tree, tree.NamespaceSelectors, tree.InheritsNamespaces())
 }
 
-func validateSelectorsInNode(node *ast.TreeNode, nsSelectors map[string]ast.FileObject) status.MultiError {
-	err := validateNamespaceSelectors(node.Objects, nsSelectors)
+func validateSelectorsInNode(node *ast.TreeNode, nsSelectors map[string]ast.FileObject, inheritsNamespaces bool) status.MultiError {
+	// The Namespace of an abstract namespace declares its defaults, so the
+	// abstract namespace may declare NamespaceSelectors.
+	abstract := inheritsNamespaces && len(node.Children) > 0
+	err := validateNamespaceSelectors(node.Objects, nsSelectors, abstract)
 
 	for _, c := range node.Children {
-		err = status.Append(err, validateSelectorsInNode(c, nsSelectors))
+		err = status.Append(err, validateSelectorsInNode(c, nsSelectors, inheritsNamespaces))
 	}
 	return err
 }
 
 // validateNamespaceSelectors returns an error if the given objects contain both
-// a Namespace and one or more NamespaceSelectors.
-func validateNamespaceSelectors(objs []ast.FileObject, nsSelectors map[string]ast.FileObject) status.MultiError {
+// a Namespace and one or more NamespaceSelectors, unless the objects are in an
+// abstract namespace.
+func validateNamespaceSelectors(objs []ast.FileObject, nsSelectors map[string]ast.FileObject, abstract bool) status.MultiError {
 	var errs status.MultiError
 	var namespaceDir string
 	for _, obj := range objs {
 		switch obj.GetObjectKind().GroupVersionKind() {
 		case kinds.Namespace():
-			namespaceDir = obj.Dir().SlashPath()
+			if !abstract {
+				namespaceDir = obj.Dir().SlashPath()
+			}
 		default:
 			name, hasSelector := obj.GetAnnotations()[metadata.NamespaceSelectorAnnotationKey]
 			if hasSelector {
@@ -44,6 +44,17 @@ type Options struct {
 	// ClusterName is the spec.clusterName of the cluster's ConfigManagement. This
 	// is used when hydrating cluster selectors.
 	ClusterName string
+	// ClusterLabels are the labels of the cluster sourced from outside the
+	// repo, like the fleet Membership and the ClusterInfo ConfigMap. These are
+	// used when hydrating cluster selectors, in addition to the labels of the
+	// Cluster object declared in the repo.
+	ClusterLabels map[string]string
+	// SubstitutionEnabled is whether the `${cluster.*}` and `${values.*}`
+	// variables are substituted in the resources before they are validated.
+	SubstitutionEnabled bool
+	// SubstitutionValues are the values of the `${values.KEY}` variables
+	// substituted in the resources before they are validated.
+	SubstitutionValues map[string]string
 	// Scope is the scope of the reconciler.
 	// `:root` represents the root-reconciler.
 	// The scope of the namespace reconciler is its namespace name.
@@ -104,6 +115,9 @@ func Hierarchical(objs []ast.FileObject, opts Options) ([]ast.FileObject, status
 	//   - adding metadata to resources (such as their filepath in the repo)
 	rawObjects := &fileobjects.Raw{
 		ClusterName:             opts.ClusterName,
+		ClusterLabels:           opts.ClusterLabels,
+		SubstitutionEnabled:     opts.SubstitutionEnabled,
+		SubstitutionValues:      opts.SubstitutionValues,
 		Scope:                   opts.Scope,
 		SyncName:                opts.SyncName,
 		PolicyDir:               opts.PolicyDir,
@@ -191,6 +205,9 @@ func Unstructured(ctx context.Context, c client.Client, objs []ast.FileObject, o
 	//   - adding metadata to resources (such as their filepath in the repo)
 	rawObjects := &fileobjects.Raw{
 		ClusterName:              opts.ClusterName,
+		ClusterLabels:            opts.ClusterLabels,
+		SubstitutionEnabled:      opts.SubstitutionEnabled,
+		SubstitutionValues:       opts.SubstitutionValues,
 		Scope:                    opts.Scope,
 		SyncName:                 opts.SyncName,
 		PolicyDir:                opts.PolicyDir,
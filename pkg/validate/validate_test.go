@@ -178,6 +178,56 @@ func TestHierarchical(t *testing.T) {
 					core.Annotation(csmetadata.SourcePathAnnotationKey, dir+"/namespaces/bar/qux/role.yaml")),
 			},
 		},
+		{
+			name: "abstract namespaces with Namespace inheritance",
+			objs: []ast.FileObject{
+				k8sobjects.Repo(),
+				k8sobjects.HierarchyConfig(
+					k8sobjects.HierarchyConfigResource(v1.HierarchyModeInherit,
+						kinds.Namespace().GroupVersion(), kinds.Namespace().Kind)),
+				k8sobjects.Namespace("namespaces/bar",
+					core.Label("org", "bar"),
+					core.Label("env", "prod"),
+					core.Annotation("owner", "bar-team")),
+				k8sobjects.Namespace("namespaces/bar/foo",
+					core.Label("env", "dev")),
+				k8sobjects.RoleAtPath("namespaces/bar/role.yaml",
+					core.Name("first")),
+				k8sobjects.NamespaceSelectorAtPath("namespaces/bar/selector.yaml",
+					core.Name("prod"),
+					func(o client.Object) {
+						o.(*v1.NamespaceSelector).Spec.Selector.MatchLabels = map[string]string{"env": "prod"}
+					}),
+				k8sobjects.RoleAtPath("namespaces/bar/prod-role.yaml",
+					core.Name("prod-only"),
+					core.Annotation(csmetadata.NamespaceSelectorAnnotationKey, "prod")),
+			},
+			want: []ast.FileObject{
+				k8sobjects.Namespace("namespaces/bar/foo",
+					core.Label("org", "bar"),
+					core.Label("env", "dev"),
+					core.Annotation("owner", "bar-team"),
+					core.Label(csmetadata.DeclaredVersionLabel, "v1"),
+					core.Annotation(csmetadata.SourcePathAnnotationKey, dir+"/namespaces/bar/foo/namespace.yaml"),
+					core.Annotation(csmetadata.HNCManagedBy, csmetadata.ManagedByValue),
+					core.Label("bar.tree.hnc.x-k8s.io/depth", "1"),
+					core.Label("foo.tree.hnc.x-k8s.io/depth", "0")),
+				k8sobjects.RoleAtPath("namespaces/bar/role.yaml",
+					core.Name("first"),
+					core.Namespace("foo"),
+					core.Label(csmetadata.DeclaredVersionLabel, "v1"),
+					core.Annotation(csmetadata.SourcePathAnnotationKey, dir+"/namespaces/bar/role.yaml")),
+			},
+		},
+		{
+			name: "Namespace with subdirectories without Namespace inheritance fails",
+			objs: []ast.FileObject{
+				k8sobjects.Repo(),
+				k8sobjects.Namespace("namespaces/bar"),
+				k8sobjects.Namespace("namespaces/bar/foo"),
+			},
+			wantErrs: status.FakeMultiError(validation.IllegalNamespaceSubdirectoryErrorCode),
+		},
 		{
 			name: "CRD v1 and CR",
 			options: Options{
@@ -841,10 +891,6 @@ func TestHierarchical(t *testing.T) {
 					k8sobjects.HierarchyConfigResource(v1.HierarchyModeInherit,
 						kinds.CustomResourceDefinitionV1Beta1().GroupVersion(), kinds.CustomResourceDefinitionV1Beta1().Kind),
 					core.Name("crd-hc")),
-				k8sobjects.HierarchyConfig(
-					k8sobjects.HierarchyConfigResource(v1.HierarchyModeInherit,
-						kinds.Namespace().GroupVersion(), kinds.Namespace().Kind),
-					core.Name("namespace-hc")),
 				k8sobjects.HierarchyConfig(
 					k8sobjects.HierarchyConfigResource(v1.HierarchyModeInherit,
 						kinds.Sync().GroupVersion(), kinds.Sync().Kind),
@@ -854,7 +900,6 @@ func TestHierarchical(t *testing.T) {
 			},
 			wantErrs: status.FakeMultiError(
 				hierarchyconfig.ClusterScopedResourceInHierarchyConfigErrorCode,
-				hierarchyconfig.UnsupportedResourceInHierarchyConfigErrorCode,
 				hierarchyconfig.UnsupportedResourceInHierarchyConfigErrorCode),
 		},
 		{
@@ -1386,6 +1431,47 @@ func TestUnstructured(t *testing.T) {
 				core.Label(csmetadata.DeclaredVersionLabel, "v1beta1"),
 			)},
 		},
+		{
+			name: "variables kept when substitution is disabled",
+			options: Options{
+				Scope:       declared.Scope("foo"),
+				ClusterName: "prod",
+			},
+			objs: []ast.FileObject{
+				k8sobjects.RoleAtPath("role.yaml",
+					core.Namespace("foo"),
+					core.Annotation("team", "${cluster.name}-$${cluster.name}")),
+			},
+			want: []ast.FileObject{
+				k8sobjects.RoleAtPath("role.yaml",
+					core.Namespace("foo"),
+					core.Annotation("team", "${cluster.name}-$${cluster.name}"),
+					core.Annotation(csmetadata.ClusterNameAnnotationKey, "prod"),
+					core.Label(csmetadata.DeclaredVersionLabel, "v1"),
+					core.Annotation(csmetadata.SourcePathAnnotationKey, dir+"/role.yaml")),
+			},
+		},
+		{
+			name: "variables substituted when substitution is enabled",
+			options: Options{
+				Scope:               declared.Scope("foo"),
+				ClusterName:         "prod",
+				SubstitutionEnabled: true,
+			},
+			objs: []ast.FileObject{
+				k8sobjects.RoleAtPath("role.yaml",
+					core.Namespace("foo"),
+					core.Annotation("team", "${cluster.name}-$${cluster.name}")),
+			},
+			want: []ast.FileObject{
+				k8sobjects.RoleAtPath("role.yaml",
+					core.Namespace("foo"),
+					core.Annotation("team", "prod-${cluster.name}"),
+					core.Annotation(csmetadata.ClusterNameAnnotationKey, "prod"),
+					core.Label(csmetadata.DeclaredVersionLabel, "v1"),
+					core.Annotation(csmetadata.SourcePathAnnotationKey, dir+"/role.yaml")),
+			},
+		},
 	}
 
 	converter, err := openapitest.ValueConverterForTest()
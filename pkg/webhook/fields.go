@@ -69,7 +69,8 @@ var (
 )
 
 // ConfigSyncMetadata returns all of the metadata fields in the given fieldpath
-// Set which are ConfigSync labels or annotations.
+// Set which are ConfigSync labels or annotations, including the shared fields
+// annotations of the reconcilers co-managing the object.
 func ConfigSyncMetadata(set *fieldpath.Set) *fieldpath.Set {
 	metadataSet := set.WithPrefix(metadataPath)
 
@@ -78,7 +79,7 @@ func ConfigSyncMetadata(set *fieldpath.Set) *fieldpath.Set {
 		s := path.String()
 		if strings.HasPrefix(s, annotations) {
 			s = s[len(annotations):]
-			if csmetadata.IsConfigSyncAnnotationKey(s) {
+			if csmetadata.IsConfigSyncAnnotationKey(s) || csmetadata.IsSharedFieldsAnnotationKey(s) {
 				csSet.Insert(path)
 			}
 		} else if strings.HasPrefix(s, labels) {
@@ -35,11 +35,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
 )
 
 // AddValidator adds the admission webhook validator to the passed manager.
 func AddValidator(mgr manager.Manager) error {
-	handler, err := handler(mgr.GetConfig())
+	handler, err := handler(mgr.GetConfig(), mgr.GetClient())
 	if err != nil {
 		return err
 	}
@@ -53,12 +54,15 @@ func AddValidator(mgr manager.Manager) error {
 // requests and admits or denies them.
 type Validator struct {
 	differ *ObjectDiffer
+	// reader reads the RootSyncs and RepoSyncs, for the drift policy inherited
+	// by their managed objects. The inherited drift policy is ignored if nil.
+	reader client.Reader
 }
 
 var _ admission.Handler = &Validator{}
 
 // Handler returns a Validator which satisfies the admission.Handler interface.
-func handler(cfg *rest.Config) (*Validator, error) {
+func handler(cfg *rest.Config, reader client.Reader) (*Validator, error) {
 	dc, err := discovery.NewDiscoveryClientForConfig(cfg)
 	if err != nil {
 		return nil, err
@@ -67,11 +71,11 @@ func handler(cfg *rest.Config) (*Validator, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Validator{&ObjectDiffer{vc}}, nil
+	return &Validator{differ: &ObjectDiffer{vc}, reader: reader}, nil
 }
 
 // Handle implements admission.Handler
-func (v *Validator) Handle(_ context.Context, req admission.Request) admission.Response {
+func (v *Validator) Handle(ctx context.Context, req admission.Request) admission.Response {
 	// An admission request for a sub-resource (such as a Scale) will not include
 	// the full parent for us to validate until the admission chain is fixed:
 	// https://github.com/kubernetes/enhancements/pull/1600
@@ -96,6 +100,11 @@ func (v *Validator) Handle(_ context.Context, req admission.Request) admission.R
 		id := objectID(oldObj, newObj)
 		// TODO: validate managed=enabled?
 		err = diff.ValidateManager(username, manager, id, req.Operation)
+		if err != nil && req.Operation == admissionv1.Update && coManages(username, oldObj, newObj) {
+			// The reconciler doesn't manage the object, but co-manages some
+			// of its fields.
+			return v.handleSharedUpdate(oldObj, newObj, username)
+		}
 		if err != nil {
 			klog.Error(err.Error())
 			return deny(metav1.StatusReasonUnauthorized, err.Error())
@@ -113,9 +122,9 @@ func (v *Validator) Handle(_ context.Context, req admission.Request) admission.R
 	case admissionv1.Create:
 		return v.handleCreate(newObj, username)
 	case admissionv1.Delete:
-		return v.handleDelete(oldObj, username)
+		return v.handleDelete(ctx, oldObj, username)
 	case admissionv1.Update:
-		return v.handleUpdate(oldObj, newObj, username)
+		return v.handleUpdate(ctx, oldObj, newObj, username)
 	default:
 		klog.Errorf("Unsupported operation: %v from %s", req.Operation, username)
 		return allow()
@@ -130,12 +139,16 @@ func (v *Validator) handleCreate(newObj client.Object, username string) admissio
 	return allow()
 }
 
-func (v *Validator) handleDelete(oldObj client.Object, username string) admission.Response {
+func (v *Validator) handleDelete(ctx context.Context, oldObj client.Object, username string) admission.Response {
 	// This means a delete request was previously made and accepted, but removal of the API object is not yet complete.
 	// See http://b/199235728#comment16 for more details.
 	if oldObj.GetDeletionTimestamp() != nil {
 		return allow()
 	}
+	if v.driftPolicy(ctx, oldObj) == csmetadata.DriftPolicyReport {
+		// Drift of this resource is reported by the remediator, not prevented.
+		return allow()
+	}
 	if differ.ManagedByConfigSync(oldObj) {
 		klog.Errorf("%s is not authorized to delete managed resource %q", username, core.GKNN(oldObj))
 		return deny(metav1.StatusReasonUnauthorized, fmt.Sprintf("%s is not authorized to delete managed resource %q", username, core.GKNN(oldObj)))
@@ -143,7 +156,7 @@ func (v *Validator) handleDelete(oldObj client.Object, username string) admissio
 	return allow()
 }
 
-func (v *Validator) handleUpdate(oldObj, newObj client.Object, username string) admission.Response {
+func (v *Validator) handleUpdate(ctx context.Context, oldObj, newObj client.Object, username string) admission.Response {
 	if !differ.ManagedByConfigSync(oldObj) && !differ.ManagedByConfigSync(newObj) {
 		// Both oldObj and newObj are not managed by Config Sync.
 		// The webhook should be configured to only intercept resources which are
@@ -172,6 +185,12 @@ func (v *Validator) handleUpdate(oldObj, newObj client.Object, username string)
 		return allow()
 	}
 
+	if v.driftPolicy(ctx, oldObj) == csmetadata.DriftPolicyReport {
+		// Drift of this resource is reported by the remediator, not prevented.
+		// Per the above check, we know that this annotation has not been altered.
+		return allow()
+	}
+
 	// Use the ConfigSync declared fields annotation to build the set of fields
 	// which should not be modified.
 	declaredSet, err := DeclaredFields(oldObj)
@@ -180,6 +199,16 @@ func (v *Validator) handleUpdate(oldObj, newObj client.Object, username string)
 		return allow()
 	}
 
+	// The fields co-managed by other reconcilers must not be modified either.
+	sharedSets, err := diff.SharedFieldSets(oldObj)
+	if err != nil {
+		klog.Errorf("Failed to decode shared fields for object %q: %v", core.GKNN(oldObj), err)
+		return allow()
+	}
+	for _, sharedSet := range sharedSets {
+		declaredSet = declaredSet.Union(sharedSet)
+	}
+
 	// If the diff set and declared set have any fields in common, reject the
 	// request. Otherwise allow it.
 	invalidSet := diffSet.Intersection(declaredSet)
@@ -190,6 +219,108 @@ func (v *Validator) handleUpdate(oldObj, newObj client.Object, username string)
 	return allow()
 }
 
+// driftPolicy returns the drift policy of the managed object: its own
+// drift-policy annotation, or else the drift-policy annotation of the RootSync
+// or RepoSync managing it.
+func (v *Validator) driftPolicy(ctx context.Context, obj client.Object) csmetadata.DriftPolicy {
+	if policy := csmetadata.GetDriftPolicy(obj); policy != "" {
+		return policy
+	}
+	if v.reader == nil {
+		return ""
+	}
+	scope, name := declared.ManagerScopeAndName(core.GetAnnotation(obj, csmetadata.ResourceManagerKey))
+	if name == "" {
+		return ""
+	}
+	rsync := &metav1.PartialObjectMetadata{}
+	if scope == declared.RootScope {
+		rsync.SetGroupVersionKind(kinds.RootSyncV1Beta1())
+	} else {
+		rsync.SetGroupVersionKind(kinds.RepoSyncV1Beta1())
+	}
+	key := client.ObjectKey{Namespace: scope.SyncNamespace(), Name: name}
+	if err := v.reader.Get(ctx, key, rsync); err != nil {
+		klog.Warningf("Failed to get the drift policy of %s %s managing object %q: %v", rsync.Kind, key, core.GKNN(obj), err)
+		return ""
+	}
+	return csmetadata.GetDriftPolicy(rsync)
+}
+
+// coManages returns whether the reconciler co-manages fields of the object,
+// before or after the update.
+func coManages(reconciler string, oldObj, newObj client.Object) bool {
+	key := csmetadata.SharedFieldsAnnotationKey(reconciler)
+	for _, obj := range []client.Object{oldObj, newObj} {
+		if obj == nil {
+			continue
+		}
+		if _, found := obj.GetAnnotations()[key]; found {
+			return true
+		}
+	}
+	return false
+}
+
+// handleSharedUpdate validates the update of an object by a reconciler
+// co-managing some of its fields. The reconciler may only modify its own
+// shared fields annotation, and fields which are neither declared by the
+// manager of the object, nor co-managed by other reconcilers.
+func (v *Validator) handleSharedUpdate(oldObj, newObj client.Object, reconciler string) admission.Response {
+	newSharedSets, err := diff.SharedFieldSets(newObj)
+	if err != nil {
+		klog.Error(err.Error())
+		return deny(metav1.StatusReasonBadRequest, err.Error())
+	}
+	if fields, found := newSharedSets[reconciler]; found {
+		// The reconciler applies its shared fields, as opposed to releasing
+		// them.
+		if err := diff.ValidateSharedFields(reconciler, oldObj, fields); err != nil {
+			klog.Error(err.Error())
+			return deny(metav1.StatusReasonUnauthorized, err.Error())
+		}
+	}
+
+	diffSet, err := v.differ.FieldDiff(oldObj, newObj)
+	if err != nil {
+		klog.Errorf("Failed to generate field diff set for object %q: %v", core.GKNN(oldObj), err)
+		return allow()
+	}
+	// ConfigSyncMetadata returns the paths relative to metadata.
+	ownAnnotation := fieldpath.NewSet(fieldpath.MakePathOrDie("annotations",
+		csmetadata.SharedFieldsAnnotationKey(reconciler)))
+	invalidSet := ConfigSyncMetadata(diffSet).Difference(ownAnnotation)
+	// The declared field sets include the parents of the declared fields,
+	// like spec, which the reconciler may modify.
+	diffSet = diffSet.Leaves()
+
+	declaredSet, err := diff.DeclaredFieldSet(oldObj)
+	if err != nil {
+		klog.Errorf("Failed to decode declared fields for object %q: %v", core.GKNN(oldObj), err)
+		return allow()
+	}
+	if declaredSet != nil {
+		invalidSet = invalidSet.Union(diffSet.Intersection(declaredSet))
+	}
+	oldSharedSets, err := diff.SharedFieldSets(oldObj)
+	if err != nil {
+		klog.Errorf("Failed to decode shared fields for object %q: %v", core.GKNN(oldObj), err)
+		return allow()
+	}
+	for other, sharedSet := range oldSharedSets {
+		if other != reconciler {
+			invalidSet = invalidSet.Union(diffSet.Intersection(sharedSet))
+		}
+	}
+	if !invalidSet.Empty() {
+		msg := fmt.Sprintf("config sync %q cannot modify fields of object %q it does not co-manage: %s",
+			reconciler, core.GKNN(oldObj), invalidSet.String())
+		klog.Error(msg)
+		return deny(metav1.StatusReasonForbidden, msg)
+	}
+	return allow()
+}
+
 func convertObjects(req admission.Request) (client.Object, client.Object, error) {
 	var oldObj client.Object
 	switch {
@@ -23,6 +23,7 @@ import (
 	"github.com/GoogleContainerTools/config-sync/pkg/core/k8sobjects"
 	"github.com/GoogleContainerTools/config-sync/pkg/declared"
 	csmetadata "github.com/GoogleContainerTools/config-sync/pkg/metadata"
+	testingfake "github.com/GoogleContainerTools/config-sync/pkg/syncer/syncertest/fake"
 	"github.com/GoogleContainerTools/config-sync/pkg/testing/openapitest"
 	admissionv1 "k8s.io/api/admission/v1"
 	authenticationv1 "k8s.io/api/authentication/v1"
@@ -412,6 +413,55 @@ func TestValidator_Handle(t *testing.T) {
 			user: bob(),
 			deny: metav1.StatusReasonForbidden,
 		},
+		{
+			name: "Bob deletes a managed object with the report drift policy",
+			oldObj: k8sobjects.RoleObject(
+				core.Name("hello"),
+				core.Namespace("world"),
+				core.Label(csmetadata.ManagedByKey, csmetadata.ManagedByValue),
+				csmetadata.WithManagementMode(csmetadata.ManagementEnabled),
+				csmetadata.WithDriftPolicy(csmetadata.DriftPolicyReport),
+				core.Annotation(csmetadata.ResourceIDKey, "rbac.authorization.k8s.io_role_world_hello"),
+				core.Annotation(csmetadata.DeclaredFieldsKey, `{"f:metadata":{"f:labels":{"f:app.kubernetes.io/managed-by":{}},"f:annotations":{"f:configmanagement.gke.io/managed":{}}},"f:rules":{}}`),
+			),
+			user: bob(),
+		},
+		{
+			name: "Bob updates a managed object with the report drift policy: declared fields",
+			oldObj: k8sobjects.RoleObject(
+				core.Name("hello"),
+				core.Namespace("world"),
+				core.Label(csmetadata.ManagedByKey, csmetadata.ManagedByValue),
+				csmetadata.WithManagementMode(csmetadata.ManagementEnabled),
+				csmetadata.WithDriftPolicy(csmetadata.DriftPolicyReport),
+				core.Annotation(csmetadata.ResourceIDKey, "rbac.authorization.k8s.io_role_world_hello"),
+				setRules([]rbacv1.PolicyRule{
+					{
+						APIGroups: []string{""},
+						Resources: []string{"pods"},
+						Verbs:     []string{"get", "list"},
+					},
+				}),
+				core.Annotation(csmetadata.DeclaredFieldsKey, `{"f:metadata":{"f:labels":{"f:app.kubernetes.io/managed-by":{}},"f:annotations":{"f:configmanagement.gke.io/managed":{}}},"f:rules":{}}`),
+			),
+			newObj: k8sobjects.RoleObject(
+				core.Name("hello"),
+				core.Namespace("world"),
+				core.Label(csmetadata.ManagedByKey, csmetadata.ManagedByValue),
+				csmetadata.WithManagementMode(csmetadata.ManagementEnabled),
+				csmetadata.WithDriftPolicy(csmetadata.DriftPolicyReport),
+				core.Annotation(csmetadata.ResourceIDKey, "rbac.authorization.k8s.io_role_world_hello"),
+				setRules([]rbacv1.PolicyRule{
+					{
+						APIGroups: []string{""},
+						Resources: []string{"pods"},
+						Verbs:     []string{"*"},
+					},
+				}),
+				core.Annotation(csmetadata.DeclaredFieldsKey, `{"f:metadata":{"f:labels":{"f:app.kubernetes.io/managed-by":{}},"f:annotations":{"f:configmanagement.gke.io/managed":{}}},"f:rules":{}}`),
+			),
+			user: bob(),
+		},
 		{
 			name: "Bob updates a managed object: Config Sync metadata",
 			oldObj: k8sobjects.RoleObject(
@@ -587,6 +637,50 @@ func TestValidator_Handle(t *testing.T) {
 				core.Annotation(csmetadata.LifecycleMutationAnnotation, csmetadata.IgnoreMutation)),
 			deny: metav1.StatusReasonForbidden,
 		},
+		{
+			name:   "Namespace reconciler applies the fields it co-manages",
+			oldObj: sharedRole(rulesGetList),
+			newObj: sharedRole(rulesGetList,
+				core.Label("team", "bookstore"),
+				core.Annotation(csmetadata.SharedFieldsAnnotationKey(core.NsReconcilerName("bookstore", repoSyncName)), sharedTeamLabel)),
+			user: configSyncNamespaceReconciler("bookstore", repoSyncName),
+		},
+		{
+			name: "Namespace reconciler releases the fields it co-manages",
+			oldObj: sharedRole(rulesGetList,
+				core.Label("team", "bookstore"),
+				core.Annotation(csmetadata.SharedFieldsAnnotationKey(core.NsReconcilerName("bookstore", repoSyncName)), sharedTeamLabel)),
+			newObj: sharedRole(rulesGetList),
+			user:   configSyncNamespaceReconciler("bookstore", repoSyncName),
+		},
+		{
+			name:   "Namespace reconciler co-manages fields declared by the manager",
+			oldObj: sharedRole(rulesGetList),
+			newObj: sharedRole(setRules([]rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"*"}}}),
+				core.Annotation(csmetadata.SharedFieldsAnnotationKey(core.NsReconcilerName("bookstore", repoSyncName)), `{"f:rules":{}}`)),
+			user: configSyncNamespaceReconciler("bookstore", repoSyncName),
+			deny: metav1.StatusReasonForbidden,
+		},
+		{
+			name: "Namespace reconciler modifies fields declared by the manager",
+			oldObj: sharedRole(rulesGetList,
+				core.Annotation(csmetadata.SharedFieldsAnnotationKey(core.NsReconcilerName("bookstore", repoSyncName)), sharedTeamLabel)),
+			newObj: sharedRole(setRules([]rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"*"}}}),
+				core.Annotation(csmetadata.SharedFieldsAnnotationKey(core.NsReconcilerName("bookstore", repoSyncName)), sharedTeamLabel)),
+			user: configSyncNamespaceReconciler("bookstore", repoSyncName),
+			deny: metav1.StatusReasonForbidden,
+		},
+		{
+			name: "Bob updates a managed object: co-managed fields",
+			oldObj: sharedRole(rulesGetList,
+				core.Label("team", "bookstore"),
+				core.Annotation(csmetadata.SharedFieldsAnnotationKey(core.NsReconcilerName("bookstore", repoSyncName)), sharedTeamLabel)),
+			newObj: sharedRole(rulesGetList,
+				core.Label("team", "shipping"),
+				core.Annotation(csmetadata.SharedFieldsAnnotationKey(core.NsReconcilerName("bookstore", repoSyncName)), sharedTeamLabel)),
+			user: bob(),
+			deny: metav1.StatusReasonForbidden,
+		},
 	}
 
 	v := validatorForTest(t)
@@ -610,6 +704,88 @@ func TestValidator_Handle(t *testing.T) {
 	}
 }
 
+// sharedTeamLabel is the shared fields annotation of a reconciler
+// co-managing the team label.
+const sharedTeamLabel = `{"f:metadata":{"f:labels":{"f:team":{}}}}`
+
+var rulesGetList = setRules([]rbacv1.PolicyRule{
+	{
+		APIGroups: []string{""},
+		Resources: []string{"pods"},
+		Verbs:     []string{"get", "list"},
+	},
+})
+
+// sharedRole returns a Role managed by a RootSync, whose rules are declared.
+func TestValidator_InheritedDriftPolicy(t *testing.T) {
+	managedRole := func(manager string) client.Object {
+		return k8sobjects.RoleObject(
+			core.Name("hello"),
+			core.Namespace("bookstore"),
+			core.Label(csmetadata.ManagedByKey, csmetadata.ManagedByValue),
+			csmetadata.WithManagementMode(csmetadata.ManagementEnabled),
+			core.Annotation(csmetadata.ResourceIDKey, "rbac.authorization.k8s.io_role_bookstore_hello"),
+			core.Annotation(csmetadata.ResourceManagerKey, manager),
+		)
+	}
+	testCases := []struct {
+		name   string
+		oldObj client.Object
+		deny   metav1.StatusReason
+	}{
+		{
+			name:   "RootSync with the report drift policy",
+			oldObj: managedRole(rootSyncManagerAnnotation(rootSyncName)),
+		},
+		{
+			name:   "RepoSync without drift policy",
+			oldObj: managedRole(repoSyncManagerAnnotation("bookstore", repoSyncName)),
+			deny:   metav1.StatusReasonForbidden,
+		},
+		{
+			name:   "missing RootSync",
+			oldObj: managedRole(rootSyncManagerAnnotation("other-root-sync")),
+			deny:   metav1.StatusReasonForbidden,
+		},
+	}
+
+	v := validatorForTest(t)
+	v.reader = testingfake.NewClient(t, core.Scheme,
+		k8sobjects.RootSyncObjectV1Beta1(rootSyncName, csmetadata.WithDriftPolicy(csmetadata.DriftPolicyReport)),
+		k8sobjects.RepoSyncObjectV1Beta1("bookstore", repoSyncName))
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := request(tc.oldObj, nil)
+			req.UserInfo = bob()
+
+			resp := v.Handle(context.Background(), req)
+			if resp.Allowed {
+				if tc.deny != "" {
+					t.Errorf("got Handle() response allowed, want denied %q", tc.deny)
+				}
+			} else if tc.deny == "" {
+				t.Errorf("got Handle() response denied %q, want allowed", resp.Result.Reason)
+			} else if tc.deny != resp.Result.Reason {
+				t.Errorf("got Handle() response denied %q, want denied %q", resp.Result.Reason, tc.deny)
+			}
+		})
+	}
+}
+
+func sharedRole(opts ...core.MetaMutator) client.Object {
+	opts = append([]core.MetaMutator{
+		core.Name("hello"),
+		core.Namespace("bookstore"),
+		core.Label(csmetadata.ManagedByKey, csmetadata.ManagedByValue),
+		csmetadata.WithManagementMode(csmetadata.ManagementEnabled),
+		core.Annotation(csmetadata.ResourceIDKey, "rbac.authorization.k8s.io_role_bookstore_hello"),
+		core.Annotation(csmetadata.ResourceManagerKey, rootSyncManagerAnnotation(rootSyncName)),
+		core.Annotation(csmetadata.DeclaredFieldsKey, `{"f:metadata":{"f:labels":{"f:app.kubernetes.io/managed-by":{}},"f:annotations":{"f:configmanagement.gke.io/managed":{},"f:configsync.gke.io/manager":{}}},"f:rules":{}}`),
+	}, opts...)
+	return k8sobjects.RoleObject(opts...)
+}
+
 func validatorForTest(t *testing.T) *Validator {
 	vc, err := openapitest.ValueConverterForTest()
 	if err != nil {